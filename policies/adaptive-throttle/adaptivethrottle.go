@@ -0,0 +1,271 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package adaptivethrottle
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	defaultTargetErrorRate    = 0.05
+	defaultEvaluationInterval = 10 * time.Second
+	defaultMinRequests        = 20
+	defaultDecreaseFactor     = 0.5
+	defaultIncreaseStep       = 0.1
+	defaultMinAdmitRatio      = 0.1
+)
+
+// AdaptiveThrottlePolicy implements an AIMD (additive increase, multiplicative decrease)
+// admission controller. Upstream 5xx responses observed in OnResponse drive the admit
+// ratio down when the error rate exceeds the target; healthy windows relax it again.
+type AdaptiveThrottlePolicy struct {
+	targetErrorRate    float64
+	evaluationInterval time.Duration
+	minRequests        int
+	decreaseFactor     float64
+	increaseStep       float64
+	minAdmitRatio      float64
+
+	mu          sync.Mutex
+	now         func() time.Time // Injectable clock (for testing)
+	windowStart time.Time
+	total       int
+	errors      int
+	admitRatio  float64
+	credit      float64
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &AdaptiveThrottlePolicy{
+		targetErrorRate:    defaultTargetErrorRate,
+		evaluationInterval: defaultEvaluationInterval,
+		minRequests:        defaultMinRequests,
+		decreaseFactor:     defaultDecreaseFactor,
+		increaseStep:       defaultIncreaseStep,
+		minAdmitRatio:      defaultMinAdmitRatio,
+		now:                time.Now,
+		admitRatio:         1.0,
+	}
+
+	if err := p.parseParams(params); err != nil {
+		return nil, err
+	}
+	p.windowStart = p.now()
+
+	slog.Debug("AdaptiveThrottle: Policy initialized",
+		"route", metadata.RouteName,
+		"targetErrorRate", p.targetErrorRate,
+		"evaluationInterval", p.evaluationInterval,
+		"minRequests", p.minRequests,
+		"decreaseFactor", p.decreaseFactor,
+		"increaseStep", p.increaseStep,
+		"minAdmitRatio", p.minAdmitRatio)
+
+	return p, nil
+}
+
+// parseParams parses and validates the controller parameters
+func (p *AdaptiveThrottlePolicy) parseParams(params map[string]interface{}) error {
+	var err error
+
+	if raw, ok := params["targetErrorRate"]; ok {
+		if p.targetErrorRate, err = extractFloat(raw); err != nil {
+			return fmt.Errorf("'targetErrorRate' must be a number: %w", err)
+		}
+		if p.targetErrorRate < 0 || p.targetErrorRate >= 1 {
+			return fmt.Errorf("'targetErrorRate' must be in the range [0, 1)")
+		}
+	}
+
+	if raw, ok := params["evaluationInterval"]; ok {
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("'evaluationInterval' must be a duration string")
+		}
+		if p.evaluationInterval, err = time.ParseDuration(s); err != nil {
+			return fmt.Errorf("invalid 'evaluationInterval': %w", err)
+		}
+		if p.evaluationInterval <= 0 {
+			return fmt.Errorf("'evaluationInterval' must be greater than 0")
+		}
+	}
+
+	if raw, ok := params["minRequests"]; ok {
+		v, err := extractFloat(raw)
+		if err != nil || v != float64(int(v)) {
+			return fmt.Errorf("'minRequests' must be an integer")
+		}
+		if v < 1 {
+			return fmt.Errorf("'minRequests' must be at least 1")
+		}
+		p.minRequests = int(v)
+	}
+
+	if raw, ok := params["decreaseFactor"]; ok {
+		if p.decreaseFactor, err = extractFloat(raw); err != nil {
+			return fmt.Errorf("'decreaseFactor' must be a number: %w", err)
+		}
+		if p.decreaseFactor <= 0 || p.decreaseFactor >= 1 {
+			return fmt.Errorf("'decreaseFactor' must be in the range (0, 1)")
+		}
+	}
+
+	if raw, ok := params["increaseStep"]; ok {
+		if p.increaseStep, err = extractFloat(raw); err != nil {
+			return fmt.Errorf("'increaseStep' must be a number: %w", err)
+		}
+		if p.increaseStep <= 0 || p.increaseStep > 1 {
+			return fmt.Errorf("'increaseStep' must be in the range (0, 1]")
+		}
+	}
+
+	if raw, ok := params["minAdmitRatio"]; ok {
+		if p.minAdmitRatio, err = extractFloat(raw); err != nil {
+			return fmt.Errorf("'minAdmitRatio' must be a number: %w", err)
+		}
+		if p.minAdmitRatio <= 0 || p.minAdmitRatio > 1 {
+			return fmt.Errorf("'minAdmitRatio' must be in the range (0, 1]")
+		}
+	}
+
+	return nil
+}
+
+// extractFloat safely extracts a float from various types
+func extractFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", value)
+	}
+}
+
+// Mode returns the processing mode for this policy
+func (p *AdaptiveThrottlePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Admission decision in request phase
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Observe upstream status codes
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest admits or rejects the request based on the current admit ratio
+func (p *AdaptiveThrottlePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	p.mu.Lock()
+	p.evaluateLocked()
+
+	// Deterministic admission: accumulate the admit ratio as credit and admit a request
+	// whenever a full credit is available. This spreads rejections evenly instead of
+	// relying on random sampling.
+	p.credit += p.admitRatio
+	admitted := p.credit >= 1
+	if admitted {
+		p.credit--
+	}
+	admitRatio := p.admitRatio
+	p.mu.Unlock()
+
+	if admitted {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("AdaptiveThrottle: Request throttled", "admitRatio", admitRatio, "path", ctx.Path)
+
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Service Unavailable",
+		"message": "Upstream is degraded. Request was throttled, please retry later.",
+	})
+	retryAfter := int64(p.evaluationInterval.Seconds())
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	return policy.ImmediateResponse{
+		StatusCode: 503,
+		Headers: map[string]string{
+			"content-type": "application/json",
+			"retry-after":  strconv.FormatInt(retryAfter, 10),
+		},
+		Body: body,
+	}
+}
+
+// OnResponse records the upstream response status for the current evaluation window
+func (p *AdaptiveThrottlePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evaluateLocked()
+	p.total++
+	if ctx.ResponseStatus >= 500 {
+		p.errors++
+	}
+
+	return policy.UpstreamResponseModifications{}
+}
+
+// evaluateLocked closes the current window if it has elapsed and adjusts the admit ratio.
+// Must be called with p.mu held.
+func (p *AdaptiveThrottlePolicy) evaluateLocked() {
+	now := p.now()
+	if now.Sub(p.windowStart) < p.evaluationInterval {
+		return
+	}
+
+	previous := p.admitRatio
+	if p.total >= p.minRequests && float64(p.errors)/float64(p.total) > p.targetErrorRate {
+		// Multiplicative decrease on elevated error rate
+		p.admitRatio *= p.decreaseFactor
+		if p.admitRatio < p.minAdmitRatio {
+			p.admitRatio = p.minAdmitRatio
+		}
+	} else {
+		// Additive increase when healthy (or not enough samples to judge)
+		p.admitRatio += p.increaseStep
+		if p.admitRatio > 1 {
+			p.admitRatio = 1
+		}
+	}
+
+	if previous != p.admitRatio {
+		slog.Debug("AdaptiveThrottle: Admit ratio adjusted",
+			"previous", previous, "current", p.admitRatio,
+			"total", p.total, "errors", p.errors)
+	}
+
+	p.windowStart = now
+	p.total = 0
+	p.errors = 0
+}
@@ -0,0 +1,199 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package adaptivethrottle
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// testClock is a manually advanced clock for driving evaluation windows
+type testClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestPolicy(t *testing.T, params map[string]interface{}) (*AdaptiveThrottlePolicy, *testClock) {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	clock := &testClock{t: time.Unix(1000, 0)}
+	atp := p.(*AdaptiveThrottlePolicy)
+	atp.now = clock.Now
+	atp.windowStart = clock.Now()
+	return atp, clock
+}
+
+// drive sends n requests and returns how many were admitted. Admitted requests
+// receive a response with the given status.
+func drive(p *AdaptiveThrottlePolicy, n int, status int) int {
+	admitted := 0
+	for i := 0; i < n; i++ {
+		ctx := &policy.RequestContext{SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}}}
+		if _, ok := p.OnRequest(ctx, nil).(policy.ImmediateResponse); ok {
+			continue
+		}
+		admitted++
+		p.OnResponse(&policy.ResponseContext{ResponseStatus: status}, nil)
+	}
+	return admitted
+}
+
+func TestAdaptiveThrottlePolicy_Mode(t *testing.T) {
+	p := &AdaptiveThrottlePolicy{}
+	expected := policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess,
+		RequestBodyMode:    policy.BodyModeSkip,
+		ResponseHeaderMode: policy.HeaderModeProcess,
+		ResponseBodyMode:   policy.BodyModeSkip,
+	}
+	if mode := p.Mode(); mode != expected {
+		t.Errorf("Expected mode %+v, got %+v", expected, mode)
+	}
+}
+
+func TestAdaptiveThrottlePolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"targetErrorRate": 1.5},
+		{"decreaseFactor": 1.0},
+		{"increaseStep": 0.0},
+		{"evaluationInterval": "abc"},
+		{"minRequests": 0.0},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAdaptiveThrottlePolicy_HealthyTrafficIsAdmitted(t *testing.T) {
+	p, clock := newTestPolicy(t, map[string]interface{}{})
+
+	for window := 0; window < 3; window++ {
+		if admitted := drive(p, 50, 200); admitted != 50 {
+			t.Fatalf("Window %d: expected all 50 requests admitted, got %d", window, admitted)
+		}
+		clock.Advance(10 * time.Second)
+	}
+}
+
+func TestAdaptiveThrottlePolicy_ErrorSpikeThrottles(t *testing.T) {
+	p, clock := newTestPolicy(t, map[string]interface{}{
+		"targetErrorRate":    0.1,
+		"evaluationInterval": "10s",
+		"minRequests":        10.0,
+		"decreaseFactor":     0.5,
+	})
+
+	// Window 1: upstream fails every request
+	drive(p, 20, 503)
+	clock.Advance(10 * time.Second)
+
+	// Window 2: admit ratio halved, roughly half the requests are rejected
+	ctx := &policy.RequestContext{SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}}}
+	rejected := 0
+	for i := 0; i < 20; i++ {
+		result := p.OnRequest(ctx, nil)
+		if resp, ok := result.(policy.ImmediateResponse); ok {
+			if resp.StatusCode != 503 {
+				t.Errorf("Expected 503, got %d", resp.StatusCode)
+			}
+			if resp.Headers["retry-after"] != "10" {
+				t.Errorf("Expected retry-after 10, got %q", resp.Headers["retry-after"])
+			}
+			rejected++
+		}
+	}
+	if rejected != 10 {
+		t.Errorf("Expected 10 of 20 requests rejected at admit ratio 0.5, got %d", rejected)
+	}
+}
+
+func TestAdaptiveThrottlePolicy_FloorAndRecovery(t *testing.T) {
+	p, clock := newTestPolicy(t, map[string]interface{}{
+		"targetErrorRate": 0.1,
+		"minRequests":     1.0,
+		"decreaseFactor":  0.5,
+		"increaseStep":    0.25,
+		"minAdmitRatio":   0.2,
+	})
+
+	// Sustained errors drive the ratio down to the floor
+	for i := 0; i < 6; i++ {
+		drive(p, 20, 500)
+		clock.Advance(10 * time.Second)
+	}
+	if p.admitRatio != 0.2 {
+		t.Fatalf("Expected admit ratio at floor 0.2, got %v", p.admitRatio)
+	}
+
+	// Healthy windows relax the ratio additively back to 1
+	for i := 0; i < 4; i++ {
+		drive(p, 20, 200)
+		clock.Advance(10 * time.Second)
+	}
+	if admitted := drive(p, 20, 200); admitted != 20 {
+		t.Errorf("Expected full recovery with all 20 admitted, got %d (ratio %v)", admitted, p.admitRatio)
+	}
+}
+
+func TestAdaptiveThrottlePolicy_MinRequestsGuard(t *testing.T) {
+	p, clock := newTestPolicy(t, map[string]interface{}{
+		"minRequests": 100.0,
+	})
+
+	// Few failing samples are not enough to trigger throttling
+	drive(p, 10, 500)
+	clock.Advance(10 * time.Second)
+
+	if admitted := drive(p, 10, 200); admitted != 10 {
+		t.Errorf("Expected all requests admitted below minRequests, got %d", admitted)
+	}
+}
+
+func TestAdaptiveThrottlePolicy_ConcurrentAccess(t *testing.T) {
+	p, _ := newTestPolicy(t, map[string]interface{}{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			drive(p, 50, 500)
+		}()
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.total != 1000 || p.errors != 1000 {
+		t.Errorf("Expected 1000 observed errors, got total=%d errors=%d", p.total, p.errors)
+	}
+}
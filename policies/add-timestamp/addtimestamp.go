@@ -0,0 +1,170 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package addtimestamp
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	DefaultHeaderName = "x-gateway-timestamp"
+	DefaultFormat     = FormatRFC3339
+	DefaultApplyTo    = ApplyToRequest
+)
+
+// Supported timestamp formats
+const (
+	FormatRFC3339    = "rfc3339"
+	FormatUnix       = "unix"
+	FormatUnixMillis = "unixMillis"
+	FormatHTTPDate   = "httpDate"
+)
+
+// Supported phases
+const (
+	ApplyToRequest  = "request"
+	ApplyToResponse = "response"
+	ApplyToBoth     = "both"
+)
+
+// AddTimestampPolicy sets a header carrying the time the gateway processed the message
+type AddTimestampPolicy struct {
+	headerName string
+	format     string
+	onRequest  bool
+	onResponse bool
+	now        func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &AddTimestampPolicy{
+		headerName: DefaultHeaderName,
+		format:     DefaultFormat,
+		now:        time.Now,
+	}
+
+	if raw, ok := params["headerName"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'headerName' must be a non-empty string")
+		}
+		p.headerName = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["format"]; ok {
+		format, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'format' must be a string")
+		}
+		switch format {
+		case FormatRFC3339, FormatUnix, FormatUnixMillis, FormatHTTPDate:
+			p.format = format
+		default:
+			return nil, fmt.Errorf("'format' must be one of %s, %s, %s, %s", FormatRFC3339, FormatUnix, FormatUnixMillis, FormatHTTPDate)
+		}
+	}
+
+	applyTo := DefaultApplyTo
+	if raw, ok := params["applyTo"]; ok {
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'applyTo' must be a string")
+		}
+		applyTo = s
+	}
+	switch applyTo {
+	case ApplyToRequest:
+		p.onRequest = true
+	case ApplyToResponse:
+		p.onResponse = true
+	case ApplyToBoth:
+		p.onRequest = true
+		p.onResponse = true
+	default:
+		return nil, fmt.Errorf("'applyTo' must be one of %s, %s, %s", ApplyToRequest, ApplyToResponse, ApplyToBoth)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *AddTimestampPolicy) Mode() policy.ProcessingMode {
+	requestHeaderMode := policy.HeaderModeSkip
+	if p.onRequest {
+		requestHeaderMode = policy.HeaderModeProcess
+	}
+	responseHeaderMode := policy.HeaderModeSkip
+	if p.onResponse {
+		responseHeaderMode = policy.HeaderModeProcess
+	}
+
+	return policy.ProcessingMode{
+		RequestHeaderMode:  requestHeaderMode,
+		RequestBodyMode:    policy.BodyModeSkip, // Don't need request body
+		ResponseHeaderMode: responseHeaderMode,
+		ResponseBodyMode:   policy.BodyModeSkip, // Don't need response body
+	}
+}
+
+// OnRequest sets the timestamp header on the upstream request
+func (p *AddTimestampPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if !p.onRequest {
+		return policy.UpstreamRequestModifications{}
+	}
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{
+			p.headerName: p.timestamp(),
+		},
+	}
+}
+
+// OnResponse sets the timestamp header on the downstream response
+func (p *AddTimestampPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if !p.onResponse {
+		return policy.UpstreamResponseModifications{}
+	}
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.headerName: p.timestamp(),
+		},
+	}
+}
+
+// timestamp formats the current time using the configured format
+func (p *AddTimestampPolicy) timestamp() string {
+	now := p.now()
+	switch p.format {
+	case FormatUnix:
+		return strconv.FormatInt(now.Unix(), 10)
+	case FormatUnixMillis:
+		return strconv.FormatInt(now.UnixMilli(), 10)
+	case FormatHTTPDate:
+		return now.UTC().Format(http.TimeFormat)
+	default:
+		return now.UTC().Format(time.RFC3339)
+	}
+}
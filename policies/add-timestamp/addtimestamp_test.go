@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package addtimestamp
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var fixedTime = time.Date(2026, 1, 2, 15, 4, 5, 123000000, time.UTC)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *AddTimestampPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	atp := p.(*AddTimestampPolicy)
+	atp.now = func() time.Time { return fixedTime }
+	return atp
+}
+
+func requestHeader(p *AddTimestampPolicy, name string) string {
+	mods := p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(nil)}, nil).(policy.UpstreamRequestModifications)
+	return mods.SetHeaders[name]
+}
+
+func responseHeader(p *AddTimestampPolicy, name string) string {
+	mods := p.OnResponse(&policy.ResponseContext{ResponseHeaders: policy.NewHeaders(nil)}, nil).(policy.UpstreamResponseModifications)
+	return mods.SetHeaders[name]
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"headerName": ""},
+		{"format": "iso"},
+		{"applyTo": "upstream"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAddTimestampPolicy_Formats(t *testing.T) {
+	cases := map[string]string{
+		FormatRFC3339:    "2026-01-02T15:04:05Z",
+		FormatUnix:       "1767366245",
+		FormatUnixMillis: "1767366245123",
+		FormatHTTPDate:   "Fri, 02 Jan 2026 15:04:05 GMT",
+	}
+	for format, expected := range cases {
+		p := newPolicy(t, map[string]interface{}{"format": format})
+		if got := requestHeader(p, DefaultHeaderName); got != expected {
+			t.Errorf("Format %s: expected %q, got %q", format, expected, got)
+		}
+	}
+}
+
+func TestAddTimestampPolicy_RequestPhaseOnly(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"headerName": "X-Received-At"})
+
+	if got := requestHeader(p, "x-received-at"); got != "2026-01-02T15:04:05Z" {
+		t.Errorf("Expected request header to be set, got %q", got)
+	}
+	if got := responseHeader(p, "x-received-at"); got != "" {
+		t.Errorf("Expected no response header, got %q", got)
+	}
+}
+
+func TestAddTimestampPolicy_ResponsePhaseOnly(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"headerName": "date", "format": FormatHTTPDate, "applyTo": ApplyToResponse})
+
+	if got := requestHeader(p, "date"); got != "" {
+		t.Errorf("Expected no request header, got %q", got)
+	}
+	if got := responseHeader(p, "date"); got != "Fri, 02 Jan 2026 15:04:05 GMT" {
+		t.Errorf("Expected response Date header, got %q", got)
+	}
+	if p.Mode().RequestHeaderMode != policy.HeaderModeSkip {
+		t.Error("Expected request headers to be skipped")
+	}
+}
+
+func TestAddTimestampPolicy_BothPhases(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"format": FormatUnix, "applyTo": ApplyToBoth})
+
+	if got := requestHeader(p, DefaultHeaderName); got != "1767366245" {
+		t.Errorf("Expected request header, got %q", got)
+	}
+	if got := responseHeader(p, DefaultHeaderName); got != "1767366245" {
+		t.Errorf("Expected response header, got %q", got)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package addvary
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// AddVaryPolicy declares request headers that influence the response in the Vary header
+type AddVaryPolicy struct {
+	headers []string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	headersRaw, ok := params["headers"].([]interface{})
+	if !ok || len(headersRaw) == 0 {
+		return nil, fmt.Errorf("'headers' parameter is required and must be a non-empty array")
+	}
+
+	p := &AddVaryPolicy{}
+	for i, raw := range headersRaw {
+		name, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("headers[%d] must be a string", i)
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("headers[%d] cannot be empty", i)
+		}
+		p.headers = append(p.headers, name)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *AddVaryPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need existing Vary header
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *AddVaryPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse merges the configured header names into the response Vary header
+func (p *AddVaryPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	existing := ctx.ResponseHeaders.Get("vary")
+	merged, changed := mergeVary(existing, p.headers)
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			"vary": merged,
+		},
+	}
+}
+
+// mergeVary combines existing Vary values with additional header names, removing
+// case-insensitive duplicates while preserving the original order. It reports whether
+// the resulting value differs from what the upstream sent.
+func mergeVary(existing []string, additional []string) (string, bool) {
+	seen := make(map[string]bool)
+	var entries []string
+
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		entries = append(entries, name)
+	}
+
+	for _, value := range existing {
+		for _, name := range strings.Split(value, ",") {
+			add(name)
+		}
+	}
+
+	// A wildcard already varies on everything; additional entries are meaningless
+	if seen["*"] {
+		return "*", len(existing) != 1 || strings.TrimSpace(existing[0]) != "*"
+	}
+
+	before := len(entries)
+	for _, name := range additional {
+		add(http.CanonicalHeaderKey(name))
+	}
+
+	merged := strings.Join(entries, ", ")
+	changed := len(entries) != before || len(existing) > 1 || (len(existing) == 1 && existing[0] != merged)
+	return merged, changed
+}
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package addvary
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, headers ...interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headers": headers})
+	return p
+}
+
+func onResponse(p policy.Policy, vary []string) policy.UpstreamResponseModifications {
+	headers := map[string][]string{}
+	if vary != nil {
+		headers["vary"] = vary
+	}
+	ctx := &policy.ResponseContext{ResponseHeaders: policy.NewHeaders(headers)}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"headers": []interface{}{}},
+		{"headers": []interface{}{" "}},
+		{"headers": []interface{}{42}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAddVaryPolicy_SetWhenAbsent(t *testing.T) {
+	p := newPolicy(t, "accept-language", "X-Tenant")
+
+	mods := onResponse(p, nil)
+	if got := mods.SetHeaders["vary"]; got != "Accept-Language, X-Tenant" {
+		t.Errorf("Expected 'Accept-Language, X-Tenant', got %q", got)
+	}
+}
+
+func TestAddVaryPolicy_MergeWithExisting(t *testing.T) {
+	p := newPolicy(t, "X-Tenant")
+
+	mods := onResponse(p, []string{"Accept-Encoding"})
+	if got := mods.SetHeaders["vary"]; got != "Accept-Encoding, X-Tenant" {
+		t.Errorf("Expected 'Accept-Encoding, X-Tenant', got %q", got)
+	}
+}
+
+func TestAddVaryPolicy_Dedupe(t *testing.T) {
+	p := newPolicy(t, "accept-encoding", "X-Tenant", "x-tenant")
+
+	mods := onResponse(p, []string{"Accept-Encoding, Origin", "origin"})
+	if got := mods.SetHeaders["vary"]; got != "Accept-Encoding, Origin, X-Tenant" {
+		t.Errorf("Expected 'Accept-Encoding, Origin, X-Tenant', got %q", got)
+	}
+}
+
+func TestAddVaryPolicy_AlreadyPresentUnchanged(t *testing.T) {
+	p := newPolicy(t, "origin")
+
+	mods := onResponse(p, []string{"Accept-Encoding, Origin"})
+	if len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no header changes, got %v", mods.SetHeaders)
+	}
+}
+
+func TestAddVaryPolicy_WildcardPreserved(t *testing.T) {
+	p := newPolicy(t, "X-Tenant")
+
+	mods := onResponse(p, []string{"*"})
+	if len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected wildcard Vary to be left as is, got %v", mods.SetHeaders)
+	}
+
+	mods = onResponse(p, []string{"Origin, *"})
+	if got := mods.SetHeaders["vary"]; got != "*" {
+		t.Errorf("Expected Vary to collapse to '*', got %q", got)
+	}
+}
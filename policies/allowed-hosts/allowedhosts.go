@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package allowedhosts
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const MisdirectedRequestStatus = 421
+
+// hostPattern is a parsed allowed host entry
+type hostPattern struct {
+	// suffix is set for wildcard patterns ("*.example.com" -> ".example.com")
+	suffix string
+	// exact is set for exact host patterns
+	exact string
+}
+
+// AllowedHostsPolicy rejects requests whose Host/:authority is not in the configured allow-list
+type AllowedHostsPolicy struct {
+	patterns []hostPattern
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	hostsRaw, ok := params["allowedHosts"].([]interface{})
+	if !ok || len(hostsRaw) == 0 {
+		return nil, fmt.Errorf("'allowedHosts' parameter is required and must be a non-empty array")
+	}
+
+	p := &AllowedHostsPolicy{}
+	for i, raw := range hostsRaw {
+		host, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("allowedHosts[%d] must be a string", i)
+		}
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" {
+			return nil, fmt.Errorf("allowedHosts[%d] cannot be empty", i)
+		}
+
+		if strings.HasPrefix(host, "*.") {
+			suffix := host[1:]
+			if strings.Contains(suffix, "*") || len(suffix) < 2 {
+				return nil, fmt.Errorf("allowedHosts[%d] has an invalid wildcard pattern: %s", i, host)
+			}
+			p.patterns = append(p.patterns, hostPattern{suffix: suffix})
+			continue
+		}
+		if strings.Contains(host, "*") {
+			return nil, fmt.Errorf("allowedHosts[%d]: wildcards are only supported as a leading '*.' label: %s", i, host)
+		}
+		p.patterns = append(p.patterns, hostPattern{exact: stripPort(host)})
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *AllowedHostsPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the Host header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates the request host against the allow-list
+func (p *AllowedHostsPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	host := ctx.Authority
+	if host == "" {
+		if values := ctx.Headers.Get("host"); len(values) > 0 {
+			host = values[0]
+		}
+	}
+	host = strings.TrimSuffix(stripPort(strings.ToLower(strings.TrimSpace(host))), ".")
+
+	if host != "" && p.isAllowed(host) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("AllowedHosts: Rejecting request for disallowed host", "host", host)
+
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Misdirected Request",
+		"message": "The requested host is not served by this gateway",
+	})
+	return policy.ImmediateResponse{
+		StatusCode: MisdirectedRequestStatus,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// isAllowed checks the normalized host against the configured patterns
+func (p *AllowedHostsPolicy) isAllowed(host string) bool {
+	for _, pattern := range p.patterns {
+		if pattern.exact != "" && host == pattern.exact {
+			return true
+		}
+		// Wildcards match any subdomain depth but not the apex domain itself
+		if pattern.suffix != "" && strings.HasSuffix(host, pattern.suffix) && len(host) > len(pattern.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripPort removes an optional port from a host, handling bracketed IPv6 literals
+func stripPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
+
+// OnResponse is not used by this policy
+func (p *AllowedHostsPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
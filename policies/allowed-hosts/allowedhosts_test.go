@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package allowedhosts
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, hosts ...string) policy.Policy {
+	t.Helper()
+	allowed := make([]interface{}, len(hosts))
+	for i, h := range hosts {
+		allowed[i] = h
+	}
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"allowedHosts": allowed})
+	return p
+}
+
+func isAllowed(p policy.Policy, authority string) bool {
+	ctx := &policy.RequestContext{
+		Headers:   policy.NewHeaders(nil),
+		Authority: authority,
+	}
+	_, ok := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+	return ok
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"allowedHosts": []interface{}{}},
+		{"allowedHosts": []interface{}{""}},
+		{"allowedHosts": []interface{}{"api.*.com"}},
+		{"allowedHosts": []interface{}{"*."}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAllowedHostsPolicy_ExactMatch(t *testing.T) {
+	p := newPolicy(t, "api.example.com")
+
+	if !isAllowed(p, "api.example.com") {
+		t.Error("Expected exact host to be allowed")
+	}
+	if !isAllowed(p, "API.Example.COM") {
+		t.Error("Expected case-insensitive host match to be allowed")
+	}
+}
+
+func TestAllowedHostsPolicy_WildcardSubdomain(t *testing.T) {
+	p := newPolicy(t, "*.example.com")
+
+	if !isAllowed(p, "shop.example.com") {
+		t.Error("Expected subdomain to match wildcard")
+	}
+	if !isAllowed(p, "eu.shop.example.com") {
+		t.Error("Expected nested subdomain to match wildcard")
+	}
+	if isAllowed(p, "example.com") {
+		t.Error("Expected apex domain not to match wildcard")
+	}
+	if isAllowed(p, "evilexample.com") {
+		t.Error("Expected lookalike domain not to match wildcard")
+	}
+}
+
+func TestAllowedHostsPolicy_PortStripped(t *testing.T) {
+	p := newPolicy(t, "api.example.com", "::1")
+
+	if !isAllowed(p, "api.example.com:8443") {
+		t.Error("Expected host with port to be allowed")
+	}
+	if !isAllowed(p, "[::1]:9090") {
+		t.Error("Expected bracketed IPv6 host with port to be allowed")
+	}
+}
+
+func TestAllowedHostsPolicy_HostHeaderFallback(t *testing.T) {
+	p := newPolicy(t, "api.example.com")
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"Host": {"api.example.com:80"}}),
+	}
+
+	if _, ok := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected Host header to be used when authority is empty")
+	}
+}
+
+func TestAllowedHostsPolicy_DisallowedHostRejected(t *testing.T) {
+	p := newPolicy(t, "api.example.com", "*.example.org")
+	ctx := &policy.RequestContext{
+		Headers:   policy.NewHeaders(nil),
+		Authority: "attacker.com",
+	}
+
+	resp, ok := p.OnRequest(ctx, nil).(policy.ImmediateResponse)
+	if !ok {
+		t.Fatal("Expected ImmediateResponse for disallowed host")
+	}
+	if resp.StatusCode != 421 {
+		t.Errorf("Expected status 421, got %d", resp.StatusCode)
+	}
+	if resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", resp.Headers["content-type"])
+	}
+
+	if isAllowed(p, "") {
+		t.Error("Expected missing host to be rejected")
+	}
+}
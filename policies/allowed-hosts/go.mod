@@ -0,0 +1,10 @@
+module github.com/wso2/gateway-controllers/policies/allowed-hosts
+
+go 1.25.1
+
+require (
+	github.com/wso2/api-platform/sdk v0.3.1
+	github.com/wso2/gateway-controllers/utils v0.1.0
+)
+
+replace github.com/wso2/gateway-controllers/utils => ../../utils
@@ -0,0 +1,182 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package allowedresponsetypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"mime"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// AllowedResponseTypesPolicy replaces upstream responses whose content type is not in an
+// allow-list, so consumers never receive unexpected content such as an HTML error page
+type AllowedResponseTypesPolicy struct {
+	allowed        map[string]bool // Exact media types, e.g. application/json
+	allowedPrefix  []string        // Wildcard types, stored as "application/"
+	exemptStatuses map[int]bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	typesRaw, ok := params["allowedTypes"].([]interface{})
+	if !ok || len(typesRaw) == 0 {
+		return nil, fmt.Errorf("'allowedTypes' parameter is required and must be a non-empty array")
+	}
+
+	p := &AllowedResponseTypesPolicy{
+		allowed:        make(map[string]bool),
+		exemptStatuses: make(map[int]bool),
+	}
+	for i, raw := range typesRaw {
+		mediaType, ok := raw.(string)
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if !ok || mediaType == "" {
+			return nil, fmt.Errorf("allowedTypes[%d] must be a non-empty string", i)
+		}
+		typ, subtype, found := strings.Cut(mediaType, "/")
+		if !found || typ == "" || typ == "*" || subtype == "" || strings.ContainsAny(mediaType, "; ") {
+			return nil, fmt.Errorf("allowedTypes[%d] must be a media type like 'application/json' or 'text/*'", i)
+		}
+		if subtype == "*" {
+			p.allowedPrefix = append(p.allowedPrefix, typ+"/")
+			continue
+		}
+		p.allowed[mediaType] = true
+	}
+
+	if raw, ok := params["exemptStatuses"]; ok {
+		statuses, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'exemptStatuses' must be an array")
+		}
+		for i, s := range statuses {
+			status, err := extractInt(s)
+			if err != nil || status < 100 || status > 599 {
+				return nil, fmt.Errorf("exemptStatuses[%d] must be an HTTP status code between 100 and 599", i)
+			}
+			p.exemptStatuses[status] = true
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *AllowedResponseTypesPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to replace it
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *AllowedResponseTypesPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse replaces responses with a content type outside the allow-list with a 502
+func (p *AllowedResponseTypesPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if p.exemptStatuses[ctx.ResponseStatus] {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	var contentType string
+	if values := ctx.ResponseHeaders.Get("content-type"); len(values) > 0 {
+		contentType = strings.TrimSpace(values[0])
+	}
+	if contentType == "" {
+		// Responses without content carry no type to enforce
+		if ctx.ResponseBody == nil || len(ctx.ResponseBody.Content) == 0 {
+			return policy.UpstreamResponseModifications{}
+		}
+		return p.reject("Upstream returned a response body without a content type")
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return p.reject("Upstream returned a response with a malformed content type")
+	}
+	if p.isAllowed(mediaType) {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	slog.Debug("AllowedResponseTypes: Replacing response with disallowed content type",
+		"contentType", mediaType, "status", ctx.ResponseStatus)
+	return p.reject(fmt.Sprintf("Upstream returned unexpected content type '%s'", mediaType))
+}
+
+// isAllowed reports whether a parsed (lower-cased) media type matches the allow-list
+func (p *AllowedResponseTypesPolicy) isAllowed(mediaType string) bool {
+	if p.allowed[mediaType] {
+		return true
+	}
+	for _, prefix := range p.allowedPrefix {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// reject replaces the upstream response with a 502 JSON error
+func (p *AllowedResponseTypesPolicy) reject(message string) policy.ResponseAction {
+	statusCode := 502
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Bad Gateway",
+		"message": message,
+	})
+	return policy.UpstreamResponseModifications{
+		StatusCode: &statusCode,
+		Body:       body,
+		SetHeaders: map[string]string{
+			"content-type":   "application/json",
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+		// The replacement body is never encoded like the original
+		RemoveHeaders: []string{"content-encoding"},
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
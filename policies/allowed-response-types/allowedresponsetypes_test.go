@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package allowedresponsetypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	if _, ok := params["allowedTypes"]; !ok {
+		params["allowedTypes"] = []interface{}{"application/json", "application/problem+json"}
+	}
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onResponse(p policy.Policy, status int, contentType string, body string) policy.UpstreamResponseModifications {
+	headers := map[string][]string{}
+	if contentType != "" {
+		headers["content-type"] = []string{contentType}
+	}
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(headers),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: body != "", EndOfStream: true},
+		ResponseStatus:  status,
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func expectReplaced(t *testing.T, mods policy.UpstreamResponseModifications) {
+	t.Helper()
+	if mods.StatusCode == nil || *mods.StatusCode != 502 {
+		t.Fatalf("Expected response to be replaced with a 502, got %+v", mods)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(mods.Body, &body); err != nil || body["error"] != "Bad Gateway" {
+		t.Errorf("Expected JSON error body, got %s", mods.Body)
+	}
+	if mods.SetHeaders["content-type"] != "application/json" {
+		t.Errorf("Expected JSON content type, got %v", mods.SetHeaders)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"allowedTypes": []interface{}{}},
+		{"allowedTypes": []interface{}{"json"}},
+		{"allowedTypes": []interface{}{"*/*"}},
+		{"allowedTypes": []interface{}{"application/json; charset=utf-8"}},
+		{"allowedTypes": []interface{}{"application/json"}, "exemptStatuses": []interface{}{600}},
+		{"allowedTypes": []interface{}{"application/json"}, "exemptStatuses": 304},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAllowedResponseTypesPolicy_AllowedTypePasses(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"allowedTypes": []interface{}{"Application/JSON", "text/*"},
+	})
+
+	for _, contentType := range []string{"application/json; charset=utf-8", "text/csv"} {
+		if mods := onResponse(p, 200, contentType, `{}`); mods.StatusCode != nil || mods.Body != nil {
+			t.Errorf("Expected %s to pass, got %+v", contentType, mods)
+		}
+	}
+	// Empty responses carry no type to enforce
+	if mods := onResponse(p, 204, "", ""); mods.StatusCode != nil {
+		t.Errorf("Expected empty response to pass, got %+v", mods)
+	}
+}
+
+func TestAllowedResponseTypesPolicy_DisallowedTypeReplaced(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	mods := onResponse(p, 200, "text/html", "<html></html>")
+	expectReplaced(t, mods)
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "content-encoding" {
+		t.Errorf("Expected content-encoding to be removed, got %v", mods.RemoveHeaders)
+	}
+
+	expectReplaced(t, onResponse(p, 200, "", "<html></html>"))
+	expectReplaced(t, onResponse(p, 200, "application/json;;", `{}`))
+}
+
+func TestAllowedResponseTypesPolicy_ExemptStatusPasses(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"exemptStatuses": []interface{}{float64(503)}})
+
+	if mods := onResponse(p, 503, "text/html", "<html>maintenance</html>"); mods.StatusCode != nil {
+		t.Errorf("Expected exempt status to pass, got %+v", mods)
+	}
+	expectReplaced(t, onResponse(p, 500, "text/html", "<html>error</html>"))
+}
@@ -0,0 +1,192 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package allowedschemes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Handling of requests without an X-Forwarded-Proto header
+	MissingProtoStrict  = "strict"
+	MissingProtoLenient = "lenient"
+)
+
+// AllowedSchemesPolicy rejects requests whose original scheme, as reported by
+// X-Forwarded-Proto, is not in an allow-list
+type AllowedSchemesPolicy struct {
+	allowed      map[string]bool
+	rejectStatus int
+	missingProto string
+	trustedCount int // Number of trusted proxies that append to X-Forwarded-Proto
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &AllowedSchemesPolicy{
+		allowed:      map[string]bool{"https": true},
+		rejectStatus: http.StatusForbidden,
+		missingProto: MissingProtoStrict,
+		trustedCount: 1,
+	}
+
+	if raw, ok := params["allowedSchemes"]; ok {
+		schemes, ok := raw.([]interface{})
+		if !ok || len(schemes) == 0 {
+			return nil, fmt.Errorf("'allowedSchemes' must be a non-empty array")
+		}
+		p.allowed = make(map[string]bool)
+		for i, s := range schemes {
+			scheme, ok := s.(string)
+			scheme = strings.ToLower(strings.TrimSpace(scheme))
+			if !ok || scheme == "" {
+				return nil, fmt.Errorf("allowedSchemes[%d] must be a non-empty string", i)
+			}
+			p.allowed[scheme] = true
+		}
+	}
+
+	if raw, ok := params["rejectStatus"]; ok {
+		status, err := extractInt(raw)
+		if err != nil || (status != http.StatusBadRequest && status != http.StatusForbidden) {
+			return nil, fmt.Errorf("'rejectStatus' must be 400 or 403")
+		}
+		p.rejectStatus = status
+	}
+
+	if raw, ok := params["missingProto"]; ok {
+		missingProto, ok := raw.(string)
+		if !ok || (missingProto != MissingProtoStrict && missingProto != MissingProtoLenient) {
+			return nil, fmt.Errorf("'missingProto' must be one of %s, %s", MissingProtoStrict, MissingProtoLenient)
+		}
+		p.missingProto = missingProto
+	}
+
+	if raw, ok := params["trustedProxyCount"]; ok {
+		count, err := extractInt(raw)
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("'trustedProxyCount' must be a positive integer")
+		}
+		p.trustedCount = count
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *AllowedSchemesPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need X-Forwarded-Proto header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests that did not arrive over an allowed scheme
+func (p *AllowedSchemesPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	proto := p.forwardedProto(ctx.Headers.Get("x-forwarded-proto"))
+	if proto == "" {
+		if p.missingProto == MissingProtoLenient {
+			return policy.UpstreamRequestModifications{}
+		}
+		return p.reject("The request scheme could not be determined")
+	}
+	if !p.allowed[proto] {
+		return p.reject(fmt.Sprintf("Scheme '%s' is not allowed; use one of: %s", proto, p.allowedList()))
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *AllowedSchemesPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// forwardedProto returns the scheme recorded by the outermost trusted proxy. Each proxy appends
+// the scheme it received, so with trustedCount proxies that entry is trustedCount from the
+// right; anything before it was sent by the client and could be forged.
+func (p *AllowedSchemesPolicy) forwardedProto(values []string) string {
+	var entries []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			entries = append(entries, strings.ToLower(strings.TrimSpace(entry)))
+		}
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	idx := len(entries) - p.trustedCount
+	if idx < 0 {
+		idx = 0
+	}
+	return entries[idx]
+}
+
+func (p *AllowedSchemesPolicy) allowedList() string {
+	schemes := make([]string, 0, len(p.allowed))
+	for scheme := range p.allowed {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return strings.Join(schemes, ", ")
+}
+
+// reject returns a JSON error with the configured status
+func (p *AllowedSchemesPolicy) reject(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(p.rejectStatus),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: p.rejectStatus,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package allowedschemes
+
+import (
+	"encoding/json"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, proto ...string) policy.RequestAction {
+	headers := map[string][]string{}
+	if len(proto) > 0 {
+		headers["x-forwarded-proto"] = proto
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"allowedSchemes": []interface{}{}},
+		{"allowedSchemes": []interface{}{" "}},
+		{"rejectStatus": 401},
+		{"missingProto": "ignore"},
+		{"trustedProxyCount": 0},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAllowedSchemesPolicy_AllowedScheme(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectForwarded(t, onRequest(p, "https"))
+	policytest.ExpectForwarded(t, onRequest(p, "HTTPS"))
+	policytest.ExpectForwarded(t, onRequest(p, "http, https"))
+	policytest.ExpectForwarded(t, onRequest(p, "http", "https"))
+}
+
+func TestAllowedSchemesPolicy_DisallowedScheme(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	resp := policytest.ExpectStatus(t, onRequest(p, "http"), 403)
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil || body["message"] == "" {
+		t.Errorf("Expected JSON error body, got %s", resp.Body)
+	}
+	policytest.ExpectStatus(t, onRequest(p, "https, http"), 403)
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"allowedSchemes": []interface{}{"https", "wss"}, "rejectStatus": float64(400)})
+	policytest.ExpectForwarded(t, onRequest(p, "wss"))
+	policytest.ExpectStatus(t, onRequest(p, "ws"), 400)
+}
+
+func TestAllowedSchemesPolicy_MissingProto(t *testing.T) {
+	strict := policytest.New(t, GetPolicy, map[string]interface{}{})
+	policytest.ExpectStatus(t, onRequest(strict), 403)
+	policytest.ExpectStatus(t, onRequest(strict, " "), 403)
+
+	lenient := policytest.New(t, GetPolicy, map[string]interface{}{"missingProto": "lenient"})
+	policytest.ExpectForwarded(t, onRequest(lenient))
+	// Lenient mode still enforces the allow-list when the header is present
+	policytest.ExpectStatus(t, onRequest(lenient, "http"), 403)
+}
+
+func TestAllowedSchemesPolicy_SpoofedProto(t *testing.T) {
+	// The client claims https, but the proxy in front of the gateway appended the scheme it saw
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	policytest.ExpectStatus(t, onRequest(p, "https, https, http"), 403)
+
+	// Behind two proxies the outer one's entry is used, not the inner hop's or the client's
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"trustedProxyCount": 2})
+	policytest.ExpectStatus(t, onRequest(p, "https, http, https"), 403)
+	policytest.ExpectForwarded(t, onRequest(p, "http, https, http"))
+	policytest.ExpectForwarded(t, onRequest(p, "https"))
+}
@@ -0,0 +1,306 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package auditresponse
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	DefaultTimeoutMs = 1000
+	DefaultQueueSize = 1000
+
+	// sinkWorkers is the number of goroutines delivering events to each audit endpoint
+	sinkWorkers = 2
+)
+
+// AuditEvent is the document posted to the audit endpoint for each sampled response
+type AuditEvent struct {
+	Timestamp  string            `json:"timestamp"`
+	RequestID  string            `json:"requestId,omitempty"`
+	APIName    string            `json:"apiName,omitempty"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Status     int               `json:"status"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	BodySHA256 string            `json:"bodySha256,omitempty"`
+}
+
+// sink delivers audit events to one endpoint from a bounded queue. Sinks are shared by every
+// policy instance with the same endpoint settings so that reconfiguration doesn't leak workers.
+type sink struct {
+	url    string
+	client *http.Client
+	queue  chan AuditEvent
+}
+
+// sinks caches started sinks by endpoint settings
+var sinks sync.Map
+
+// getSink returns the shared sink for the endpoint settings, starting it on first use
+func getSink(auditURL string, timeoutMs, queueSize int) *sink {
+	key := fmt.Sprintf("%s|%d|%d", auditURL, timeoutMs, queueSize)
+	if s, ok := sinks.Load(key); ok {
+		return s.(*sink)
+	}
+	s := &sink{
+		url:    auditURL,
+		client: &http.Client{Timeout: time.Duration(timeoutMs) * time.Millisecond},
+		queue:  make(chan AuditEvent, queueSize),
+	}
+	actual, loaded := sinks.LoadOrStore(key, s)
+	if !loaded {
+		for i := 0; i < sinkWorkers; i++ {
+			go s.run()
+		}
+	}
+	return actual.(*sink)
+}
+
+// enqueue hands an event to the workers without blocking; events are dropped when the queue is full
+func (s *sink) enqueue(event AuditEvent) bool {
+	select {
+	case s.queue <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// run delivers queued events until the process exits
+func (s *sink) run() {
+	for event := range s.queue {
+		if err := s.deliver(event); err != nil {
+			slog.Warn("AuditResponse: Failed to deliver audit event", "url", s.url, "error", err)
+		}
+	}
+}
+
+func (s *sink) deliver(event AuditEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// AuditResponsePolicy sends a copy of response metadata to an audit endpoint without delaying
+// the response
+type AuditResponsePolicy struct {
+	sink            *sink
+	headers         []string
+	includeBodyHash bool
+	sampleRate      float64
+	random          func() float64   // Injectable sampler (for testing)
+	now             func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	auditURL, ok := params["auditUrl"].(string)
+	if !ok || strings.TrimSpace(auditURL) == "" {
+		return nil, fmt.Errorf("'auditUrl' parameter is required and must be a non-empty string")
+	}
+	u, err := url.Parse(strings.TrimSpace(auditURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("'auditUrl' must be an absolute http or https URL")
+	}
+
+	timeoutMs := DefaultTimeoutMs
+	if raw, ok := params["timeoutMs"]; ok {
+		if timeoutMs, err = extractInt(raw); err != nil || timeoutMs <= 0 {
+			return nil, fmt.Errorf("'timeoutMs' must be a positive integer")
+		}
+	}
+
+	queueSize := DefaultQueueSize
+	if raw, ok := params["queueSize"]; ok {
+		if queueSize, err = extractInt(raw); err != nil || queueSize <= 0 {
+			return nil, fmt.Errorf("'queueSize' must be a positive integer")
+		}
+	}
+
+	p := &AuditResponsePolicy{
+		headers:    []string{"content-type"},
+		sampleRate: 1,
+		random:     rand.Float64,
+		now:        time.Now,
+	}
+
+	if raw, ok := params["includeBodyHash"]; ok {
+		if p.includeBodyHash, ok = raw.(bool); !ok {
+			return nil, fmt.Errorf("'includeBodyHash' must be a boolean")
+		}
+	}
+
+	if raw, ok := params["sampleRate"]; ok {
+		rate, err := extractFloat(raw)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("'sampleRate' must be a number between 0 and 1")
+		}
+		p.sampleRate = rate
+	}
+
+	if raw, ok := params["headers"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'headers' must be an array")
+		}
+		p.headers = make([]string, 0, len(list))
+		for i, item := range list {
+			name, ok := item.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("headers[%d] must be a non-empty string", i)
+			}
+			p.headers = append(p.headers, strings.ToLower(strings.TrimSpace(name)))
+		}
+	}
+
+	p.sink = getSink(u.String(), timeoutMs, queueSize)
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *AuditResponsePolicy) Mode() policy.ProcessingMode {
+	responseBodyMode := policy.BodyModeSkip
+	if p.includeBodyHash {
+		responseBodyMode = policy.BodyModeBuffer
+	}
+
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need response headers for the event
+		ResponseBodyMode:   responseBodyMode,
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *AuditResponsePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse queues an audit event for sampled responses and never modifies the response
+func (p *AuditResponsePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if p.sampleRate < 1 && p.random() >= p.sampleRate {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	if !p.sink.enqueue(p.buildEvent(ctx)) {
+		slog.Warn("AuditResponse: Audit queue is full, dropping event", "url", p.sink.url)
+	}
+	return policy.UpstreamResponseModifications{}
+}
+
+// buildEvent captures the response metadata
+func (p *AuditResponsePolicy) buildEvent(ctx *policy.ResponseContext) AuditEvent {
+	event := AuditEvent{
+		Timestamp: p.now().UTC().Format(time.RFC3339Nano),
+		Method:    ctx.RequestMethod,
+		Path:      ctx.RequestPath,
+		Status:    ctx.ResponseStatus,
+	}
+	if ctx.SharedContext != nil {
+		event.RequestID = ctx.RequestID
+		event.APIName = ctx.APIName
+		event.APIVersion = ctx.APIVersion
+	}
+
+	for _, name := range p.headers {
+		if values := ctx.ResponseHeaders.Get(name); len(values) > 0 {
+			if event.Headers == nil {
+				event.Headers = make(map[string]string)
+			}
+			event.Headers[name] = strings.Join(values, ", ")
+		}
+	}
+
+	if p.includeBodyHash {
+		var content []byte
+		if ctx.ResponseBody != nil {
+			content = ctx.ResponseBody.Content
+		}
+		sum := sha256.Sum256(content)
+		event.BodySHA256 = hex.EncodeToString(sum[:])
+	}
+	return event
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
+
+// extractFloat safely extracts a float from various types
+func extractFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", value)
+	}
+}
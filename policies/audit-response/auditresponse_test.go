@@ -0,0 +1,167 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package auditresponse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// stubSink records the audit events it receives
+func stubSink(t *testing.T, block chan struct{}) (*httptest.Server, chan AuditEvent) {
+	t.Helper()
+	events := make(chan AuditEvent, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if block != nil {
+			<-block
+		}
+		var event AuditEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Expected JSON audit event, got error %v", err)
+		}
+		events <- event
+	}))
+	t.Cleanup(server.Close)
+	return server, events
+}
+
+func newPolicy(t *testing.T, params map[string]interface{}) *AuditResponsePolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*AuditResponsePolicy)
+}
+
+func onResponse(p policy.Policy, body string) policy.ResponseAction {
+	ctx := &policy.ResponseContext{
+		SharedContext:   &policy.SharedContext{RequestID: "req-1", APIName: "orders"},
+		RequestMethod:   "GET",
+		RequestPath:     "/orders/7",
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/json"}, "x-internal": {"1"}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+		ResponseStatus:  200,
+	}
+	return p.OnResponse(ctx, nil)
+}
+
+func receive(t *testing.T, events chan AuditEvent) AuditEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected an audit event to be delivered")
+	}
+	return AuditEvent{}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"auditUrl": "/audit"},
+		{"auditUrl": "ftp://audit.example.com"},
+		{"auditUrl": "http://audit", "sampleRate": 1.5},
+		{"auditUrl": "http://audit", "timeoutMs": 0},
+		{"auditUrl": "http://audit", "queueSize": -1},
+		{"auditUrl": "http://audit", "includeBodyHash": "yes"},
+		{"auditUrl": "http://audit", "headers": []interface{}{""}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestAuditResponsePolicy_FireAndForgetDelivery(t *testing.T) {
+	block := make(chan struct{})
+	server, events := stubSink(t, block)
+	p := newPolicy(t, map[string]interface{}{"auditUrl": server.URL, "includeBodyHash": true})
+
+	// The response is not held up by a slow audit endpoint
+	start := time.Now()
+	mods, ok := onResponse(p, `{"id":7}`).(policy.UpstreamResponseModifications)
+	if !ok || mods.StatusCode != nil || mods.Body != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected the response to be untouched, got %+v", mods)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected OnResponse not to block, took %v", elapsed)
+	}
+	close(block)
+
+	event := receive(t, events)
+	sum := sha256.Sum256([]byte(`{"id":7}`))
+	if event.RequestID != "req-1" || event.Method != "GET" || event.Path != "/orders/7" || event.Status != 200 {
+		t.Errorf("Expected response metadata in the event, got %+v", event)
+	}
+	if event.BodySHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected body hash %x, got %q", sum, event.BodySHA256)
+	}
+	if len(event.Headers) != 1 || event.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected only the selected headers, got %v", event.Headers)
+	}
+}
+
+func TestAuditResponsePolicy_Sampling(t *testing.T) {
+	server, events := stubSink(t, nil)
+	p := newPolicy(t, map[string]interface{}{"auditUrl": server.URL, "sampleRate": 0.25})
+
+	samples := []float64{0.1, 0.5, 0.24, 0.25, 0.9}
+	p.random = func() float64 {
+		sample := samples[0]
+		samples = samples[1:]
+		return sample
+	}
+	for i := 0; i < 5; i++ {
+		onResponse(p, "")
+	}
+
+	receive(t, events)
+	receive(t, events)
+	select {
+	case event := <-events:
+		t.Errorf("Expected only two sampled events, got an extra %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	p = newPolicy(t, map[string]interface{}{"auditUrl": server.URL, "sampleRate": 0})
+	onResponse(p, "")
+	select {
+	case event := <-events:
+		t.Errorf("Expected no events with a zero sample rate, got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAuditResponsePolicy_FullQueueDropsEvents(t *testing.T) {
+	block := make(chan struct{})
+	server, _ := stubSink(t, block)
+	defer close(block)
+	p := newPolicy(t, map[string]interface{}{"auditUrl": server.URL, "queueSize": 1})
+
+	start := time.Now()
+	for i := 0; i < 50; i++ {
+		onResponse(p, "")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected a full queue not to block responses, took %v", elapsed)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package bodychecksum
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Digest algorithms
+	AlgorithmSHA256 = "sha-256"
+	AlgorithmSHA512 = "sha-512"
+	AlgorithmMD5    = "md5"
+	AlgorithmCRC32  = "crc32"
+
+	// Header value formats
+	FormatDigest = "digest" // RFC 3230 instance digest, e.g. "SHA-256=<base64>"
+	FormatBase64 = "base64"
+	FormatHex    = "hex"
+)
+
+// algorithms maps each supported algorithm to its hash constructor and RFC 3230 token
+var algorithms = map[string]struct {
+	newHash func() hash.Hash
+	token   string
+}{
+	AlgorithmSHA256: {sha256.New, "SHA-256"},
+	AlgorithmSHA512: {sha512.New, "SHA-512"},
+	AlgorithmMD5:    {md5.New, "MD5"},
+	AlgorithmCRC32:  {func() hash.Hash { return crc32.NewIEEE() }, "CRC32"},
+}
+
+// BodyChecksumPolicy sets a header carrying a digest of the response body so downstreams can
+// verify its integrity
+type BodyChecksumPolicy struct {
+	algorithm string
+	header    string
+	format    string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &BodyChecksumPolicy{
+		algorithm: AlgorithmSHA256,
+		header:    "digest",
+	}
+
+	if raw, ok := params["algorithm"]; ok {
+		algorithm, _ := raw.(string)
+		algorithm = strings.ToLower(strings.TrimSpace(algorithm))
+		if _, ok := algorithms[algorithm]; !ok {
+			return nil, fmt.Errorf("'algorithm' must be one of %s, %s, %s, %s",
+				AlgorithmSHA256, AlgorithmSHA512, AlgorithmMD5, AlgorithmCRC32)
+		}
+		p.algorithm = algorithm
+	}
+
+	if raw, ok := params["header"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'header' must be a non-empty string")
+		}
+		p.header = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	// The Digest header carries an algorithm token; other headers such as Content-MD5 carry the
+	// bare base64 value
+	p.format = FormatBase64
+	if p.header == "digest" {
+		p.format = FormatDigest
+	}
+	if raw, ok := params["format"]; ok {
+		format, _ := raw.(string)
+		if format != FormatDigest && format != FormatBase64 && format != FormatHex {
+			return nil, fmt.Errorf("'format' must be one of %s, %s, %s", FormatDigest, FormatBase64, FormatHex)
+		}
+		p.format = format
+	}
+
+	if p.header == "content-md5" && (p.algorithm != AlgorithmMD5 || p.format != FormatBase64) {
+		return nil, fmt.Errorf("the content-md5 header requires algorithm %s with format %s", AlgorithmMD5, FormatBase64)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *BodyChecksumPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Sets the checksum header
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to digest it
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *BodyChecksumPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse digests the response body and sets the checksum header
+func (p *BodyChecksumPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.header: p.checksum(ctx.ResponseBody.Content),
+		},
+	}
+}
+
+// checksum digests the body and encodes the result in the configured format. The digest covers
+// the body as sent, after any content encoding.
+func (p *BodyChecksumPolicy) checksum(body []byte) string {
+	algorithm := algorithms[p.algorithm]
+	h := algorithm.newHash()
+	h.Write(body)
+	sum := h.Sum(nil)
+
+	switch p.format {
+	case FormatHex:
+		return hex.EncodeToString(sum)
+	case FormatDigest:
+		return algorithm.token + "=" + base64.StdEncoding.EncodeToString(sum)
+	default:
+		return base64.StdEncoding.EncodeToString(sum)
+	}
+}
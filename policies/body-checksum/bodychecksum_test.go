@@ -0,0 +1,93 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package bodychecksum
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, body string) map[string]string {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications).SetHeaders
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"algorithm": "sha-1"},
+		{"header": ""},
+		{"format": "base32"},
+		{"header": "Content-MD5"},
+		{"header": "content-md5", "algorithm": "md5", "format": "hex"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestBodyChecksumPolicy_Algorithms(t *testing.T) {
+	tests := []struct {
+		params   map[string]interface{}
+		header   string
+		expected string
+	}{
+		{
+			params:   map[string]interface{}{},
+			header:   "digest",
+			expected: "SHA-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=",
+		},
+		{
+			params:   map[string]interface{}{"algorithm": "SHA-512", "format": "hex"},
+			header:   "digest",
+			expected: "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043",
+		},
+		{
+			params:   map[string]interface{}{"algorithm": "md5", "header": "Content-MD5"},
+			header:   "content-md5",
+			expected: "XUFAKrxLKna5cZ2REBfFkg==",
+		},
+		{
+			params:   map[string]interface{}{"algorithm": "crc32", "header": "x-checksum", "format": "hex"},
+			header:   "x-checksum",
+			expected: "3610a686",
+		},
+	}
+
+	for _, tt := range tests {
+		headers := onResponse(policytest.New(t, GetPolicy, tt.params), "hello")
+		if got := headers[tt.header]; got != tt.expected {
+			t.Errorf("Expected %s %q for params %v, got %q", tt.header, tt.expected, tt.params, got)
+		}
+	}
+}
+
+func TestBodyChecksumPolicy_EmptyBody(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	if got := onResponse(p, "")["digest"]; got != "SHA-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=" {
+		t.Errorf("Expected digest of the empty body, got %q", got)
+	}
+
+	ctx := &policy.ResponseContext{ResponseHeaders: policy.NewHeaders(map[string][]string{})}
+	if mods := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications); mods.SetHeaders != nil {
+		t.Errorf("Expected no header without a body, got %v", mods.SetHeaders)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package cachecontrol
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/pathmatch"
+)
+
+// cacheRule applies a Cache-Control value to paths matching a pattern
+type cacheRule struct {
+	pattern      string
+	cacheControl string
+}
+
+// CacheControlPolicy sets Cache-Control and Expires response headers by request path
+type CacheControlPolicy struct {
+	rules        []cacheRule
+	defaultValue string           // Applied to unmatched paths; empty leaves them untouched
+	now          func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	rulesRaw, ok := params["rules"].([]interface{})
+	if !ok || len(rulesRaw) == 0 {
+		return nil, fmt.Errorf("'rules' parameter is required and must be a non-empty array")
+	}
+
+	p := &CacheControlPolicy{now: time.Now}
+	for i, raw := range rulesRaw {
+		ruleMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("rules[%d] must be an object", i)
+		}
+		pattern, _ := ruleMap["pattern"].(string)
+		pattern = strings.TrimSpace(pattern)
+		if !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("rules[%d].pattern must be a non-empty path starting with '/'", i)
+		}
+		if err := pathmatch.Validate(pattern); err != nil {
+			return nil, fmt.Errorf("rules[%d].pattern is invalid: %w", i, err)
+		}
+		value, _ := ruleMap["cacheControl"].(string)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("rules[%d].cacheControl must be a non-empty string", i)
+		}
+		p.rules = append(p.rules, cacheRule{pattern: pattern, cacheControl: value})
+	}
+
+	if raw, ok := params["default"]; ok {
+		value, ok := raw.(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("'default' must be a non-empty string")
+		}
+		p.defaultValue = strings.TrimSpace(value)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *CacheControlPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Sets caching headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *CacheControlPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse sets the caching headers of the first rule matching the request path
+func (p *CacheControlPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	reqPath, _, _ := strings.Cut(ctx.RequestPath, "?")
+
+	value := p.defaultValue
+	for _, rule := range p.rules {
+		if pathmatch.Match(rule.pattern, reqPath) {
+			value = rule.cacheControl
+			break
+		}
+	}
+	if value == "" {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	headers := map[string]string{"cache-control": value}
+	if expires, ok := p.expires(value); ok {
+		headers["expires"] = expires
+	}
+	return policy.UpstreamResponseModifications{SetHeaders: headers}
+}
+
+// expires derives an Expires value for HTTP/1.0 caches from a Cache-Control value. Uncacheable
+// responses get "0", which caches treat as already expired.
+func (p *CacheControlPolicy) expires(cacheControl string) (string, bool) {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return "0", true
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(arg, `"`))
+			if err != nil || seconds < 0 {
+				return "", false
+			}
+			if seconds == 0 {
+				return "0", true
+			}
+			return p.now().UTC().Add(time.Duration(seconds) * time.Second).Format(http.TimeFormat), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package cachecontrol
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *CacheControlPolicy {
+	t.Helper()
+	if _, ok := params["rules"]; !ok {
+		params["rules"] = []interface{}{
+			map[string]interface{}{"pattern": "/static/**", "cacheControl": "public, max-age=86400"},
+			map[string]interface{}{"pattern": "/api/*", "cacheControl": "no-store"},
+		}
+	}
+	p := policytest.New(t, GetPolicy, params)
+	cp := p.(*CacheControlPolicy)
+	cp.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return cp
+}
+
+func onResponse(p policy.Policy, path string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{RequestPath: path, ResponseHeaders: policy.NewHeaders(nil)}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"rules": []interface{}{}},
+		{"rules": []interface{}{map[string]interface{}{"pattern": "static", "cacheControl": "no-store"}}},
+		{"rules": []interface{}{map[string]interface{}{"pattern": "/[", "cacheControl": "no-store"}}},
+		{"rules": []interface{}{map[string]interface{}{"pattern": "/a", "cacheControl": " "}}},
+		{"rules": []interface{}{map[string]interface{}{"pattern": "/a", "cacheControl": "no-store"}}, "default": 60},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestCacheControlPolicy_StaticLongTTL(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	mods := onResponse(p, "/static/js/app.js?v=3")
+	if got := mods.SetHeaders["cache-control"]; got != "public, max-age=86400" {
+		t.Errorf("Expected long TTL, got %q", got)
+	}
+	if got := mods.SetHeaders["expires"]; got != "Mon, 02 Mar 2026 12:00:00 GMT" {
+		t.Errorf("Expected Expires one day ahead, got %q", got)
+	}
+}
+
+func TestCacheControlPolicy_APINoStore(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	mods := onResponse(p, "/api/orders")
+	if mods.SetHeaders["cache-control"] != "no-store" || mods.SetHeaders["expires"] != "0" {
+		t.Errorf("Expected no-store with Expires 0, got %v", mods.SetHeaders)
+	}
+}
+
+func TestCacheControlPolicy_FirstMatchWins(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"pattern": "/api/public", "cacheControl": "public, max-age=60"},
+			map[string]interface{}{"pattern": "/api/*", "cacheControl": "no-store"},
+		},
+	})
+
+	if got := onResponse(p, "/api/public").SetHeaders["cache-control"]; got != "public, max-age=60" {
+		t.Errorf("Expected the first matching rule, got %q", got)
+	}
+}
+
+func TestCacheControlPolicy_UnmatchedDefault(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"default": "private"})
+
+	mods := onResponse(p, "/health")
+	if mods.SetHeaders["cache-control"] != "private" {
+		t.Errorf("Expected default value, got %v", mods.SetHeaders)
+	}
+	if _, ok := mods.SetHeaders["expires"]; ok {
+		t.Errorf("Expected no Expires without a TTL, got %v", mods.SetHeaders)
+	}
+
+	// Without a default, unmatched paths are left untouched
+	p = newPolicy(t, map[string]interface{}{})
+	if mods := onResponse(p, "/health"); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no headers, got %v", mods.SetHeaders)
+	}
+}
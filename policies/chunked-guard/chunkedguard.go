@@ -0,0 +1,157 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package chunkedguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// knownCodings are the registered transfer codings accepted in Transfer-Encoding
+var knownCodings = map[string]bool{
+	"chunked":  true,
+	"compress": true,
+	"deflate":  true,
+	"gzip":     true,
+}
+
+// ChunkedGuardPolicy rejects requests whose Transfer-Encoding header is malformed or written in
+// a way that proxies and upstreams may interpret differently, hardening against request
+// smuggling
+type ChunkedGuardPolicy struct{}
+
+var ins = &ChunkedGuardPolicy{}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	return ins, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ChunkedGuardPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need Transfer-Encoding header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates the Transfer-Encoding tokens and rejects suspicious values with 400
+func (p *ChunkedGuardPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get("transfer-encoding")
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	err := validateCodings(values)
+	if err == nil && ctx.Headers.Has("content-length") {
+		// Framing by both headers is the classic smuggling vector (RFC 9112 section 6.3)
+		err = fmt.Errorf("must not be combined with Content-Length")
+	}
+	if err != nil {
+		slog.Debug("ChunkedGuard: Rejecting request with suspicious Transfer-Encoding", "values", values, "error", err)
+		body, _ := json.Marshal(map[string]string{
+			"error":   "Bad Request",
+			"message": "Invalid Transfer-Encoding header: " + err.Error(),
+		})
+		return policy.ImmediateResponse{
+			StatusCode: http.StatusBadRequest,
+			Headers: map[string]string{
+				"content-type": "application/json",
+			},
+			Body: body,
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *ChunkedGuardPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// validateCodings checks the transfer codings listed across all Transfer-Encoding values, in
+// order. Each coding must be a known, lower-case token; chunked must appear exactly once, as the
+// final coding, because a request body is otherwise unframed (RFC 9112 sections 6.1 and 6.3).
+func validateCodings(values []string) error {
+	var codings []string
+	for _, value := range values {
+		for _, token := range strings.Split(value, ",") {
+			token = strings.Trim(token, " \t")
+			if token == "" {
+				return fmt.Errorf("empty transfer coding")
+			}
+			if !isToken(token) {
+				return fmt.Errorf("malformed transfer coding %q", token)
+			}
+			codings = append(codings, token)
+		}
+	}
+
+	for i, coding := range codings {
+		lower := strings.ToLower(coding)
+		if !knownCodings[lower] {
+			return fmt.Errorf("unsupported transfer coding %q", coding)
+		}
+		if coding != lower {
+			return fmt.Errorf("transfer coding %q must be lower-case", coding)
+		}
+		if coding == "chunked" && i != len(codings)-1 {
+			if contains(codings[i+1:], "chunked") {
+				return fmt.Errorf("chunked is listed more than once")
+			}
+			return fmt.Errorf("chunked must be the final transfer coding")
+		}
+	}
+	if codings[len(codings)-1] != "chunked" {
+		return fmt.Errorf("chunked must be the final transfer coding")
+	}
+	return nil
+}
+
+// isToken reports whether s consists only of RFC 9110 token characters
+func isToken(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package chunkedguard
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(values []string) policy.RequestAction {
+	headers := map[string][]string{}
+	if values != nil {
+		headers["transfer-encoding"] = values
+	}
+	return onRequestHeaders(headers)
+}
+
+func onRequestHeaders(headers map[string][]string) policy.RequestAction {
+	p, _ := GetPolicy(policy.PolicyMetadata{}, nil)
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestChunkedGuardPolicy_DuplicatedChunked(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest([]string{"chunked, chunked"}), 400)
+	policytest.ExpectStatus(t, onRequest([]string{"chunked", "chunked"}), 400)
+	policytest.ExpectStatus(t, onRequest([]string{"chunked, gzip"}), 400)
+}
+
+func TestChunkedGuardPolicy_ObfuscatedCasing(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest([]string{"Chunked"}), 400)
+	policytest.ExpectStatus(t, onRequest([]string{"CHUNKED"}), 400)
+	policytest.ExpectStatus(t, onRequest([]string{"gzip, chunKed"}), 400)
+}
+
+func TestChunkedGuardPolicy_TrailingJunk(t *testing.T) {
+	for _, value := range []string{"chunked;x=1", "chunked x", "chunked\x0b", "chunked,", "xchunked", "identity"} {
+		policytest.ExpectStatus(t, onRequest([]string{value}), 400)
+	}
+}
+
+func TestChunkedGuardPolicy_ValidChunkedPasses(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest([]string{"chunked"}), 0)
+	policytest.ExpectStatus(t, onRequest([]string{" chunked\t"}), 0)
+	policytest.ExpectStatus(t, onRequest([]string{"gzip", "chunked"}), 0)
+	policytest.ExpectStatus(t, onRequest(nil), 0)
+}
+
+func TestChunkedGuardPolicy_ChunkedRequired(t *testing.T) {
+	// A request body without chunked as the final coding has no framing
+	policytest.ExpectStatus(t, onRequest([]string{"gzip"}), 400)
+	policytest.ExpectStatus(t, onRequest([]string{"gzip, deflate"}), 400)
+	policytest.ExpectStatus(t, onRequest([]string{"chunked", "gzip"}), 400)
+}
+
+func TestChunkedGuardPolicy_ContentLengthConflict(t *testing.T) {
+	policytest.ExpectStatus(t, onRequestHeaders(map[string][]string{
+		"transfer-encoding": {"chunked"},
+		"content-length":    {"42"},
+	}), 400)
+	policytest.ExpectStatus(t, onRequestHeaders(map[string][]string{"content-length": {"42"}}), 0)
+}
@@ -0,0 +1,242 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package clampquerynums
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Handling of values outside [min, max]
+	OutOfRangeClamp  = "clamp"
+	OutOfRangeReject = "reject"
+)
+
+// numericParam is the validation configured for one query parameter
+type numericParam struct {
+	min        *float64
+	max        *float64
+	integer    bool
+	outOfRange string
+}
+
+// ClampQueryNumsPolicy validates numeric query parameters and clamps or rejects values outside
+// their configured ranges
+type ClampQueryNumsPolicy struct {
+	params map[string]numericParam
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	paramsRaw, ok := params["params"].([]interface{})
+	if !ok || len(paramsRaw) == 0 {
+		return nil, fmt.Errorf("'params' parameter is required and must be a non-empty array")
+	}
+
+	p := &ClampQueryNumsPolicy{params: make(map[string]numericParam, len(paramsRaw))}
+	for i, raw := range paramsRaw {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("params[%d] must be an object", i)
+		}
+		name, ok := entry["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("params[%d].name must be a non-empty string", i)
+		}
+		if _, exists := p.params[name]; exists {
+			return nil, fmt.Errorf("params[%d].name '%s' is configured more than once", i, name)
+		}
+
+		param := numericParam{outOfRange: OutOfRangeClamp}
+		for _, bound := range []struct {
+			key    string
+			target **float64
+		}{{"min", &param.min}, {"max", &param.max}} {
+			raw, ok := entry[bound.key]
+			if !ok {
+				continue
+			}
+			value, err := extractFloat(raw)
+			if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+				return nil, fmt.Errorf("params[%d].%s must be a number", i, bound.key)
+			}
+			*bound.target = &value
+		}
+		if param.min == nil && param.max == nil {
+			return nil, fmt.Errorf("params[%d] requires at least one of 'min' or 'max'", i)
+		}
+		if param.min != nil && param.max != nil && *param.min > *param.max {
+			return nil, fmt.Errorf("params[%d].min must not be greater than max", i)
+		}
+
+		if raw, ok := entry["integer"]; ok {
+			integer, ok := raw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("params[%d].integer must be a boolean", i)
+			}
+			param.integer = integer
+		}
+		if param.integer {
+			if (param.min != nil && *param.min != math.Trunc(*param.min)) ||
+				(param.max != nil && *param.max != math.Trunc(*param.max)) {
+				return nil, fmt.Errorf("params[%d] bounds must be integers when 'integer' is true", i)
+			}
+		}
+
+		if raw, ok := entry["outOfRange"]; ok {
+			outOfRange, ok := raw.(string)
+			if !ok || (outOfRange != OutOfRangeClamp && outOfRange != OutOfRangeReject) {
+				return nil, fmt.Errorf("params[%d].outOfRange must be one of %s, %s", i, OutOfRangeClamp, OutOfRangeReject)
+			}
+			param.outOfRange = outOfRange
+		}
+
+		p.params[name] = param
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ClampQueryNumsPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request path and query
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects non-numeric values of configured parameters and clamps or rejects values
+// outside their range. Every occurrence of a repeated parameter is checked.
+func (p *ClampQueryNumsPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, query, hasQuery := strings.Cut(ctx.Path, "?")
+	if !hasQuery || query == "" {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	pairs := strings.Split(query, "&")
+	changed := false
+	for i, pair := range pairs {
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		param, ok := p.params[key]
+		if !ok {
+			continue
+		}
+
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			value = rawValue
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return badRequest(fmt.Sprintf("Query parameter '%s' must be a number", key))
+		}
+		if param.integer && number != math.Trunc(number) {
+			return badRequest(fmt.Sprintf("Query parameter '%s' must be an integer", key))
+		}
+
+		clamped := number
+		if param.min != nil && clamped < *param.min {
+			clamped = *param.min
+		}
+		if param.max != nil && clamped > *param.max {
+			clamped = *param.max
+		}
+		if clamped == number {
+			continue
+		}
+		if param.outOfRange == OutOfRangeReject {
+			return badRequest(fmt.Sprintf("Query parameter '%s' must be %s", key, param.rangeText()))
+		}
+
+		slog.Debug("ClampQueryNums: Clamping query parameter", "name", key, "value", value, "clamped", clamped)
+		pairs[i] = rawKey + "=" + strconv.FormatFloat(clamped, 'f', -1, 64)
+		changed = true
+	}
+
+	if !changed {
+		return policy.UpstreamRequestModifications{}
+	}
+	newPath := reqPath + "?" + strings.Join(pairs, "&")
+	return policy.UpstreamRequestModifications{Path: &newPath}
+}
+
+// OnResponse is not used by this policy
+func (p *ClampQueryNumsPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// rangeText describes the allowed range for error messages
+func (param numericParam) rangeText() string {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	switch {
+	case param.min != nil && param.max != nil:
+		return fmt.Sprintf("between %s and %s", format(*param.min), format(*param.max))
+	case param.min != nil:
+		return "at least " + format(*param.min)
+	default:
+		return "at most " + format(*param.max)
+	}
+}
+
+// badRequest builds a 400 response with a JSON error body
+func badRequest(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Bad Request",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusBadRequest,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractFloat safely extracts a float from various types
+func extractFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", value)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package clampquerynums
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var testParams = map[string]interface{}{
+	"params": []interface{}{
+		map[string]interface{}{"name": "limit", "min": 1, "max": 100, "integer": true},
+		map[string]interface{}{"name": "ratio", "min": 0, "max": 1, "outOfRange": "reject"},
+	},
+}
+
+func onRequest(t *testing.T, path string) policy.RequestAction {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, testParams)
+	return p.OnRequest(&policy.RequestContext{Path: path}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"params": []interface{}{map[string]interface{}{"name": "a"}}},
+		{"params": []interface{}{map[string]interface{}{"name": "a", "min": 5, "max": 1}}},
+		{"params": []interface{}{map[string]interface{}{"name": "a", "max": 1.5, "integer": true}}},
+		{"params": []interface{}{map[string]interface{}{"name": "a", "max": 1, "outOfRange": "drop"}}},
+		{"params": []interface{}{
+			map[string]interface{}{"name": "a", "max": 1},
+			map[string]interface{}{"name": "a", "max": 2},
+		}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestClampQueryNumsPolicy_ClampsOverMax(t *testing.T) {
+	mods, ok := onRequest(t, "/items?q=shoes&limit=500&limit=0").(policy.UpstreamRequestModifications)
+	if !ok || mods.Path == nil {
+		t.Fatalf("Expected path to be rewritten, got %+v", mods)
+	}
+	if *mods.Path != "/items?q=shoes&limit=100&limit=1" {
+		t.Errorf("Expected clamped limits, got %s", *mods.Path)
+	}
+
+	// Out-of-range values of a reject parameter are refused
+	policytest.ExpectStatus(t, onRequest(t, "/items?ratio=1.5"), 400)
+}
+
+func TestClampQueryNumsPolicy_RejectsNonNumeric(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, "/items?limit=ten"), 400)
+	policytest.ExpectStatus(t, onRequest(t, "/items?limit=2.5"), 400)
+	policytest.ExpectStatus(t, onRequest(t, "/items?limit="), 400)
+}
+
+func TestClampQueryNumsPolicy_WithinRangePasses(t *testing.T) {
+	for _, path := range []string{"/items?limit=20&ratio=0.25", "/items?q=x", "/items"} {
+		mods, ok := onRequest(t, path).(policy.UpstreamRequestModifications)
+		if !ok || mods.Path != nil {
+			t.Errorf("Expected %s to pass unchanged, got %+v", path, mods)
+		}
+	}
+}
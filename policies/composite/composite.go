@@ -0,0 +1,299 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package composite
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]policy.PolicyFactory)
+)
+
+// Register makes a policy available to composite chains under the given name. Policies are
+// separate modules, so the gateway build registers the factories of the policies it links in
+// (typically from an init function); only registered policies can be used in a chain.
+func Register(name string, factory policy.PolicyFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// lookup returns the factory registered under name
+func lookup(name string) (policy.PolicyFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// step is one sub-policy in the chain together with its parameters
+type step struct {
+	name   string
+	policy policy.Policy
+	params map[string]interface{}
+	mode   policy.ProcessingMode
+}
+
+// CompositePolicy runs an ordered chain of registered policies as a single policy
+type CompositePolicy struct {
+	steps []step
+	mode  policy.ProcessingMode
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	stepsRaw, ok := params["policies"].([]interface{})
+	if !ok || len(stepsRaw) == 0 {
+		return nil, fmt.Errorf("'policies' parameter is required and must be a non-empty array")
+	}
+
+	p := &CompositePolicy{
+		mode: policy.ProcessingMode{
+			RequestHeaderMode:  policy.HeaderModeSkip,
+			RequestBodyMode:    policy.BodyModeSkip,
+			ResponseHeaderMode: policy.HeaderModeSkip,
+			ResponseBodyMode:   policy.BodyModeSkip,
+		},
+	}
+	for i, raw := range stepsRaw {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("policies[%d] must be an object", i)
+		}
+		name, _ := entry["policy"].(string)
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("policies[%d].policy must be a non-empty string", i)
+		}
+		stepParams := map[string]interface{}{}
+		if paramsRaw, ok := entry["params"]; ok {
+			if stepParams, ok = paramsRaw.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("policies[%d].params must be an object", i)
+			}
+		}
+
+		factory, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("policies[%d]: policy '%s' is not registered", i, name)
+		}
+		sub, err := factory(metadata, stepParams)
+		if err != nil {
+			return nil, fmt.Errorf("policies[%d] (%s): %w", i, name, err)
+		}
+
+		mode := sub.Mode()
+		if mode.RequestBodyMode == policy.BodyModeStream || mode.ResponseBodyMode == policy.BodyModeStream {
+			return nil, fmt.Errorf("policies[%d] (%s): streaming policies cannot be composed", i, name)
+		}
+		p.steps = append(p.steps, step{name: name, policy: sub, params: stepParams, mode: mode})
+		p.mode = mergeMode(p.mode, mode)
+	}
+
+	return p, nil
+}
+
+// mergeMode combines two processing modes, keeping the more demanding setting for each phase
+func mergeMode(a, b policy.ProcessingMode) policy.ProcessingMode {
+	if b.RequestHeaderMode == policy.HeaderModeProcess {
+		a.RequestHeaderMode = policy.HeaderModeProcess
+	}
+	if b.RequestBodyMode == policy.BodyModeBuffer {
+		a.RequestBodyMode = policy.BodyModeBuffer
+	}
+	if b.ResponseHeaderMode == policy.HeaderModeProcess {
+		a.ResponseHeaderMode = policy.HeaderModeProcess
+	}
+	if b.ResponseBodyMode == policy.BodyModeBuffer {
+		a.ResponseBodyMode = policy.BodyModeBuffer
+	}
+	return a
+}
+
+// Mode returns the combined processing mode of the sub-policies
+func (p *CompositePolicy) Mode() policy.ProcessingMode {
+	return p.mode
+}
+
+// OnRequest runs the sub-policies in order against a working copy of the request so each one
+// sees the changes made before it. The first immediate response stops the chain and is
+// returned; otherwise the net change to the request is returned as one modification.
+func (p *CompositePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	work := *ctx
+	work.Headers = policy.NewHeaders(ctx.Headers.GetAll())
+	if ctx.Body != nil {
+		body := *ctx.Body
+		work.Body = &body
+	}
+
+	var bodyChanged bool
+	var analytics map[string]any
+	for _, s := range p.steps {
+		if s.mode.RequestHeaderMode == policy.HeaderModeSkip && s.mode.RequestBodyMode == policy.BodyModeSkip {
+			continue
+		}
+		switch action := s.policy.OnRequest(&work, s.params).(type) {
+		case policy.ImmediateResponse:
+			return action
+		case policy.UpstreamRequestModifications:
+			applyHeaders(work.Headers, action.RemoveHeaders, action.SetHeaders, action.AppendHeaders)
+			if action.Path != nil {
+				work.Path = *action.Path
+			}
+			if action.Method != nil {
+				work.Method = *action.Method
+			}
+			if action.Body != nil {
+				work.Body = &policy.Body{Content: action.Body, EndOfStream: true, Present: true}
+				bodyChanged = true
+			}
+			analytics = mergeAnalytics(analytics, action.AnalyticsMetadata)
+		}
+	}
+
+	mods := policy.UpstreamRequestModifications{AnalyticsMetadata: analytics}
+	mods.RemoveHeaders, mods.SetHeaders, mods.AppendHeaders = diffHeaders(ctx.Headers, work.Headers)
+	if work.Path != ctx.Path {
+		mods.Path = &work.Path
+	}
+	if work.Method != ctx.Method {
+		mods.Method = &work.Method
+	}
+	if bodyChanged {
+		mods.Body = work.Body.Content
+	}
+	return mods
+}
+
+// OnResponse runs the sub-policies in order against a working copy of the response and returns
+// the net change as one modification
+func (p *CompositePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	work := *ctx
+	work.ResponseHeaders = policy.NewHeaders(ctx.ResponseHeaders.GetAll())
+	if ctx.ResponseBody != nil {
+		body := *ctx.ResponseBody
+		work.ResponseBody = &body
+	}
+
+	var bodyChanged bool
+	var analytics map[string]any
+	for _, s := range p.steps {
+		if s.mode.ResponseHeaderMode == policy.HeaderModeSkip && s.mode.ResponseBodyMode == policy.BodyModeSkip {
+			continue
+		}
+		action, ok := s.policy.OnResponse(&work, s.params).(policy.UpstreamResponseModifications)
+		if !ok {
+			continue
+		}
+		applyHeaders(work.ResponseHeaders, action.RemoveHeaders, action.SetHeaders, action.AppendHeaders)
+		if action.StatusCode != nil {
+			work.ResponseStatus = *action.StatusCode
+		}
+		if action.Body != nil {
+			work.ResponseBody = &policy.Body{Content: action.Body, EndOfStream: true, Present: true}
+			bodyChanged = true
+		}
+		analytics = mergeAnalytics(analytics, action.AnalyticsMetadata)
+	}
+
+	mods := policy.UpstreamResponseModifications{AnalyticsMetadata: analytics}
+	mods.RemoveHeaders, mods.SetHeaders, mods.AppendHeaders = diffHeaders(ctx.ResponseHeaders, work.ResponseHeaders)
+	if work.ResponseStatus != ctx.ResponseStatus {
+		mods.StatusCode = &work.ResponseStatus
+	}
+	if bodyChanged {
+		mods.Body = work.ResponseBody.Content
+	}
+	return mods
+}
+
+// applyHeaders applies header modifications to the composite's private working copy in the
+// order removals, replacements, appends
+func applyHeaders(headers *policy.Headers, remove []string, set map[string]string, add map[string][]string) {
+	values := headers.UnsafeInternalValues()
+	for _, name := range remove {
+		delete(values, strings.ToLower(name))
+	}
+	for name, value := range set {
+		values[strings.ToLower(name)] = []string{value}
+	}
+	for name, vals := range add {
+		name = strings.ToLower(name)
+		values[name] = append(values[name], vals...)
+	}
+}
+
+// diffHeaders expresses the difference between the original and final headers as removals,
+// replacements and appends. A header that ends with several values is replaced with the first
+// and the rest are appended.
+func diffHeaders(original, final *policy.Headers) ([]string, map[string]string, map[string][]string) {
+	before, after := original.GetAll(), final.GetAll()
+
+	var remove []string
+	var set map[string]string
+	var add map[string][]string
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			remove = append(remove, name)
+		}
+	}
+	sort.Strings(remove)
+
+	for name, values := range after {
+		if slices.Equal(before[name], values) {
+			continue
+		}
+		if len(values) == 0 {
+			remove = append(remove, name)
+			continue
+		}
+		if set == nil {
+			set = make(map[string]string)
+		}
+		set[name] = values[0]
+		if len(values) > 1 {
+			if add == nil {
+				add = make(map[string][]string)
+			}
+			add[name] = slices.Clone(values[1:])
+		}
+	}
+	return remove, set, add
+}
+
+// mergeAnalytics merges analytics metadata, later sub-policies overriding earlier ones
+func mergeAnalytics(into, from map[string]any) map[string]any {
+	if len(from) == 0 {
+		return into
+	}
+	if into == nil {
+		into = make(map[string]any, len(from))
+	}
+	maps.Copy(into, from)
+	return into
+}
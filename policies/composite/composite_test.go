@@ -0,0 +1,164 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package composite
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// fakePolicy sets a header on each phase, optionally rejecting requests that carry a header
+type fakePolicy struct {
+	name     string
+	rejectIf string
+	calls    *[]string
+}
+
+func (f *fakePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess,
+		RequestBodyMode:    policy.BodyModeSkip,
+		ResponseHeaderMode: policy.HeaderModeProcess,
+		ResponseBodyMode:   policy.BodyModeSkip,
+	}
+}
+
+func (f *fakePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	*f.calls = append(*f.calls, f.name)
+	if f.rejectIf != "" && ctx.Headers.Has(f.rejectIf) {
+		return policy.ImmediateResponse{StatusCode: 403, Body: []byte(f.name)}
+	}
+	// Record the chain seen so far to prove later policies observe earlier changes
+	seen := strings.Join(ctx.Headers.Get("x-chain"), "")
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{"x-chain": seen + f.name},
+	}
+}
+
+func (f *fakePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	*f.calls = append(*f.calls, f.name)
+	mods := policy.UpstreamResponseModifications{
+		AppendHeaders: map[string][]string{"x-order": {f.name}},
+		RemoveHeaders: []string{"x-" + f.name},
+	}
+	if status, ok := params["status"].(int); ok {
+		mods.StatusCode = &status
+	}
+	return mods
+}
+
+func register(t *testing.T, calls *[]string) {
+	t.Helper()
+	for _, name := range []string{"a", "b", "c"} {
+		Register(name, func(metadata policy.PolicyMetadata, params map[string]interface{}) (policy.Policy, error) {
+			if _, ok := params["invalid"]; ok {
+				return nil, fmt.Errorf("'invalid' is not supported")
+			}
+			rejectIf, _ := params["rejectIf"].(string)
+			return &fakePolicy{name: name, rejectIf: rejectIf, calls: calls}, nil
+		})
+	}
+}
+
+func stepConfig(name string, params map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"policy": name, "params": params}
+}
+
+func newPolicy(t *testing.T, steps ...interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"policies": steps})
+	return p
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	register(t, &[]string{})
+
+	invalid := []map[string]interface{}{
+		{},
+		{"policies": []interface{}{}},
+		{"policies": []interface{}{"a"}},
+		{"policies": []interface{}{map[string]interface{}{"params": map[string]interface{}{}}}},
+		{"policies": []interface{}{stepConfig("missing", nil)}},
+		{"policies": []interface{}{map[string]interface{}{"policy": "a", "params": "x"}}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestCompositePolicy_SubPolicyErrorPropagated(t *testing.T) {
+	register(t, &[]string{})
+
+	_, err := GetPolicy(policy.PolicyMetadata{}, map[string]interface{}{
+		"policies": []interface{}{stepConfig("a", nil), stepConfig("b", map[string]interface{}{"invalid": true})},
+	})
+	if err == nil || !strings.Contains(err.Error(), "policies[1] (b)") || !strings.Contains(err.Error(), "'invalid' is not supported") {
+		t.Errorf("Expected sub-policy error to be propagated, got %v", err)
+	}
+}
+
+func TestCompositePolicy_ShortCircuitOnImmediateResponse(t *testing.T) {
+	var calls []string
+	register(t, &calls)
+	p := newPolicy(t,
+		stepConfig("a", nil),
+		stepConfig("b", map[string]interface{}{"rejectIf": "x-chain"}),
+		stepConfig("c", map[string]interface{}{"rejectIf": "x-chain"}),
+	)
+
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(map[string][]string{})}
+	resp, ok := p.OnRequest(ctx, nil).(policy.ImmediateResponse)
+	if !ok || string(resp.Body) != "b" {
+		t.Fatalf("Expected immediate response from b, got %+v", resp)
+	}
+	if strings.Join(calls, ",") != "a,b" {
+		t.Errorf("Expected chain to stop after b, got %v", calls)
+	}
+	if ctx.Headers.Has("x-chain") {
+		t.Errorf("Expected the original request to be untouched")
+	}
+}
+
+func TestCompositePolicy_SequentialHeaderModifications(t *testing.T) {
+	var calls []string
+	register(t, &calls)
+	p := newPolicy(t, stepConfig("a", nil), stepConfig("b", nil), stepConfig("c", map[string]interface{}{"status": 201}))
+
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(map[string][]string{})}
+	mods := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+	if got := mods.SetHeaders["x-chain"]; got != "abc" {
+		t.Errorf("Expected each policy to see earlier changes, got x-chain %q", got)
+	}
+
+	resp := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"x-order": {"upstream"}, "x-b": {"1"}}),
+		ResponseStatus:  200,
+	}
+	rmods := p.OnResponse(resp, nil).(policy.UpstreamResponseModifications)
+	if rmods.SetHeaders["x-order"] != "upstream" || strings.Join(rmods.AppendHeaders["x-order"], ",") != "a,b,c" {
+		t.Errorf("Expected x-order to be appended in order, got set %v append %v", rmods.SetHeaders, rmods.AppendHeaders)
+	}
+	if len(rmods.RemoveHeaders) != 1 || rmods.RemoveHeaders[0] != "x-b" {
+		t.Errorf("Expected x-b to be removed, got %v", rmods.RemoveHeaders)
+	}
+	if rmods.StatusCode == nil || *rmods.StatusCode != 201 {
+		t.Errorf("Expected status 201, got %v", rmods.StatusCode)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package compressrequest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const (
+	DefaultMinBytes = 1024
+	DefaultLevel    = gzip.DefaultCompression
+)
+
+// CompressRequestPolicy gzips large request bodies before they are forwarded upstream
+type CompressRequestPolicy struct {
+	minBytes int
+	level    int
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &CompressRequestPolicy{
+		minBytes: DefaultMinBytes,
+		level:    DefaultLevel,
+	}
+
+	if raw, ok := params["minBytes"]; ok {
+		minBytes, err := extractInt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'minBytes' must be an integer: %w", err)
+		}
+		if minBytes < 0 {
+			return nil, fmt.Errorf("'minBytes' cannot be negative")
+		}
+		p.minBytes = minBytes
+	}
+
+	if raw, ok := params["level"]; ok {
+		level, err := extractInt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'level' must be an integer: %w", err)
+		}
+		if level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, fmt.Errorf("'level' must be between %d and %d", gzip.BestSpeed, gzip.BestCompression)
+		}
+		p.level = level
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *CompressRequestPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need and update encoding headers
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to compress
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest compresses the request body when it is at least minBytes long
+func (p *CompressRequestPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) < p.minBytes || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Never double-encode a body that already has a content coding
+	for _, encoding := range ctx.Headers.Get("content-encoding") {
+		if !strings.EqualFold(strings.TrimSpace(encoding), "identity") {
+			return policy.UpstreamRequestModifications{}
+		}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, bodyutil.Options{}); pass {
+		slog.Debug("CompressRequest: Skipping request body compression", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	var buf bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buf, p.level)
+	if err != nil {
+		slog.Debug("CompressRequest: Failed to create gzip writer", "error", err)
+		return policy.UpstreamRequestModifications{}
+	}
+	if _, err := writer.Write(ctx.Body.Content); err != nil {
+		slog.Debug("CompressRequest: Failed to compress request body", "error", err)
+		return policy.UpstreamRequestModifications{}
+	}
+	if err := writer.Close(); err != nil {
+		slog.Debug("CompressRequest: Failed to compress request body", "error", err)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	compressed := buf.Bytes()
+	if len(compressed) >= len(ctx.Body.Content) {
+		// Already-compact payloads such as images grow under gzip, so keep the original
+		slog.Debug("CompressRequest: Skipping compression that does not shrink the body",
+			"originalBytes", len(ctx.Body.Content), "compressedBytes", len(compressed))
+		return policy.UpstreamRequestModifications{}
+	}
+	slog.Debug("CompressRequest: Compressed request body", "originalBytes", len(ctx.Body.Content), "compressedBytes", len(compressed))
+
+	return policy.UpstreamRequestModifications{
+		Body: compressed,
+		SetHeaders: map[string]string{
+			"content-encoding": "gzip",
+			"content-length":   fmt.Sprintf("%d", len(compressed)),
+		},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *CompressRequestPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package compressrequest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, headers map[string][]string, body []byte) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(headers),
+		Body:    &policy.Body{Content: body, Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"minBytes": -1},
+		{"minBytes": 1.5},
+		{"level": 10},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestCompressRequestPolicy_CompressesLargeBody(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"minBytes": float64(100)})
+	original := []byte(strings.Repeat(`{"item":"value"},`, 100))
+
+	mods := onRequest(p, map[string][]string{"content-type": {"application/json"}}, original)
+	if mods.Body == nil {
+		t.Fatal("Expected body to be compressed")
+	}
+	if len(mods.Body) >= len(original) {
+		t.Errorf("Expected compressed body to be smaller, got %d >= %d", len(mods.Body), len(original))
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(mods.Body))
+	if err != nil {
+		t.Fatalf("Expected gzip body, got %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress body: %v", err)
+	}
+	if !bytes.Equal(decompressed, original) {
+		t.Error("Expected decompressed body to match the original")
+	}
+}
+
+func TestCompressRequestPolicy_HeaderUpdates(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"minBytes": 10, "level": 9})
+	original := []byte(strings.Repeat("a", 500))
+
+	mods := onRequest(p, map[string][]string{"content-length": {"500"}}, original)
+	if mods.SetHeaders["content-encoding"] != "gzip" {
+		t.Errorf("Expected content-encoding gzip, got %q", mods.SetHeaders["content-encoding"])
+	}
+	if mods.SetHeaders["content-length"] != fmt.Sprintf("%d", len(mods.Body)) {
+		t.Errorf("Expected content-length %d, got %q", len(mods.Body), mods.SetHeaders["content-length"])
+	}
+}
+
+func TestCompressRequestPolicy_SmallBodyUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onRequest(p, nil, []byte(`{"small":true}`))
+	if mods.Body != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected small body to be forwarded unchanged, got %v", mods.SetHeaders)
+	}
+}
+
+func TestCompressRequestPolicy_AlreadyEncodedUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"minBytes": 0})
+
+	mods := onRequest(p, map[string][]string{"content-encoding": {"br"}}, []byte(strings.Repeat("a", 100)))
+	if mods.Body != nil {
+		t.Error("Expected already encoded body to be forwarded unchanged")
+	}
+}
+
+func TestCompressRequestPolicy_IncompressibleBodyUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"minBytes": 0})
+
+	// Random bytes do not compress, so gzip framing makes them larger
+	body := make([]byte, 2048)
+	if _, err := rand.Read(body); err != nil {
+		t.Fatal(err)
+	}
+	mods := onRequest(p, nil, body)
+	if mods.Body != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected incompressible body to be forwarded unchanged, got %v", mods.SetHeaders)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package contentnegotiation
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const defaultHeaderName = "x-api-version"
+
+var (
+	// versionRegex validates configured versions such as "v2" or "2"
+	versionRegex = regexp.MustCompile(`^v?(\d+)$`)
+
+	// vendorRegex validates the configured vendor name
+	vendorRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*$`)
+)
+
+// mediaRange is one entry of an Accept header
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// ContentNegotiationPolicy selects the API version from a versioned vendor media type in the
+// Accept header and passes it upstream in a header for routing
+type ContentNegotiationPolicy struct {
+	vendorPattern  *regexp.Regexp
+	supported      map[string]bool
+	versions       []string
+	defaultVersion string
+	headerName     string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	versionsRaw, ok := params["supportedVersions"].([]interface{})
+	if !ok || len(versionsRaw) == 0 {
+		return nil, fmt.Errorf("'supportedVersions' parameter is required and must be a non-empty array")
+	}
+
+	p := &ContentNegotiationPolicy{
+		supported:  make(map[string]bool),
+		headerName: defaultHeaderName,
+	}
+	for i, raw := range versionsRaw {
+		version, ok := normalizeVersion(raw)
+		if !ok {
+			return nil, fmt.Errorf("supportedVersions[%d] must be a version such as 'v2'", i)
+		}
+		if !p.supported[version] {
+			p.supported[version] = true
+			p.versions = append(p.versions, version)
+		}
+	}
+
+	p.defaultVersion = p.versions[0]
+	if raw, ok := params["defaultVersion"]; ok {
+		version, ok := normalizeVersion(raw)
+		if !ok || !p.supported[version] {
+			return nil, fmt.Errorf("'defaultVersion' must be one of the supported versions")
+		}
+		p.defaultVersion = version
+	}
+
+	vendor := `[a-z0-9][a-z0-9.-]*?`
+	if raw, ok := params["vendor"]; ok {
+		v, ok := raw.(string)
+		v = strings.ToLower(strings.TrimSpace(v))
+		if !ok || !vendorRegex.MatchString(v) {
+			return nil, fmt.Errorf("'vendor' must be a vendor name such as 'acme'")
+		}
+		vendor = regexp.QuoteMeta(v)
+	}
+	p.vendorPattern = regexp.MustCompile(`^application/vnd\.` + vendor + `\.(v[^.+]*)\+json$`)
+
+	if raw, ok := params["headerName"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'headerName' must be a non-empty string")
+		}
+		p.headerName = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return p, nil
+}
+
+// normalizeVersion converts "2", "v2" or the number 2 to "v2"
+func normalizeVersion(raw interface{}) (string, bool) {
+	var s string
+	switch v := raw.(type) {
+	case string:
+		s = strings.ToLower(strings.TrimSpace(v))
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		s = strconv.Itoa(v)
+	default:
+		return "", false
+	}
+	matches := versionRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return "", false
+	}
+	n, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return "", false
+	}
+	return "v" + strconv.Itoa(n), true
+}
+
+// Mode returns the processing mode for this policy
+func (p *ContentNegotiationPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the Accept header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest selects the API version from the Accept header and sets the version header
+func (p *ContentNegotiationPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	ranges, err := parseAccept(ctx.Headers.Get("accept"))
+	if err != nil {
+		slog.Debug("ContentNegotiation: Malformed Accept header", "error", err)
+		return errorResponse(http.StatusBadRequest, "Bad Request", "Malformed Accept header")
+	}
+
+	version := ""
+	if len(ranges) == 0 {
+		version = p.defaultVersion
+	}
+	for _, r := range ranges {
+		if matches := p.vendorPattern.FindStringSubmatch(r.mediaType); matches != nil {
+			if v, ok := normalizeVersion(matches[1]); ok && p.supported[v] {
+				version = v
+				break
+			}
+			continue
+		}
+		switch r.mediaType {
+		case "application/json", "application/*", "*/*":
+			version = p.defaultVersion
+		}
+		if version != "" {
+			break
+		}
+	}
+
+	if version == "" {
+		slog.Debug("ContentNegotiation: No acceptable version", "accept", ctx.Headers.Get("accept"))
+		return errorResponse(http.StatusNotAcceptable, "Not Acceptable",
+			fmt.Sprintf("None of the requested media types are supported. Supported versions: %s", strings.Join(p.versions, ", ")))
+	}
+
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{
+			p.headerName: version,
+		},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *ContentNegotiationPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// parseAccept parses Accept header values into media ranges ordered by preference. Ranges with
+// q=0 are dropped.
+func parseAccept(values []string) ([]mediaRange, error) {
+	var ranges []mediaRange
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			mediaType, mediaParams, err := mime.ParseMediaType(part)
+			if err != nil {
+				return nil, err
+			}
+			q := 1.0
+			if raw, ok := mediaParams["q"]; ok {
+				q, err = strconv.ParseFloat(raw, 64)
+				if err != nil || q < 0 || q > 1 {
+					return nil, fmt.Errorf("invalid q-value %q", raw)
+				}
+			}
+			if q > 0 {
+				ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+			}
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges, nil
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, title, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
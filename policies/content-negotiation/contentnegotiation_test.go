@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package contentnegotiation
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	if _, ok := params["supportedVersions"]; !ok {
+		params["supportedVersions"] = []interface{}{"v1", "v2"}
+	}
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onRequest(p policy.Policy, accept ...string) policy.RequestAction {
+	headers := map[string][]string{}
+	if len(accept) > 0 {
+		headers["accept"] = accept
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func selectedVersion(t *testing.T, action policy.RequestAction) string {
+	t.Helper()
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected request to be forwarded, got %+v", action)
+	}
+	return mods.SetHeaders["x-api-version"]
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"supportedVersions": []interface{}{}},
+		{"supportedVersions": []interface{}{"beta"}},
+		{"supportedVersions": []interface{}{"v1"}, "defaultVersion": "v3"},
+		{"supportedVersions": []interface{}{"v1"}, "vendor": "Acme Corp"},
+		{"supportedVersions": []interface{}{"v1"}, "headerName": ""},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestContentNegotiationPolicy_SupportedVersion(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"vendor": "acme"})
+
+	if got := selectedVersion(t, onRequest(p, "application/vnd.acme.v2+json")); got != "v2" {
+		t.Errorf("Expected v2, got %q", got)
+	}
+
+	// Preference order follows q-values
+	got := selectedVersion(t, onRequest(p, "application/vnd.acme.v1+json;q=0.5, application/vnd.acme.v2+json"))
+	if got != "v2" {
+		t.Errorf("Expected v2 by q-value, got %q", got)
+	}
+}
+
+func TestContentNegotiationPolicy_UnsupportedVersion(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"vendor": "acme"})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/vnd.acme.v3+json"), 406)
+	// Another vendor's media type is not acceptable either
+	policytest.ExpectStatus(t, onRequest(p, "application/vnd.other.v1+json"), 406)
+	policytest.ExpectStatus(t, onRequest(p, "text/html"), 406)
+
+	// A lower-preference fallback is still honored
+	got := selectedVersion(t, onRequest(p, "application/vnd.acme.v3+json, application/json;q=0.1"))
+	if got != "v1" {
+		t.Errorf("Expected fallback to default v1, got %q", got)
+	}
+}
+
+func TestContentNegotiationPolicy_PlainJSONFallsBackToDefault(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"defaultVersion": "2"})
+
+	for _, accept := range [][]string{{"application/json"}, {"*/*"}, nil} {
+		if got := selectedVersion(t, onRequest(p, accept...)); got != "v2" {
+			t.Errorf("Expected default v2 for Accept %v, got %q", accept, got)
+		}
+	}
+}
+
+func TestContentNegotiationPolicy_MalformedMediaType(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/vnd.acme.v2+json;q=high"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "not a media type"), 400)
+	// A vendor media type without a usable version is not acceptable
+	policytest.ExpectStatus(t, onRequest(p, "application/vnd.acme.vnext+json"), 406)
+}
@@ -0,0 +1,147 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package cookielimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// CookieLimitPolicy rejects requests whose Cookie header carries too many cookies or too many bytes
+type CookieLimitPolicy struct {
+	maxCookies int // 0 disables the count check
+	maxBytes   int // 0 disables the size check
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &CookieLimitPolicy{}
+
+	if raw, ok := params["maxCookies"]; ok {
+		maxCookies, err := extractInt(raw)
+		if err != nil || maxCookies < 1 {
+			return nil, fmt.Errorf("'maxCookies' must be a positive integer")
+		}
+		p.maxCookies = maxCookies
+	}
+
+	if raw, ok := params["maxBytes"]; ok {
+		maxBytes, err := extractInt(raw)
+		if err != nil || maxBytes < 1 {
+			return nil, fmt.Errorf("'maxBytes' must be a positive integer")
+		}
+		p.maxBytes = maxBytes
+	}
+
+	if p.maxCookies == 0 && p.maxBytes == 0 {
+		return nil, fmt.Errorf("at least one of 'maxCookies' or 'maxBytes' must be configured")
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *CookieLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need Cookie header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests whose cookies exceed the configured count or total size with 431
+func (p *CookieLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get("cookie")
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Several Cookie headers are combined into one ("; "-separated) before being forwarded
+	size, count := 0, 0
+	for i, value := range values {
+		if i > 0 {
+			size += len("; ")
+		}
+		size += len(value)
+		for _, pair := range strings.Split(value, ";") {
+			if strings.TrimSpace(pair) != "" {
+				count++
+			}
+		}
+	}
+
+	if p.maxBytes > 0 && size > p.maxBytes {
+		slog.Debug("CookieLimit: Cookie header too large", "bytes", size, "maxBytes", p.maxBytes)
+		return tooLarge(fmt.Sprintf("Cookie header is %d bytes, at most %d allowed", size, p.maxBytes))
+	}
+	if p.maxCookies > 0 && count > p.maxCookies {
+		slog.Debug("CookieLimit: Too many cookies", "cookies", count, "maxCookies", p.maxCookies)
+		return tooLarge(fmt.Sprintf("Request has %d cookies, at most %d allowed", count, p.maxCookies))
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *CookieLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// tooLarge builds a 431 response with a JSON error body
+func tooLarge(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Request Header Fields Too Large",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusRequestHeaderFieldsTooLarge,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package cookielimit
+
+import (
+	"strings"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, cookies []string) policy.RequestAction {
+	headers := map[string][]string{}
+	if cookies != nil {
+		headers["cookie"] = cookies
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxCookies": 0},
+		{"maxBytes": -1},
+		{"maxBytes": "big"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestCookieLimitPolicy_TooManyCookies(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxCookies": float64(3)})
+
+	policytest.ExpectStatus(t, onRequest(p, []string{"a=1; b=2; c=3; d=4"}), 431)
+	// Cookies across several headers are counted together
+	policytest.ExpectStatus(t, onRequest(p, []string{"a=1; b=2", "c=3; d=4"}), 431)
+}
+
+func TestCookieLimitPolicy_OversizedTotal(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxBytes": 64})
+
+	policytest.ExpectStatus(t, onRequest(p, []string{"session=" + strings.Repeat("x", 60)}), 431)
+	policytest.ExpectStatus(t, onRequest(p, []string{"a=" + strings.Repeat("x", 30), "b=" + strings.Repeat("y", 30)}), 431)
+}
+
+func TestCookieLimitPolicy_NormalCookiesPass(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxCookies": 3, "maxBytes": 64})
+
+	policytest.ExpectStatus(t, onRequest(p, []string{"session=abc; theme=dark; ;"}), 0)
+	policytest.ExpectStatus(t, onRequest(p, nil), 0)
+}
@@ -0,0 +1,182 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package correlation
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	DefaultHeaderName = "x-correlation-id"
+
+	// MetadataKey is the shared metadata key under which the correlation ID is stored so that
+	// other policies in the chain can read it
+	MetadataKey = "correlation:id"
+
+	FormatUUID = "uuid"
+	FormatHex  = "hex"
+
+	// maxInboundLength bounds inbound IDs that are reused
+	maxInboundLength = 256
+)
+
+// CorrelationPolicy generates or propagates a correlation ID on the request and the response
+type CorrelationPolicy struct {
+	requestHeader  string
+	responseHeader string
+	format         string
+	randRead       func([]byte) (int, error) // Injectable randomness source (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &CorrelationPolicy{
+		requestHeader: DefaultHeaderName,
+		format:        FormatUUID,
+		randRead:      rand.Read,
+	}
+
+	if raw, ok := params["requestHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'requestHeader' must be a non-empty string")
+		}
+		p.requestHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	// The response header defaults to the request header name
+	p.responseHeader = p.requestHeader
+	if raw, ok := params["responseHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'responseHeader' must be a non-empty string")
+		}
+		p.responseHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["format"]; ok {
+		format, ok := raw.(string)
+		if !ok || (format != FormatUUID && format != FormatHex) {
+			return nil, fmt.Errorf("'format' must be one of %s, %s", FormatUUID, FormatHex)
+		}
+		p.format = format
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *CorrelationPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Read or set the correlation ID
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Echo the correlation ID to the client
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest reuses an inbound correlation ID or generates a new one
+func (p *CorrelationPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if values := ctx.Headers.Get(p.requestHeader); len(values) > 0 {
+		if id := strings.TrimSpace(values[0]); isValidID(id) {
+			p.store(ctx.SharedContext, id)
+			return policy.UpstreamRequestModifications{}
+		}
+		slog.Debug("Correlation: Replacing invalid inbound correlation ID", "header", p.requestHeader)
+	}
+
+	id, err := p.generate()
+	if err != nil {
+		slog.Debug("Correlation: Failed to generate correlation ID", "error", err)
+		return policy.UpstreamRequestModifications{}
+	}
+	p.store(ctx.SharedContext, id)
+
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{
+			p.requestHeader: id,
+		},
+	}
+}
+
+// OnResponse returns the correlation ID to the client
+func (p *CorrelationPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.SharedContext == nil {
+		return policy.UpstreamResponseModifications{}
+	}
+	id, ok := ctx.Metadata[MetadataKey].(string)
+	if !ok || id == "" {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.responseHeader: id,
+		},
+	}
+}
+
+// store records the correlation ID in the shared metadata
+func (p *CorrelationPolicy) store(shared *policy.SharedContext, id string) {
+	if shared == nil {
+		return
+	}
+	if shared.Metadata == nil {
+		shared.Metadata = make(map[string]interface{})
+	}
+	shared.Metadata[MetadataKey] = id
+}
+
+// generate creates a new random correlation ID in the configured format
+func (p *CorrelationPolicy) generate() (string, error) {
+	b := make([]byte, 16)
+	if _, err := p.randRead(b); err != nil {
+		return "", err
+	}
+	if p.format == FormatHex {
+		return hex.EncodeToString(b), nil
+	}
+
+	// RFC 9562 version 4 (random) UUID
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	s := hex.EncodeToString(b)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], nil
+}
+
+// isValidID reports whether an inbound ID is safe to propagate: non-empty, bounded and made of
+// visible ASCII characters only
+func isValidID(id string) bool {
+	if id == "" || len(id) > maxInboundLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
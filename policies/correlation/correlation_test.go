@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package correlation
+
+import (
+	"regexp"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// roundTrip runs the request and response phases with a shared context
+func roundTrip(p policy.Policy, headers map[string][]string) (policy.UpstreamRequestModifications, policy.UpstreamResponseModifications, *policy.SharedContext) {
+	shared := &policy.SharedContext{Metadata: map[string]interface{}{}}
+	reqMods := p.OnRequest(&policy.RequestContext{SharedContext: shared, Headers: policy.NewHeaders(headers)}, nil).(policy.UpstreamRequestModifications)
+	respMods := p.OnResponse(&policy.ResponseContext{SharedContext: shared}, nil).(policy.UpstreamResponseModifications)
+	return reqMods, respMods, shared
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"requestHeader": ""},
+		{"responseHeader": 5},
+		{"format": "ulid"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestCorrelationPolicy_Generation(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	reqMods, _, shared := roundTrip(p, nil)
+	id := reqMods.SetHeaders[DefaultHeaderName]
+	if !uuidPattern.MatchString(id) {
+		t.Errorf("Expected a version 4 UUID, got %q", id)
+	}
+	if shared.Metadata[MetadataKey] != id {
+		t.Errorf("Expected ID to be stored in metadata, got %v", shared.Metadata[MetadataKey])
+	}
+
+	hexPolicy := policytest.New(t, GetPolicy, map[string]interface{}{"format": FormatHex})
+	reqMods, _, _ = roundTrip(hexPolicy, nil)
+	if !regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(reqMods.SetHeaders[DefaultHeaderName]) {
+		t.Errorf("Expected 32 hex characters, got %q", reqMods.SetHeaders[DefaultHeaderName])
+	}
+}
+
+func TestCorrelationPolicy_PropagatesBothDirections(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"requestHeader":  "X-Request-Correlation",
+		"responseHeader": "X-Correlation-Id",
+	})
+
+	reqMods, respMods, _ := roundTrip(p, nil)
+	id := reqMods.SetHeaders["x-request-correlation"]
+	if id == "" {
+		t.Fatal("Expected correlation ID on the upstream request")
+	}
+	if respMods.SetHeaders["x-correlation-id"] != id {
+		t.Errorf("Expected response header %q, got %q", id, respMods.SetHeaders["x-correlation-id"])
+	}
+}
+
+func TestCorrelationPolicy_ReusesInboundID(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	reqMods, respMods, shared := roundTrip(p, map[string][]string{DefaultHeaderName: {"abc-123"}})
+	if len(reqMods.SetHeaders) != 0 {
+		t.Errorf("Expected inbound ID to be forwarded unchanged, got %v", reqMods.SetHeaders)
+	}
+	if respMods.SetHeaders[DefaultHeaderName] != "abc-123" {
+		t.Errorf("Expected inbound ID on the response, got %q", respMods.SetHeaders[DefaultHeaderName])
+	}
+	if shared.Metadata[MetadataKey] != "abc-123" {
+		t.Errorf("Expected inbound ID in metadata, got %v", shared.Metadata[MetadataKey])
+	}
+}
+
+func TestCorrelationPolicy_ReplacesInvalidInboundID(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	reqMods, _, _ := roundTrip(p, map[string][]string{DefaultHeaderName: {"has spaces\x01"}})
+	if !uuidPattern.MatchString(reqMods.SetHeaders[DefaultHeaderName]) {
+		t.Errorf("Expected invalid inbound ID to be replaced, got %q", reqMods.SetHeaders[DefaultHeaderName])
+	}
+}
@@ -0,0 +1,383 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package costlimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/clientkey"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+const (
+	// Cost sources
+	CostStatic = "static"
+	CostHeader = "header"
+	CostBody   = "body"
+
+	remainingHeader = "x-cost-remaining"
+
+	// Metadata key for passing the remaining budget from the request to the response phase
+	remainingKey = "costlimit:remaining"
+)
+
+// bucket is a client's budget, refilled continuously at the configured rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// CostLimitPolicy deducts a per-request cost from a per-client budget that refills over time
+type CostLimitPolicy struct {
+	budget     float64
+	refillRate float64 // Tokens added per second
+
+	costSource  string
+	staticCost  int
+	costName    string        // Header name or JSONPath of the cost
+	costPath    jsonpath.Path // Field path for body costs
+	defaultCost int           // Cost used when the header or field is absent
+
+	clientKey clientkey.Key
+
+	mu        sync.Mutex
+	now       func() time.Time // Injectable clock (for testing)
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	budgetRaw, ok := params["budget"]
+	if !ok {
+		return nil, fmt.Errorf("'budget' parameter is required")
+	}
+	budget, err := extractInt(budgetRaw)
+	if err != nil || budget < 1 {
+		return nil, fmt.Errorf("'budget' must be a positive integer")
+	}
+
+	p := &CostLimitPolicy{
+		budget:      float64(budget),
+		refillRate:  float64(budget) / 60,
+		costSource:  CostStatic,
+		staticCost:  1,
+		defaultCost: 1,
+		now:         time.Now,
+		buckets:     make(map[string]*bucket),
+	}
+
+	if raw, ok := params["refill"]; ok {
+		refill, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'refill' must be an object")
+		}
+		amount, interval := budget, 60
+		if raw, ok := refill["amount"]; ok {
+			if amount, err = extractInt(raw); err != nil || amount < 1 {
+				return nil, fmt.Errorf("'refill.amount' must be a positive integer")
+			}
+		}
+		if raw, ok := refill["intervalSeconds"]; ok {
+			if interval, err = extractInt(raw); err != nil || interval < 1 {
+				return nil, fmt.Errorf("'refill.intervalSeconds' must be a positive integer")
+			}
+		}
+		p.refillRate = float64(amount) / float64(interval)
+	}
+
+	if raw, ok := params["cost"]; ok {
+		if err := p.parseCost(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	clientKey, err := clientkey.Parse(params["clientKey"])
+	if err != nil {
+		return nil, err
+	}
+	p.clientKey = clientKey
+
+	return p, nil
+}
+
+// parseCost parses the cost source configuration
+func (p *CostLimitPolicy) parseCost(raw interface{}) error {
+	cost, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("'cost' must be an object")
+	}
+
+	source, _ := cost["source"].(string)
+	switch source {
+	case CostStatic:
+		if raw, ok := cost["value"]; ok {
+			value, err := extractInt(raw)
+			if err != nil || value < 0 {
+				return fmt.Errorf("'cost.value' must be a non-negative integer")
+			}
+			p.staticCost = value
+		}
+	case CostHeader, CostBody:
+		name, ok := cost["name"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("'cost.name' is required for source '%s'", source)
+		}
+		name = strings.TrimSpace(name)
+		p.costName = name
+		if source == CostHeader {
+			p.costName = strings.ToLower(name)
+		} else {
+			path, err := jsonpath.Parse(name)
+			if err != nil {
+				return fmt.Errorf("'cost.name' must be a valid JSONPath for source 'body': %w", err)
+			}
+			// The cost is a single value, so the path can't select several fields
+			for _, segment := range path {
+				if segment == "*" || segment == "[*]" {
+					return fmt.Errorf("'cost.name' must not contain wildcards: %s", name)
+				}
+			}
+			p.costPath = path
+		}
+		if raw, ok := cost["default"]; ok {
+			value, err := extractInt(raw)
+			if err != nil || value < 0 {
+				return fmt.Errorf("'cost.default' must be a non-negative integer")
+			}
+			p.defaultCost = value
+		}
+	default:
+		return fmt.Errorf("'cost.source' must be one of: static, header, body")
+	}
+	p.costSource = source
+	return nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *CostLimitPolicy) Mode() policy.ProcessingMode {
+	// The body is only needed when the cost is read from it
+	requestBodyMode := policy.BodyModeSkip
+	if p.costSource == CostBody {
+		requestBodyMode = policy.BodyModeBuffer
+	}
+
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need client key and cost headers
+		RequestBodyMode:    requestBodyMode,
+		ResponseHeaderMode: policy.HeaderModeProcess, // Sets the remaining budget header
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest deducts the request's cost from the client's budget, rejecting the request when the
+// budget can't cover it. A body cost fails closed: bodies that can't be read are rejected rather
+// than charged the default cost.
+func (p *CostLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	// Compressed bodies can't be inspected without decoding them
+	if p.costSource == CostBody && ctx.Body != nil && ctx.Body.Present && len(ctx.Body.Content) > 0 &&
+		bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("CostLimit: Rejecting encoded request body")
+		return errorResponse(http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType),
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding", nil)
+	}
+
+	cost, err := p.requestCost(ctx)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "Bad Request", err.Error(), nil)
+	}
+
+	key := p.clientKey.Resolve(ctx)
+	allowed, remaining, retryAfter := p.take(key, cost)
+	if !allowed {
+		slog.Debug("CostLimit: Budget overdrawn", "key", key, "cost", cost, "remaining", remaining)
+		headers := map[string]string{remainingHeader: strconv.Itoa(remaining)}
+		if retryAfter > 0 {
+			headers["retry-after"] = strconv.FormatInt(max(int64(math.Ceil(retryAfter.Seconds())), 1), 10)
+		}
+		return errorResponse(http.StatusTooManyRequests, "Too Many Requests",
+			fmt.Sprintf("Request cost %d exceeds the remaining budget of %d", cost, remaining), headers)
+	}
+
+	if ctx.SharedContext != nil {
+		if ctx.Metadata == nil {
+			ctx.Metadata = make(map[string]interface{})
+		}
+		ctx.Metadata[remainingKey] = remaining
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse reports the client's remaining budget
+func (p *CostLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.SharedContext == nil {
+		return policy.UpstreamResponseModifications{}
+	}
+	remaining, ok := ctx.Metadata[remainingKey].(int)
+	if !ok {
+		return policy.UpstreamResponseModifications{}
+	}
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{remainingHeader: strconv.Itoa(remaining)},
+	}
+}
+
+// requestCost returns the cost of the request from the configured source
+func (p *CostLimitPolicy) requestCost(ctx *policy.RequestContext) (int, error) {
+	switch p.costSource {
+	case CostHeader:
+		values := ctx.Headers.Get(p.costName)
+		if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+			return p.defaultCost, nil
+		}
+		cost, err := strconv.Atoi(strings.TrimSpace(values[0]))
+		if err != nil || cost < 0 {
+			return 0, fmt.Errorf("header '%s' must be a non-negative integer", p.costName)
+		}
+		return cost, nil
+	case CostBody:
+		if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+			return p.defaultCost, nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(ctx.Body.Content))
+		decoder.UseNumber()
+		var data interface{}
+		if err := decoder.Decode(&data); err != nil {
+			return 0, fmt.Errorf("request body must be valid JSON to determine its cost")
+		}
+		var value interface{}
+		found := false
+		jsonpath.Walk(data, func(path []string, node interface{}) jsonpath.Transform {
+			if !found && p.costPath.Matches(path) {
+				value, found = node, true
+			}
+			return nil
+		})
+		if !found {
+			return p.defaultCost, nil
+		}
+		number, ok := value.(json.Number)
+		cost, err := strconv.Atoi(number.String())
+		if !ok || err != nil || cost < 0 {
+			return 0, fmt.Errorf("body field '%s' must be a non-negative integer", p.costName)
+		}
+		return cost, nil
+	default:
+		return p.staticCost, nil
+	}
+}
+
+// take refills the client's bucket and deducts cost if it is covered. It returns whether the
+// request is allowed, the whole tokens remaining and, when rejected, how long until the cost
+// would be covered.
+func (p *CostLimitPolicy) take(key string, cost int) (bool, int, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.sweep(now)
+
+	b, ok := p.buckets[key]
+	if !ok {
+		b = &bucket{tokens: p.budget, last: now}
+		p.buckets[key] = b
+	} else if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(p.budget, b.tokens+elapsed*p.refillRate)
+		b.last = now
+	}
+
+	if float64(cost) > b.tokens {
+		var retryAfter time.Duration
+		if float64(cost) <= p.budget {
+			retryAfter = time.Duration((float64(cost) - b.tokens) / p.refillRate * float64(time.Second))
+		}
+		return false, int(b.tokens), retryAfter
+	}
+	b.tokens -= float64(cost)
+	return true, int(b.tokens), 0
+}
+
+// sweep evicts buckets that have refilled completely, since a new bucket starts full. It runs
+// at most once per full refill period. Callers must hold p.mu.
+func (p *CostLimitPolicy) sweep(now time.Time) {
+	period := time.Duration(p.budget / p.refillRate * float64(time.Second))
+	if p.lastSweep.IsZero() {
+		p.lastSweep = now
+		return
+	}
+	if now.Sub(p.lastSweep) < period {
+		return
+	}
+	for key, b := range p.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*p.refillRate >= p.budget {
+			delete(p.buckets, key)
+		}
+	}
+	p.lastSweep = now
+}
+
+// errorResponse builds a JSON error response with optional extra headers
+func errorResponse(status int, title, message string, extra map[string]string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	headers := map[string]string{
+		"content-type": "application/json",
+	}
+	for name, value := range extra {
+		headers[name] = value
+	}
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
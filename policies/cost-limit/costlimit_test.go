@@ -0,0 +1,133 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package costlimit
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}, now *time.Time) *CostLimitPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	cp := p.(*CostLimitPolicy)
+	cp.now = func() time.Time { return *now }
+	return cp
+}
+
+func newRequest(headers map[string][]string, body string) *policy.RequestContext {
+	return &policy.RequestContext{
+		SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}},
+		Headers:       policy.NewHeaders(headers),
+		Body:          &policy.Body{Content: []byte(body), Present: body != "", EndOfStream: true},
+	}
+}
+
+func onRequest(p policy.Policy, headers map[string][]string, body string) policy.RequestAction {
+	return p.OnRequest(newRequest(headers, body), nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"budget": 0},
+		{"budget": 10, "refill": map[string]interface{}{"amount": 0}},
+		{"budget": 10, "refill": map[string]interface{}{"intervalSeconds": -1}},
+		{"budget": 10, "cost": map[string]interface{}{"source": "query"}},
+		{"budget": 10, "cost": map[string]interface{}{"source": "header"}},
+		{"budget": 10, "cost": map[string]interface{}{"source": "body", "name": "cost"}},
+		{"budget": 10, "cost": map[string]interface{}{"source": "body", "name": "$.items[*].cost"}},
+		{"budget": 10, "cost": map[string]interface{}{"source": "static", "value": -1}},
+		{"budget": 10, "clientKey": map[string]interface{}{"type": "header"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestCostLimitPolicy_VariableCostDeductions(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{
+		"budget": 10,
+		"cost":   map[string]interface{}{"source": "body", "name": "$.query.cost", "default": 2},
+	}, &now)
+
+	policytest.ExpectStatus(t, onRequest(p, nil, `{"query":{"cost":3}}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, nil, `{"query":{}}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, nil, `{"query":{"cost":"high"}}`), 400)
+	// Bodies that can't be read are rejected rather than charged the default
+	policytest.ExpectStatus(t, onRequest(p, nil, `{"query":`), 400)
+	encoded := newRequest(map[string][]string{"content-encoding": {"gzip"}}, "\x1f\x8b compressed")
+	policytest.ExpectStatus(t, p.OnRequest(encoded, nil), 415)
+	if tokens := p.buckets["unknown"].tokens; tokens != 5 {
+		t.Errorf("Expected 5 tokens left after costs 3 and 2, got %v", tokens)
+	}
+
+	// Array elements can be indexed
+	a := newPolicy(t, map[string]interface{}{
+		"budget": 10,
+		"cost":   map[string]interface{}{"source": "body", "name": "$.operations[1].cost"},
+	}, &now)
+	policytest.ExpectStatus(t, onRequest(a, nil, `{"operations":[{"cost":1},{"cost":6}]}`), 0)
+	if tokens := a.buckets["unknown"].tokens; tokens != 4 {
+		t.Errorf("Expected 4 tokens left after cost 6, got %v", tokens)
+	}
+
+	h := newPolicy(t, map[string]interface{}{
+		"budget":    10,
+		"cost":      map[string]interface{}{"source": "header", "name": "X-Query-Cost"},
+		"clientKey": map[string]interface{}{"type": "header", "key": "x-client"},
+	}, &now)
+	policytest.ExpectStatus(t, onRequest(h, map[string][]string{"x-client": {"a"}, "x-query-cost": {"7"}}, ""), 0)
+	policytest.ExpectStatus(t, onRequest(h, map[string][]string{"x-client": {"b"}, "x-query-cost": {"7"}}, ""), 0)
+}
+
+func TestCostLimitPolicy_OverdraftRejected(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{
+		"budget": 10,
+		"refill": map[string]interface{}{"amount": 1, "intervalSeconds": 1},
+		"cost":   map[string]interface{}{"source": "header", "name": "x-cost"},
+	}, &now)
+
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-cost": {"8"}}, ""), 0)
+	resp := policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-cost": {"5"}}, ""), 429)
+	if resp.Headers["retry-after"] != "3" || resp.Headers["x-cost-remaining"] != "2" {
+		t.Errorf("Expected retry-after 3 and 2 remaining, got %v", resp.Headers)
+	}
+
+	// The budget refills over time
+	now = now.Add(3 * time.Second)
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-cost": {"5"}}, ""), 0)
+}
+
+func TestCostLimitPolicy_RemainingHeader(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{
+		"budget": 100,
+		"cost":   map[string]interface{}{"source": "static", "value": 30},
+	}, &now)
+
+	ctx := newRequest(nil, "")
+	policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 0)
+	mods := p.OnResponse(&policy.ResponseContext{SharedContext: ctx.SharedContext}, nil).(policy.UpstreamResponseModifications)
+	if mods.SetHeaders["x-cost-remaining"] != "70" {
+		t.Errorf("Expected x-cost-remaining 70, got %v", mods.SetHeaders)
+	}
+}
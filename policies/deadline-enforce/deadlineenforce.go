@@ -0,0 +1,200 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package deadlineenforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	DefaultHeaderName = "x-request-received-at"
+
+	OnInvalidPassthrough = "passthrough"
+	OnInvalidReject      = "reject"
+)
+
+// DeadlineEnforcePolicy rejects requests whose end-to-end time budget, measured from an
+// edge-provided receive timestamp, has already elapsed
+type DeadlineEnforcePolicy struct {
+	budget             time.Duration
+	headerName         string
+	onInvalidTimestamp string
+	now                func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &DeadlineEnforcePolicy{
+		headerName:         DefaultHeaderName,
+		onInvalidTimestamp: OnInvalidPassthrough,
+		now:                time.Now,
+	}
+
+	budgetRaw, ok := params["budgetMs"]
+	if !ok {
+		return nil, fmt.Errorf("'budgetMs' parameter is required")
+	}
+	budgetMs, err := extractInt(budgetRaw)
+	if err != nil {
+		return nil, fmt.Errorf("'budgetMs' must be an integer: %w", err)
+	}
+	if budgetMs <= 0 {
+		return nil, fmt.Errorf("'budgetMs' must be greater than 0")
+	}
+	p.budget = time.Duration(budgetMs) * time.Millisecond
+
+	if raw, ok := params["headerName"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'headerName' must be a non-empty string")
+		}
+		p.headerName = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["onInvalidTimestamp"]; ok {
+		onInvalid, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'onInvalidTimestamp' must be a string")
+		}
+		switch onInvalid {
+		case OnInvalidPassthrough, OnInvalidReject:
+			p.onInvalidTimestamp = onInvalid
+		default:
+			return nil, fmt.Errorf("'onInvalidTimestamp' must be one of: passthrough, reject")
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *DeadlineEnforcePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the receive timestamp header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest returns 504 when the request budget has already been spent
+func (p *DeadlineEnforcePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	var raw string
+	if values := ctx.Headers.Get(p.headerName); len(values) > 0 {
+		raw = strings.TrimSpace(values[0])
+	}
+
+	receivedAt, err := parseTimestamp(raw)
+	if err != nil {
+		if p.onInvalidTimestamp == OnInvalidReject {
+			slog.Debug("DeadlineEnforce: Rejecting request with invalid timestamp", "header", p.headerName, "error", err)
+			body, _ := json.Marshal(map[string]string{
+				"error":   "Bad Request",
+				"message": fmt.Sprintf("Header '%s' %s", p.headerName, err.Error()),
+			})
+			return policy.ImmediateResponse{
+				StatusCode: http.StatusBadRequest,
+				Headers: map[string]string{
+					"content-type": "application/json",
+				},
+				Body: body,
+			}
+		}
+		return policy.UpstreamRequestModifications{}
+	}
+
+	elapsed := p.now().Sub(receivedAt)
+	if elapsed <= p.budget {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("DeadlineEnforce: Request deadline exceeded", "elapsed", elapsed, "budget", p.budget)
+
+	body, _ := json.Marshal(map[string]string{
+		"error": "Gateway Timeout",
+		"message": fmt.Sprintf("Request deadline exceeded: %dms elapsed since the request was received, budget is %dms",
+			elapsed.Milliseconds(), p.budget.Milliseconds()),
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusGatewayTimeout,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *DeadlineEnforcePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// parseTimestamp accepts RFC 3339 timestamps or Unix epoch values in seconds, milliseconds or
+// microseconds (distinguished by magnitude; fractional seconds are allowed)
+func parseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("is missing")
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	epoch, err := strconv.ParseFloat(value, 64)
+	if err != nil || epoch <= 0 || math.IsInf(epoch, 0) {
+		return time.Time{}, fmt.Errorf("is not a valid timestamp")
+	}
+	switch {
+	case epoch >= 1e15:
+		return time.UnixMicro(int64(epoch)), nil
+	case epoch >= 1e12:
+		return time.UnixMilli(int64(epoch)), nil
+	default:
+		sec, frac := math.Modf(epoch)
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
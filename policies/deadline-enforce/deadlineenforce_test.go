@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package deadlineenforce
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var fixedNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *DeadlineEnforcePolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	dp := p.(*DeadlineEnforcePolicy)
+	dp.now = func() time.Time { return fixedNow }
+	return dp
+}
+
+func onRequest(p *DeadlineEnforcePolicy, headers map[string][]string) policy.RequestAction {
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"budgetMs": 0},
+		{"budgetMs": 1.5},
+		{"budgetMs": 100, "onInvalidTimestamp": "ignore"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestDeadlineEnforcePolicy_ExceededDeadline(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"budgetMs": float64(500)})
+	receivedAt := strconv.FormatInt(fixedNow.Add(-750*time.Millisecond).UnixMilli(), 10)
+
+	action := onRequest(p, map[string][]string{"x-request-received-at": {receivedAt}})
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("Expected status 504, got %d", resp.StatusCode)
+	}
+	expected := `{"error":"Gateway Timeout","message":"Request deadline exceeded: 750ms elapsed since the request was received, budget is 500ms"}`
+	if string(resp.Body) != expected {
+		t.Errorf("Expected body %s, got %s", expected, resp.Body)
+	}
+}
+
+func TestDeadlineEnforcePolicy_WithinBudget(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"budgetMs": 1000, "headerName": "X-Edge-Start"})
+
+	timestamps := []string{
+		fixedNow.Add(-200 * time.Millisecond).Format(time.RFC3339Nano),
+		strconv.FormatInt(fixedNow.Add(-200*time.Millisecond).UnixMicro(), 10),
+		"1772366399.5",
+	}
+	for _, ts := range timestamps {
+		if _, ok := onRequest(p, map[string][]string{"x-edge-start": {ts}}).(policy.UpstreamRequestModifications); !ok {
+			t.Errorf("Expected request with timestamp %s to pass", ts)
+		}
+	}
+}
+
+func TestDeadlineEnforcePolicy_MalformedTimestamp(t *testing.T) {
+	headers := map[string][]string{"x-request-received-at": {"yesterday"}}
+
+	p := newPolicy(t, map[string]interface{}{"budgetMs": 100})
+	if _, ok := onRequest(p, headers).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected malformed timestamp to pass through by default")
+	}
+
+	p = newPolicy(t, map[string]interface{}{"budgetMs": 100, "onInvalidTimestamp": OnInvalidReject})
+	resp, ok := onRequest(p, headers).(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed timestamp, got %v", resp.StatusCode)
+	}
+}
+
+func TestDeadlineEnforcePolicy_MissingTimestamp(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"budgetMs": 100})
+	if _, ok := onRequest(p, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected missing timestamp to pass through by default")
+	}
+
+	p = newPolicy(t, map[string]interface{}{"budgetMs": 100, "onInvalidTimestamp": OnInvalidReject})
+	if _, ok := onRequest(p, nil).(policy.ImmediateResponse); !ok {
+		t.Error("Expected missing timestamp to be rejected")
+	}
+}
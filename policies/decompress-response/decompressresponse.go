@@ -0,0 +1,184 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package decompressresponse
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const DefaultMaxDecompressedBytes = 10 * 1024 * 1024
+
+// DecompressResponsePolicy decodes gzip/deflate encoded response bodies so that later body
+// policies can inspect and modify the plaintext
+type DecompressResponsePolicy struct {
+	maxDecompressedBytes int64
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &DecompressResponsePolicy{
+		maxDecompressedBytes: DefaultMaxDecompressedBytes,
+	}
+
+	if raw, ok := params["maxDecompressedBytes"]; ok {
+		limit, err := extractInt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'maxDecompressedBytes' must be an integer: %w", err)
+		}
+		if limit <= 0 {
+			return nil, fmt.Errorf("'maxDecompressedBytes' must be greater than 0")
+		}
+		p.maxDecompressedBytes = limit
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *DecompressResponsePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need and update encoding headers
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to decompress
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *DecompressResponsePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse decodes the response body according to its Content-Encoding
+func (p *DecompressResponsePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	encodings := parseEncodings(ctx.ResponseHeaders.Get("content-encoding"))
+	if len(encodings) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+	for _, encoding := range encodings {
+		if encoding != "gzip" && encoding != "x-gzip" && encoding != "deflate" {
+			slog.Debug("DecompressResponse: Skipping unsupported content encoding", "encoding", encoding)
+			return policy.UpstreamResponseModifications{}
+		}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("DecompressResponse: Skipping response body decompression", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Codings are listed in the order they were applied, so decode in reverse
+	body := ctx.ResponseBody.Content
+	for i := len(encodings) - 1; i >= 0; i-- {
+		decoded, err := p.decode(encodings[i], body)
+		if err != nil {
+			slog.Debug("DecompressResponse: Failed to decompress response body", "encoding", encodings[i], "error", err)
+			return policy.UpstreamResponseModifications{}
+		}
+		body = decoded
+	}
+
+	return policy.UpstreamResponseModifications{
+		Body:          body,
+		RemoveHeaders: []string{"content-encoding"},
+		SetHeaders: map[string]string{
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// parseEncodings returns the lower-cased content codings, ignoring identity
+func parseEncodings(values []string) []string {
+	var encodings []string
+	for _, value := range values {
+		for _, token := range strings.Split(value, ",") {
+			token = strings.ToLower(strings.TrimSpace(token))
+			if token != "" && token != "identity" {
+				encodings = append(encodings, token)
+			}
+		}
+	}
+	return encodings
+}
+
+// decode decompresses a single content coding, enforcing the decompressed size limit
+func (p *DecompressResponsePolicy) decode(encoding string, data []byte) ([]byte, error) {
+	var reader io.ReadCloser
+	var err error
+	switch encoding {
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(bytes.NewReader(data))
+	default:
+		// "deflate" is zlib-wrapped per RFC 9110, but some servers send raw DEFLATE data
+		reader, err = zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			reader, err = flate.NewReader(bytes.NewReader(data)), nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, p.maxDecompressedBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decoded)) > p.maxDecompressedBytes {
+		return nil, fmt.Errorf("decompressed body exceeds %d bytes", p.maxDecompressedBytes)
+	}
+	return decoded, nil
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package decompressresponse
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"strings"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+const plaintext = `{"message":"hello from the upstream"}`
+
+func onResponse(p policy.Policy, encoding string, body []byte) policy.UpstreamResponseModifications {
+	headers := map[string][]string{"content-type": {"application/json"}}
+	if encoding != "" {
+		headers["content-encoding"] = []string{encoding}
+	}
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(headers),
+		ResponseBody:    &policy.Body{Content: body, Present: true, EndOfStream: true},
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func gzipBytes(data string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(data))
+	w.Close()
+	return buf.Bytes()
+}
+
+func zlibBytes(data string) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write([]byte(data))
+	w.Close()
+	return buf.Bytes()
+}
+
+func assertDecompressed(t *testing.T, mods policy.UpstreamResponseModifications) {
+	t.Helper()
+	if string(mods.Body) != plaintext {
+		t.Errorf("Expected decompressed body %s, got %q", plaintext, mods.Body)
+	}
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "content-encoding" {
+		t.Errorf("Expected content-encoding to be removed, got %v", mods.RemoveHeaders)
+	}
+	if mods.SetHeaders["content-length"] != "37" {
+		t.Errorf("Expected content-length 37, got %q", mods.SetHeaders["content-length"])
+	}
+}
+
+func TestDecompressResponsePolicy_Gzip(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+	assertDecompressed(t, onResponse(p, "gzip", gzipBytes(plaintext)))
+}
+
+func TestDecompressResponsePolicy_Deflate(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+	assertDecompressed(t, onResponse(p, "Deflate", zlibBytes(plaintext)))
+
+	// Raw DEFLATE without the zlib wrapper
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+	w.Write([]byte(plaintext))
+	w.Close()
+	assertDecompressed(t, onResponse(p, "deflate", buf.Bytes()))
+}
+
+func TestDecompressResponsePolicy_StackedEncodings(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+	body := gzipBytes(string(zlibBytes(plaintext)))
+	assertDecompressed(t, onResponse(p, "deflate, gzip", body))
+}
+
+func TestDecompressResponsePolicy_IdentityUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	for _, encoding := range []string{"", "identity", "br"} {
+		mods := onResponse(p, encoding, []byte(plaintext))
+		if mods.Body != nil || len(mods.RemoveHeaders) != 0 {
+			t.Errorf("Expected %q encoded response to be untouched", encoding)
+		}
+	}
+}
+
+func TestDecompressResponsePolicy_SizeLimit(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxDecompressedBytes": 100})
+
+	mods := onResponse(p, "gzip", gzipBytes(strings.Repeat("a", 1000)))
+	if mods.Body != nil {
+		t.Error("Expected response exceeding the limit to be passed through")
+	}
+}
+
+func TestDecompressResponsePolicy_CorruptBodyUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	mods := onResponse(p, "gzip", []byte("not gzip"))
+	if mods.Body != nil {
+		t.Error("Expected corrupt body to be passed through")
+	}
+}
@@ -0,0 +1,354 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package dedupebybody
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const (
+	// Handling of duplicate submissions
+	ModeReject   = "reject"
+	ModeCoalesce = "coalesce"
+
+	defaultTTL         = 10 * time.Second
+	defaultWaitTimeout = 5 * time.Second
+
+	// entryMetadataKey links a first submission to its entry so OnResponse can record the result
+	entryMetadataKey = "dedupebybody:entry"
+)
+
+// excludedHeaders are response headers that are not copied into replayed responses
+var excludedHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"keep-alive":        true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// storedResponse is the first submission's response replayed to duplicates
+type storedResponse struct {
+	status  int
+	headers map[string]string
+	body    []byte
+}
+
+// entry tracks a first submission for the TTL
+type entry struct {
+	key    string
+	seen   time.Time
+	done   chan struct{}
+	once   sync.Once
+	result *storedResponse // Set before done is closed; nil when the response cannot be replayed
+}
+
+// DedupeByBodyPolicy catches duplicate submissions of the same body within a TTL, rejecting
+// them or replaying the first submission's response
+type DedupeByBodyPolicy struct {
+	ttl         time.Duration
+	mode        string
+	methods     map[string]bool
+	keyHeaders  []string
+	waitTimeout time.Duration
+
+	mu        sync.Mutex
+	now       func() time.Time // Injectable clock (for testing)
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &DedupeByBodyPolicy{
+		ttl:         defaultTTL,
+		mode:        ModeReject,
+		methods:     map[string]bool{"POST": true, "PUT": true, "PATCH": true},
+		keyHeaders:  []string{"authorization"},
+		waitTimeout: defaultWaitTimeout,
+		now:         time.Now,
+		entries:     make(map[string]*entry),
+	}
+
+	if raw, ok := params["ttlSeconds"]; ok {
+		ttl, err := extractInt(raw)
+		if err != nil || ttl < 1 {
+			return nil, fmt.Errorf("'ttlSeconds' must be a positive integer")
+		}
+		p.ttl = time.Duration(ttl) * time.Second
+	}
+
+	if raw, ok := params["mode"]; ok {
+		mode, ok := raw.(string)
+		if !ok || (mode != ModeReject && mode != ModeCoalesce) {
+			return nil, fmt.Errorf("'mode' must be one of %s, %s", ModeReject, ModeCoalesce)
+		}
+		p.mode = mode
+	}
+
+	if raw, ok := params["methods"]; ok {
+		methodsRaw, ok := raw.([]interface{})
+		if !ok || len(methodsRaw) == 0 {
+			return nil, fmt.Errorf("'methods' must be a non-empty array")
+		}
+		p.methods = make(map[string]bool)
+		for i, m := range methodsRaw {
+			method, ok := m.(string)
+			if !ok || strings.TrimSpace(method) == "" {
+				return nil, fmt.Errorf("methods[%d] must be a non-empty string", i)
+			}
+			p.methods[strings.ToUpper(strings.TrimSpace(method))] = true
+		}
+	}
+
+	if raw, ok := params["keyHeaders"]; ok {
+		headersRaw, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'keyHeaders' must be an array")
+		}
+		p.keyHeaders = nil
+		for i, h := range headersRaw {
+			name, ok := h.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("keyHeaders[%d] must be a non-empty string", i)
+			}
+			p.keyHeaders = append(p.keyHeaders, strings.ToLower(strings.TrimSpace(name)))
+		}
+	}
+
+	if raw, ok := params["waitTimeoutMs"]; ok {
+		timeout, err := extractInt(raw)
+		if err != nil || timeout < 1 {
+			return nil, fmt.Errorf("'waitTimeoutMs' must be a positive integer")
+		}
+		p.waitTimeout = time.Duration(timeout) * time.Millisecond
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *DedupeByBodyPolicy) Mode() policy.ProcessingMode {
+	responseBodyMode := policy.BodyModeSkip // Rejected duplicates don't need the response
+	if p.mode == ModeCoalesce {
+		responseBodyMode = policy.BodyModeBuffer // Need response body to replay it
+	}
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need method, path and key headers
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to hash it
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need response status
+		ResponseBodyMode:   responseBodyMode,
+	}
+}
+
+// OnRequest forwards the first submission of a body and catches duplicates within the TTL. In
+// coalesce mode a duplicate waits for the first submission's response and receives a copy; it
+// is rejected with 409 when that response can't be replayed or doesn't arrive in time.
+func (p *DedupeByBodyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if !p.methods[strings.ToUpper(ctx.Method)] || ctx.SharedContext == nil {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, bodyutil.Options{}); pass {
+		slog.Debug("DedupeByBody: Skipping request body", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+	key := p.requestKey(ctx)
+
+	p.mu.Lock()
+	now := p.now()
+	p.sweep(now)
+	e, ok := p.entries[key]
+	if !ok || now.Sub(e.seen) >= p.ttl {
+		e = &entry{key: key, seen: now, done: make(chan struct{})}
+		p.entries[key] = e
+		p.mu.Unlock()
+
+		if ctx.Metadata == nil {
+			ctx.Metadata = make(map[string]interface{})
+		}
+		ctx.Metadata[entryMetadataKey] = e
+		return policy.UpstreamRequestModifications{}
+	}
+	p.mu.Unlock()
+
+	if p.mode == ModeReject {
+		slog.Debug("DedupeByBody: Rejecting duplicate submission")
+		return conflict()
+	}
+
+	timer := time.NewTimer(p.waitTimeout)
+	defer timer.Stop()
+	select {
+	case <-e.done:
+	case <-timer.C:
+		slog.Debug("DedupeByBody: Timed out waiting for the first submission")
+		return conflict()
+	}
+	if e.result == nil {
+		return conflict()
+	}
+
+	headers := make(map[string]string, len(e.result.headers)+1)
+	for name, value := range e.result.headers {
+		headers[name] = value
+	}
+	headers["x-dedupe-replayed"] = "true"
+	return policy.ImmediateResponse{
+		StatusCode: e.result.status,
+		Headers:    headers,
+		Body:       e.result.body,
+	}
+}
+
+// OnResponse records the first submission's response. Entries for 5xx responses are dropped so
+// that retries of a failed submission reach the upstream.
+func (p *DedupeByBodyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.SharedContext == nil {
+		return nil
+	}
+	e, ok := ctx.Metadata[entryMetadataKey].(*entry)
+	if !ok {
+		return nil
+	}
+	delete(ctx.Metadata, entryMetadataKey)
+
+	var result *storedResponse
+	if ctx.ResponseStatus >= 500 {
+		p.mu.Lock()
+		if p.entries[e.key] == e {
+			delete(p.entries, e.key)
+		}
+		p.mu.Unlock()
+	} else if p.mode == ModeCoalesce {
+		result = replayable(ctx)
+	}
+	e.once.Do(func() {
+		e.result = result
+		close(e.done)
+	})
+	return nil
+}
+
+// replayable captures the response for replay. Responses that set cookies are specific to the
+// client and are not replayed.
+func replayable(ctx *policy.ResponseContext) *storedResponse {
+	if ctx.ResponseHeaders.Has("set-cookie") {
+		return nil
+	}
+
+	result := &storedResponse{
+		status:  ctx.ResponseStatus,
+		headers: make(map[string]string),
+	}
+	if result.status == 0 {
+		result.status = 200
+	}
+	ctx.ResponseHeaders.Iterate(func(name string, values []string) {
+		name = strings.ToLower(name)
+		if excludedHeaders[name] || strings.HasPrefix(name, ":") {
+			return
+		}
+		result.headers[name] = strings.Join(values, ", ")
+	})
+	if ctx.ResponseBody != nil && ctx.ResponseBody.Present {
+		result.body = append([]byte(nil), ctx.ResponseBody.Content...)
+	}
+	return result
+}
+
+// requestKey hashes the method, authority, path, key headers and body of a request
+func (p *DedupeByBodyPolicy) requestKey(ctx *policy.RequestContext) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s%s\n", strings.ToUpper(ctx.Method), ctx.Authority, ctx.Path)
+	for _, name := range p.keyHeaders {
+		fmt.Fprintf(h, "%s:%s\n", name, strings.Join(ctx.Headers.Get(name), ","))
+	}
+	h.Write([]byte("\n"))
+	if ctx.Body != nil && ctx.Body.Present {
+		h.Write(ctx.Body.Content)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sweep evicts entries older than the TTL. It runs at most once per TTL so the cost is amortized
+// across requests. Callers must hold p.mu.
+func (p *DedupeByBodyPolicy) sweep(now time.Time) {
+	if p.lastSweep.IsZero() {
+		p.lastSweep = now
+		return
+	}
+	if now.Sub(p.lastSweep) < p.ttl {
+		return
+	}
+	for key, e := range p.entries {
+		if now.Sub(e.seen) >= p.ttl {
+			delete(p.entries, key)
+		}
+	}
+	p.lastSweep = now
+}
+
+func conflict() policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Conflict",
+		"message": "Duplicate submission",
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusConflict,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package dedupebybody
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}, now *time.Time) *DedupeByBodyPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	dp := p.(*DedupeByBodyPolicy)
+	dp.now = func() time.Time { return *now }
+	return dp
+}
+
+func newRequest(method, path, body string, headers map[string][]string) *policy.RequestContext {
+	return &policy.RequestContext{
+		SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}},
+		Method:        method,
+		Path:          path,
+		Headers:       policy.NewHeaders(headers),
+		Body:          &policy.Body{Content: []byte(body), Present: body != "", EndOfStream: true},
+	}
+}
+
+func onRequest(p policy.Policy, body string) policy.RequestAction {
+	return p.OnRequest(newRequest("POST", "/orders", body, nil), nil)
+}
+
+func respond(p policy.Policy, ctx *policy.RequestContext, status int, body string) {
+	p.OnResponse(&policy.ResponseContext{
+		SharedContext:   ctx.SharedContext,
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/json"}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+		ResponseStatus:  status,
+	}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"ttlSeconds": 0},
+		{"mode": "drop"},
+		{"methods": []interface{}{}},
+		{"methods": []interface{}{" "}},
+		{"keyHeaders": "authorization"},
+		{"waitTimeoutMs": -1},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestDedupeByBodyPolicy_DuplicateWithinTTLRejected(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{"ttlSeconds": 5}, &now)
+
+	policytest.ExpectStatus(t, onRequest(p, `{"item":42}`), 0)
+	now = now.Add(4 * time.Second)
+	policytest.ExpectStatus(t, onRequest(p, `{"item":42}`), 409)
+
+	// Duplicates are caught until the TTL after the first submission
+	now = now.Add(time.Second)
+	policytest.ExpectStatus(t, onRequest(p, `{"item":42}`), 0)
+
+	// A failed first submission can be retried
+	ctx := newRequest("POST", "/orders", `{"item":7}`, nil)
+	policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 0)
+	respond(p, ctx, 503, `{"error":"unavailable"}`)
+	policytest.ExpectStatus(t, onRequest(p, `{"item":7}`), 0)
+}
+
+func TestDedupeByBodyPolicy_DuplicateCoalesced(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{"mode": "coalesce"}, &now)
+
+	ctx := newRequest("POST", "/orders", `{"item":42}`, nil)
+	policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 0)
+	respond(p, ctx, 201, `{"id":"o-1"}`)
+
+	resp := policytest.ExpectStatus(t, onRequest(p, `{"item":42}`), 201)
+	if string(resp.Body) != `{"id":"o-1"}` || resp.Headers["x-dedupe-replayed"] != "true" {
+		t.Errorf("Expected the first response to be replayed, got %+v", resp)
+	}
+}
+
+func TestDedupeByBodyPolicy_DistinctBodiesPass(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{}, &now)
+
+	policytest.ExpectStatus(t, onRequest(p, `{"item":1}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, `{"item":2}`), 0)
+
+	// The same body for another path, client or an excluded method is not a duplicate
+	policytest.ExpectStatus(t, p.OnRequest(newRequest("POST", "/carts", `{"item":1}`, nil), nil), 0)
+	policytest.ExpectStatus(t, p.OnRequest(newRequest("POST", "/orders", `{"item":1}`, map[string][]string{"authorization": {"Bearer b"}}), nil), 0)
+	policytest.ExpectStatus(t, p.OnRequest(newRequest("GET", "/orders", `{"item":1}`, nil), nil), 0)
+	policytest.ExpectStatus(t, p.OnRequest(newRequest("GET", "/orders", `{"item":1}`, nil), nil), 0)
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package defaultheaders
+
+import (
+	"fmt"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// DefaultHeadersPolicy sets request headers to configured defaults only when the client did
+// not send them, so client-provided values are never overwritten
+type DefaultHeadersPolicy struct {
+	defaults map[string]string // lower-cased header name to default value
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	headersRaw, ok := params["headers"].(map[string]interface{})
+	if !ok || len(headersRaw) == 0 {
+		return nil, fmt.Errorf("'headers' parameter is required and must be a non-empty object")
+	}
+
+	p := &DefaultHeadersPolicy{defaults: make(map[string]string, len(headersRaw))}
+	for name, raw := range headersRaw {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			return nil, fmt.Errorf("'headers' must not contain an empty header name")
+		}
+		value, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("headers.%s must be a string", name)
+		}
+		if _, exists := p.defaults[key]; exists {
+			return nil, fmt.Errorf("headers.%s is configured more than once", name)
+		}
+		p.defaults[key] = value
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *DefaultHeadersPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers to detect absent ones
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sets each configured header that is absent from the request to its default
+func (p *DefaultHeadersPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	var set map[string]string
+	for name, value := range p.defaults {
+		if ctx.Headers.Has(name) {
+			continue
+		}
+		if set == nil {
+			set = make(map[string]string)
+		}
+		set[name] = value
+	}
+	return policy.UpstreamRequestModifications{SetHeaders: set}
+}
+
+// OnResponse is not used by this policy
+func (p *DefaultHeadersPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
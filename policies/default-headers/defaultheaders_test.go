@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package defaultheaders
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, defaults map[string]interface{}, headers map[string][]string) map[string]string {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headers": defaults})
+	mods, _ := p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil).(policy.UpstreamRequestModifications)
+	return mods.SetHeaders
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"headers": map[string]interface{}{}},
+		{"headers": map[string]interface{}{" ": "x"}},
+		{"headers": map[string]interface{}{"accept": 1}},
+		{"headers": map[string]interface{}{"Accept": "a", "accept": "b"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestDefaultHeadersPolicy_InjectsWhenAbsent(t *testing.T) {
+	set := onRequest(t, map[string]interface{}{"Accept": "application/json"}, map[string][]string{})
+	if set["accept"] != "application/json" {
+		t.Errorf("Expected accept to be set, got %v", set)
+	}
+}
+
+func TestDefaultHeadersPolicy_PreservesClientValue(t *testing.T) {
+	set := onRequest(t, map[string]interface{}{"Accept": "application/json"}, map[string][]string{"accept": {"text/html"}})
+	if len(set) != 0 {
+		t.Errorf("Expected no headers to be set, got %v", set)
+	}
+}
+
+func TestDefaultHeadersPolicy_MultipleDefaults(t *testing.T) {
+	set := onRequest(t, map[string]interface{}{
+		"accept":          "application/json",
+		"accept-language": "en",
+		"x-client":        "unknown",
+	}, map[string][]string{"x-client": {"mobile"}})
+
+	if len(set) != 2 || set["accept"] != "application/json" || set["accept-language"] != "en" {
+		t.Errorf("Expected accept and accept-language defaults, got %v", set)
+	}
+}
@@ -0,0 +1,262 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package errorenvelope
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const (
+	// Placeholders available in envelope templates
+	PlaceholderStatus    = "{{status}}"
+	PlaceholderMessage   = "{{message}}"
+	PlaceholderRequestID = "{{requestId}}"
+
+	headerPlaceholderPrefix = "{{header."
+
+	// maxMessageBytes bounds how much of an upstream error body is copied into the envelope
+	maxMessageBytes = 4096
+)
+
+var placeholderRegex = regexp.MustCompile(`\{\{(status|message|requestId|header\.[a-zA-Z0-9-_]+)\}\}`)
+
+// ErrorEnvelopePolicy rewrites 4xx and 5xx responses into a consistent JSON error envelope
+type ErrorEnvelopePolicy struct {
+	template interface{} // Decoded JSON template whose string leaves may hold placeholders
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	_, hasFields := params["fields"]
+	_, hasTemplate := params["template"]
+	if hasFields && hasTemplate {
+		return nil, fmt.Errorf("'fields' and 'template' cannot be configured together")
+	}
+
+	if hasTemplate {
+		template, ok := params["template"].(map[string]interface{})
+		if !ok || len(template) == 0 {
+			return nil, fmt.Errorf("'template' must be a non-empty object")
+		}
+		if err := validateTemplate(template, "template"); err != nil {
+			return nil, err
+		}
+		return &ErrorEnvelopePolicy{template: template}, nil
+	}
+
+	fields := map[string]string{
+		"error":     "error",
+		"code":      "code",
+		"message":   "message",
+		"requestId": "requestId",
+	}
+	if hasFields {
+		fieldsRaw, ok := params["fields"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'fields' must be an object")
+		}
+		for key, raw := range fieldsRaw {
+			if _, ok := fields[key]; !ok {
+				return nil, fmt.Errorf("fields.%s is not supported; use error, code, message or requestId", key)
+			}
+			name, ok := raw.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("fields.%s must be a non-empty string", key)
+			}
+			fields[key] = strings.TrimSpace(name)
+		}
+	}
+
+	return &ErrorEnvelopePolicy{
+		template: map[string]interface{}{
+			fields["error"]: map[string]interface{}{
+				fields["code"]:      PlaceholderStatus,
+				fields["message"]:   PlaceholderMessage,
+				fields["requestId"]: PlaceholderRequestID,
+			},
+		},
+	}, nil
+}
+
+// validateTemplate checks that every placeholder-like token in the template's strings is known
+func validateTemplate(value interface{}, location string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if err := validateTemplate(child, location+"."+key); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			if err := validateTemplate(child, fmt.Sprintf("%s[%d]", location, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		rest := placeholderRegex.ReplaceAllString(v, "")
+		if strings.Contains(rest, "{{") {
+			return fmt.Errorf("%s contains an unknown placeholder; use %s, %s, %s or {{header.<name>}}",
+				location, PlaceholderStatus, PlaceholderMessage, PlaceholderRequestID)
+		}
+	}
+	return nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ErrorEnvelopePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers for placeholders
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Replaces content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to wrap it
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *ErrorEnvelopePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse wraps error responses in the envelope, keeping the status code
+func (p *ErrorEnvelopePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseStatus < 400 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	values := map[string]string{
+		PlaceholderStatus:    fmt.Sprintf("%d", ctx.ResponseStatus),
+		PlaceholderMessage:   errorMessage(ctx),
+		PlaceholderRequestID: requestID(ctx),
+	}
+	envelope := p.render(p.template, ctx, values)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(envelope); err != nil {
+		return policy.UpstreamResponseModifications{}
+	}
+	body := bytes.TrimRight(buf.Bytes(), "\n")
+
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-type":   "application/json",
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+		// The replacement body is never encoded like the original
+		RemoveHeaders: []string{"content-encoding"},
+	}
+}
+
+// render copies the template, substituting placeholders in string leaves. A leaf that is
+// exactly the status placeholder becomes a JSON number.
+func (p *ErrorEnvelopePolicy) render(value interface{}, ctx *policy.ResponseContext, values map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[key] = p.render(child, ctx, values)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = p.render(child, ctx, values)
+		}
+		return out
+	case string:
+		if v == PlaceholderStatus {
+			return ctx.ResponseStatus
+		}
+		return placeholderRegex.ReplaceAllStringFunc(v, func(placeholder string) string {
+			if name, ok := strings.CutPrefix(placeholder, headerPlaceholderPrefix); ok {
+				return firstValue(ctx.RequestHeaders, strings.TrimSuffix(name, "}}"))
+			}
+			return values[placeholder]
+		})
+	default:
+		return v
+	}
+}
+
+// errorMessage derives the message from the upstream body: the "message" field of a JSON
+// object body, the text of any other body, or the standard status text when the body is empty
+// or encoded
+func errorMessage(ctx *policy.ResponseContext) string {
+	fallback := http.StatusText(ctx.ResponseStatus)
+	if fallback == "" {
+		fallback = "Error"
+	}
+	if ctx.ResponseBody == nil || len(ctx.ResponseBody.Content) == 0 {
+		return fallback
+	}
+	if bodyutil.IsContentEncoded(ctx.ResponseHeaders) {
+		return fallback
+	}
+
+	content := ctx.ResponseBody.Content
+	var object map[string]interface{}
+	if json.Unmarshal(content, &object) == nil {
+		if message, ok := object["message"].(string); ok && strings.TrimSpace(message) != "" {
+			return strings.TrimSpace(message)
+		}
+		return fallback
+	}
+
+	if len(content) > maxMessageBytes {
+		content = content[:maxMessageBytes]
+	}
+	message := strings.TrimSpace(strings.ToValidUTF8(string(content), ""))
+	if message == "" {
+		return fallback
+	}
+	return message
+}
+
+// requestID returns the x-request-id request header, falling back to the gateway request ID
+func requestID(ctx *policy.ResponseContext) string {
+	if id := firstValue(ctx.RequestHeaders, "x-request-id"); id != "" {
+		return id
+	}
+	if ctx.SharedContext != nil {
+		return ctx.RequestID
+	}
+	return ""
+}
+
+func firstValue(headers *policy.Headers, name string) string {
+	if headers == nil {
+		return ""
+	}
+	if values := headers.Get(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package errorenvelope
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, status int, contentType, body string, requestHeaders map[string][]string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		SharedContext:   &policy.SharedContext{RequestID: "gw-1"},
+		RequestHeaders:  policy.NewHeaders(requestHeaders),
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+		ResponseStatus:  status,
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func expectBody(t *testing.T, mods policy.UpstreamResponseModifications, expected string) {
+	t.Helper()
+	var got, want interface{}
+	if err := json.Unmarshal(mods.Body, &got); err != nil {
+		t.Fatalf("Expected JSON body, got %s", mods.Body)
+	}
+	json.Unmarshal([]byte(expected), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected body %s, got %s", expected, mods.Body)
+	}
+	if mods.SetHeaders["content-type"] != "application/json" {
+		t.Errorf("Expected JSON content type, got %v", mods.SetHeaders)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"fields": "error"},
+		{"fields": map[string]interface{}{"status": "s"}},
+		{"fields": map[string]interface{}{"code": ""}},
+		{"template": map[string]interface{}{}},
+		{"template": map[string]interface{}{"e": "{{body}}"}},
+		{"template": map[string]interface{}{"e": "x"}, "fields": map[string]interface{}{}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestErrorEnvelopePolicy_WrapsPlainTextError(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onResponse(p, 503, "text/plain", "upstream overloaded\n", nil)
+	expectBody(t, mods, `{"error":{"code":503,"message":"upstream overloaded","requestId":"gw-1"}}`)
+
+	mods = onResponse(p, 400, "application/json", `{"message":"name is required","code":"E1"}`, nil)
+	expectBody(t, mods, `{"error":{"code":400,"message":"name is required","requestId":"gw-1"}}`)
+
+	mods = onResponse(p, 404, "text/html", "", nil)
+	expectBody(t, mods, `{"error":{"code":404,"message":"Not Found","requestId":"gw-1"}}`)
+}
+
+func TestErrorEnvelopePolicy_PreservesStatus(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onResponse(p, 502, "text/plain", "bad", nil)
+	if mods.StatusCode != nil {
+		t.Errorf("Expected status to be left unchanged, got %d", *mods.StatusCode)
+	}
+
+	// Successful responses pass through
+	mods = onResponse(p, 200, "text/plain", "ok", nil)
+	if mods.Body != nil || mods.SetHeaders != nil {
+		t.Errorf("Expected successful response to pass through, got %+v", mods)
+	}
+}
+
+func TestErrorEnvelopePolicy_IncludesRequestID(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"fields": map[string]interface{}{"error": "fault", "requestId": "traceId"},
+	})
+	mods := onResponse(p, 500, "text/plain", "boom", map[string][]string{"x-request-id": {"req-42"}})
+	expectBody(t, mods, `{"fault":{"code":500,"message":"boom","traceId":"req-42"}}`)
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{
+		"template": map[string]interface{}{
+			"status":  "{{status}}",
+			"detail":  "HTTP {{status}}: {{message}}",
+			"request": []interface{}{"{{requestId}}", "{{header.x-tenant}}"},
+		},
+	})
+	mods = onResponse(p, 429, "text/plain", "", map[string][]string{"x-request-id": {"req-7"}, "x-tenant": {"acme"}})
+	expectBody(t, mods, `{"status":429,"detail":"HTTP 429: Too Many Requests","request":["req-7","acme"]}`)
+}
+
+func TestErrorEnvelopePolicy_EncodedBodyUsesStatusText(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	for _, encoding := range []string{"gzip", "identity, br"} {
+		ctx := &policy.ResponseContext{
+			SharedContext: &policy.SharedContext{RequestID: "gw-1"},
+			ResponseHeaders: policy.NewHeaders(map[string][]string{
+				"content-type":     {"text/plain"},
+				"content-encoding": {encoding},
+			}),
+			ResponseBody:   &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+			ResponseStatus: 500,
+		}
+		mods := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+		expectBody(t, mods, `{"error":{"code":500,"message":"Internal Server Error","requestId":"gw-1"}}`)
+	}
+}
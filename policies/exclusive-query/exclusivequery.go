@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package exclusivequery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// ExclusiveQueryPolicy rejects requests that combine query parameters configured as mutually
+// exclusive
+type ExclusiveQueryPolicy struct {
+	groups [][]string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	groupsRaw, ok := params["groups"].([]interface{})
+	if !ok || len(groupsRaw) == 0 {
+		return nil, fmt.Errorf("'groups' parameter is required and must be a non-empty array")
+	}
+
+	p := &ExclusiveQueryPolicy{}
+	for i, raw := range groupsRaw {
+		namesRaw, ok := raw.([]interface{})
+		if !ok || len(namesRaw) < 2 {
+			return nil, fmt.Errorf("groups[%d] must be an array of at least two parameter names", i)
+		}
+		seen := make(map[string]bool, len(namesRaw))
+		group := make([]string, 0, len(namesRaw))
+		for j, n := range namesRaw {
+			name, ok := n.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("groups[%d][%d] must be a non-empty string", i, j)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("groups[%d] lists '%s' more than once", i, name)
+			}
+			seen[name] = true
+			group = append(group, name)
+		}
+		p.groups = append(p.groups, group)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ExclusiveQueryPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request path and query
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects the request with 400 when more than one parameter of a group is present.
+// A parameter counts as present even with an empty value. Names with invalid percent-encoding
+// are also rejected.
+func (p *ExclusiveQueryPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	_, query, _ := strings.Cut(ctx.Path, "?")
+	if query == "" {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	present := make(map[string]bool)
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, _, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			// An undecodable name could hide a parameter the upstream decodes differently
+			return errorResponse(http.StatusBadRequest, fmt.Sprintf("Query parameter name '%s' is not properly encoded", rawKey))
+		}
+		present[key] = true
+	}
+
+	for _, group := range p.groups {
+		var found []string
+		for _, name := range group {
+			if present[name] {
+				found = append(found, name)
+			}
+		}
+		if len(found) > 1 {
+			return errorResponse(http.StatusBadRequest, fmt.Sprintf("Query parameters %s are mutually exclusive", strings.Join(found, ", ")))
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{"error": http.StatusText(status), "message": message})
+	return policy.ImmediateResponse{StatusCode: status, Headers: map[string]string{"content-type": "application/json"}, Body: body}
+}
+
+// OnResponse is not used by this policy
+func (p *ExclusiveQueryPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
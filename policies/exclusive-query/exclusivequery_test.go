@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package exclusivequery
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, path string) policy.RequestAction {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"groups": []interface{}{
+			[]interface{}{"sort", "order"},
+			[]interface{}{"id", "ids", "filter"},
+		},
+	})
+	return p.OnRequest(&policy.RequestContext{Path: path}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"groups": []interface{}{}},
+		{"groups": []interface{}{[]interface{}{"sort"}}},
+		{"groups": []interface{}{[]interface{}{"sort", ""}}},
+		{"groups": []interface{}{[]interface{}{"sort", "sort"}}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestExclusiveQueryPolicy_SingleParamPasses(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, "/items?sort=name&id=1"), 0)
+	policytest.ExpectStatus(t, onRequest(t, "/items?sort=name&sort=date"), 0)
+}
+
+func TestExclusiveQueryPolicy_ConflictRejected(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, "/items?sort=name&order=asc"), 400)
+	policytest.ExpectStatus(t, onRequest(t, "/items?sort=&order="), 400)
+	policytest.ExpectStatus(t, onRequest(t, "/items?i%64s=1,2&filter=x"), 400)
+}
+
+func TestExclusiveQueryPolicy_MalformedNameRejected(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, "/items?sort=name&ord%zzer=asc"), 400)
+	policytest.ExpectStatus(t, onRequest(t, "/items?%"), 400)
+	// Values are not decoded, so their encoding doesn't matter
+	policytest.ExpectStatus(t, onRequest(t, "/items?sort=%zz"), 0)
+}
+
+func TestExclusiveQueryPolicy_NonePresentPasses(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, "/items?q=shoes"), 0)
+	policytest.ExpectStatus(t, onRequest(t, "/items"), 0)
+}
@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package expectcontinue
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Handling of Expect: 100-continue
+	ModeStrip = "strip"
+	ModePass  = "pass"
+
+	expectContinue = "100-continue"
+)
+
+// ExpectContinuePolicy controls whether Expect: 100-continue reaches the upstream and rejects
+// expectations the gateway can't meet
+type ExpectContinuePolicy struct {
+	mode string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &ExpectContinuePolicy{mode: ModeStrip}
+
+	if raw, ok := params["mode"]; ok {
+		mode, ok := raw.(string)
+		if !ok || (mode != ModeStrip && mode != ModePass) {
+			return nil, fmt.Errorf("'mode' must be one of %s, %s", ModeStrip, ModePass)
+		}
+		p.mode = mode
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ExpectContinuePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the Expect header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest strips or forwards Expect: 100-continue. Any other expectation is rejected with
+// 417 Expectation Failed, as 100-continue is the only expectation defined by HTTP.
+func (p *ExpectContinuePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get("expect")
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	for _, value := range values {
+		for _, expectation := range strings.Split(value, ",") {
+			expectation = strings.TrimSpace(expectation)
+			if expectation != "" && !strings.EqualFold(expectation, expectContinue) {
+				slog.Debug("ExpectContinue: Rejecting unsupported expectation", "expect", expectation)
+				body, _ := json.Marshal(map[string]string{
+					"error":   "Expectation Failed",
+					"message": fmt.Sprintf("Unsupported expectation '%s'", expectation),
+				})
+				return policy.ImmediateResponse{
+					StatusCode: http.StatusExpectationFailed,
+					Headers: map[string]string{
+						"content-type": "application/json",
+					},
+					Body: body,
+				}
+			}
+		}
+	}
+
+	if p.mode == ModePass {
+		return policy.UpstreamRequestModifications{}
+	}
+	return policy.UpstreamRequestModifications{
+		RemoveHeaders: []string{"expect"},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *ExpectContinuePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package expectcontinue
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, expect ...string) policy.RequestAction {
+	headers := map[string][]string{}
+	if len(expect) > 0 {
+		headers["expect"] = expect
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"mode": "drop"},
+		{"mode": true},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestExpectContinuePolicy_StripsHeader(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"mode": "strip"})
+
+	mods, ok := onRequest(p, "100-Continue").(policy.UpstreamRequestModifications)
+	if !ok || len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "expect" {
+		t.Errorf("Expected expect header to be removed, got %+v", mods)
+	}
+
+	// Requests without the header are untouched
+	if mods := onRequest(p).(policy.UpstreamRequestModifications); mods.RemoveHeaders != nil {
+		t.Errorf("Expected no modifications, got %+v", mods)
+	}
+}
+
+func TestExpectContinuePolicy_PassesHeader(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"mode": "pass"})
+
+	mods, ok := onRequest(p, "100-continue").(policy.UpstreamRequestModifications)
+	if !ok || mods.RemoveHeaders != nil {
+		t.Errorf("Expected expect header to be forwarded, got %+v", mods)
+	}
+}
+
+func TestExpectContinuePolicy_UnsupportedExpectationRejected(t *testing.T) {
+	for _, mode := range []string{"strip", "pass"} {
+		p := policytest.New(t, GetPolicy, map[string]interface{}{"mode": mode})
+		resp, ok := onRequest(p, "100-continue, x-custom=1").(policy.ImmediateResponse)
+		if !ok || resp.StatusCode != 417 {
+			t.Errorf("Expected 417 in %s mode, got %+v", mode, resp)
+		}
+	}
+}
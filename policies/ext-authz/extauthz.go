@@ -0,0 +1,300 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package extauthz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	DefaultTimeoutMs = 1000
+
+	// maxDeniedBodyBytes bounds how much of a denial body is relayed to the client
+	maxDeniedBodyBytes = 64 * 1024
+)
+
+// hopByHopHeaders are never copied between the authorization service and the client
+var hopByHopHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"keep-alive":        true,
+	"proxy-connection":  true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// ExtAuthzPolicy delegates the authorization decision for each request to an external HTTP service
+type ExtAuthzPolicy struct {
+	authzURL        string
+	includeBody     bool
+	forwardHeaders  []string
+	upstreamHeaders []string
+	failOpen        bool
+	client          *http.Client
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	authzURL, ok := params["authzUrl"].(string)
+	if !ok || strings.TrimSpace(authzURL) == "" {
+		return nil, fmt.Errorf("'authzUrl' parameter is required and must be a non-empty string")
+	}
+	u, err := url.Parse(strings.TrimSpace(authzURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("'authzUrl' must be an absolute http or https URL")
+	}
+
+	timeoutMs := DefaultTimeoutMs
+	if raw, ok := params["timeoutMs"]; ok {
+		if timeoutMs, err = extractInt(raw); err != nil {
+			return nil, fmt.Errorf("'timeoutMs' must be an integer: %w", err)
+		}
+		if timeoutMs <= 0 {
+			return nil, fmt.Errorf("'timeoutMs' must be greater than 0")
+		}
+	}
+
+	p := &ExtAuthzPolicy{
+		authzURL:       u.String(),
+		forwardHeaders: []string{"authorization"},
+		client: &http.Client{
+			Timeout: time.Duration(timeoutMs) * time.Millisecond,
+			// Redirects (e.g. to a login page) are relayed to the client as denials
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+
+	if raw, ok := params["includeBody"]; ok {
+		if p.includeBody, ok = raw.(bool); !ok {
+			return nil, fmt.Errorf("'includeBody' must be a boolean")
+		}
+	}
+
+	if raw, ok := params["failOpen"]; ok {
+		if p.failOpen, ok = raw.(bool); !ok {
+			return nil, fmt.Errorf("'failOpen' must be a boolean")
+		}
+	}
+
+	if raw, ok := params["forwardHeaders"]; ok {
+		if p.forwardHeaders, err = parseHeaderNames(raw, "forwardHeaders"); err != nil {
+			return nil, err
+		}
+	}
+
+	if raw, ok := params["upstreamHeaders"]; ok {
+		if p.upstreamHeaders, err = parseHeaderNames(raw, "upstreamHeaders"); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// parseHeaderNames parses a list of header names, lower-casing them
+func parseHeaderNames(raw interface{}, param string) ([]string, error) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'%s' must be an array", param)
+	}
+	names := make([]string, 0, len(list))
+	for i, item := range list {
+		name, ok := item.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%s[%d] must be a non-empty string", param, i)
+		}
+		names = append(names, strings.ToLower(strings.TrimSpace(name)))
+	}
+	return names, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ExtAuthzPolicy) Mode() policy.ProcessingMode {
+	requestBodyMode := policy.BodyModeSkip
+	if p.includeBody {
+		requestBodyMode = policy.BodyModeBuffer
+	}
+
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers for the check
+		RequestBodyMode:    requestBodyMode,
+		ResponseHeaderMode: policy.HeaderModeSkip, // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,   // Don't need response body
+	}
+}
+
+// OnRequest asks the authorization service whether the request may proceed. Transport errors,
+// timeouts and 5xx responses mean the service failed rather than denied the request, so clients
+// get 503 instead of the 403 of a real denial.
+func (p *ExtAuthzPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	resp, err := p.check(ctx)
+	if err == nil && resp.StatusCode >= 500 {
+		// Drain so the connection can be reused
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxDeniedBodyBytes))
+		resp.Body.Close()
+		err = fmt.Errorf("authorization service returned status %d", resp.StatusCode)
+	}
+	if err != nil {
+		if p.failOpen {
+			slog.Warn("ExtAuthz: Authorization check failed (fail-open)", "error", err)
+			return policy.UpstreamRequestModifications{}
+		}
+		slog.Error("ExtAuthz: Authorization check failed (fail-closed)", "error", err)
+		return p.buildErrorResponse(http.StatusServiceUnavailable, "Service Unavailable", "Authorization service unavailable")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		// Drain so the connection can be reused
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxDeniedBodyBytes))
+		return p.allow(resp)
+	}
+
+	slog.Debug("ExtAuthz: Request denied by authorization service", "status", resp.StatusCode)
+	return p.deny(resp)
+}
+
+// OnResponse is not used by this policy
+func (p *ExtAuthzPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// check sends the authorization request. The original method is reused; the original path and
+// host are conveyed in X-Forwarded-Uri and X-Forwarded-Host.
+func (p *ExtAuthzPolicy) check(ctx *policy.RequestContext) (*http.Response, error) {
+	var body io.Reader
+	if p.includeBody && ctx.Body != nil && ctx.Body.Present {
+		body = bytes.NewReader(ctx.Body.Content)
+	}
+
+	method := ctx.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, p.authzURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range p.forwardHeaders {
+		for _, value := range ctx.Headers.Get(name) {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("X-Forwarded-Method", method)
+	req.Header.Set("X-Forwarded-Uri", ctx.Path)
+	if ctx.Authority != "" {
+		req.Header.Set("X-Forwarded-Host", ctx.Authority)
+	}
+	if body != nil {
+		if values := ctx.Headers.Get("content-type"); len(values) > 0 {
+			req.Header.Set("Content-Type", values[0])
+		}
+	}
+
+	return p.client.Do(req)
+}
+
+// allow forwards the request, copying the configured authorization response headers onto it
+func (p *ExtAuthzPolicy) allow(resp *http.Response) policy.RequestAction {
+	mods := policy.UpstreamRequestModifications{}
+	for _, name := range p.upstreamHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if mods.SetHeaders == nil {
+				mods.SetHeaders = make(map[string]string)
+			}
+			mods.SetHeaders[name] = value
+		}
+	}
+	return mods
+}
+
+// deny relays the authorization service's status, headers and body to the client
+func (p *ExtAuthzPolicy) deny(resp *http.Response) policy.RequestAction {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDeniedBodyBytes))
+	if err != nil {
+		body = nil
+	}
+
+	headers := make(map[string]string)
+	for name, values := range resp.Header {
+		name = strings.ToLower(name)
+		if hopByHopHeaders[name] || len(values) == 0 {
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	return policy.ImmediateResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+// buildErrorResponse returns a JSON error response
+func (p *ExtAuthzPolicy) buildErrorResponse(statusCode int, errorText, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   errorText,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: statusCode,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package extauthz
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newRequest(headers map[string][]string, body string) *policy.RequestContext {
+	return &policy.RequestContext{
+		Method:    "POST",
+		Path:      "/orders?limit=5",
+		Authority: "api.example.com",
+		Headers:   policy.NewHeaders(headers),
+		Body:      &policy.Body{Content: []byte(body), Present: body != "", EndOfStream: true},
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"authzUrl": "authz.local/check"},
+		{"authzUrl": "ftp://authz.local"},
+		{"authzUrl": "http://authz.local", "timeoutMs": 0},
+		{"authzUrl": "http://authz.local", "forwardHeaders": "authorization"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestExtAuthzPolicy_Allow(t *testing.T) {
+	var received *http.Request
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		b, _ := io.ReadAll(r.Body)
+		receivedBody = string(b)
+		w.Header().Set("X-User-Id", "user-42")
+		w.Header().Set("X-Internal", "secret")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"authzUrl":        server.URL + "/check",
+		"includeBody":     true,
+		"forwardHeaders":  []interface{}{"Authorization", "x-tenant"},
+		"upstreamHeaders": []interface{}{"x-user-id"},
+	})
+
+	ctx := newRequest(map[string][]string{
+		"authorization": {"Bearer token"},
+		"x-tenant":      {"acme"},
+		"cookie":        {"session=1"},
+		"content-type":  {"application/json"},
+	}, `{"item":1}`)
+	action := p.OnRequest(ctx, nil)
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected request to be allowed, got %T", action)
+	}
+	if mods.SetHeaders["x-user-id"] != "user-42" {
+		t.Errorf("Expected x-user-id to be copied, got %v", mods.SetHeaders)
+	}
+	if _, ok := mods.SetHeaders["x-internal"]; ok {
+		t.Error("Expected unlisted authorization response headers not to be copied")
+	}
+
+	if received.Method != "POST" || received.URL.Path != "/check" {
+		t.Errorf("Unexpected authorization request %s %s", received.Method, received.URL.Path)
+	}
+	if received.Header.Get("Authorization") != "Bearer token" || received.Header.Get("X-Tenant") != "acme" {
+		t.Errorf("Expected configured headers to be forwarded, got %v", received.Header)
+	}
+	if received.Header.Get("Cookie") != "" {
+		t.Error("Expected unlisted headers not to be forwarded")
+	}
+	if received.Header.Get("X-Forwarded-Uri") != "/orders?limit=5" || received.Header.Get("X-Forwarded-Host") != "api.example.com" {
+		t.Errorf("Expected forwarded request details, got %v", received.Header)
+	}
+	if receivedBody != `{"item":1}` {
+		t.Errorf("Expected request body to be forwarded, got %q", receivedBody)
+	}
+}
+
+func TestExtAuthzPolicy_Deny(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"reason":"token expired"}`))
+	}))
+	defer server.Close()
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"authzUrl": server.URL})
+
+	action := p.OnRequest(newRequest(nil, ""), nil)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", resp.StatusCode)
+	}
+	if string(resp.Body) != `{"reason":"token expired"}` {
+		t.Errorf("Expected authorization body to be relayed, got %s", resp.Body)
+	}
+	if resp.Headers["www-authenticate"] != `Bearer realm="api"` || resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected authorization headers to be relayed, got %v", resp.Headers)
+	}
+	if _, ok := resp.Headers["content-length"]; ok {
+		t.Error("Expected content-length not to be relayed")
+	}
+}
+
+func TestExtAuthzPolicy_RedirectRelayed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://login.example.com/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"authzUrl": server.URL})
+
+	resp := p.OnRequest(newRequest(nil, ""), nil).(policy.ImmediateResponse)
+	if resp.StatusCode != http.StatusFound || resp.Headers["location"] != "https://login.example.com/" {
+		t.Errorf("Expected redirect to be relayed, got %d %v", resp.StatusCode, resp.Headers)
+	}
+}
+
+func TestExtAuthzPolicy_TimeoutFailClosed(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"authzUrl": server.URL, "timeoutMs": float64(50)})
+
+	action := p.OnRequest(newRequest(nil, ""), nil)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", resp.StatusCode)
+	}
+}
+
+func TestExtAuthzPolicy_ServerErrorIsNotDenial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("database down"))
+	}))
+	defer server.Close()
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"authzUrl": server.URL})
+	action := p.OnRequest(newRequest(nil, ""), nil)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", resp.StatusCode)
+	}
+	if string(resp.Body) == "database down" {
+		t.Error("Expected the authorization service error body not to be relayed")
+	}
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"authzUrl": server.URL, "failOpen": true})
+	if _, ok := p.OnRequest(newRequest(nil, ""), nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected request to be allowed on a 5xx when failing open")
+	}
+}
+
+func TestExtAuthzPolicy_UnreachableFailOpen(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"authzUrl": url, "failOpen": true})
+
+	if _, ok := p.OnRequest(newRequest(nil, ""), nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected request to be allowed when failing open")
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package featureflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// FeatureFlagPolicy short-circuits requests with a configured response while a flag is off,
+// so endpoints can be dark-launched. The flag is resolved per request from the override
+// header, then the environment variable, then the static value.
+type FeatureFlagPolicy struct {
+	enabled        bool
+	envVar         string
+	overrideHeader string // Lower-cased; empty disables header overrides
+
+	offStatus      int
+	offBody        []byte
+	offContentType string
+
+	lookupEnv func(string) (string, bool) // Injectable environment (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &FeatureFlagPolicy{
+		offStatus:      http.StatusNotFound,
+		offContentType: "application/json",
+		lookupEnv:      os.LookupEnv,
+	}
+
+	if raw, ok := params["enabled"]; ok {
+		enabled, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'enabled' must be a boolean")
+		}
+		p.enabled = enabled
+	}
+
+	if raw, ok := params["envVar"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'envVar' must be a non-empty string")
+		}
+		p.envVar = strings.TrimSpace(name)
+	}
+
+	if raw, ok := params["overrideHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'overrideHeader' must be a non-empty string")
+		}
+		p.overrideHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["offResponse"]; ok {
+		if err := p.parseOffResponse(raw); err != nil {
+			return nil, err
+		}
+	}
+	if p.offBody == nil {
+		p.offBody, _ = json.Marshal(map[string]string{
+			"error":   http.StatusText(p.offStatus),
+			"message": "This feature is not available",
+		})
+	}
+
+	return p, nil
+}
+
+// parseOffResponse parses the response returned while the flag is off
+func (p *FeatureFlagPolicy) parseOffResponse(raw interface{}) error {
+	offResponse, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("'offResponse' must be an object")
+	}
+	if rawStatus, ok := offResponse["statusCode"]; ok {
+		status, err := extractInt(rawStatus)
+		if err != nil || status < 100 || status > 599 {
+			return fmt.Errorf("'offResponse.statusCode' must be a status code between 100 and 599")
+		}
+		p.offStatus = status
+	}
+	if rawBody, ok := offResponse["body"]; ok {
+		body, ok := rawBody.(string)
+		if !ok {
+			return fmt.Errorf("'offResponse.body' must be a string")
+		}
+		p.offBody = []byte(body)
+	}
+	if rawType, ok := offResponse["contentType"]; ok {
+		contentType, ok := rawType.(string)
+		if !ok || strings.TrimSpace(contentType) == "" {
+			return fmt.Errorf("'offResponse.contentType' must be a non-empty string")
+		}
+		p.offContentType = strings.TrimSpace(contentType)
+	}
+	return nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *FeatureFlagPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the override header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest forwards the request while the flag is on and returns the off response otherwise.
+// The override header is never forwarded.
+func (p *FeatureFlagPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if !p.isEnabled(ctx) {
+		return policy.ImmediateResponse{
+			StatusCode: p.offStatus,
+			Headers: map[string]string{
+				"content-type": p.offContentType,
+			},
+			Body: p.offBody,
+		}
+	}
+	if p.overrideHeader != "" && ctx.Headers.Has(p.overrideHeader) {
+		return policy.UpstreamRequestModifications{RemoveHeaders: []string{p.overrideHeader}}
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *FeatureFlagPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// isEnabled resolves the flag. Unrecognized override or environment values are ignored.
+func (p *FeatureFlagPolicy) isEnabled(ctx *policy.RequestContext) bool {
+	if p.overrideHeader != "" {
+		if values := ctx.Headers.Get(p.overrideHeader); len(values) > 0 {
+			if enabled, ok := parseFlag(values[0]); ok {
+				return enabled
+			}
+			slog.Debug("FeatureFlag: Ignoring unrecognized override", "header", p.overrideHeader, "value", values[0])
+		}
+	}
+	if p.envVar != "" {
+		if value, ok := p.lookupEnv(p.envVar); ok {
+			if enabled, ok := parseFlag(value); ok {
+				return enabled
+			}
+			slog.Debug("FeatureFlag: Ignoring unrecognized environment value", "envVar", p.envVar, "value", value)
+		}
+	}
+	return p.enabled
+}
+
+// parseFlag parses on/off style flag values
+func parseFlag(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "on", "1", "yes", "enabled":
+		return true, true
+	case "false", "off", "0", "no", "disabled":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
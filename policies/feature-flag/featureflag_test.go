@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package featureflag
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}, env map[string]string) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	fp := p.(*FeatureFlagPolicy)
+	fp.lookupEnv = func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	return fp
+}
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.RequestAction {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil)
+}
+
+func expectOff(t *testing.T, action policy.RequestAction) policy.ImmediateResponse {
+	t.Helper()
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected the off response, got %+v", action)
+	}
+	return resp
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"enabled": "true"},
+		{"envVar": ""},
+		{"overrideHeader": 1},
+		{"offResponse": "disabled"},
+		{"offResponse": map[string]interface{}{"statusCode": 99}},
+		{"offResponse": map[string]interface{}{"body": 1}},
+		{"offResponse": map[string]interface{}{"contentType": " "}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestFeatureFlagPolicy_FlagOnPassesThrough(t *testing.T) {
+	policytest.ExpectForwarded(t, onRequest(newPolicy(t, map[string]interface{}{"enabled": true}, nil), nil))
+
+	// The environment variable overrides the static value
+	env := newPolicy(t, map[string]interface{}{"envVar": "FEATURE_SEARCH_V2"}, map[string]string{"FEATURE_SEARCH_V2": "on"})
+	policytest.ExpectForwarded(t, onRequest(env, nil))
+}
+
+func TestFeatureFlagPolicy_FlagOffShortCircuits(t *testing.T) {
+	resp := expectOff(t, onRequest(newPolicy(t, map[string]interface{}{}, nil), nil))
+	if resp.StatusCode != 404 || resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected a 404 JSON response, got %+v", resp)
+	}
+
+	custom := newPolicy(t, map[string]interface{}{
+		"enabled": true,
+		"envVar":  "FEATURE_SEARCH_V2",
+		"offResponse": map[string]interface{}{
+			"statusCode":  503,
+			"body":        "Coming soon",
+			"contentType": "text/plain",
+		},
+	}, map[string]string{"FEATURE_SEARCH_V2": "false"})
+	resp = expectOff(t, onRequest(custom, nil))
+	if resp.StatusCode != 503 || string(resp.Body) != "Coming soon" || resp.Headers["content-type"] != "text/plain" {
+		t.Errorf("Expected the configured off response, got %+v", resp)
+	}
+}
+
+func TestFeatureFlagPolicy_HeaderOverridesStaticFlag(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"overrideHeader": "X-Feature-Preview"}, nil)
+
+	mods := policytest.ExpectForwarded(t, onRequest(p, map[string][]string{"x-feature-preview": {"true"}}))
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-feature-preview" {
+		t.Errorf("Expected the override header to be stripped, got %v", mods.RemoveHeaders)
+	}
+	expectOff(t, onRequest(p, map[string][]string{"x-feature-preview": {"maybe"}}))
+
+	on := newPolicy(t, map[string]interface{}{"enabled": true, "overrideHeader": "x-feature-preview"}, nil)
+	expectOff(t, onRequest(on, map[string][]string{"x-feature-preview": {"off"}}))
+}
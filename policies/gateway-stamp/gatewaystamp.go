@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package gatewaystamp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Sources of the node name
+	NodeSourceHostname = "hostname"
+	NodeSourceEnv      = "env"
+	NodeSourceStatic   = "static"
+)
+
+// GatewayStampPolicy sets the gateway version and node name on every response. Both values are
+// resolved once when the policy is loaded.
+type GatewayStampPolicy struct {
+	headers map[string]string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	versionHeader, err := parseHeader(params, "versionHeader", "x-gateway-version")
+	if err != nil {
+		return nil, err
+	}
+	nodeHeader, err := parseHeader(params, "nodeHeader", "x-gateway-node")
+	if err != nil {
+		return nil, err
+	}
+	if versionHeader == nodeHeader {
+		return nil, fmt.Errorf("'versionHeader' and 'nodeHeader' must be different")
+	}
+
+	version, err := resolveVersion(params)
+	if err != nil {
+		return nil, err
+	}
+	node, err := resolveNode(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GatewayStampPolicy{
+		headers: map[string]string{
+			versionHeader: version,
+			nodeHeader:    node,
+		},
+	}, nil
+}
+
+// parseHeader reads an optional header name parameter
+func parseHeader(params map[string]interface{}, param, defaultName string) (string, error) {
+	raw, ok := params[param]
+	if !ok {
+		return defaultName, nil
+	}
+	name, ok := raw.(string)
+	if !ok || strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("'%s' must be a non-empty string", param)
+	}
+	return strings.ToLower(strings.TrimSpace(name)), nil
+}
+
+// resolveVersion returns the static version, falling back to the version environment variable
+func resolveVersion(params map[string]interface{}) (string, error) {
+	if raw, ok := params["version"]; ok {
+		version, ok := raw.(string)
+		if !ok || strings.TrimSpace(version) == "" {
+			return "", fmt.Errorf("'version' must be a non-empty string")
+		}
+		return strings.TrimSpace(version), nil
+	}
+
+	envVar := "GATEWAY_VERSION"
+	if raw, ok := params["versionEnv"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return "", fmt.Errorf("'versionEnv' must be a non-empty string")
+		}
+		envVar = strings.TrimSpace(name)
+	}
+	version := strings.TrimSpace(os.Getenv(envVar))
+	if version == "" {
+		return "", fmt.Errorf("environment variable '%s' must be set when 'version' is not configured", envVar)
+	}
+	return version, nil
+}
+
+// resolveNode returns the node name from the configured source
+func resolveNode(params map[string]interface{}) (string, error) {
+	source := NodeSourceHostname
+	var value string
+	if raw, ok := params["node"]; ok {
+		nodeMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("'node' must be an object")
+		}
+		source, _ = nodeMap["source"].(string)
+		value, _ = nodeMap["value"].(string)
+		value = strings.TrimSpace(value)
+	}
+
+	switch source {
+	case NodeSourceHostname:
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			return "", fmt.Errorf("failed to resolve the node hostname: %v", err)
+		}
+		return hostname, nil
+	case NodeSourceEnv:
+		if value == "" {
+			return "", fmt.Errorf("'node.value' must name an environment variable for source '%s'", source)
+		}
+		node := strings.TrimSpace(os.Getenv(value))
+		if node == "" {
+			return "", fmt.Errorf("environment variable '%s' must be set for the node name", value)
+		}
+		return node, nil
+	case NodeSourceStatic:
+		if value == "" {
+			return "", fmt.Errorf("'node.value' is required for source '%s'", source)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("'node.source' must be one of: hostname, env, static")
+	}
+}
+
+// Mode returns the processing mode for this policy
+func (p *GatewayStampPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Sets the stamp headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *GatewayStampPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse sets the version and node headers, replacing any set by the upstream
+func (p *GatewayStampPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	headers := make(map[string]string, len(p.headers))
+	for name, value := range p.headers {
+		headers[name] = value
+	}
+	return policy.UpstreamResponseModifications{SetHeaders: headers}
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package gatewaystamp
+
+import (
+	"os"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(t *testing.T, params map[string]interface{}) map[string]string {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	ctx := &policy.ResponseContext{ResponseHeaders: policy.NewHeaders(nil), ResponseStatus: 200}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications).SetHeaders
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	t.Setenv("GATEWAY_VERSION", "")
+	t.Setenv("NODE_NAME", "")
+	invalid := []map[string]interface{}{
+		{},
+		{"version": ""},
+		{"versionEnv": "UNSET_GATEWAY_VERSION"},
+		{"version": "1.0", "node": "gw-1"},
+		{"version": "1.0", "node": map[string]interface{}{"source": "pod"}},
+		{"version": "1.0", "node": map[string]interface{}{"source": "env"}},
+		{"version": "1.0", "node": map[string]interface{}{"source": "env", "value": "NODE_NAME"}},
+		{"version": "1.0", "node": map[string]interface{}{"source": "static"}},
+		{"version": "1.0", "versionHeader": "x-stamp", "nodeHeader": "X-Stamp"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestGatewayStampPolicy_HeadersFromEnvironment(t *testing.T) {
+	t.Setenv("GATEWAY_VERSION", "1.4.2")
+	t.Setenv("NODE_NAME", "gw-node-3")
+
+	headers := onResponse(t, map[string]interface{}{
+		"node": map[string]interface{}{"source": "env", "value": "NODE_NAME"},
+	})
+	if headers["x-gateway-version"] != "1.4.2" || headers["x-gateway-node"] != "gw-node-3" {
+		t.Errorf("Expected version 1.4.2 on gw-node-3, got %v", headers)
+	}
+
+	t.Setenv("RELEASE", "2026.10")
+	headers = onResponse(t, map[string]interface{}{"versionEnv": "RELEASE"})
+	hostname, _ := os.Hostname()
+	if headers["x-gateway-version"] != "2026.10" || headers["x-gateway-node"] != hostname {
+		t.Errorf("Expected version 2026.10 on %s, got %v", hostname, headers)
+	}
+}
+
+func TestGatewayStampPolicy_StaticValues(t *testing.T) {
+	t.Setenv("GATEWAY_VERSION", "1.4.2")
+
+	headers := onResponse(t, map[string]interface{}{
+		"version":       "2.0.0",
+		"node":          map[string]interface{}{"source": "static", "value": "edge-eu"},
+		"versionHeader": "X-Version",
+		"nodeHeader":    "X-Node",
+	})
+	if len(headers) != 2 || headers["x-version"] != "2.0.0" || headers["x-node"] != "edge-eu" {
+		t.Errorf("Expected the static version and node, got %v", headers)
+	}
+}
+
+func TestGatewayStampPolicy_ValuesLoadedOnce(t *testing.T) {
+	t.Setenv("GATEWAY_VERSION", "1.4.2")
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	t.Setenv("GATEWAY_VERSION", "9.9.9")
+	ctx := &policy.ResponseContext{ResponseHeaders: policy.NewHeaders(nil), ResponseStatus: 200}
+	headers := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications).SetHeaders
+	if headers["x-gateway-version"] != "1.4.2" {
+		t.Errorf("Expected the version resolved at load, got %v", headers)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package geoheaders
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	FieldCountry = "country"
+	FieldRegion  = "region"
+	FieldCity    = "city"
+
+	defaultIPSourceHeader = "x-forwarded-for"
+)
+
+// fieldHeaders maps location fields to the request headers they are written to
+var fieldHeaders = map[string]string{
+	FieldCountry: "x-geo-country",
+	FieldRegion:  "x-geo-region",
+	FieldCity:    "x-geo-city",
+}
+
+// databaseCache holds loaded databases by path so routes sharing a file load it once
+var databaseCache sync.Map // map[string]*database
+
+// location is the geolocation of a network
+type location map[string]string
+
+// database maps networks to locations. Networks are grouped by prefix length so lookups can
+// find the most specific network first.
+type database struct {
+	byPrefixLen map[int]map[netip.Prefix]location
+	prefixLens  []int // Descending
+}
+
+// GeoHeadersPolicy sets geolocation headers on the request based on the client IP
+type GeoHeadersPolicy struct {
+	db             *database
+	fields         []string
+	ipSourceHeader string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	dbPath, ok := params["databasePath"].(string)
+	if !ok || strings.TrimSpace(dbPath) == "" {
+		return nil, fmt.Errorf("'databasePath' parameter is required and must be a non-empty string")
+	}
+
+	db, err := loadDatabase(strings.TrimSpace(dbPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load geolocation database: %w", err)
+	}
+
+	p := &GeoHeadersPolicy{
+		db:             db,
+		fields:         []string{FieldCountry},
+		ipSourceHeader: defaultIPSourceHeader,
+	}
+
+	if raw, ok := params["fields"]; ok {
+		fieldsRaw, ok := raw.([]interface{})
+		if !ok || len(fieldsRaw) == 0 {
+			return nil, fmt.Errorf("'fields' must be a non-empty array")
+		}
+		p.fields = nil
+		for i, f := range fieldsRaw {
+			field, ok := f.(string)
+			if _, known := fieldHeaders[field]; !ok || !known {
+				return nil, fmt.Errorf("fields[%d] must be one of: country, region, city", i)
+			}
+			p.fields = append(p.fields, field)
+		}
+	}
+
+	if raw, ok := params["ipSourceHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'ipSourceHeader' must be a non-empty string")
+		}
+		p.ipSourceHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *GeoHeadersPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the client IP and to set geo headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sets the configured geolocation headers. Client-supplied geo headers are always
+// removed so that upstreams can trust them.
+func (p *GeoHeadersPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	mods := policy.UpstreamRequestModifications{}
+	for _, field := range p.fields {
+		mods.RemoveHeaders = append(mods.RemoveHeaders, fieldHeaders[field])
+	}
+
+	addr, ok := p.clientIP(ctx.Headers)
+	if !ok {
+		slog.Debug("GeoHeaders: No valid client IP found", "header", p.ipSourceHeader)
+		return mods
+	}
+	if addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsUnspecified() {
+		return mods
+	}
+
+	loc := p.db.lookup(addr)
+	if loc == nil {
+		slog.Debug("GeoHeaders: Client IP not found in database", "ip", addr)
+		return mods
+	}
+
+	for _, field := range p.fields {
+		if value := loc[field]; value != "" {
+			if mods.SetHeaders == nil {
+				mods.SetHeaders = make(map[string]string)
+			}
+			mods.SetHeaders[fieldHeaders[field]] = value
+		}
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *GeoHeadersPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// clientIP reads the client address from the configured header, taking the first entry of a
+// comma-separated list such as X-Forwarded-For
+func (p *GeoHeadersPolicy) clientIP(headers *policy.Headers) (netip.Addr, bool) {
+	values := headers.Get(p.ipSourceHeader)
+	if len(values) == 0 {
+		return netip.Addr{}, false
+	}
+	first, _, _ := strings.Cut(values[0], ",")
+	addr, err := netip.ParseAddr(strings.TrimSpace(first))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// lookup returns the location of the most specific network containing addr
+func (db *database) lookup(addr netip.Addr) location {
+	for _, bits := range db.prefixLens {
+		if bits > addr.BitLen() {
+			continue
+		}
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			continue
+		}
+		if loc, ok := db.byPrefixLen[bits][prefix]; ok {
+			return loc
+		}
+	}
+	return nil
+}
+
+// loadDatabase loads a database file once per path
+func loadDatabase(path string) (*database, error) {
+	if cached, ok := databaseCache.Load(path); ok {
+		return cached.(*database), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	db, err := parseDatabase(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	actual, _ := databaseCache.LoadOrStore(path, db)
+	return actual.(*database), nil
+}
+
+// parseDatabase reads a CSV database in the layout of the MaxMind GeoLite2 CSV exports: a
+// header row naming the columns, a "network" column with CIDR blocks and any of the "country",
+// "region" and "city" columns.
+func parseDatabase(r io.Reader) (*database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header row: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	networkCol, ok := columns["network"]
+	if !ok {
+		return nil, fmt.Errorf("header row must include a 'network' column")
+	}
+
+	db := &database{byPrefixLen: make(map[int]map[netip.Prefix]location)}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if networkCol >= len(record) {
+			return nil, fmt.Errorf("line %d: missing network", line)
+		}
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(record[networkCol]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		prefix = prefix.Masked()
+
+		loc := make(location)
+		for field := range fieldHeaders {
+			if col, ok := columns[field]; ok && col < len(record) {
+				loc[field] = strings.TrimSpace(record[col])
+			}
+		}
+
+		bits := prefix.Bits()
+		if db.byPrefixLen[bits] == nil {
+			db.byPrefixLen[bits] = make(map[netip.Prefix]location)
+			db.prefixLens = append(db.prefixLens, bits)
+		}
+		db.byPrefixLen[bits][prefix] = loc
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(db.prefixLens)))
+	return db, nil
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package geoheaders
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+const fixtureDB = `network,country,region,city
+203.0.113.0/24,AU,NSW,Sydney
+198.51.100.0/24,US,CA,
+198.51.100.128/25,US,WA,Seattle
+2001:db8::/32,DE,BE,Berlin
+`
+
+func writeFixture(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "geo.csv")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	params["databasePath"] = writeFixture(t, fixtureDB)
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	valid := writeFixture(t, fixtureDB)
+	invalid := []map[string]interface{}{
+		{},
+		{"databasePath": filepath.Join(t.TempDir(), "missing.csv")},
+		{"databasePath": writeFixture(t, "cidr,country\n1.2.3.0/24,US\n")},
+		{"databasePath": writeFixture(t, "network,country\nnot-a-network,US\n")},
+		{"databasePath": valid, "fields": []interface{}{"continent"}},
+		{"databasePath": valid, "ipSourceHeader": ""},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestGeoHeadersPolicy_KnownIP(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"fields": []interface{}{"country", "region", "city"}})
+
+	mods := onRequest(p, map[string][]string{"x-forwarded-for": {"198.51.100.200, 10.0.0.1"}})
+	want := map[string]string{"x-geo-country": "US", "x-geo-region": "WA", "x-geo-city": "Seattle"}
+	for name, value := range want {
+		if mods.SetHeaders[name] != value {
+			t.Errorf("Expected %s %q, got %q", name, value, mods.SetHeaders[name])
+		}
+	}
+
+	// Empty fields are not set
+	mods = onRequest(p, map[string][]string{"x-forwarded-for": {"198.51.100.7"}})
+	if _, ok := mods.SetHeaders["x-geo-city"]; ok {
+		t.Errorf("Expected no city header, got %v", mods.SetHeaders)
+	}
+
+	mods = onRequest(p, map[string][]string{"x-forwarded-for": {"2001:db8::1"}})
+	if mods.SetHeaders["x-geo-country"] != "DE" {
+		t.Errorf("Expected IPv6 lookup to resolve DE, got %v", mods.SetHeaders)
+	}
+}
+
+func TestGeoHeadersPolicy_CustomSourceHeader(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"ipSourceHeader": "X-Real-IP"})
+
+	mods := onRequest(p, map[string][]string{"x-real-ip": {"203.0.113.9"}})
+	if mods.SetHeaders["x-geo-country"] != "AU" {
+		t.Errorf("Expected country AU, got %v", mods.SetHeaders)
+	}
+}
+
+func TestGeoHeadersPolicy_UnresolvableOrPrivateIP(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	for _, ip := range []string{"10.1.2.3", "127.0.0.1", "192.0.2.1", "not-an-ip"} {
+		mods := onRequest(p, map[string][]string{
+			"x-forwarded-for": {ip},
+			"x-geo-country":   {"spoofed"},
+		})
+		if len(mods.SetHeaders) != 0 {
+			t.Errorf("Expected no geo headers for %q, got %v", ip, mods.SetHeaders)
+		}
+		if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-geo-country" {
+			t.Errorf("Expected client-supplied geo header to be removed, got %v", mods.RemoveHeaders)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package headertoquery
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+var headerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
+
+// headerMapping copies a request header into a query parameter
+type headerMapping struct {
+	header string // lower-cased
+	param  string
+}
+
+// HeaderToQueryPolicy appends request header values as query parameters for legacy upstreams
+// that only read the URL
+type HeaderToQueryPolicy struct {
+	mappings  []headerMapping // Sorted by header name for a stable query order
+	overwrite bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["mappings"].(map[string]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'mappings' parameter is required and must be a non-empty object")
+	}
+
+	p := &HeaderToQueryPolicy{overwrite: true}
+	for header, raw := range mappingsRaw {
+		header = strings.TrimSpace(header)
+		if !headerNameRegex.MatchString(header) {
+			return nil, fmt.Errorf("mappings key '%s' must be a valid header name", header)
+		}
+		param, ok := raw.(string)
+		if !ok || strings.TrimSpace(param) == "" {
+			return nil, fmt.Errorf("mappings.%s must be a non-empty query parameter name", header)
+		}
+		p.mappings = append(p.mappings, headerMapping{header: strings.ToLower(header), param: strings.TrimSpace(param)})
+	}
+	sort.Slice(p.mappings, func(i, j int) bool {
+		return p.mappings[i].header < p.mappings[j].header
+	})
+
+	if raw, ok := params["overwrite"]; ok {
+		overwrite, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'overwrite' must be a boolean")
+		}
+		p.overwrite = overwrite
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *HeaderToQueryPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers and path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest adds the values of mapped headers to the query string
+func (p *HeaderToQueryPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	var added []string
+	replaced := make(map[string]bool)
+	for _, mapping := range p.mappings {
+		values := ctx.Headers.Get(mapping.header)
+		if len(values) == 0 {
+			continue
+		}
+		added = append(added, url.QueryEscape(mapping.param)+"="+url.QueryEscape(values[0]))
+		replaced[mapping.param] = true
+	}
+	if len(added) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	reqPath, query, _ := strings.Cut(ctx.Path, "?")
+	var pairs []string
+	if query != "" {
+		for _, pair := range strings.Split(query, "&") {
+			if p.overwrite {
+				rawKey, _, _ := strings.Cut(pair, "=")
+				key, err := url.QueryUnescape(rawKey)
+				if err != nil {
+					key = rawKey
+				}
+				// Existing occurrences are dropped so the header value is the only one
+				if replaced[key] {
+					continue
+				}
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+	pairs = append(pairs, added...)
+
+	newPath := reqPath + "?" + strings.Join(pairs, "&")
+	return policy.UpstreamRequestModifications{Path: &newPath}
+}
+
+// OnResponse is not used by this policy
+func (p *HeaderToQueryPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package headertoquery
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	if _, ok := params["mappings"]; !ok {
+		params["mappings"] = map[string]interface{}{"X-Tenant": "tenant", "x-user": "user id"}
+	}
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onRequest(p policy.Policy, path string, headers map[string][]string) *string {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers), Path: path}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications).Path
+}
+
+func expectPath(t *testing.T, got *string, expected string) {
+	t.Helper()
+	if got == nil || *got != expected {
+		t.Errorf("Expected path %q, got %v", expected, got)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"mappings": map[string]interface{}{}},
+		{"mappings": map[string]interface{}{"x tenant": "tenant"}},
+		{"mappings": map[string]interface{}{"x-tenant": ""}},
+		{"mappings": map[string]interface{}{"x-tenant": "tenant"}, "overwrite": "no"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestHeaderToQueryPolicy_AppendHeaderValue(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	expectPath(t, onRequest(p, "/orders", map[string][]string{"x-tenant": {"acme"}}), "/orders?tenant=acme")
+	expectPath(t, onRequest(p, "/orders?limit=5", map[string][]string{"x-tenant": {"acme"}}), "/orders?limit=5&tenant=acme")
+
+	if got := onRequest(p, "/orders", nil); got != nil {
+		t.Errorf("Expected path to be unchanged without mapped headers, got %q", *got)
+	}
+}
+
+func TestHeaderToQueryPolicy_URLEncoding(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	headers := map[string][]string{"x-tenant": {"a&b=c"}, "x-user": {"jane doe+1/ü"}}
+	expectPath(t, onRequest(p, "/orders", headers), "/orders?tenant=a%26b%3Dc&user+id=jane+doe%2B1%2F%C3%BC")
+}
+
+func TestHeaderToQueryPolicy_OverwriteExisting(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	headers := map[string][]string{"x-tenant": {"acme"}}
+	expectPath(t, onRequest(p, "/orders?tenant=evil&limit=5&ten%61nt=evil2", headers), "/orders?limit=5&tenant=acme")
+}
+
+func TestHeaderToQueryPolicy_AppendAlongsideExisting(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"overwrite": false})
+
+	headers := map[string][]string{"x-tenant": {"acme"}}
+	expectPath(t, onRequest(p, "/orders?tenant=other", headers), "/orders?tenant=other&tenant=acme")
+}
@@ -0,0 +1,192 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package headerstobody
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+// fieldMapping copies a response header into a field of the JSON body
+type fieldMapping struct {
+	header string   // lower-cased header name
+	path   []string // field path segments below the root object
+}
+
+// HeadersToBodyPolicy copies selected response headers into fields of JSON response bodies for
+// clients that can't read headers
+type HeadersToBodyPolicy struct {
+	mappings []fieldMapping
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["mappings"].(map[string]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'mappings' parameter is required and must be a non-empty object")
+	}
+
+	// Sort header names so fields are written in a stable order
+	names := make([]string, 0, len(mappingsRaw))
+	for name := range mappingsRaw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	p := &HeadersToBodyPolicy{}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		header := strings.ToLower(strings.TrimSpace(name))
+		if header == "" {
+			return nil, fmt.Errorf("'mappings' must not contain an empty header name")
+		}
+		if seen[header] {
+			return nil, fmt.Errorf("mappings.%s is configured more than once", name)
+		}
+		seen[header] = true
+
+		jsonPath, ok := mappingsRaw[name].(string)
+		if !ok {
+			return nil, fmt.Errorf("mappings.%s must be a JSONPath string", name)
+		}
+		segments, err := jsonpath.Parse(jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("mappings.%s: %w", name, err)
+		}
+		// Fields are created where missing, so the path must name every one of them
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("mappings.%s must select a field below the root object", name)
+		}
+		for _, segment := range segments {
+			if segment == "*" || strings.HasPrefix(segment, "[") {
+				return nil, fmt.Errorf("mappings.%s must be a dotted path of field names: %s", name, jsonPath)
+			}
+		}
+		p.mappings = append(p.mappings, fieldMapping{header: header, path: segments})
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *HeadersToBodyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type and mapped headers
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to embed headers
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *HeadersToBodyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse writes the mapped headers present on the response into the JSON body object.
+// Intermediate objects are created as needed; a mapping whose path runs through a non-object
+// value is skipped.
+func (p *HeadersToBodyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+	if !strings.Contains(bodyutil.MediaType(ctx.ResponseHeaders), "json") {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Compressed bodies can't be rewritten without decoding them
+	if bodyutil.IsContentEncoded(ctx.ResponseHeaders) {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("HeadersToBody: Skipping response body rewrite", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(ctx.ResponseBody.Content))
+	decoder.UseNumber()
+	var root map[string]interface{}
+	if err := decoder.Decode(&root); err != nil || root == nil {
+		slog.Debug("HeadersToBody: Skipping body that is not a JSON object", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	changed := false
+	for _, mapping := range p.mappings {
+		values := ctx.ResponseHeaders.Get(mapping.header)
+		if len(values) == 0 {
+			continue
+		}
+		if setField(root, mapping.path, strings.Join(values, ", ")) {
+			changed = true
+		}
+	}
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(root); err != nil {
+		slog.Debug("HeadersToBody: Failed to encode rewritten body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	body := bytes.TrimRight(buf.Bytes(), "\n")
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": strconv.Itoa(len(body)),
+		},
+	}
+}
+
+// setField sets the value at path below object, creating intermediate objects. It returns
+// false when the path runs through a value that is not an object.
+func setField(object map[string]interface{}, path []string, value string) bool {
+	for _, segment := range path[:len(path)-1] {
+		child, exists := object[segment]
+		if !exists {
+			next := make(map[string]interface{})
+			object[segment] = next
+			object = next
+			continue
+		}
+		next, ok := child.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		object = next
+	}
+	object[path[len(path)-1]] = value
+	return true
+}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package headerstobody
+
+import (
+	"strconv"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(t *testing.T, contentType, body string) policy.UpstreamResponseModifications {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"X-Request-Id": "$.meta.requestId",
+			"x-region":     "$.region",
+			"x-absent":     "$.meta.absent",
+		},
+	})
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{
+			"content-type": {contentType},
+			"x-request-id": {"req-123"},
+			"x-region":     {"eu-west-1"},
+		}),
+		ResponseBody: &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	mods, _ := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	return mods
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"mappings": map[string]interface{}{}},
+		{"mappings": map[string]interface{}{"x-id": "meta.id"}},
+		{"mappings": map[string]interface{}{"x-id": "$.items[0].id"}},
+		{"mappings": map[string]interface{}{"x-id": "$.meta..id"}},
+		{"mappings": map[string]interface{}{"x-id": "$.meta.*"}},
+		{"mappings": map[string]interface{}{"x-id": "$"}},
+		{"mappings": map[string]interface{}{"X-Id": "$.a", "x-id": "$.b"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestHeadersToBodyPolicy_EmbedsNestedField(t *testing.T) {
+	mods := onResponse(t, "application/json", `{"data":[1,2],"meta":{"page":1}}`)
+	want := `{"data":[1,2],"meta":{"page":1,"requestId":"req-123"},"region":"eu-west-1"}`
+	if string(mods.Body) != want {
+		t.Errorf("Expected body %s, got %s", want, mods.Body)
+	}
+	if mods.SetHeaders["content-length"] != strconv.Itoa(len(want)) {
+		t.Errorf("Expected content-length %d, got %q", len(want), mods.SetHeaders["content-length"])
+	}
+
+	// A path through a non-object value is skipped
+	mods = onResponse(t, "application/json", `{"meta":"none"}`)
+	if want := `{"meta":"none","region":"eu-west-1"}`; string(mods.Body) != want {
+		t.Errorf("Expected body %s, got %s", want, mods.Body)
+	}
+}
+
+func TestHeadersToBodyPolicy_NonJSONPassesThrough(t *testing.T) {
+	for _, tc := range []struct{ contentType, body string }{
+		{"text/plain", `{"meta":{}}`},
+		{"application/json", `[1,2,3]`},
+		{"application/json", `not json`},
+	} {
+		if mods := onResponse(t, tc.contentType, tc.body); mods.Body != nil {
+			t.Errorf("Expected %s body %q to pass through, got %s", tc.contentType, tc.body, mods.Body)
+		}
+	}
+}
+
+func TestHeadersToBodyPolicy_EncodedBodyUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"mappings": map[string]interface{}{"x-region": "$.region"}})
+	for _, encoding := range []string{"gzip", "identity, br"} {
+		ctx := &policy.ResponseContext{
+			ResponseHeaders: policy.NewHeaders(map[string][]string{
+				"content-type":     {"application/json"},
+				"content-encoding": {encoding},
+				"x-region":         {"eu-west-1"},
+			}),
+			ResponseBody: &policy.Body{Content: []byte(`{"id":1}`), Present: true, EndOfStream: true},
+		}
+		if mods := policytest.ExpectResponseStatus(t, p.OnResponse(ctx, nil), 0); mods.Body != nil {
+			t.Errorf("Expected %q body to be untouched, got %s", encoding, mods.Body)
+		}
+	}
+}
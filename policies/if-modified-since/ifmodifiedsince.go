@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ifmodifiedsince
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// IfModifiedSincePolicy answers conditional GET requests with 304 Not Modified when the
+// upstream response has not changed since the time given in If-Modified-Since
+type IfModifiedSincePolicy struct{}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	return &IfModifiedSincePolicy{}, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *IfModifiedSincePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need If-Modified-Since header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need Last-Modified header
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to drop it on 304
+	}
+}
+
+// OnRequest is not used by this policy; the request headers are read in the response phase
+func (p *IfModifiedSincePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse replaces a 200 response to a GET or HEAD request with an empty 304 when its
+// Last-Modified time is not after the request's If-Modified-Since time
+func (p *IfModifiedSincePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseStatus != http.StatusOK {
+		return policy.UpstreamResponseModifications{}
+	}
+	method := strings.ToUpper(ctx.RequestMethod)
+	if method != http.MethodGet && method != http.MethodHead {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// If-None-Match takes precedence over If-Modified-Since (RFC 9110 section 13.1.3)
+	if ctx.RequestHeaders.Has("if-none-match") {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	since, ok := parseDate(ctx.RequestHeaders, "if-modified-since")
+	if !ok {
+		return policy.UpstreamResponseModifications{}
+	}
+	lastModified, ok := parseDate(ctx.ResponseHeaders, "last-modified")
+	if !ok {
+		return policy.UpstreamResponseModifications{}
+	}
+	if lastModified.After(since) {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	slog.Debug("IfModifiedSince: Responding with 304", "path", ctx.RequestPath)
+	status := http.StatusNotModified
+	return policy.UpstreamResponseModifications{
+		StatusCode:    &status,
+		Body:          []byte{},
+		RemoveHeaders: []string{"content-length", "content-type", "content-encoding", "transfer-encoding"},
+	}
+}
+
+// parseDate parses an HTTP date header, reporting false when it is missing or malformed
+func parseDate(headers *policy.Headers, name string) (time.Time, bool) {
+	values := headers.Get(name)
+	if len(values) == 0 {
+		return time.Time{}, false
+	}
+	t, err := http.ParseTime(strings.TrimSpace(values[0]))
+	if err != nil {
+		slog.Debug("IfModifiedSince: Ignoring malformed date", "header", name, "value", values[0])
+		return time.Time{}, false
+	}
+	return t, true
+}
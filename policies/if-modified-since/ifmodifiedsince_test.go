@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ifmodifiedsince
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+const lastModified = "Wed, 14 Oct 2026 10:00:00 GMT"
+
+func onResponse(t *testing.T, requestHeaders map[string][]string) policy.UpstreamResponseModifications {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, nil)
+	ctx := &policy.ResponseContext{
+		RequestHeaders: policy.NewHeaders(requestHeaders),
+		RequestMethod:  "GET",
+		ResponseHeaders: policy.NewHeaders(map[string][]string{
+			"last-modified":  {lastModified},
+			"content-type":   {"text/plain"},
+			"content-length": {"5"},
+		}),
+		ResponseBody:   &policy.Body{Content: []byte("hello"), Present: true, EndOfStream: true},
+		ResponseStatus: 200,
+	}
+	mods, _ := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	return mods
+}
+
+func TestIfModifiedSincePolicy_NotModified(t *testing.T) {
+	for _, since := range []string{lastModified, "Thu, 15 Oct 2026 00:00:00 GMT"} {
+		mods := onResponse(t, map[string][]string{"if-modified-since": {since}})
+		if mods.StatusCode == nil || *mods.StatusCode != 304 {
+			t.Fatalf("Expected status 304 for %q, got %v", since, mods.StatusCode)
+		}
+		if mods.Body == nil || len(mods.Body) != 0 {
+			t.Errorf("Expected body to be cleared, got %q", mods.Body)
+		}
+	}
+}
+
+func TestIfModifiedSincePolicy_ModifiedPassesThrough(t *testing.T) {
+	mods := onResponse(t, map[string][]string{"if-modified-since": {"Tue, 13 Oct 2026 10:00:00 GMT"}})
+	if mods.StatusCode != nil || mods.Body != nil {
+		t.Errorf("Expected full response, got %+v", mods)
+	}
+
+	// If-None-Match takes precedence
+	mods = onResponse(t, map[string][]string{"if-modified-since": {lastModified}, "if-none-match": {`"abc"`}})
+	if mods.StatusCode != nil {
+		t.Errorf("Expected full response with If-None-Match, got %+v", mods)
+	}
+}
+
+func TestIfModifiedSincePolicy_MalformedDatePassesThrough(t *testing.T) {
+	for _, headers := range []map[string][]string{
+		{"if-modified-since": {"yesterday"}},
+		{},
+	} {
+		if mods := onResponse(t, headers); mods.StatusCode != nil {
+			t.Errorf("Expected full response for %v, got %+v", headers, mods)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package injectupstreamauth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, authorization string) policy.UpstreamRequestModifications {
+	headers := map[string][]string{}
+	if authorization != "" {
+		headers["authorization"] = []string{authorization}
+	}
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func envSource(key string) map[string]interface{} {
+	return map[string]interface{}{"type": "env", "key": key}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	t.Setenv("UPSTREAM_TOKEN", "s3cr3t")
+	t.Setenv("EMPTY_TOKEN", " \n")
+
+	invalid := []map[string]interface{}{
+		{},
+		{"secretSource": map[string]interface{}{"type": "vault", "key": "x"}},
+		{"secretSource": envSource("")},
+		{"secretSource": envSource("UNSET_UPSTREAM_TOKEN")},
+		{"secretSource": envSource("EMPTY_TOKEN")},
+		{"secretSource": map[string]interface{}{"type": "file", "key": filepath.Join(t.TempDir(), "missing")}},
+		{"secretSource": envSource("UPSTREAM_TOKEN"), "scheme": "Digest"},
+		{"secretSource": envSource("UPSTREAM_TOKEN"), "scheme": "Basic"},
+		{"secretSource": envSource("UPSTREAM_TOKEN"), "overwrite": "yes"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestInjectUpstreamAuthPolicy_BearerToken(t *testing.T) {
+	t.Setenv("UPSTREAM_TOKEN", "s3cr3t\n")
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"secretSource": envSource("UPSTREAM_TOKEN")})
+
+	mods := onRequest(p, "")
+	if got := mods.SetHeaders["authorization"]; got != "Bearer s3cr3t" {
+		t.Errorf("Expected 'Bearer s3cr3t', got %q", got)
+	}
+
+	// The secret is loaded once at initialization
+	t.Setenv("UPSTREAM_TOKEN", "rotated")
+	if got := onRequest(p, "").SetHeaders["authorization"]; got != "Bearer s3cr3t" {
+		t.Errorf("Expected the initial secret to be used, got %q", got)
+	}
+}
+
+func TestInjectUpstreamAuthPolicy_BasicFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte("svc:pa55\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"secretSource": map[string]interface{}{"type": "file", "key": path},
+		"scheme":       "Basic",
+	})
+
+	mods := onRequest(p, "")
+	if got := mods.SetHeaders["authorization"]; got != "Basic c3ZjOnBhNTU=" {
+		t.Errorf("Expected 'Basic c3ZjOnBhNTU=', got %q", got)
+	}
+}
+
+func TestInjectUpstreamAuthPolicy_Overwrite(t *testing.T) {
+	t.Setenv("UPSTREAM_TOKEN", "s3cr3t")
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"secretSource": envSource("UPSTREAM_TOKEN")})
+
+	mods := onRequest(p, "Bearer client-token")
+	if got := mods.SetHeaders["authorization"]; got != "Bearer s3cr3t" {
+		t.Errorf("Expected client credential to be replaced, got %q", got)
+	}
+}
+
+func TestInjectUpstreamAuthPolicy_Preserve(t *testing.T) {
+	t.Setenv("UPSTREAM_TOKEN", "s3cr3t")
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"secretSource": envSource("UPSTREAM_TOKEN"),
+		"overwrite":    false,
+	})
+
+	if mods := onRequest(p, "Bearer client-token"); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected client credential to be preserved, got %v", mods.SetHeaders)
+	}
+	if got := onRequest(p, "").SetHeaders["authorization"]; got != "Bearer s3cr3t" {
+		t.Errorf("Expected credential to be added when absent, got %q", got)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jitterdelay
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// maxDelay bounds the configurable delay so a misconfiguration can't stall responses
+const maxDelay = 10 * time.Second
+
+// JitterDelayPolicy holds responses for a random delay within a configured range so that
+// clients retrying in lockstep are spread out
+type JitterDelayPolicy struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+	statuses map[int]bool // nil delays every response
+
+	random func() float64      // Injectable sampler (for testing)
+	sleep  func(time.Duration) // Injectable delay (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &JitterDelayPolicy{
+		random: rand.Float64,
+		sleep:  time.Sleep,
+	}
+
+	rawMax, ok := params["maxDelayMs"]
+	if !ok {
+		return nil, fmt.Errorf("'maxDelayMs' parameter is required")
+	}
+	maxMs, err := extractInt(rawMax)
+	if err != nil || maxMs < 1 || time.Duration(maxMs)*time.Millisecond > maxDelay {
+		return nil, fmt.Errorf("'maxDelayMs' must be an integer between 1 and %d", maxDelay.Milliseconds())
+	}
+	p.maxDelay = time.Duration(maxMs) * time.Millisecond
+
+	if raw, ok := params["minDelayMs"]; ok {
+		minMs, err := extractInt(raw)
+		if err != nil || minMs < 0 {
+			return nil, fmt.Errorf("'minDelayMs' must be a non-negative integer")
+		}
+		if minMs > maxMs {
+			return nil, fmt.Errorf("'minDelayMs' cannot exceed 'maxDelayMs'")
+		}
+		p.minDelay = time.Duration(minMs) * time.Millisecond
+	}
+
+	if raw, ok := params["statuses"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'statuses' must be a non-empty array")
+		}
+		p.statuses = make(map[int]bool, len(list))
+		for i, item := range list {
+			status, err := extractInt(item)
+			if err != nil || status < 100 || status > 599 {
+				return nil, fmt.Errorf("statuses[%d] must be a status code between 100 and 599", i)
+			}
+			p.statuses[status] = true
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *JitterDelayPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Delays responses
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *JitterDelayPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse holds the response for a uniformly distributed delay between the configured
+// minimum and maximum
+func (p *JitterDelayPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if p.statuses != nil && !p.statuses[ctx.ResponseStatus] {
+		return policy.UpstreamResponseModifications{}
+	}
+	if delay := p.delay(); delay > 0 {
+		p.sleep(delay)
+	}
+	return policy.UpstreamResponseModifications{}
+}
+
+// delay picks a delay in [minDelay, maxDelay], rounded to the millisecond
+func (p *JitterDelayPolicy) delay() time.Duration {
+	span := float64(p.maxDelay - p.minDelay)
+	return (p.minDelay + time.Duration(p.random()*span)).Round(time.Millisecond)
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jitterdelay
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// newPolicy returns a policy with a seeded sampler whose sleeps advance a fake clock
+func newPolicy(t *testing.T, params map[string]interface{}, now *time.Time) *JitterDelayPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	jp := p.(*JitterDelayPolicy)
+	jp.random = rand.New(rand.NewSource(42)).Float64
+	jp.sleep = func(d time.Duration) { *now = now.Add(d) }
+	return jp
+}
+
+func onResponse(p policy.Policy, status int) {
+	p.OnResponse(&policy.ResponseContext{ResponseStatus: status}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxDelayMs": 0},
+		{"maxDelayMs": 10001},
+		{"maxDelayMs": 1.5},
+		{"maxDelayMs": 100, "minDelayMs": -1},
+		{"maxDelayMs": 100, "minDelayMs": 101},
+		{"maxDelayMs": 100, "statuses": []interface{}{}},
+		{"maxDelayMs": 100, "statuses": []interface{}{999}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJitterDelayPolicy_DelayWithinRange(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{"minDelayMs": 50, "maxDelayMs": 250}, &now)
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 200; i++ {
+		before := now
+		onResponse(p, 200)
+		delay := now.Sub(before)
+		if delay < 50*time.Millisecond || delay > 250*time.Millisecond {
+			t.Fatalf("Expected a delay between 50ms and 250ms, got %v", delay)
+		}
+		if delay != delay.Round(time.Millisecond) {
+			t.Errorf("Expected a whole millisecond delay, got %v", delay)
+		}
+		seen[delay] = true
+	}
+	if len(seen) < 50 {
+		t.Errorf("Expected delays to vary, got %d distinct values", len(seen))
+	}
+}
+
+func TestJitterDelayPolicy_OnlyConfiguredStatuses(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newPolicy(t, map[string]interface{}{
+		"minDelayMs": 10,
+		"maxDelayMs": 20,
+		"statuses":   []interface{}{429, 503},
+	}, &now)
+
+	onResponse(p, 200)
+	if !now.Equal(time.Unix(1000, 0)) {
+		t.Errorf("Expected 200 responses not to be delayed, got %v", now.Sub(time.Unix(1000, 0)))
+	}
+	onResponse(p, 503)
+	if delay := now.Sub(time.Unix(1000, 0)); delay < 10*time.Millisecond || delay > 20*time.Millisecond {
+		t.Errorf("Expected 503 to be delayed 10-20ms, got %v", delay)
+	}
+}
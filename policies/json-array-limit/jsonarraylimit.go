@@ -0,0 +1,214 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonarraylimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+// arrayLimit is the maximum length for the array at a JSONPath
+type arrayLimit struct {
+	path     string
+	segments jsonpath.Path
+	maxItems int
+}
+
+// JSONArrayLimitPolicy rejects JSON request bodies whose configured arrays are too long
+type JSONArrayLimitPolicy struct {
+	limits []arrayLimit
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	limitsRaw, ok := params["limits"].(map[string]interface{})
+	if !ok || len(limitsRaw) == 0 {
+		return nil, fmt.Errorf("'limits' parameter is required and must be a non-empty object")
+	}
+
+	p := &JSONArrayLimitPolicy{}
+	for path, raw := range limitsRaw {
+		segments, err := jsonpath.Parse(path)
+		if err != nil {
+			return nil, fmt.Errorf("limits key '%s' is not a valid JSONPath: %w", path, err)
+		}
+		maxItems, err := extractInt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("limits['%s'] must be an integer: %w", path, err)
+		}
+		if maxItems < 0 {
+			return nil, fmt.Errorf("limits['%s'] cannot be negative", path)
+		}
+		p.limits = append(p.limits, arrayLimit{path: path, segments: segments, maxItems: maxItems})
+	}
+	// Evaluate in a stable order so the reported violation is deterministic
+	sort.Slice(p.limits, func(i, j int) bool { return p.limits[i].path < p.limits[j].path })
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *JSONArrayLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to count array items
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests whose configured arrays exceed their maximum length. The check
+// fails closed: encoded bodies are rejected rather than skipped, and ndjson bodies are checked
+// line by line.
+func (p *JSONArrayLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	mediaType := bodyutil.MediaType(ctx.Headers)
+	if !strings.Contains(mediaType, "json") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be inspected without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("JSONArrayLimit: Rejecting encoded request body")
+		return errorResponse(http.StatusUnsupportedMediaType,
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	if isLineDelimited(mediaType) {
+		return p.checkLines(ctx.Body.Content, ctx.Body.EndOfStream)
+	}
+
+	if violation := p.check(ctx.Body.Content); violation != "" {
+		return errorResponse(http.StatusBadRequest, violation)
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// checkLines checks each line of an ndjson body as its own document, so a malformed line
+// doesn't hide the arrays on the lines after it. A trailing line of an incomplete body can't
+// be checked and fails the request.
+func (p *JSONArrayLimitPolicy) checkLines(content []byte, complete bool) policy.RequestAction {
+	lines := bytes.Split(content, []byte("\n"))
+	if !complete && len(bytes.TrimSpace(lines[len(lines)-1])) > 0 {
+		slog.Debug("JSONArrayLimit: Rejecting incomplete ndjson line")
+		return errorResponse(http.StatusBadRequest, "The request body ends with an incomplete line")
+	}
+
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if violation := p.check(line); violation != "" {
+			return errorResponse(http.StatusBadRequest, fmt.Sprintf("%s on line %d", violation, i+1))
+		}
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// check returns a description of the first over-limit array in one JSON document, or "" when
+// every array is within its limit. Malformed JSON is left for schema validation policies or the
+// upstream to handle.
+func (p *JSONArrayLimitPolicy) check(document []byte) string {
+	var data interface{}
+	if err := json.Unmarshal(document, &data); err != nil {
+		slog.Debug("JSONArrayLimit: Skipping invalid JSON document", "error", err)
+		return ""
+	}
+
+	for _, limit := range p.limits {
+		if items := longestArray(data, limit.segments); items > limit.maxItems {
+			slog.Debug("JSONArrayLimit: Array exceeds maximum length", "path", limit.path, "items", items, "maxItems", limit.maxItems)
+			return fmt.Sprintf("Array at '%s' has %d items, which exceeds the maximum of %d",
+				limit.path, items, limit.maxItems)
+		}
+	}
+	return ""
+}
+
+// OnResponse is not used by this policy
+func (p *JSONArrayLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// longestArray returns the length of the longest array selected by the path, or -1 when the
+// path selects no array. Wildcard paths may select several arrays.
+func longestArray(data interface{}, path jsonpath.Path) int {
+	longest := -1
+	jsonpath.Walk(data, func(nodePath []string, value interface{}) jsonpath.Transform {
+		if array, ok := value.([]interface{}); ok && path.Matches(nodePath) && len(array) > longest {
+			longest = len(array)
+		}
+		return nil
+	})
+	return longest
+}
+
+// isLineDelimited reports whether the media type carries one JSON document per line
+func isLineDelimited(mediaType string) bool {
+	return strings.Contains(mediaType, "ndjson") || strings.Contains(mediaType, "jsonl")
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
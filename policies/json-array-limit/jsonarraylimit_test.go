@@ -0,0 +1,142 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonarraylimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, limits map[string]interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"limits": limits})
+	return p
+}
+
+func onRequest(p policy.Policy, contentType, body string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"limits": map[string]interface{}{}},
+		{"limits": map[string]interface{}{"items": 10}},
+		{"limits": map[string]interface{}{"$.items": -1}},
+		{"limits": map[string]interface{}{"$.items": "many"}},
+		{"limits": map[string]interface{}{"$..items": 1}},
+		{"limits": map[string]interface{}{"$.items[x]": 1}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJSONArrayLimitPolicy_OverLimitRejected(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$.batch.operations": float64(2)})
+
+	action := onRequest(p, "application/json", `{"batch":{"operations":[{"op":1},{"op":2},{"op":3}]}}`)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		t.Fatalf("Expected JSON error body, got %v", err)
+	}
+	if body["message"] != "Array at '$.batch.operations' has 3 items, which exceeds the maximum of 2" {
+		t.Errorf("Unexpected message: %s", body["message"])
+	}
+}
+
+func TestJSONArrayLimitPolicy_TopLevelArray(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$": 1})
+
+	if _, ok := onRequest(p, "application/json", `[1,2]`).(policy.ImmediateResponse); !ok {
+		t.Error("Expected over-limit top-level array to be rejected")
+	}
+}
+
+func TestJSONArrayLimitPolicy_WithinLimitPasses(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$.items": 3, "$.tags": 0})
+
+	if _, ok := onRequest(p, "application/json", `{"items":[1,2,3],"tags":[]}`).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected within-limit arrays to pass")
+	}
+}
+
+func TestJSONArrayLimitPolicy_MissingPathIgnored(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$.items": 1, "$.name": 1})
+
+	if _, ok := onRequest(p, "application/json", `{"other":[1,2,3],"name":"not an array"}`).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected missing and non-array paths to be ignored")
+	}
+}
+
+func TestJSONArrayLimitPolicy_NonJSONPassthrough(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$": 0})
+
+	if _, ok := onRequest(p, "text/csv", `[1,2,3]`).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected non-JSON body to pass through")
+	}
+}
+
+func TestJSONArrayLimitPolicy_WildcardPaths(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$.batches[*].items": 2, "$.groups[1]": 1})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"batches":[{"items":[1]},{"items":[1,2,3]}]}`), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"batches":[{"items":[1,2]}],"groups":[[1,2,3],[1]]}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"groups":[[1],[1,2]]}`), 400)
+}
+
+func TestJSONArrayLimitPolicy_EncodedAndStreamingBodiesFailClosed(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$.items": 1})
+
+	encoded := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{
+			"content-type":     {"application/json"},
+			"content-encoding": {"gzip"},
+		}),
+		Body: &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(encoded, nil), 415)
+
+	// Each ndjson line is checked, and a malformed line doesn't hide the ones after it
+	resp := policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"items\":[1]}\n{\"items\":[1,2]}\n"), 400)
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil || body["message"] != "Array at '$.items' has 2 items, which exceeds the maximum of 1 on line 2" {
+		t.Errorf("Unexpected error body: %s", resp.Body)
+	}
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"items\":\n{\"items\":[1,2]}\n"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"items\":[1]}\n\n{\"items\":[]}\n"), 0)
+
+	partial := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {"application/x-ndjson"}}),
+		Body:    &policy.Body{Content: []byte("{\"items\":[1]}\n{\"items\":[1,"), Present: true, EndOfStream: false},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(partial, nil), 400)
+}
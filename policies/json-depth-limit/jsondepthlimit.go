@@ -0,0 +1,177 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsondepthlimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+// JSONDepthLimitPolicy rejects JSON request bodies nested deeper than allowed, protecting
+// upstream parsers from stack exhaustion and excessive work
+type JSONDepthLimitPolicy struct {
+	maxDepth int
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	raw, ok := params["maxDepth"]
+	if !ok {
+		return nil, fmt.Errorf("'maxDepth' parameter is required")
+	}
+	maxDepth, err := extractInt(raw)
+	if err != nil {
+		return nil, fmt.Errorf("'maxDepth' must be an integer: %w", err)
+	}
+	if maxDepth < 1 {
+		return nil, fmt.Errorf("'maxDepth' must be at least 1")
+	}
+
+	return &JSONDepthLimitPolicy{maxDepth: maxDepth}, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *JSONDepthLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to measure nesting
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests whose JSON body is nested deeper than maxDepth. The check fails
+// closed: encoded bodies are rejected rather than skipped, and streaming bodies such as ndjson
+// are scanned as far as they are buffered.
+func (p *JSONDepthLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	if !strings.Contains(bodyutil.MediaType(ctx.Headers), "json") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be measured without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("JSONDepthLimit: Rejecting encoded request body")
+		return errorResponse(http.StatusUnsupportedMediaType,
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	tooDeep, balanced := scanDepth(ctx.Body.Content, p.maxDepth)
+	if !balanced {
+		slog.Debug("JSONDepthLimit: Body has unbalanced closing brackets")
+		return errorResponse(http.StatusBadRequest, "JSON body is malformed")
+	}
+	if tooDeep {
+		slog.Debug("JSONDepthLimit: Body exceeds maximum nesting depth", "maxDepth", p.maxDepth)
+		return errorResponse(http.StatusBadRequest,
+			fmt.Sprintf("JSON body exceeds the maximum nesting depth of %d", p.maxDepth))
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *JSONDepthLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// scanDepth scans JSON text and reports whether objects and arrays nest deeper than maxDepth.
+// It keeps only a depth counter and string state instead of decoding, and stops as soon as
+// the limit is crossed. A closing bracket without a matching opening one makes the text
+// unbalanced, since letting the depth go negative would hide nesting that follows it. The text
+// is not otherwise validated, so concatenated documents such as ndjson are scanned as one.
+func scanDepth(data []byte, maxDepth int) (tooDeep bool, balanced bool) {
+	depth := 0
+	inString := false
+	escaped := false
+	for _, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				return true, true
+			}
+		case '}', ']':
+			if depth == 0 {
+				return false, false
+			}
+			depth--
+		}
+	}
+	return false, true
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsondepthlimit
+
+import (
+	"strings"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, maxDepth interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxDepth": maxDepth})
+	return p
+}
+
+func onRequest(p policy.Policy, contentType, body string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxDepth": 0},
+		{"maxDepth": 2.5},
+		{"maxDepth": "deep"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJSONDepthLimitPolicy_DeeplyNestedRejected(t *testing.T) {
+	p := newPolicy(t, 3)
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":{"b":[{"c":1}]}}`), 400)
+
+	// A huge nesting is rejected without decoding the rest of the body
+	deep := strings.Repeat("[", 100000) + strings.Repeat("]", 100000)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", deep), 400)
+}
+
+func TestJSONDepthLimitPolicy_ShallowPasses(t *testing.T) {
+	p := newPolicy(t, float64(3))
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":{"b":[1,2]},"c":[{"d":null}]}`), 0)
+	// Brackets inside strings don't count
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"text":"[[[[{{{{ \"]]]] \\"}`), 0)
+}
+
+func TestJSONDepthLimitPolicy_NonJSONPassesThrough(t *testing.T) {
+	p := newPolicy(t, 1)
+
+	policytest.ExpectStatus(t, onRequest(p, "text/plain", `[[[[]]]]`), 0)
+}
+
+func TestJSONDepthLimitPolicy_UnbalancedRejected(t *testing.T) {
+	p := newPolicy(t, 2)
+
+	// Stray closing brackets must not cancel out the nesting that follows them
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `]]]][[[[1]]]]`), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":1}}`), 400)
+}
+
+func TestJSONDepthLimitPolicy_EncodedAndStreamingBodiesFailClosed(t *testing.T) {
+	p := newPolicy(t, 2)
+
+	encoded := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{
+			"content-type":     {"application/json"},
+			"content-encoding": {"gzip"},
+		}),
+		Body: &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(encoded, nil), 415)
+
+	// ndjson and partially buffered bodies are scanned rather than skipped
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":[1]}\n{\"a\":[[1]]}\n"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":[1]}\n{\"b\":[2]}\n"), 0)
+	partial := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{
+			"content-type":      {"application/json"},
+			"transfer-encoding": {"chunked"},
+		}),
+		Body: &policy.Body{Content: []byte(`{"a":[[[`), Present: true, EndOfStream: false},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(partial, nil), 400)
+}
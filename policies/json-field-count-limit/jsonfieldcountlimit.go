@@ -0,0 +1,239 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonfieldcountlimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+// JSONFieldCountLimitPolicy rejects JSON request bodies whose objects have too many fields
+type JSONFieldCountLimitPolicy struct {
+	maxFields int
+	recursive bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	raw, ok := params["maxFields"]
+	if !ok {
+		return nil, fmt.Errorf("'maxFields' parameter is required")
+	}
+	maxFields, err := extractInt(raw)
+	if err != nil {
+		return nil, fmt.Errorf("'maxFields' must be an integer: %w", err)
+	}
+	if maxFields < 1 {
+		return nil, fmt.Errorf("'maxFields' must be at least 1")
+	}
+
+	p := &JSONFieldCountLimitPolicy{maxFields: maxFields}
+	if raw, ok := params["recursive"]; ok {
+		if p.recursive, ok = raw.(bool); !ok {
+			return nil, fmt.Errorf("'recursive' must be a boolean")
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *JSONFieldCountLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to count fields
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests whose JSON body has more fields than maxFields. The check fails
+// closed: encoded bodies are rejected rather than skipped, and ndjson bodies are checked line by
+// line.
+func (p *JSONFieldCountLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	mediaType := bodyutil.MediaType(ctx.Headers)
+	if !strings.Contains(mediaType, "json") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be inspected without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("JSONFieldCountLimit: Rejecting encoded request body")
+		return errorResponse(http.StatusUnsupportedMediaType,
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	if isLineDelimited(mediaType) {
+		return p.checkLines(ctx.Body.Content, ctx.Body.EndOfStream)
+	}
+
+	if p.exceedsFields(ctx.Body.Content) {
+		slog.Debug("JSONFieldCountLimit: Body exceeds maximum field count", "maxFields", p.maxFields, "recursive", p.recursive)
+		return errorResponse(http.StatusBadRequest, p.violation())
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// checkLines checks each line of an ndjson body as its own document, so a malformed line
+// doesn't hide the fields on the lines after it. A trailing line of an incomplete body can't
+// be checked and fails the request.
+func (p *JSONFieldCountLimitPolicy) checkLines(content []byte, complete bool) policy.RequestAction {
+	lines := bytes.Split(content, []byte("\n"))
+	if !complete && len(bytes.TrimSpace(lines[len(lines)-1])) > 0 {
+		slog.Debug("JSONFieldCountLimit: Rejecting incomplete ndjson line")
+		return errorResponse(http.StatusBadRequest, "The request body ends with an incomplete line")
+	}
+
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if p.exceedsFields(line) {
+			slog.Debug("JSONFieldCountLimit: Line exceeds maximum field count", "line", i+1, "maxFields", p.maxFields)
+			return errorResponse(http.StatusBadRequest, fmt.Sprintf("%s on line %d", p.violation(), i+1))
+		}
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *JSONFieldCountLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// frame tracks an open object or array while tokenizing
+type frame struct {
+	object  bool
+	keyNext bool // In an object, the next token is a key
+}
+
+// exceedsFields tokenizes the body and reports whether the field count crosses maxFields. Only
+// the keys of a top-level object are counted unless recursive is set, in which case the keys of
+// every object in the body are counted together. Tokenizing stops as soon as the limit is
+// exceeded; invalid JSON is left for the upstream to reject.
+func (p *JSONFieldCountLimitPolicy) exceedsFields(data []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var stack []frame
+	count := 0
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].keyNext {
+			if _, ok := token.(string); ok {
+				stack[len(stack)-1].keyNext = false
+				if p.recursive || len(stack) == 1 {
+					count++
+					if count > p.maxFields {
+						return true
+					}
+				}
+				continue
+			}
+		}
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, frame{object: true, keyNext: true})
+			continue
+		case json.Delim('['):
+			// Without recursion only a top-level object has fields to count
+			if !p.recursive && len(stack) == 0 {
+				return false
+			}
+			stack = append(stack, frame{})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		}
+
+		// A complete value was read; the enclosing object expects a key next
+		if len(stack) == 0 {
+			return false
+		}
+		if top := &stack[len(stack)-1]; top.object {
+			top.keyNext = true
+		}
+	}
+}
+
+// violation describes the exceeded limit
+func (p *JSONFieldCountLimitPolicy) violation() string {
+	if p.recursive {
+		return fmt.Sprintf("JSON body exceeds the maximum of %d fields across all objects", p.maxFields)
+	}
+	return fmt.Sprintf("JSON object exceeds the maximum of %d fields", p.maxFields)
+}
+
+// isLineDelimited reports whether the media type carries one JSON document per line
+func isLineDelimited(mediaType string) bool {
+	return strings.Contains(mediaType, "ndjson") || strings.Contains(mediaType, "jsonl")
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonfieldcountlimit
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, contentType, body string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxFields": 0},
+		{"maxFields": 1.5},
+		{"maxFields": 3, "recursive": "yes"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJSONFieldCountLimitPolicy_OverLimitRejected(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxFields": float64(3)})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":1,"b":2,"c":3,"d":4}`), 400)
+	// Nested values are skipped over, not counted
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":{"x":1,"y":2},"b":[{"z":1}],"c":"k","d":null}`), 400)
+}
+
+func TestJSONFieldCountLimitPolicy_WithinLimitPasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxFields": 3})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":{"x":1,"y":2,"z":3,"w":4},"b":"c","c":["d","e","f","g"]}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `[{"a":1,"b":2,"c":3,"d":4}]`), 0)
+	// Non-JSON passes through
+	policytest.ExpectStatus(t, onRequest(p, "text/plain", `{"a":1,"b":2,"c":3,"d":4}`), 0)
+}
+
+func TestJSONFieldCountLimitPolicy_RecursiveCounting(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxFields": 4, "recursive": true})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":{"x":1,"y":2},"b":2}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":{"x":1,"y":2},"b":{"z":3}}`), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `[{"a":1,"b":2},{"c":3},{"d":4,"e":5}]`), 400)
+	// Keys in string values are not fields
+	policytest.ExpectStatus(t, onRequest(p, "application/json", `{"a":"{\"x\":1,\"y\":2,\"z\":3}","b":["c","d","e"]}`), 0)
+}
+
+func TestJSONFieldCountLimitPolicy_EncodedAndStreamingBodiesFailClosed(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxFields": 2})
+
+	encoded := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{
+			"content-type":     {"application/json"},
+			"content-encoding": {"gzip"},
+		}),
+		Body: &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(encoded, nil), 415)
+
+	// Each ndjson line is checked, and a malformed line doesn't hide the ones after it
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":1}\n{\"a\":1,\"b\":2,\"c\":3}\n"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":\n{\"a\":1,\"b\":2,\"c\":3}\n"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":1,\"b\":2}\n\n{\"c\":3}\n"), 0)
+
+	partial := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {"application/x-ndjson"}}),
+		Body:    &policy.Body{Content: []byte("{\"a\":1}\n{\"a\":1,"), Present: true, EndOfStream: false},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(partial, nil), 400)
+}
@@ -0,0 +1,347 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonnumberguard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const (
+	ModeReject = "reject"
+	ModeClamp  = "clamp"
+)
+
+// JSONNumberGuardPolicy rejects or clamps JSON numbers that exceed configured bounds
+type JSONNumberGuardPolicy struct {
+	maxInt   *big.Int // nil when integers are not checked
+	maxFloat float64  // zero when non-integers are not checked
+	mode     string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &JSONNumberGuardPolicy{mode: ModeReject}
+
+	if raw, ok := params["maxInt"]; ok {
+		maxInt, err := extractInt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'maxInt' must be an integer: %w", err)
+		}
+		if maxInt <= 0 {
+			return nil, fmt.Errorf("'maxInt' must be greater than 0")
+		}
+		p.maxInt = big.NewInt(maxInt)
+	}
+
+	if raw, ok := params["maxFloat"]; ok {
+		maxFloat, err := extractFloat(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'maxFloat' must be a number: %w", err)
+		}
+		if maxFloat <= 0 || math.IsInf(maxFloat, 0) || math.IsNaN(maxFloat) {
+			return nil, fmt.Errorf("'maxFloat' must be a finite number greater than 0")
+		}
+		p.maxFloat = maxFloat
+	}
+
+	if p.maxInt == nil && p.maxFloat == 0 {
+		return nil, fmt.Errorf("at least one of 'maxInt' or 'maxFloat' must be specified")
+	}
+
+	if raw, ok := params["mode"]; ok {
+		mode, ok := raw.(string)
+		if !ok || (mode != ModeReject && mode != ModeClamp) {
+			return nil, fmt.Errorf("'mode' must be one of %s, %s", ModeReject, ModeClamp)
+		}
+		p.mode = mode
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *JSONNumberGuardPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to inspect numbers
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates numbers in JSON request bodies. The check fails closed: encoded bodies
+// are rejected rather than skipped, and ndjson bodies are validated line by line.
+func (p *JSONNumberGuardPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	mediaType := bodyutil.MediaType(ctx.Headers)
+	if !strings.Contains(mediaType, "json") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be inspected without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("JSONNumberGuard: Rejecting encoded request body")
+		return errorResponse(http.StatusUnsupportedMediaType,
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	if isLineDelimited(mediaType) {
+		return p.guardLines(ctx.Body.Content, ctx.Body.EndOfStream)
+	}
+
+	result, violation := p.guard(ctx.Body.Content)
+	if violation != "" {
+		slog.Debug("JSONNumberGuard: Rejecting request with out-of-bound number", "path", violation)
+		return errorResponse(http.StatusBadRequest, fmt.Sprintf("Number at '%s' exceeds the allowed bounds", violation))
+	}
+	if result == nil {
+		return policy.UpstreamRequestModifications{}
+	}
+	return replaceBody(result)
+}
+
+// guardLines validates each line of an ndjson body as its own document, so a malformed line
+// doesn't hide the numbers on the lines after it. A trailing line of an incomplete body can't
+// be validated and fails the request.
+func (p *JSONNumberGuardPolicy) guardLines(content []byte, complete bool) policy.RequestAction {
+	lines := bytes.Split(content, []byte("\n"))
+	if !complete && len(bytes.TrimSpace(lines[len(lines)-1])) > 0 {
+		slog.Debug("JSONNumberGuard: Rejecting incomplete ndjson line")
+		return errorResponse(http.StatusBadRequest, "The request body ends with an incomplete line")
+	}
+
+	changed := false
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		result, violation := p.guard(line)
+		if violation != "" {
+			slog.Debug("JSONNumberGuard: Rejecting request with out-of-bound number", "line", i+1, "path", violation)
+			return errorResponse(http.StatusBadRequest,
+				fmt.Sprintf("Number at '%s' on line %d exceeds the allowed bounds", violation, i+1))
+		}
+		if result != nil {
+			lines[i] = result
+			changed = true
+		}
+	}
+	if !changed {
+		return policy.UpstreamRequestModifications{}
+	}
+	return replaceBody(bytes.Join(lines, []byte("\n")))
+}
+
+// guard checks the numbers in one JSON document. It returns the JSON path of the first
+// violation in reject mode, or the re-encoded document when numbers were clamped. Malformed
+// JSON is left for schema validation policies or the upstream to handle.
+func (p *JSONNumberGuardPolicy) guard(document []byte) ([]byte, string) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		slog.Debug("JSONNumberGuard: Skipping invalid JSON document", "error", err)
+		return nil, ""
+	}
+
+	result, violation, changed := p.walk(data, "$")
+	if violation != "" || !changed {
+		return nil, violation
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(result); err != nil {
+		slog.Debug("JSONNumberGuard: Failed to encode clamped body", "error", err)
+		return nil, ""
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), ""
+}
+
+// OnResponse is not used by this policy
+func (p *JSONNumberGuardPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// walk checks every number in the decoded value. In reject mode it returns the JSON path of the
+// first violation; in clamp mode it returns the value with out-of-bound numbers replaced.
+func (p *JSONNumberGuardPolicy) walk(value interface{}, path string) (interface{}, string, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		changed := false
+		for key, child := range v {
+			result, violation, childChanged := p.walk(child, path+"."+key)
+			if violation != "" {
+				return nil, violation, false
+			}
+			if childChanged {
+				v[key] = result
+				changed = true
+			}
+		}
+		return v, "", changed
+	case []interface{}:
+		changed := false
+		for i, child := range v {
+			result, violation, childChanged := p.walk(child, fmt.Sprintf("%s[%d]", path, i))
+			if violation != "" {
+				return nil, violation, false
+			}
+			if childChanged {
+				v[i] = result
+				changed = true
+			}
+		}
+		return v, "", changed
+	case json.Number:
+		clamped, ok := p.check(v)
+		if ok {
+			return v, "", false
+		}
+		if p.mode == ModeReject {
+			return nil, path, false
+		}
+		return clamped, "", true
+	default:
+		return v, "", false
+	}
+}
+
+// check reports whether a number is within bounds, returning the clamped value when it is not.
+// Numbers with an integral value are checked against maxInt however they are written, so 9e18 and
+// 1.0e400 cannot slip past the integer bound. Numbers written with a fraction or exponent are also
+// checked against maxFloat, since most parsers read them as floating point.
+func (p *JSONNumberGuardPolicy) check(n json.Number) (json.Number, bool) {
+	s := n.String()
+	negative := strings.HasPrefix(s, "-")
+
+	value, _, err := big.ParseFloat(s, 10, 256, big.ToNearestEven)
+	if err != nil {
+		// Only exponents beyond the big.Float range fail to parse; large ones exceed any bound
+		// and very negative ones round to zero
+		if exp := strings.IndexAny(s, "eE"); exp < 0 || strings.HasPrefix(s[exp+1:], "-") {
+			return n, true
+		}
+		value = new(big.Float).SetInf(negative)
+	}
+
+	if p.maxInt != nil && (value.IsInf() || value.IsInt()) &&
+		new(big.Float).Abs(value).Cmp(new(big.Float).SetInt(p.maxInt)) > 0 {
+		bound := new(big.Int).Set(p.maxInt)
+		if negative {
+			bound.Neg(bound)
+		}
+		return json.Number(bound.String()), false
+	}
+
+	if p.maxFloat == 0 || !strings.ContainsAny(s, ".eE") {
+		return n, true
+	}
+	// Values beyond float64 range parse as ±Inf, which correctly exceed any finite bound
+	f, _ := value.Float64()
+	if math.Abs(f) <= p.maxFloat {
+		return n, true
+	}
+	bound := p.maxFloat
+	if negative {
+		bound = -bound
+	}
+	return json.Number(strconv.FormatFloat(bound, 'g', -1, 64)), false
+}
+
+// isLineDelimited reports whether a JSON media type carries one document per line
+func isLineDelimited(mediaType string) bool {
+	return strings.Contains(mediaType, "ndjson") || strings.Contains(mediaType, "jsonl")
+}
+
+// replaceBody forwards the request with a rewritten body
+func replaceBody(body []byte) policy.RequestAction {
+	return policy.UpstreamRequestModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > math.MaxInt64 {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
+
+// extractFloat safely extracts a float from various types
+func extractFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", value)
+	}
+}
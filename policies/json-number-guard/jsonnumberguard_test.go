@@ -0,0 +1,173 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonnumberguard
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, contentType, body string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxInt": 0},
+		{"maxInt": 1.5},
+		{"maxFloat": -1.0},
+		{"maxInt": 10, "mode": "truncate"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJSONNumberGuardPolicy_RejectOverBoundInteger(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": float64(9007199254740991)})
+
+	action := onRequest(p, "application/json", `{"order":{"ids":[1,12345678901234567890]}}`)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		t.Fatalf("Expected JSON error body, got %v", err)
+	}
+	if body["message"] != "Number at '$.order.ids[1]' exceeds the allowed bounds" {
+		t.Errorf("Unexpected message: %s", body["message"])
+	}
+}
+
+func TestJSONNumberGuardPolicy_RejectOverBoundFloat(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxFloat": 1000.0})
+
+	if _, ok := onRequest(p, "application/json", `{"price":-1000.5}`).(policy.ImmediateResponse); !ok {
+		t.Error("Expected negative float beyond the bound to be rejected")
+	}
+	if _, ok := onRequest(p, "application/json", `{"price":1e400}`).(policy.ImmediateResponse); !ok {
+		t.Error("Expected float beyond float64 range to be rejected")
+	}
+}
+
+func TestJSONNumberGuardPolicy_Clamp(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": 100, "maxFloat": 1.5, "mode": ModeClamp})
+
+	action := onRequest(p, "application/json", `{"count":-250,"ratio":2.75,"ok":42,"name":"a<b"}`)
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamRequestModifications, got %T", action)
+	}
+	expected := `{"count":-100,"name":"a<b","ok":42,"ratio":1.5}`
+	if string(mods.Body) != expected {
+		t.Errorf("Expected %s, got %s", expected, mods.Body)
+	}
+	if mods.SetHeaders["content-length"] != "47" {
+		t.Errorf("Expected content-length 47, got %q", mods.SetHeaders["content-length"])
+	}
+}
+
+func TestJSONNumberGuardPolicy_WithinBounds(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": 100, "maxFloat": 10.0, "mode": ModeClamp})
+
+	action := onRequest(p, "application/json", `{"count":100,"ratio":-9.99,"items":[1,2,3]}`)
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamRequestModifications, got %T", action)
+	}
+	if mods.Body != nil {
+		t.Errorf("Expected body to be unchanged, got %s", mods.Body)
+	}
+}
+
+func TestJSONNumberGuardPolicy_NonJSONPassthrough(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": 10})
+
+	if _, ok := onRequest(p, "text/plain", `99999`).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected non-JSON body to pass through")
+	}
+	if _, ok := onRequest(p, "application/json", `{"count":`).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected malformed JSON to pass through")
+	}
+}
+
+func TestJSONNumberGuardPolicy_ExponentIntegers(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": float64(9007199254740991)})
+
+	for _, body := range []string{`{"id":9e18}`, `{"id":1e400}`, `{"id":-1.5e16}`, `{"id":1e99999999999}`} {
+		if _, ok := onRequest(p, "application/json", body).(policy.ImmediateResponse); !ok {
+			t.Errorf("Expected %s to be rejected as an over-bound integer", body)
+		}
+	}
+	for _, body := range []string{`{"id":9e15}`, `{"ratio":1.5e-3}`, `{"ratio":1e-99999999999}`} {
+		if _, ok := onRequest(p, "application/json", body).(policy.UpstreamRequestModifications); !ok {
+			t.Errorf("Expected %s to pass", body)
+		}
+	}
+
+	clamp := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": 100, "mode": ModeClamp})
+	mods, ok := onRequest(clamp, "application/json", `{"count":-2.5e3}`).(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatal("Expected UpstreamRequestModifications")
+	}
+	if string(mods.Body) != `{"count":-100}` {
+		t.Errorf("Expected exponent integer to be clamped to the integer bound, got %s", mods.Body)
+	}
+}
+
+func TestJSONNumberGuardPolicy_EncodedAndStreamingBodiesFailClosed(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": 1000})
+
+	encoded := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{
+			"content-type":     {"application/json"},
+			"content-encoding": {"gzip"},
+		}),
+		Body: &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(encoded, nil), 415)
+
+	// Each ndjson line is checked, and a malformed line doesn't hide the ones after it
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":1}\n{\"a\":5000}\n"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":\n{\"a\":5000}\n"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/x-ndjson", "{\"a\":1}\n\n{\"a\":2}\n"), 0)
+
+	partial := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {"application/x-ndjson"}}),
+		Body:    &policy.Body{Content: []byte("{\"a\":1}\n{\"a\":50"), Present: true, EndOfStream: false},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(partial, nil), 400)
+
+	clamp := policytest.New(t, GetPolicy, map[string]interface{}{"maxInt": 1000, "mode": "clamp"})
+	mods := policytest.ExpectForwarded(t, onRequest(clamp, "application/x-ndjson", "{\"a\":1}\n{\"a\":-5000}\n"))
+	if string(mods.Body) != "{\"a\":1}\n{\"a\":-1000}\n" {
+		t.Errorf("Expected the second line to be clamped, got %q", mods.Body)
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonprojection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+// node is a trie of projected path segments. A terminal node selects the whole value.
+type node struct {
+	terminal bool
+	children map[string]*node
+}
+
+// JSONProjectionPolicy strips JSON responses down to an allow-list of fields
+type JSONProjectionPolicy struct {
+	root *node
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	fieldsRaw, ok := params["fields"].([]interface{})
+	if !ok || len(fieldsRaw) == 0 {
+		return nil, fmt.Errorf("'fields' parameter is required and must be a non-empty array")
+	}
+
+	p := &JSONProjectionPolicy{root: &node{}}
+	for i, raw := range fieldsRaw {
+		field, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("fields[%d] must be a string", i)
+		}
+		segments, err := parsePath(field)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%d]: %w", i, err)
+		}
+		p.root.insert(segments)
+	}
+
+	return p, nil
+}
+
+// parsePath parses a field selection. Projection keeps the shape of arrays, so only "[*]"
+// selects array elements; an index such as "[0]" is rejected.
+func parsePath(path string) (jsonpath.Path, error) {
+	segments, err := jsonpath.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, "[") && segment != "[*]" {
+			return nil, fmt.Errorf("only '[*]' array selectors are supported: %s", path)
+		}
+	}
+	return segments, nil
+}
+
+// insert adds a path to the trie
+func (n *node) insert(segments []string) {
+	current := n
+	for _, segment := range segments {
+		if current.terminal {
+			// A shorter path already selects this whole subtree
+			return
+		}
+		if current.children == nil {
+			current.children = make(map[string]*node)
+		}
+		child, ok := current.children[segment]
+		if !ok {
+			child = &node{}
+			current.children[segment] = child
+		}
+		current = child
+	}
+	current.terminal = true
+	current.children = nil
+}
+
+// merge combines two tries, returning nil when both are nil
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.terminal || b.terminal {
+		return &node{terminal: true}
+	}
+	merged := &node{children: make(map[string]*node)}
+	for key, child := range a.children {
+		merged.children[key] = child
+	}
+	for key, child := range b.children {
+		merged.children[key] = merge(merged.children[key], child)
+	}
+	return merged
+}
+
+// Mode returns the processing mode for this policy
+func (p *JSONProjectionPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to project
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *JSONProjectionPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse projects JSON response bodies onto the configured fields. Since unselected fields
+// must never reach the client, encoded and malformed bodies are replaced with a 502 rather than
+// passed through, and ndjson bodies are projected line by line.
+func (p *JSONProjectionPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	mediaType := bodyutil.MediaType(ctx.ResponseHeaders)
+	if !strings.Contains(mediaType, "json") {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Compressed bodies can't be projected without decoding them
+	if bodyutil.IsContentEncoded(ctx.ResponseHeaders) {
+		slog.Debug("JSONProjection: Replacing encoded response body")
+		return reject("Upstream returned an encoded response body that cannot be projected")
+	}
+
+	if !isLineDelimited(mediaType) {
+		body, err := p.projectDocument(ctx.ResponseBody.Content)
+		if err != nil {
+			slog.Debug("JSONProjection: Replacing invalid JSON body", "error", err)
+			return reject("Upstream returned a malformed JSON response body")
+		}
+		return replaceBody(body)
+	}
+
+	// A trailing line of an incomplete body can't be projected
+	lines := bytes.Split(ctx.ResponseBody.Content, []byte("\n"))
+	if !ctx.ResponseBody.EndOfStream && len(bytes.TrimSpace(lines[len(lines)-1])) > 0 {
+		slog.Debug("JSONProjection: Replacing body with an incomplete ndjson line")
+		return reject("Upstream returned a response body that ends with an incomplete line")
+	}
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		projected, err := p.projectDocument(line)
+		if err != nil {
+			slog.Debug("JSONProjection: Replacing body with an invalid ndjson line", "line", i+1, "error", err)
+			return reject(fmt.Sprintf("Upstream returned a malformed JSON document on line %d", i+1))
+		}
+		lines[i] = projected
+	}
+	return replaceBody(bytes.Join(lines, []byte("\n")))
+}
+
+// projectDocument projects one JSON document and re-encodes it
+func (p *JSONProjectionPolicy) projectDocument(document []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	// Trailing content after the document would otherwise reach the client unprojected
+	if _, err := decoder.Token(); err == nil {
+		return nil, fmt.Errorf("unexpected content after the JSON document")
+	}
+
+	projected, ok := project(data, p.root)
+	if !ok {
+		projected = map[string]interface{}{}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(projected); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// isLineDelimited reports whether the media type carries one JSON document per line
+func isLineDelimited(mediaType string) bool {
+	return strings.Contains(mediaType, "ndjson") || strings.Contains(mediaType, "jsonl")
+}
+
+// replaceBody forwards the response with a projected body
+func replaceBody(body []byte) policy.ResponseAction {
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// reject replaces the upstream response with a 502 JSON error
+func reject(message string) policy.ResponseAction {
+	statusCode := http.StatusBadGateway
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(statusCode),
+		"message": message,
+	})
+	return policy.UpstreamResponseModifications{
+		StatusCode: &statusCode,
+		Body:       body,
+		SetHeaders: map[string]string{
+			"content-type":   "application/json",
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+		// The replacement body is never encoded like the original
+		RemoveHeaders: []string{"content-encoding"},
+	}
+}
+
+// project keeps only the parts of value selected by the trie. Objects keep selected keys, and
+// arrays apply the selection to each element so that arrays of objects keep their shape. It
+// reports false when nothing in value was selected.
+func project(value interface{}, n *node) (interface{}, bool) {
+	if n.terminal {
+		return value, true
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for key, child := range v {
+			childNode := merge(n.children[key], n.children["*"])
+			if childNode == nil {
+				continue
+			}
+			if projected, ok := project(child, childNode); ok {
+				result[key] = projected
+			}
+		}
+		return result, len(result) > 0
+	case []interface{}:
+		// "[*]" and "*" select elements; other segments apply to each element implicitly
+		elementNode := n
+		if selector := merge(n.children["[*]"], n.children["*"]); selector != nil {
+			elementNode = selector
+		}
+		result := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			if projected, ok := project(element, elementNode); ok {
+				result = append(result, projected)
+			}
+		}
+		// Keep empty arrays, but drop arrays where no element had a selected field
+		return result, len(result) > 0 || len(v) == 0
+	default:
+		return nil, false
+	}
+}
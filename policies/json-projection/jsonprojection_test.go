@@ -0,0 +1,145 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonprojection
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, fields ...interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"fields": fields})
+	return p
+}
+
+func onResponse(p policy.Policy, contentType, body string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func assertBody(t *testing.T, mods policy.UpstreamResponseModifications, expected string) {
+	t.Helper()
+	if string(mods.Body) != expected {
+		t.Errorf("Expected %s, got %s", expected, mods.Body)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"fields": []interface{}{}},
+		{"fields": []interface{}{"id"}},
+		{"fields": []interface{}{"$.items[0].id"}},
+		{"fields": []interface{}{"$.a..b"}},
+		{"fields": []interface{}{"$.items[x]"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJSONProjectionPolicy_TopLevelFields(t *testing.T) {
+	p := newPolicy(t, "$.id", "$.name")
+
+	mods := onResponse(p, "application/json", `{"id":7,"name":"Ada","password":"secret","internal":{"shard":3}}`)
+	assertBody(t, mods, `{"id":7,"name":"Ada"}`)
+	if mods.SetHeaders["content-length"] != "21" {
+		t.Errorf("Expected content-length 21, got %q", mods.SetHeaders["content-length"])
+	}
+}
+
+func TestJSONProjectionPolicy_NestedFields(t *testing.T) {
+	p := newPolicy(t, "$.user.name", "$.user.address.city", "$.meta")
+
+	body := `{"user":{"name":"Ada","ssn":"123","address":{"city":"London","street":"Baker St"}},"meta":{"v":1,"tags":["x"]},"debug":true}`
+	assertBody(t, onResponse(p, "application/json", body),
+		`{"meta":{"tags":["x"],"v":1},"user":{"address":{"city":"London"},"name":"Ada"}}`)
+}
+
+func TestJSONProjectionPolicy_ArraysOfObjects(t *testing.T) {
+	p := newPolicy(t, "$.items[*].id", "$.items[*].price.amount", "$.total")
+
+	body := `{"items":[{"id":1,"price":{"amount":9.5,"cost":4},"sku":"a"},{"id":2,"sku":"b"}],"total":2,"cursor":"abc"}`
+	assertBody(t, onResponse(p, "application/json", body),
+		`{"items":[{"id":1,"price":{"amount":9.5}},{"id":2}],"total":2}`)
+}
+
+func TestJSONProjectionPolicy_TopLevelArray(t *testing.T) {
+	p := newPolicy(t, "$.id")
+
+	assertBody(t, onResponse(p, "application/json", `[{"id":1,"x":1},{"id":2,"x":2}]`), `[{"id":1},{"id":2}]`)
+}
+
+func TestJSONProjectionPolicy_WildcardKeys(t *testing.T) {
+	p := newPolicy(t, "$.links.*.href", "$.links.self")
+
+	body := `{"links":{"self":{"href":"/a","method":"GET"},"next":{"href":"/b","method":"GET"}}}`
+	assertBody(t, onResponse(p, "application/json", body),
+		`{"links":{"next":{"href":"/b"},"self":{"href":"/a","method":"GET"}}}`)
+}
+
+func TestJSONProjectionPolicy_NoMatches(t *testing.T) {
+	p := newPolicy(t, "$.missing")
+
+	assertBody(t, onResponse(p, "application/json", `{"id":1}`), `{}`)
+}
+
+func TestJSONProjectionPolicy_NonJSONPassthrough(t *testing.T) {
+	p := newPolicy(t, "$.id")
+
+	if mods := onResponse(p, "text/plain", `{"id":1,"secret":2}`); mods.Body != nil {
+		t.Errorf("Expected non-JSON body to be untouched, got %s", mods.Body)
+	}
+}
+
+func TestJSONProjectionPolicy_UnprojectableBodiesFailClosed(t *testing.T) {
+	p := newPolicy(t, "$.id")
+
+	policytest.ExpectResponseStatus(t, onResponse(p, "application/json", `{"id":`), 502)
+	policytest.ExpectResponseStatus(t, onResponse(p, "application/json", `{"id":1} {"secret":2}`), 502)
+
+	encoded := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{
+			"content-type":     {"application/json"},
+			"content-encoding": {"gzip"},
+		}),
+		ResponseBody: &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+	}
+	mods := policytest.ExpectResponseStatus(t, p.OnResponse(encoded, nil), 502)
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "content-encoding" {
+		t.Errorf("Expected content-encoding to be removed, got %v", mods.RemoveHeaders)
+	}
+}
+
+func TestJSONProjectionPolicy_LineDelimited(t *testing.T) {
+	p := newPolicy(t, "$.id")
+
+	assertBody(t, onResponse(p, "application/x-ndjson", "{\"id\":1,\"secret\":1}\n\n{\"id\":2,\"secret\":2}\n"),
+		"{\"id\":1}\n\n{\"id\":2}\n")
+	policytest.ExpectResponseStatus(t, onResponse(p, "application/x-ndjson", "{\"id\":1}\n{\"id\":\n"), 502)
+
+	partial := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/x-ndjson"}}),
+		ResponseBody:    &policy.Body{Content: []byte("{\"id\":1}\n{\"id\":2,\"sec"), Present: true, EndOfStream: false},
+	}
+	policytest.ExpectResponseStatus(t, p.OnResponse(partial, nil), 502)
+}
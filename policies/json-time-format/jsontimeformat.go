@@ -0,0 +1,291 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsontimeformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+const (
+	// Named layouts; any other value is a Go time layout
+	LayoutEpochSeconds = "epoch-seconds"
+	LayoutEpochMillis  = "epoch-millis"
+	LayoutRFC3339      = "rfc3339"
+	LayoutRFC3339Nano  = "rfc3339nano"
+	LayoutRFC1123      = "rfc1123"
+)
+
+// maxEpochMillis is 9999-12-31T23:59:59.999Z in milliseconds
+const maxEpochMillis = 253402300799999
+
+// namedLayouts maps named textual layouts to Go layouts
+var namedLayouts = map[string]string{
+	LayoutRFC3339:     time.RFC3339,
+	LayoutRFC3339Nano: time.RFC3339Nano,
+	LayoutRFC1123:     time.RFC1123,
+}
+
+// JSONTimeFormatPolicy reformats timestamps at configured JSONPaths in JSON response bodies
+type JSONTimeFormatPolicy struct {
+	paths        []jsonpath.Path
+	sourceLayout string
+	targetLayout string
+	location     *time.Location
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	pathsRaw, ok := params["paths"].([]interface{})
+	if !ok || len(pathsRaw) == 0 {
+		return nil, fmt.Errorf("'paths' parameter is required and must be a non-empty array")
+	}
+
+	p := &JSONTimeFormatPolicy{
+		sourceLayout: LayoutRFC3339,
+		targetLayout: LayoutRFC3339,
+		location:     time.UTC,
+	}
+	for i, raw := range pathsRaw {
+		path, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("paths[%d] must be a string", i)
+		}
+		segments, err := jsonpath.Parse(path)
+		if err != nil {
+			return nil, fmt.Errorf("paths[%d]: %w", i, err)
+		}
+		p.paths = append(p.paths, segments)
+	}
+
+	var err error
+	if raw, ok := params["sourceLayout"]; ok {
+		if p.sourceLayout, err = parseLayout(raw); err != nil {
+			return nil, fmt.Errorf("invalid 'sourceLayout': %w", err)
+		}
+	}
+	if raw, ok := params["targetLayout"]; ok {
+		if p.targetLayout, err = parseLayout(raw); err != nil {
+			return nil, fmt.Errorf("invalid 'targetLayout': %w", err)
+		}
+	}
+
+	if raw, ok := params["timezone"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'timezone' must be a non-empty string")
+		}
+		if p.location, err = time.LoadLocation(strings.TrimSpace(name)); err != nil {
+			return nil, fmt.Errorf("invalid 'timezone': %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// parseLayout validates a named layout or a Go time layout
+func parseLayout(raw interface{}) (string, error) {
+	layout, ok := raw.(string)
+	if !ok || strings.TrimSpace(layout) == "" {
+		return "", fmt.Errorf("must be a non-empty string")
+	}
+	switch strings.ToLower(layout) {
+	case LayoutEpochSeconds, LayoutEpochMillis, LayoutRFC3339, LayoutRFC3339Nano, LayoutRFC1123:
+		return strings.ToLower(layout), nil
+	}
+	// A layout without any time elements formats to itself
+	sample := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	if sample.Format(layout) == layout {
+		return "", fmt.Errorf("'%s' is not a named layout and contains no Go time layout elements", layout)
+	}
+	return layout, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *JSONTimeFormatPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to reformat timestamps
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *JSONTimeFormatPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse reformats timestamps at the configured paths in JSON response bodies
+func (p *JSONTimeFormatPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	if !strings.Contains(bodyutil.MediaType(ctx.ResponseHeaders), "json") {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("JSONTimeFormat: Skipping response body", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(ctx.ResponseBody.Content))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		slog.Debug("JSONTimeFormat: Skipping invalid JSON body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	result, changed := jsonpath.Walk(data, func(path []string, value interface{}) jsonpath.Transform {
+		if p.selected(path) {
+			return p.apply
+		}
+		return nil
+	})
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(result); err != nil {
+		slog.Debug("JSONTimeFormat: Failed to encode reformatted body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	body := bytes.TrimRight(buf.Bytes(), "\n")
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// selected reports whether one of the configured paths selects the node exactly
+func (p *JSONTimeFormatPolicy) selected(path []string) bool {
+	for _, pattern := range p.paths {
+		if pattern.Matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply reformats a timestamp value. For arrays, each element is reformatted.
+func (p *JSONTimeFormatPolicy) apply(value interface{}) (interface{}, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return p.reformat(value)
+	}
+
+	changed := false
+	for i, item := range items {
+		if result, ok := p.reformat(item); ok {
+			items[i] = result
+			changed = true
+		}
+	}
+	return items, changed
+}
+
+// reformat converts a single timestamp; values that don't parse are left unchanged
+func (p *JSONTimeFormatPolicy) reformat(value interface{}) (interface{}, bool) {
+	t, ok := p.parse(value)
+	if !ok {
+		return value, false
+	}
+	t = t.In(p.location)
+
+	var result interface{}
+	switch p.targetLayout {
+	case LayoutEpochSeconds:
+		result = json.Number(strconv.FormatInt(t.Unix(), 10))
+	case LayoutEpochMillis:
+		result = json.Number(strconv.FormatInt(t.UnixMilli(), 10))
+	default:
+		result = t.Format(goLayout(p.targetLayout))
+	}
+	return result, true
+}
+
+// parse reads a timestamp in the source layout. Epoch values may be JSON numbers or numeric
+// strings; textual values without a zone are interpreted in the configured timezone.
+func (p *JSONTimeFormatPolicy) parse(value interface{}) (time.Time, bool) {
+	switch p.sourceLayout {
+	case LayoutEpochSeconds, LayoutEpochMillis:
+		var text string
+		switch v := value.(type) {
+		case json.Number:
+			text = v.String()
+		case string:
+			text = strings.TrimSpace(v)
+		default:
+			return time.Time{}, false
+		}
+		epoch, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsInf(epoch, 0) || math.IsNaN(epoch) {
+			return time.Time{}, false
+		}
+		if p.sourceLayout == LayoutEpochSeconds {
+			epoch *= 1000
+		}
+		// Values beyond year 9999 are not timestamps
+		if math.Abs(epoch) > maxEpochMillis {
+			return time.Time{}, false
+		}
+		seconds := math.Floor(epoch / 1000)
+		nanos := math.Round((epoch - seconds*1000) * float64(time.Millisecond))
+		return time.Unix(int64(seconds), int64(nanos)), true
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return time.Time{}, false
+		}
+		t, err := time.ParseInLocation(goLayout(p.sourceLayout), text, p.location)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+}
+
+// goLayout resolves a named textual layout to its Go layout
+func goLayout(layout string) string {
+	if named, ok := namedLayouts[layout]; ok {
+		return named
+	}
+	return layout
+}
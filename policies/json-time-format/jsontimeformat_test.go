@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsontimeformat
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, body string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/json"}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func expectBody(t *testing.T, mods policy.UpstreamResponseModifications, expected string) {
+	t.Helper()
+	if string(mods.Body) != expected {
+		t.Errorf("Expected body %s, got %s", expected, mods.Body)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"paths": []interface{}{}},
+		{"paths": []interface{}{"createdAt"}},
+		{"paths": []interface{}{"$.createdAt"}, "sourceLayout": "whenever"},
+		{"paths": []interface{}{"$.createdAt"}, "targetLayout": ""},
+		{"paths": []interface{}{"$.createdAt"}, "timezone": "Nowhere/Land"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestJSONTimeFormatPolicy_EpochToRFC3339(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"paths":        []interface{}{"$.createdAt", "$.events[*].at", "$.history"},
+		"sourceLayout": "epoch-millis",
+	})
+
+	mods := onResponse(p, `{"createdAt":1767225600123,"events":[{"at":"1767225600000"}],"history":[0,1767225600000],"id":1767225600000}`)
+	expectBody(t, mods, `{"createdAt":"2026-01-01T00:00:00Z","events":[{"at":"2026-01-01T00:00:00Z"}],"history":["1970-01-01T00:00:00Z","2026-01-01T00:00:00Z"],"id":1767225600000}`)
+	if mods.SetHeaders["content-length"] == "" {
+		t.Error("Expected content-length to be updated")
+	}
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{
+		"paths":        []interface{}{"$.at"},
+		"sourceLayout": "epoch-seconds",
+		"targetLayout": "rfc3339nano",
+	})
+	expectBody(t, onResponse(p, `{"at":1767225600.25}`), `{"at":"2026-01-01T00:00:00.25Z"}`)
+}
+
+func TestJSONTimeFormatPolicy_TimezoneShift(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"paths":    []interface{}{"$.start"},
+		"timezone": "Asia/Kolkata",
+	})
+	expectBody(t, onResponse(p, `{"start":"2026-01-01T20:00:00Z"}`), `{"start":"2026-01-02T01:30:00+05:30"}`)
+
+	// Zone-less source values are read in the configured timezone
+	p = policytest.New(t, GetPolicy, map[string]interface{}{
+		"paths":        []interface{}{"$.start"},
+		"sourceLayout": "2006-01-02 15:04",
+		"targetLayout": "epoch-seconds",
+		"timezone":     "Asia/Kolkata",
+	})
+	expectBody(t, onResponse(p, `{"start":"2026-01-01 05:30"}`), `{"start":1767225600}`)
+}
+
+func TestJSONTimeFormatPolicy_UnparseableUnchanged(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"paths":        []interface{}{"$.at"},
+		"sourceLayout": "epoch-millis",
+	})
+
+	for _, body := range []string{`{"at":"yesterday"}`, `{"at":true}`, `{"at":1e300}`, `{"other":1}`} {
+		if mods := onResponse(p, body); mods.Body != nil {
+			t.Errorf("Expected %s to be unchanged, got %s", body, mods.Body)
+		}
+	}
+
+	// Only the unparseable element of an array is left as is
+	expectBody(t, onResponse(p, `{"at":["n/a",0]}`), `{"at":["n/a","1970-01-01T00:00:00Z"]}`)
+}
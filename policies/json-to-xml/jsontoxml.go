@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"log/slog"
 	"strings"
 	"unicode"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
 )
 
 // JSONToXMLPolicy implements transforming a request/response with a JSON payload to a request/response with an XML payload
@@ -66,6 +68,12 @@ func (p *JSONToXMLPolicy) OnRequest(ctx *policy.RequestContext, params map[strin
 		return policy.UpstreamRequestModifications{}
 	}
 
+	// Leave streaming or oversized payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, streamOptions(params)); pass {
+		slog.Debug("JSONToXML: Skipping request body transformation", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
 	// Check content type to ensure it is JSON
 	contentType := ""
 	if contentTypeHeaders := ctx.Headers.Get("content-type"); len(contentTypeHeaders) > 0 {
@@ -115,6 +123,12 @@ func (p *JSONToXMLPolicy) OnResponse(ctx *policy.ResponseContext, params map[str
 		return policy.UpstreamResponseModifications{}
 	}
 
+	// Leave streaming or oversized payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, streamOptions(params)); pass {
+		slog.Debug("JSONToXML: Skipping response body transformation", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
 	// Check content type to ensure it is JSON
 	contentType := ""
 	if contentTypeHeaders := ctx.ResponseHeaders.Get("content-type"); len(contentTypeHeaders) > 0 {
@@ -147,6 +161,20 @@ func (p *JSONToXMLPolicy) OnResponse(ctx *policy.ResponseContext, params map[str
 	}
 }
 
+// streamOptions builds the streaming guard options from the policy parameters
+func streamOptions(params map[string]interface{}) bodyutil.Options {
+	opts := bodyutil.Options{}
+	switch v := params["maxBodyBytes"].(type) {
+	case float64:
+		opts.MaxBodyBytes = int64(v)
+	case int:
+		opts.MaxBodyBytes = int64(v)
+	case int64:
+		opts.MaxBodyBytes = v
+	}
+	return opts
+}
+
 // handleInternalServerError returns a 500 internal server error response for request flow
 func (p *JSONToXMLPolicy) handleInternalServerError(message string) policy.RequestAction {
 	errorResponse := map[string]interface{}{
@@ -1595,3 +1595,25 @@ func TestJSONToXMLPolicy_ComplexNestedSanitization(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONToXMLPolicy_OnResponse_StreamingPassthrough(t *testing.T) {
+	p := &JSONToXMLPolicy{}
+	ctx := &policy.ResponseContext{
+		ResponseBody: &policy.Body{
+			Content:     []byte("data: {\"delta\":\"hi\"}\n\n"),
+			Present:     true,
+			EndOfStream: true,
+		},
+		ResponseHeaders: createTestHeaders("content-type", "text/event-stream"),
+	}
+
+	result := p.OnResponse(ctx, map[string]interface{}{"onResponseFlow": true})
+
+	mods, ok := result.(policy.UpstreamResponseModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamResponseModifications, got %T", result)
+	}
+	if mods.Body != nil || mods.StatusCode != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected SSE response to pass through untouched, got %+v", mods)
+	}
+}
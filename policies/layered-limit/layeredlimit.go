@@ -0,0 +1,189 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package layeredlimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	ratelimit "github.com/wso2/gateway-controllers/policies/advanced-ratelimit"
+)
+
+const (
+	// Scopes of the two limits, used as quota names and in the scope header
+	ScopeClient = "client"
+	ScopeGlobal = "global"
+
+	// scopeHeader tells the client which limit rejected the request
+	scopeHeader = "x-ratelimit-scope"
+
+	// quotaHeader is set by the ratelimit policy to the name of the violated quota
+	quotaHeader = "x-ratelimit-quota"
+)
+
+// unitDurations maps supported units to Go duration strings understood by the ratelimit policy
+var unitDurations = map[string]string{
+	"second": "1s",
+	"minute": "1m",
+	"hour":   "1h",
+	"day":    "24h",
+}
+
+// LayeredLimitPolicy enforces a per-client limit for fairness and a global limit protecting the
+// upstream in one policy. Both limits are quotas of a single core ratelimit delegate; the
+// per-client quota is checked first so a client that is over its own limit does not consume the
+// global budget.
+type LayeredLimitPolicy struct {
+	delegate policy.Policy
+}
+
+// GetPolicy builds a core ratelimit delegate with a per-client quota and a global quota keyed
+// by route
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	clientRaw, ok := params["clientLimit"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'clientLimit' parameter is required and must be an object")
+	}
+	globalRaw, ok := params["globalLimit"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'globalLimit' parameter is required and must be an object")
+	}
+
+	keyExtraction, ok := params["keyExtraction"]
+	if !ok {
+		keyExtraction = []interface{}{
+			map[string]interface{}{"type": "ip"},
+		}
+	}
+
+	clientQuota, err := newQuota(ScopeClient, clientRaw, keyExtraction)
+	if err != nil {
+		return nil, fmt.Errorf("clientLimit: %w", err)
+	}
+	globalQuota, err := newQuota(ScopeGlobal, globalRaw, []interface{}{
+		map[string]interface{}{"type": "routename"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("globalLimit: %w", err)
+	}
+
+	rlParams := map[string]interface{}{
+		"quotas": []interface{}{clientQuota, globalQuota},
+	}
+
+	// Pass through system parameters
+	for _, name := range []string{"algorithm", "backend", "redis", "memory"} {
+		if value, ok := params[name]; ok {
+			rlParams[name] = value
+		}
+	}
+
+	delegate, err := ratelimit.GetPolicy(metadata, rlParams)
+	if err != nil {
+		return nil, err
+	}
+	return &LayeredLimitPolicy{delegate: delegate}, nil
+}
+
+// newQuota builds a ratelimit quota for a {limit, unit} configuration
+func newQuota(name string, limitMap map[string]interface{}, keyExtraction interface{}) (map[string]interface{}, error) {
+	limit, err := extractInt(limitMap["limit"])
+	if err != nil || limit < 1 {
+		return nil, fmt.Errorf("'limit' is required and must be a positive integer")
+	}
+	unit, _ := limitMap["unit"].(string)
+	duration, ok := unitDurations[unit]
+	if !ok {
+		return nil, fmt.Errorf("'unit' must be one of: second, minute, hour, day")
+	}
+
+	return map[string]interface{}{
+		"name": name,
+		"limits": []interface{}{
+			map[string]interface{}{
+				"limit":    float64(limit),
+				"duration": duration,
+			},
+		},
+		"keyExtraction": keyExtraction,
+	}, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *LayeredLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the key headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need to add rate limit headers to response
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest applies both limits and marks a rejection with the scope of the limit exceeded
+func (p *LayeredLimitPolicy) OnRequest(
+	ctx *policy.RequestContext,
+	params map[string]interface{},
+) policy.RequestAction {
+	action := p.delegate.OnRequest(ctx, params)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		return action
+	}
+
+	headers := make(map[string]string, len(resp.Headers)+1)
+	for name, value := range resp.Headers {
+		headers[name] = value
+	}
+	if scope := headers[quotaHeader]; scope == ScopeClient || scope == ScopeGlobal {
+		headers[scopeHeader] = scope
+	}
+	resp.Headers = headers
+	return resp
+}
+
+// OnResponse delegates to the ratelimit policy to add rate limit headers
+func (p *LayeredLimitPolicy) OnResponse(
+	ctx *policy.ResponseContext,
+	params map[string]interface{},
+) policy.ResponseAction {
+	return p.delegate.OnResponse(ctx, params)
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package layeredlimit
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// newPolicy creates a policy on a route unique to the test, since memory limiters are cached
+// per route
+func newPolicy(t *testing.T, clientLimit, globalLimit int) policy.Policy {
+	t.Helper()
+	p := policytest.NewWithMetadata(t, GetPolicy, policy.PolicyMetadata{RouteName: t.Name()}, map[string]interface{}{
+		"clientLimit": limit(clientLimit, "minute"),
+		"globalLimit": limit(globalLimit, "minute"),
+	})
+	return p
+}
+
+func limit(n int, unit string) map[string]interface{} {
+	return map[string]interface{}{"limit": n, "unit": unit}
+}
+
+func onRequest(p policy.Policy, client string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}},
+		Headers:       policy.NewHeaders(map[string][]string{"x-forwarded-for": {client}}),
+		Path:          "/orders",
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+// expectLimited checks whether the request was rejected and, if so, by which scope
+func expectLimited(t *testing.T, action policy.RequestAction, scope string) {
+	t.Helper()
+	resp, ok := action.(policy.ImmediateResponse)
+	if scope == "" {
+		if ok {
+			t.Errorf("Expected request to pass, got %+v", resp)
+		}
+		return
+	}
+	if !ok || resp.StatusCode != 429 {
+		t.Fatalf("Expected status 429, got %+v", action)
+	}
+	if resp.Headers["x-ratelimit-scope"] != scope {
+		t.Errorf("Expected scope %q, got %q", scope, resp.Headers["x-ratelimit-scope"])
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"clientLimit": limit(1, "minute")},
+		{"globalLimit": limit(1, "minute")},
+		{"clientLimit": limit(0, "minute"), "globalLimit": limit(1, "minute")},
+		{"clientLimit": limit(1, "minute"), "globalLimit": limit(1, "week")},
+	}
+	for _, params := range invalid {
+		if _, err := GetPolicy(policy.PolicyMetadata{RouteName: t.Name()}, params); err == nil {
+			t.Errorf("Expected error for params %v", params)
+		}
+	}
+}
+
+func TestLayeredLimitPolicy_ClientExhausted(t *testing.T) {
+	p := newPolicy(t, 2, 100)
+
+	expectLimited(t, onRequest(p, "10.0.0.1"), "")
+	expectLimited(t, onRequest(p, "10.0.0.1"), "")
+	expectLimited(t, onRequest(p, "10.0.0.1"), ScopeClient)
+
+	// Other clients keep their own allowance
+	expectLimited(t, onRequest(p, "10.0.0.2"), "")
+}
+
+func TestLayeredLimitPolicy_GlobalExhausted(t *testing.T) {
+	p := newPolicy(t, 5, 3)
+
+	expectLimited(t, onRequest(p, "10.0.0.1"), "")
+	expectLimited(t, onRequest(p, "10.0.0.2"), "")
+	expectLimited(t, onRequest(p, "10.0.0.3"), "")
+	expectLimited(t, onRequest(p, "10.0.0.4"), ScopeGlobal)
+	expectLimited(t, onRequest(p, "10.0.0.1"), ScopeGlobal)
+}
+
+func TestLayeredLimitPolicy_BothLimits(t *testing.T) {
+	p := newPolicy(t, 2, 3)
+
+	expectLimited(t, onRequest(p, "10.0.0.1"), "")
+	expectLimited(t, onRequest(p, "10.0.0.1"), "")
+
+	// A client over its own limit does not consume the global budget
+	expectLimited(t, onRequest(p, "10.0.0.1"), ScopeClient)
+	expectLimited(t, onRequest(p, "10.0.0.1"), ScopeClient)
+
+	expectLimited(t, onRequest(p, "10.0.0.2"), "")
+	expectLimited(t, onRequest(p, "10.0.0.2"), ScopeGlobal)
+}
@@ -0,0 +1,228 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package leakybucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/clientkey"
+)
+
+const (
+	// Handling of requests that arrive while the bucket is filling
+	ModeReject = "reject"
+	ModeDelay  = "delay"
+)
+
+// LeakyBucketPolicy admits each client's requests at a steady rate. The bucket holds up to
+// capacity requests and drains at rate per second; a request arriving while the bucket is full
+// is rejected. In delay mode admitted requests are also held until their turn to drain, so the
+// upstream sees an evenly spaced stream instead of bursts.
+type LeakyBucketPolicy struct {
+	interval time.Duration // Time for one request to drain (1/rate)
+	capacity int
+	mode     string
+
+	clientKey clientkey.Key
+
+	mu        sync.Mutex
+	now       func() time.Time     // Injectable clock (for testing)
+	sleep     func(time.Duration)  // Injectable delay (for testing)
+	drainAt   map[string]time.Time // Time at which each client's bucket is empty
+	lastSweep time.Time
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	rateRaw, ok := params["rate"]
+	if !ok {
+		return nil, fmt.Errorf("'rate' parameter is required")
+	}
+	rate, err := extractFloat(rateRaw)
+	if err != nil || !(rate > 0) {
+		return nil, fmt.Errorf("'rate' must be a positive number of requests per second")
+	}
+
+	p := &LeakyBucketPolicy{
+		interval: time.Duration(float64(time.Second) / rate),
+		capacity: 1,
+		mode:     ModeReject,
+		now:      time.Now,
+		sleep:    time.Sleep,
+		drainAt:  make(map[string]time.Time),
+	}
+	if p.interval <= 0 {
+		return nil, fmt.Errorf("'rate' must be a positive number of requests per second")
+	}
+
+	if raw, ok := params["capacity"]; ok {
+		capacity, err := extractInt(raw)
+		if err != nil || capacity < 1 {
+			return nil, fmt.Errorf("'capacity' must be a positive integer")
+		}
+		p.capacity = capacity
+	}
+
+	if raw, ok := params["mode"]; ok {
+		mode, ok := raw.(string)
+		if !ok || (mode != ModeReject && mode != ModeDelay) {
+			return nil, fmt.Errorf("'mode' must be one of %s, %s", ModeReject, ModeDelay)
+		}
+		p.mode = mode
+	}
+
+	clientKey, err := clientkey.Parse(params["clientKey"])
+	if err != nil {
+		return nil, err
+	}
+	p.clientKey = clientKey
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *LeakyBucketPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need client key headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest adds the request to the client's bucket, rejecting it with 429 when the bucket is
+// full. In delay mode the request is held until its turn to drain.
+func (p *LeakyBucketPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	key := p.clientKey.Resolve(ctx)
+	wait, retryAfter, ok := p.add(key)
+	if !ok {
+		slog.Debug("LeakyBucket: Bucket full", "key", key)
+		body, _ := json.Marshal(map[string]string{
+			"error":   "Too Many Requests",
+			"message": "Request rate exceeded; retry later",
+		})
+		return policy.ImmediateResponse{
+			StatusCode: http.StatusTooManyRequests,
+			Headers: map[string]string{
+				"content-type": "application/json",
+				"retry-after":  strconv.FormatInt(max(int64(math.Ceil(retryAfter.Seconds())), 1), 10),
+			},
+			Body: body,
+		}
+	}
+
+	if p.mode == ModeDelay && wait > 0 {
+		slog.Debug("LeakyBucket: Delaying request", "key", key, "delay", wait)
+		p.sleep(wait)
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *LeakyBucketPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// add places a request in the client's bucket. It returns how long the request waits for the
+// requests ahead of it to drain and whether it fit; when it doesn't fit, retryAfter is how
+// long until there is room.
+func (p *LeakyBucketPolicy) add(key string) (wait, retryAfter time.Duration, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.sweep(now)
+
+	start := now
+	if drainAt, exists := p.drainAt[key]; exists && drainAt.After(now) {
+		start = drainAt
+	}
+
+	// The bucket holds the requests that have not drained yet, including this one
+	full := now.Add(time.Duration(p.capacity) * p.interval)
+	next := start.Add(p.interval)
+	if next.After(full) {
+		return 0, next.Sub(full), false
+	}
+	p.drainAt[key] = next
+	return start.Sub(now), 0, true
+}
+
+// sweep evicts clients whose buckets have drained, since a missing bucket is empty. It runs at
+// most once per time taken to drain a full bucket. Callers must hold p.mu.
+func (p *LeakyBucketPolicy) sweep(now time.Time) {
+	period := time.Duration(p.capacity) * p.interval
+	if p.lastSweep.IsZero() {
+		p.lastSweep = now
+		return
+	}
+	if now.Sub(p.lastSweep) < period {
+		return
+	}
+	for key, drainAt := range p.drainAt {
+		if !drainAt.After(now) {
+			delete(p.drainAt, key)
+		}
+	}
+	p.lastSweep = now
+}
+
+// extractFloat safely extracts a float from various types
+func extractFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", value)
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
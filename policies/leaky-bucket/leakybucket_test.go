@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package leakybucket
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}, now *time.Time) *LeakyBucketPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	lp := p.(*LeakyBucketPolicy)
+	lp.now = func() time.Time { return *now }
+	return lp
+}
+
+func onRequest(p policy.Policy, ip string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"x-forwarded-for": {ip}}),
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"rate": 0},
+		{"rate": -1.5},
+		{"rate": "NaN"},
+		{"rate": 1, "capacity": 0},
+		{"rate": 1, "mode": "queue"},
+		{"rate": 1, "clientKey": map[string]interface{}{"type": "header"}},
+		{"rate": 1, "clientKey": map[string]interface{}{"type": "ip", "trustedProxyCount": -1}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestLeakyBucketPolicy_SteadyRateAdmitted(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	p := newPolicy(t, map[string]interface{}{"rate": float64(2)}, &now)
+
+	// Requests spaced at the drain rate are always admitted
+	for i := 0; i < 10; i++ {
+		policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 0)
+		now = now.Add(500 * time.Millisecond)
+	}
+}
+
+func TestLeakyBucketPolicy_BurstOverflowRejected(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	p := newPolicy(t, map[string]interface{}{"rate": 1, "capacity": 3}, &now)
+
+	for i := 0; i < 3; i++ {
+		policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 0)
+	}
+	resp := policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 429)
+	if resp.Headers["retry-after"] != "1" {
+		t.Errorf("Expected retry-after 1, got %q", resp.Headers["retry-after"])
+	}
+
+	// Other clients have their own bucket
+	policytest.ExpectStatus(t, onRequest(p, "10.0.0.2"), 0)
+
+	// One request drains per second
+	now = now.Add(time.Second)
+	policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 429)
+}
+
+func TestLeakyBucketPolicy_SpoofedForwardedFor(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	p := newPolicy(t, map[string]interface{}{"rate": 1}, &now)
+
+	// Entries prepended by the client don't give it a fresh bucket
+	policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "203.0.113.7, 10.0.0.1"), 429)
+}
+
+func TestLeakyBucketPolicy_DelayMode(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	p := newPolicy(t, map[string]interface{}{"rate": 4, "capacity": 3, "mode": "delay"}, &now)
+
+	var delays []time.Duration
+	p.sleep = func(d time.Duration) { delays = append(delays, d) }
+
+	for i := 0; i < 3; i++ {
+		policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 0)
+	}
+	policytest.ExpectStatus(t, onRequest(p, "10.0.0.1"), 429)
+
+	// The first request goes straight through and the rest are spaced at the drain rate
+	want := []time.Duration{250 * time.Millisecond, 500 * time.Millisecond}
+	if len(delays) != len(want) || delays[0] != want[0] || delays[1] != want[1] {
+		t.Errorf("Expected delays %v, got %v", want, delays)
+	}
+}
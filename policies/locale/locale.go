@@ -0,0 +1,167 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// languageRange is one entry of an Accept-Language header
+type languageRange struct {
+	tag string // Lower-cased language range, e.g. "en-us" or "*"
+	q   float64
+}
+
+// LocalePolicy selects the best supported locale from Accept-Language and passes it upstream
+// in a header
+type LocalePolicy struct {
+	supported     []string
+	defaultLocale string
+	header        string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	localesRaw, ok := params["supportedLocales"].([]interface{})
+	if !ok || len(localesRaw) == 0 {
+		return nil, fmt.Errorf("'supportedLocales' parameter is required and must be a non-empty array")
+	}
+
+	p := &LocalePolicy{header: "x-locale"}
+	for i, raw := range localesRaw {
+		locale, ok := raw.(string)
+		locale = strings.TrimSpace(locale)
+		if !ok || locale == "" || locale == "*" || strings.ContainsAny(locale, " ,;") {
+			return nil, fmt.Errorf("supportedLocales[%d] must be a language tag such as 'en' or 'en-US'", i)
+		}
+		p.supported = append(p.supported, locale)
+	}
+	p.defaultLocale = p.supported[0]
+
+	if raw, ok := params["defaultLocale"]; ok {
+		locale, ok := raw.(string)
+		if !ok || strings.TrimSpace(locale) == "" {
+			return nil, fmt.Errorf("'defaultLocale' must be a non-empty string")
+		}
+		p.defaultLocale = strings.TrimSpace(locale)
+	}
+
+	if raw, ok := params["header"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'header' must be a non-empty string")
+		}
+		p.header = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *LocalePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need Accept-Language header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sets the locale header to the best supported match, or the default locale
+func (p *LocalePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	locale := p.defaultLocale
+	for _, r := range parseAcceptLanguage(ctx.Headers.Get("accept-language")) {
+		if match, ok := p.match(r.tag); ok {
+			locale = match
+			break
+		}
+	}
+
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{p.header: locale},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *LocalePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// match finds the supported locale for a language range. An exact match wins, then a
+// supported locale the range is a prefix of ("en" matches "en-US"), then the range is
+// shortened one subtag at a time ("de-CH" falls back to "de"). "*" never matches so the
+// default locale applies.
+func (p *LocalePolicy) match(tag string) (string, bool) {
+	if tag == "*" {
+		return "", false
+	}
+	for _, locale := range p.supported {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+	}
+	for _, locale := range p.supported {
+		if strings.HasPrefix(strings.ToLower(locale), tag+"-") {
+			return locale, true
+		}
+	}
+	for i := strings.LastIndex(tag, "-"); i > 0; i = strings.LastIndex(tag, "-") {
+		tag = tag[:i]
+		for _, locale := range p.supported {
+			if strings.EqualFold(locale, tag) {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseAcceptLanguage parses Accept-Language values into language ranges ordered by q-value,
+// keeping header order for equal weights. Malformed entries and ranges with q=0 are dropped.
+func parseAcceptLanguage(values []string) []languageRange {
+	var ranges []languageRange
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			tag, paramsPart, _ := strings.Cut(part, ";")
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" {
+				continue
+			}
+			q := 1.0
+			if name, raw, found := strings.Cut(strings.TrimSpace(paramsPart), "="); found && strings.TrimSpace(name) == "q" {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+				if err != nil || parsed < 0 || parsed > 1 {
+					continue
+				}
+				q = parsed
+			}
+			if q > 0 {
+				ranges = append(ranges, languageRange{tag: tag, q: q})
+			}
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges
+}
@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package locale
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func expectLocale(t *testing.T, p policy.Policy, acceptLanguage, expected string) {
+	t.Helper()
+	headers := map[string][]string{}
+	if acceptLanguage != "" {
+		headers["accept-language"] = []string{acceptLanguage}
+	}
+	mods := p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil).(policy.UpstreamRequestModifications)
+	if got := mods.SetHeaders["x-locale"]; got != expected {
+		t.Errorf("Expected locale %q for %q, got %q", expected, acceptLanguage, got)
+	}
+}
+
+var supported = []interface{}{"en-US", "fr", "de", "pt-BR"}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"supportedLocales": []interface{}{}},
+		{"supportedLocales": []interface{}{"en, fr"}},
+		{"supportedLocales": []interface{}{"*"}},
+		{"supportedLocales": supported, "defaultLocale": ""},
+		{"supportedLocales": supported, "header": ""},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestLocalePolicy_QValueOrdering(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"supportedLocales": supported})
+
+	expectLocale(t, p, "fr;q=0.7, de;q=0.9, en-US;q=0.8", "de")
+	// Equal weights keep header order
+	expectLocale(t, p, "ja, fr, de", "fr")
+	// Prefix and subtag fallback matches
+	expectLocale(t, p, "en;q=0.5, xx", "en-US")
+	expectLocale(t, p, "de-CH", "de")
+	expectLocale(t, p, "PT-br", "pt-BR")
+	// q=0 excludes a language
+	expectLocale(t, p, "fr;q=0, de;q=0.1", "de")
+}
+
+func TestLocalePolicy_UnsupportedFallsBackToDefault(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"supportedLocales": supported, "defaultLocale": "fr"})
+
+	expectLocale(t, p, "ja-JP, zh;q=0.8", "fr")
+	expectLocale(t, p, "*", "fr")
+}
+
+func TestLocalePolicy_MissingAcceptLanguage(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"supportedLocales": supported})
+
+	expectLocale(t, p, "", "en-US")
+}
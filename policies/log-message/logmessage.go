@@ -33,6 +33,9 @@ const (
 	MediationFlowRequest  = "REQUEST"
 	MediationFlowResponse = "RESPONSE"
 	MediationFlowFault    = "FAULT"
+
+	// MetadataKeyRedactedPath holds a redacted request path set by the redact-for-log policy
+	MetadataKeyRedactedPath = "log:redacted-path"
 )
 
 // LogMessagePolicy implements logging of request/response payloads and headers
@@ -74,7 +77,7 @@ func (p *LogMessagePolicy) OnRequest(ctx *policy.RequestContext, params map[stri
 		MediationFlow: MediationFlowRequest,
 		RequestID:     p.getRequestID(ctx.Headers),
 		HTTPMethod:    ctx.Method,
-		ResourcePath:  ctx.Path,
+		ResourcePath:  p.resourcePath(ctx.SharedContext, ctx.Path),
 	}
 
 	// Log payload if enabled
@@ -111,7 +114,7 @@ func (p *LogMessagePolicy) OnResponse(ctx *policy.ResponseContext, params map[st
 		MediationFlow: MediationFlowResponse,
 		RequestID:     p.getResponseRequestID(ctx.ResponseHeaders),
 		HTTPMethod:    ctx.RequestMethod,
-		ResourcePath:  ctx.RequestPath,
+		ResourcePath:  p.resourcePath(ctx.SharedContext, ctx.RequestPath),
 	}
 
 	// Log payload if enabled
@@ -141,6 +144,16 @@ type LogRecord struct {
 	Headers       map[string]interface{} `json:"headers,omitempty"`
 }
 
+// resourcePath returns the redacted request path when one is available, otherwise the raw path
+func (p *LogMessagePolicy) resourcePath(shared *policy.SharedContext, path string) string {
+	if shared != nil {
+		if redacted, ok := shared.Metadata[MetadataKeyRedactedPath].(string); ok && redacted != "" {
+			return redacted
+		}
+	}
+	return path
+}
+
 // getRequestID extracts request ID from request headers
 func (p *LogMessagePolicy) getRequestID(headers *policy.Headers) string {
 	if requestIDs := headers.Get(HeaderXRequestID); len(requestIDs) > 0 {
@@ -482,6 +482,22 @@ func TestLogMessagePolicy_GetRequestID(t *testing.T) {
 	}
 }
 
+func TestLogMessagePolicy_ResourcePath(t *testing.T) {
+	p := &LogMessagePolicy{}
+
+	shared := &policy.SharedContext{Metadata: map[string]interface{}{
+		MetadataKeyRedactedPath: "/api/users?token=***",
+	}}
+	if got := p.resourcePath(shared, "/api/users?token=secret"); got != "/api/users?token=***" {
+		t.Errorf("Expected redacted path, got: %s", got)
+	}
+
+	// Fall back to the raw path without a redacted view
+	if got := p.resourcePath(nil, "/api/users"); got != "/api/users" {
+		t.Errorf("Expected raw path, got: %s", got)
+	}
+}
+
 func TestLogRecord_JSONMarshaling(t *testing.T) {
 	logRecord := LogRecord{
 		MediationFlow: MediationFlowRequest,
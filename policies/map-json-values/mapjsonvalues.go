@@ -0,0 +1,198 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package mapjsonvalues
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+// valueMapping replaces values at the fields selected by a JSONPath
+type valueMapping struct {
+	path   jsonpath.Path
+	values map[string]interface{} // Keyed by the string form of the original value
+}
+
+// MapJSONValuesPolicy rewrites JSON field values in response bodies using lookup tables
+type MapJSONValuesPolicy struct {
+	mappings []valueMapping
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["mappings"].(map[string]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'mappings' parameter is required and must be a non-empty map of JSONPath to value lookup")
+	}
+
+	// Sort paths so mappings are applied in a deterministic order
+	paths := make([]string, 0, len(mappingsRaw))
+	for path := range mappingsRaw {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	p := &MapJSONValuesPolicy{}
+	for _, path := range paths {
+		segments, err := jsonpath.Parse(path)
+		if err != nil {
+			return nil, fmt.Errorf("mappings: %w", err)
+		}
+		values, ok := mappingsRaw[path].(map[string]interface{})
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("mappings.%s must be a non-empty map of original to replacement values", path)
+		}
+		p.mappings = append(p.mappings, valueMapping{path: segments, values: values})
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *MapJSONValuesPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to map values
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *MapJSONValuesPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse maps values at the configured paths in JSON response bodies
+func (p *MapJSONValuesPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	if !strings.Contains(bodyutil.MediaType(ctx.ResponseHeaders), "json") {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("MapJSONValues: Skipping response body", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(ctx.ResponseBody.Content))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		slog.Debug("MapJSONValues: Skipping invalid JSON body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	result, changed := jsonpath.Walk(data, func(path []string, value interface{}) jsonpath.Transform {
+		if mapping := p.mappingFor(path); mapping != nil {
+			return mapping.apply
+		}
+		return nil
+	})
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(result); err != nil {
+		slog.Debug("MapJSONValues: Failed to encode mapped body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	body := bytes.TrimRight(buf.Bytes(), "\n")
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// mappingFor returns the first mapping whose path selects the node exactly
+func (p *MapJSONValuesPolicy) mappingFor(path []string) *valueMapping {
+	for i := range p.mappings {
+		if p.mappings[i].path.Matches(path) {
+			return &p.mappings[i]
+		}
+	}
+	return nil
+}
+
+// apply replaces a scalar value found in the lookup. For arrays, each scalar element is
+// mapped. Unmapped values and objects are left unchanged.
+func (m *valueMapping) apply(value interface{}) (interface{}, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return m.lookup(value)
+	}
+
+	changed := false
+	for i, item := range items {
+		if result, ok := m.lookup(item); ok {
+			items[i] = result
+			changed = true
+		}
+	}
+	return items, changed
+}
+
+// lookup returns the replacement for a scalar value, if one is configured
+func (m *valueMapping) lookup(value interface{}) (interface{}, bool) {
+	key, ok := scalarKey(value)
+	if !ok {
+		return value, false
+	}
+	replacement, ok := m.values[key]
+	if !ok {
+		return value, false
+	}
+	return replacement, true
+}
+
+// scalarKey returns the lookup key for a JSON scalar
+func scalarKey(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case nil:
+		return "null", true
+	default:
+		return "", false
+	}
+}
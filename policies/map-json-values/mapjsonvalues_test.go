@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package mapjsonvalues
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var statusLookup = map[string]interface{}{
+	"A": "Active",
+	"B": "Blocked",
+	"C": "Closed",
+}
+
+func newPolicy(t *testing.T, mappings map[string]interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"mappings": mappings})
+	return p
+}
+
+func onResponse(p policy.Policy, body string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/json"}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"mappings": map[string]interface{}{}},
+		{"mappings": map[string]interface{}{"status": statusLookup}},
+		{"mappings": map[string]interface{}{"$.items[x]": statusLookup}},
+		{"mappings": map[string]interface{}{"$.status": map[string]interface{}{}}},
+		{"mappings": map[string]interface{}{"$.status": "A"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestMapJSONValuesPolicy_ScalarField(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"$.status":   statusLookup,
+		"$.priority": map[string]interface{}{"1": "high", "true": "yes"},
+	})
+
+	mods := onResponse(p, `{"id":7,"status":"B","priority":1,"note":"A"}`)
+	if string(mods.Body) != `{"id":7,"note":"A","priority":"high","status":"Blocked"}` {
+		t.Errorf("Unexpected body: %s", mods.Body)
+	}
+	if mods.SetHeaders["content-length"] != "56" {
+		t.Errorf("Expected content-length 56, got %q", mods.SetHeaders["content-length"])
+	}
+}
+
+func TestMapJSONValuesPolicy_ArrayOfObjects(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"$.orders[*].state": statusLookup,
+		"$.tags":            map[string]interface{}{"x": "X"},
+	})
+
+	mods := onResponse(p, `{"orders":[{"state":"A"},{"state":"C"},{"other":"A"}],"tags":["x","y"]}`)
+	want := `{"orders":[{"state":"Active"},{"state":"Closed"},{"other":"A"}],"tags":["X","y"]}`
+	if string(mods.Body) != want {
+		t.Errorf("Expected %s, got %s", want, mods.Body)
+	}
+}
+
+func TestMapJSONValuesPolicy_UnmappedValueUnchanged(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"$.status": statusLookup})
+
+	mods := onResponse(p, `{"status":"Z"}`)
+	if mods.Body != nil {
+		t.Errorf("Expected no body modification, got %s", mods.Body)
+	}
+
+	// Objects at the path are not scalars and are left alone
+	if mods := onResponse(p, `{"status":{"code":"A"}}`); mods.Body != nil {
+		t.Errorf("Expected object value to be left unchanged, got %s", mods.Body)
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package meshroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/pathmatch"
+)
+
+// target is the mesh destination a request is routed to
+type target struct {
+	destination string
+	namespace   string // optional
+}
+
+// routeRule maps requests matching a host and/or path pattern to a mesh destination
+type routeRule struct {
+	host string // pathmatch pattern over the lower-cased host without port, empty matches any
+	path string // pathmatch pattern over the normalized request path, empty matches any
+	target
+}
+
+// MeshRoutePolicy sets service mesh routing headers on requests based on their host and path,
+// so the mesh can route them to the right destination
+type MeshRoutePolicy struct {
+	rules             []routeRule
+	defaultTarget     *target // nil when unmatched requests pass through without routing headers
+	destinationHeader string
+	namespaceHeader   string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	rulesRaw, ok := params["rules"].([]interface{})
+	if !ok || len(rulesRaw) == 0 {
+		return nil, fmt.Errorf("'rules' parameter is required and must be a non-empty array")
+	}
+
+	p := &MeshRoutePolicy{
+		destinationHeader: "x-mesh-destination",
+		namespaceHeader:   "x-mesh-namespace",
+	}
+
+	for i, raw := range rulesRaw {
+		ruleMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("rules[%d] must be an object", i)
+		}
+
+		var rule routeRule
+		if raw, ok := ruleMap["host"]; ok {
+			host, ok := raw.(string)
+			host = strings.ToLower(strings.TrimSpace(host))
+			if !ok || host == "" {
+				return nil, fmt.Errorf("rules[%d].host must be a non-empty string", i)
+			}
+			if err := pathmatch.Validate(host); err != nil {
+				return nil, fmt.Errorf("rules[%d].host is invalid: %w", i, err)
+			}
+			rule.host = host
+		}
+		if raw, ok := ruleMap["path"]; ok {
+			pattern, ok := raw.(string)
+			if !ok || !strings.HasPrefix(pattern, "/") {
+				return nil, fmt.Errorf("rules[%d].path must start with '/'", i)
+			}
+			if err := pathmatch.Validate(pattern); err != nil {
+				return nil, fmt.Errorf("rules[%d].path is invalid: %w", i, err)
+			}
+			rule.path = pattern
+		}
+		if rule.host == "" && rule.path == "" {
+			return nil, fmt.Errorf("rules[%d] requires at least one of 'host' or 'path'", i)
+		}
+
+		t, err := parseTarget(ruleMap)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		rule.target = t
+		p.rules = append(p.rules, rule)
+	}
+
+	if raw, ok := params["default"]; ok {
+		defaultMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'default' must be an object")
+		}
+		t, err := parseTarget(defaultMap)
+		if err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+		p.defaultTarget = &t
+	}
+
+	if raw, ok := params["destinationHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'destinationHeader' must be a non-empty string")
+		}
+		p.destinationHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["namespaceHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'namespaceHeader' must be a non-empty string")
+		}
+		p.namespaceHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if p.destinationHeader == p.namespaceHeader {
+		return nil, fmt.Errorf("'destinationHeader' and 'namespaceHeader' must be different headers")
+	}
+
+	return p, nil
+}
+
+// parseTarget parses the destination and optional namespace of a rule or the default
+func parseTarget(m map[string]interface{}) (target, error) {
+	destination, ok := m["destination"].(string)
+	if !ok || strings.TrimSpace(destination) == "" {
+		return target{}, fmt.Errorf("'destination' is required and must be a non-empty string")
+	}
+	t := target{destination: strings.TrimSpace(destination)}
+	if raw, ok := m["namespace"]; ok {
+		namespace, ok := raw.(string)
+		if !ok || strings.TrimSpace(namespace) == "" {
+			return target{}, fmt.Errorf("'namespace' must be a non-empty string")
+		}
+		t.namespace = strings.TrimSpace(namespace)
+	}
+	return t, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *MeshRoutePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need host and path, sets routing headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sets the routing headers of the first rule matching the request, or of the default.
+// Routing headers sent by the client are always replaced or removed so they can't be spoofed.
+func (p *MeshRoutePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, ok := pathmatch.Normalize(ctx.Path)
+	if !ok {
+		slog.Debug("MeshRoute: Rejecting unnormalizable path", "path", ctx.Path)
+		return errorResponse(http.StatusBadRequest, "The request path contains encoded slashes or invalid escapes")
+	}
+
+	t := p.match(requestHost(ctx), reqPath)
+
+	mods := policy.UpstreamRequestModifications{}
+	var remove []string
+	if t == nil {
+		remove = []string{p.destinationHeader, p.namespaceHeader}
+	} else {
+		slog.Debug("MeshRoute: Routing request", "path", reqPath, "destination", t.destination, "namespace", t.namespace)
+		mods.SetHeaders = map[string]string{p.destinationHeader: t.destination}
+		if t.namespace != "" {
+			mods.SetHeaders[p.namespaceHeader] = t.namespace
+		} else {
+			remove = []string{p.namespaceHeader}
+		}
+	}
+	for _, name := range remove {
+		if ctx.Headers.Has(name) {
+			mods.RemoveHeaders = append(mods.RemoveHeaders, name)
+		}
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *MeshRoutePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// match returns the target of the first rule matching the host and path, or the default
+func (p *MeshRoutePolicy) match(host, reqPath string) *target {
+	for i := range p.rules {
+		rule := &p.rules[i]
+		if rule.host != "" && !pathmatch.Match(rule.host, host) {
+			continue
+		}
+		if rule.path != "" && !pathmatch.Match(rule.path, reqPath) {
+			continue
+		}
+		return &rule.target
+	}
+	return p.defaultTarget
+}
+
+// requestHost returns the lower-cased request host without port, taken from the authority or
+// the Host header
+func requestHost(ctx *policy.RequestContext) string {
+	host := ctx.Authority
+	if host == "" {
+		if values := ctx.Headers.Get("host"); len(values) > 0 {
+			host = values[0]
+		}
+	}
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
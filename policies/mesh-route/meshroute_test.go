@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package meshroute
+
+import (
+	"slices"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var testRules = []interface{}{
+	map[string]interface{}{"host": "*.orders.example.com", "destination": "orders", "namespace": "commerce"},
+	map[string]interface{}{"path": "/api/users/*", "destination": "users", "namespace": "identity"},
+	map[string]interface{}{"host": "api.example.com", "path": "/billing/*", "destination": "billing"},
+}
+
+func onRequest(p policy.Policy, authority, path string, headers map[string][]string) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{
+		Headers:   policy.NewHeaders(headers),
+		Authority: authority,
+		Path:      path,
+	}
+	mods, _ := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+	return mods
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"rules": []interface{}{map[string]interface{}{"destination": "x"}}},
+		{"rules": []interface{}{map[string]interface{}{"path": "api/*", "destination": "x"}}},
+		{"rules": []interface{}{map[string]interface{}{"host": "[a", "destination": "x"}}},
+		{"rules": []interface{}{map[string]interface{}{"host": "a.com"}}},
+		{"rules": testRules, "default": map[string]interface{}{"namespace": "x"}},
+		{"rules": testRules, "destinationHeader": "x-mesh", "namespaceHeader": "X-Mesh"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestMeshRoutePolicy_HostBased(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"rules": testRules})
+
+	mods := onRequest(p, "EU.Orders.Example.com:8443", "/api/users/42", nil)
+	if mods.SetHeaders["x-mesh-destination"] != "orders" || mods.SetHeaders["x-mesh-namespace"] != "commerce" {
+		t.Errorf("Expected orders in commerce, got %v", mods.SetHeaders)
+	}
+}
+
+func TestMeshRoutePolicy_PathBased(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"rules": testRules})
+
+	mods := onRequest(p, "api.example.com", "/api/users/42?expand=true", nil)
+	if mods.SetHeaders["x-mesh-destination"] != "users" || mods.SetHeaders["x-mesh-namespace"] != "identity" {
+		t.Errorf("Expected users in identity, got %v", mods.SetHeaders)
+	}
+
+	// A rule without a namespace removes one sent by the client
+	mods = onRequest(p, "api.example.com", "/billing/invoices", map[string][]string{"x-mesh-namespace": {"admin"}})
+	if mods.SetHeaders["x-mesh-destination"] != "billing" || !slices.Equal(mods.RemoveHeaders, []string{"x-mesh-namespace"}) {
+		t.Errorf("Expected billing without namespace, got %+v", mods)
+	}
+}
+
+func TestMeshRoutePolicy_Unmatched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"rules": testRules})
+
+	mods := onRequest(p, "www.example.com", "/", map[string][]string{"x-mesh-destination": {"admin"}})
+	if len(mods.SetHeaders) != 0 || !slices.Equal(mods.RemoveHeaders, []string{"x-mesh-destination"}) {
+		t.Errorf("Expected spoofed header to be removed, got %+v", mods)
+	}
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{
+		"rules":   testRules,
+		"default": map[string]interface{}{"destination": "web", "namespace": "frontend"},
+	})
+	mods = onRequest(p, "www.example.com", "/", nil)
+	if mods.SetHeaders["x-mesh-destination"] != "web" || mods.SetHeaders["x-mesh-namespace"] != "frontend" {
+		t.Errorf("Expected default destination, got %v", mods.SetHeaders)
+	}
+}
+
+func TestMeshRoutePolicy_NormalizedPaths(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"path": "/api/users/**", "destination": "users"},
+		},
+		"default": map[string]interface{}{"destination": "web"},
+	})
+
+	for _, reqPath := range []string{"/api/users", "/api/users/42/roles", "/api//users/42", "/x/../api/%75sers/42"} {
+		if got := onRequest(p, "api.example.com", reqPath, nil).SetHeaders["x-mesh-destination"]; got != "users" {
+			t.Errorf("Expected %q to route to users, got %q", reqPath, got)
+		}
+	}
+	for _, reqPath := range []string{"/api%2Fusers/42", "/api/%zz"} {
+		ctx := &policy.RequestContext{Headers: policy.NewHeaders(nil), Authority: "api.example.com", Path: reqPath}
+		policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 400)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package methodperpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/pathmatch"
+)
+
+const (
+	// Handling of requests whose path matches no rule
+	UnmatchedAllow  = "allow"
+	UnmatchedReject = "reject"
+)
+
+// methodRule lists the methods allowed on paths matching a pattern
+type methodRule struct {
+	pattern string
+	methods map[string]bool
+	allow   string // Allow header value
+}
+
+// MethodPerPathPolicy rejects requests whose method is not allowed for their path with 405
+type MethodPerPathPolicy struct {
+	rules     []methodRule
+	unmatched string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	rulesRaw, ok := params["rules"].([]interface{})
+	if !ok || len(rulesRaw) == 0 {
+		return nil, fmt.Errorf("'rules' parameter is required and must be a non-empty array")
+	}
+
+	p := &MethodPerPathPolicy{unmatched: UnmatchedAllow}
+	for i, raw := range rulesRaw {
+		ruleMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("rules[%d] must be an object", i)
+		}
+		pattern, _ := ruleMap["pathPattern"].(string)
+		pattern = strings.TrimSpace(pattern)
+		if !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("rules[%d].pathPattern must be a non-empty path starting with '/'", i)
+		}
+		if err := pathmatch.Validate(pattern); err != nil {
+			return nil, fmt.Errorf("rules[%d].pathPattern is invalid: %w", i, err)
+		}
+
+		methodsRaw, ok := ruleMap["methods"].([]interface{})
+		if !ok || len(methodsRaw) == 0 {
+			return nil, fmt.Errorf("rules[%d].methods must be a non-empty array", i)
+		}
+		rule := methodRule{pattern: pattern, methods: make(map[string]bool)}
+		for j, m := range methodsRaw {
+			method, ok := m.(string)
+			if !ok || strings.TrimSpace(method) == "" {
+				return nil, fmt.Errorf("rules[%d].methods[%d] must be a non-empty string", i, j)
+			}
+			rule.methods[strings.ToUpper(strings.TrimSpace(method))] = true
+		}
+		// HEAD is served wherever GET is
+		if rule.methods[http.MethodGet] {
+			rule.methods[http.MethodHead] = true
+		}
+		allowed := make([]string, 0, len(rule.methods))
+		for method := range rule.methods {
+			allowed = append(allowed, method)
+		}
+		sort.Strings(allowed)
+		rule.allow = strings.Join(allowed, ", ")
+		p.rules = append(p.rules, rule)
+	}
+
+	if raw, ok := params["unmatched"]; ok {
+		unmatched, ok := raw.(string)
+		if !ok || (unmatched != UnmatchedAllow && unmatched != UnmatchedReject) {
+			return nil, fmt.Errorf("'unmatched' must be one of %s, %s", UnmatchedAllow, UnmatchedReject)
+		}
+		p.unmatched = unmatched
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *MethodPerPathPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need method and path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest checks the method against the first rule matching the path. Disallowed methods get
+// 405 with an Allow header; unmatched paths are allowed or rejected with 404.
+func (p *MethodPerPathPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, ok := pathmatch.Normalize(ctx.Path)
+	if !ok {
+		slog.Debug("MethodPerPath: Rejecting unnormalizable path", "path", ctx.Path)
+		return errorResponse(http.StatusBadRequest, "The request path contains encoded slashes or invalid escapes", nil)
+	}
+	method := strings.ToUpper(ctx.Method)
+
+	for _, rule := range p.rules {
+		if !pathmatch.Match(rule.pattern, reqPath) {
+			continue
+		}
+		if rule.methods[method] {
+			return policy.UpstreamRequestModifications{}
+		}
+		slog.Debug("MethodPerPath: Rejecting disallowed method", "method", method, "path", reqPath)
+		return errorResponse(http.StatusMethodNotAllowed,
+			fmt.Sprintf("Method %s is not allowed for this path", method),
+			map[string]string{"allow": rule.allow})
+	}
+
+	if p.unmatched == UnmatchedReject {
+		slog.Debug("MethodPerPath: Rejecting unmatched path", "path", reqPath)
+		return errorResponse(http.StatusNotFound, "The requested path is not available", nil)
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *MethodPerPathPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// errorResponse builds a JSON error response with optional extra headers
+func errorResponse(status int, message string, extra map[string]string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	headers := map[string]string{
+		"content-type": "application/json",
+	}
+	for name, value := range extra {
+		headers[name] = value
+	}
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers:    headers,
+		Body:       body,
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package methodperpath
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	params["rules"] = []interface{}{
+		map[string]interface{}{"pathPattern": "/orders/*/cancel", "methods": []interface{}{"post"}},
+		map[string]interface{}{"pathPattern": "/orders/**", "methods": []interface{}{"GET", "PUT", "DELETE"}},
+		map[string]interface{}{"pathPattern": "/health", "methods": []interface{}{"GET"}},
+	}
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onRequest(p policy.Policy, method, path string) policy.RequestAction {
+	ctx := &policy.RequestContext{Method: method, Path: path, Headers: policy.NewHeaders(nil)}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"rules": []interface{}{}},
+		{"rules": []interface{}{map[string]interface{}{"pathPattern": "orders", "methods": []interface{}{"GET"}}}},
+		{"rules": []interface{}{map[string]interface{}{"pathPattern": "/[", "methods": []interface{}{"GET"}}}},
+		{"rules": []interface{}{map[string]interface{}{"pathPattern": "/orders", "methods": []interface{}{}}}},
+		{"rules": []interface{}{map[string]interface{}{"pathPattern": "/orders", "methods": []interface{}{""}}}},
+		{"rules": []interface{}{map[string]interface{}{"pathPattern": "/orders", "methods": []interface{}{"GET"}}}, "unmatched": "deny"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestMethodPerPathPolicy_AllowedMethod(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "GET", "/orders/42?expand=items"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "delete", "/orders/42"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "POST", "/orders/42/cancel"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "HEAD", "/health"), 0)
+}
+
+func TestMethodPerPathPolicy_DisallowedMethod(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	resp := policytest.ExpectStatus(t, onRequest(p, "POST", "/orders/42"), 405)
+	if resp.Headers["allow"] != "DELETE, GET, HEAD, PUT" {
+		t.Errorf("Expected Allow 'DELETE, GET, HEAD, PUT', got %q", resp.Headers["allow"])
+	}
+
+	// The first matching rule decides, even when a later rule allows the method
+	resp = policytest.ExpectStatus(t, onRequest(p, "GET", "/orders/42/cancel"), 405)
+	if resp.Headers["allow"] != "POST" {
+		t.Errorf("Expected Allow 'POST', got %q", resp.Headers["allow"])
+	}
+
+	// Encoded and relative paths are normalized before matching
+	policytest.ExpectStatus(t, onRequest(p, "POST", "/shop/../orders/42"), 405)
+	policytest.ExpectStatus(t, onRequest(p, "POST", "/%6Frders/42"), 405)
+}
+
+func TestMethodPerPathPolicy_EncodedSlashRejected(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	// An encoded slash would otherwise let "/orders%2F42%2Fcancel" match "/orders/**" here
+	// while an upstream that decodes it routes to /orders/42/cancel
+	for _, reqPath := range []string{"/orders%2F42%2Fcancel", "/orders/42%2fcancel", "/orders/%zz", "/orders/42%"} {
+		policytest.ExpectStatus(t, onRequest(p, "GET", reqPath), 400)
+	}
+	policytest.ExpectStatus(t, onRequest(p, "GET", "/orders/a%20b?next=%2F"), 0)
+}
+
+func TestMethodPerPathPolicy_UnmatchedPath(t *testing.T) {
+	allow := newPolicy(t, map[string]interface{}{})
+	policytest.ExpectStatus(t, onRequest(allow, "PATCH", "/customers/7"), 0)
+
+	reject := newPolicy(t, map[string]interface{}{"unmatched": "reject"})
+	resp := policytest.ExpectStatus(t, onRequest(reject, "GET", "/customers/7"), 404)
+	if _, ok := resp.Headers["allow"]; ok {
+		t.Errorf("Expected no Allow header for an unmatched path, got %v", resp.Headers)
+	}
+}
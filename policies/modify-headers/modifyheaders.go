@@ -18,9 +18,19 @@
 package modifyheaders
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
 )
@@ -34,6 +44,12 @@ func GetPolicy(
 	metadata policy.PolicyMetadata,
 	params map[string]interface{},
 ) (policy.Policy, error) {
+	// Header templates are parsed up front so that syntax errors fail at load time
+	for _, phase := range []string{"request", "response"} {
+		if _, err := headerTemplate(params, phase); err != nil {
+			return nil, err
+		}
+	}
 	return ins, nil
 }
 
@@ -148,36 +164,45 @@ func (p *ModifyHeadersPolicy) applyHeaderModifications(modifications []HeaderMod
 
 // OnRequest modifies request headers
 func (p *ModifyHeadersPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	var setHeaders map[string]string
+	var removeHeaders []string
+	var appendHeaders map[string][]string
+
 	// Check if requestHeaders are configured
-	requestHeadersRaw, ok := params["requestHeaders"]
-	if !ok {
-		// No request headers to modify, pass through
-		return policy.UpstreamRequestModifications{}
+	if requestHeadersRaw, ok := params["requestHeaders"]; ok {
+		// Parse modifications
+		modifications, err := p.parseHeaderModifications(requestHeadersRaw)
+		if err != nil {
+			// Configuration error - fail with 500
+			return requestConfigError(fmt.Sprintf("Invalid requestHeaders configuration: %s", err.Error()))
+		}
+		if len(modifications) > 0 {
+			setHeaders, removeHeaders, appendHeaders = p.applyHeaderModifications(modifications)
+		}
 	}
 
-	// Parse modifications
-	modifications, err := p.parseHeaderModifications(requestHeadersRaw)
+	// Headers produced by the template are evaluated and applied as a unit
+	tmpl, err := headerTemplate(params, "request")
 	if err != nil {
-		// Configuration error - fail with 500
-		errBody, _ := json.Marshal(map[string]string{
-			"error":   "Configuration Error",
-			"message": fmt.Sprintf("Invalid requestHeaders configuration: %s", err.Error()),
-		})
-		return policy.ImmediateResponse{
-			StatusCode: 500,
-			Headers: map[string]string{
-				"content-type": "application/json",
-			},
-			Body: errBody,
-		}
+		return requestConfigError(fmt.Sprintf("Invalid template configuration: %s", err.Error()))
 	}
-	if len(modifications) == 0 {
-		return policy.UpstreamRequestModifications{}
+	if tmpl != nil {
+		data := templateData{
+			Method:         ctx.Method,
+			Path:           ctx.Path,
+			Authority:      ctx.Authority,
+			Scheme:         ctx.Scheme,
+			headers:        ctx.Headers,
+			requestHeaders: ctx.Headers,
+		}
+		data.setShared(ctx.SharedContext)
+		templated, err := evaluateTemplate(tmpl, data)
+		if err != nil {
+			return requestConfigError(fmt.Sprintf("Invalid request template: %s", err.Error()))
+		}
+		setHeaders = mergeTemplated(setHeaders, templated)
 	}
 
-	// Apply modifications
-	setHeaders, removeHeaders, appendHeaders := p.applyHeaderModifications(modifications)
-
 	return policy.UpstreamRequestModifications{
 		SetHeaders:    setHeaders,
 		RemoveHeaders: removeHeaders,
@@ -187,40 +212,234 @@ func (p *ModifyHeadersPolicy) OnRequest(ctx *policy.RequestContext, params map[s
 
 // OnResponse modifies response headers
 func (p *ModifyHeadersPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	var setHeaders map[string]string
+	var removeHeaders []string
+	var appendHeaders map[string][]string
+
 	// Check if responseHeaders are configured
-	responseHeadersRaw, ok := params["responseHeaders"]
-	if !ok {
-		// No response headers to modify, pass through
-		return policy.UpstreamResponseModifications{}
+	if responseHeadersRaw, ok := params["responseHeaders"]; ok {
+		// Parse modifications
+		modifications, err := p.parseHeaderModifications(responseHeadersRaw)
+		if err != nil {
+			// Configuration error - return error response by modifying upstream response
+			return responseConfigError(fmt.Sprintf("Invalid responseHeaders configuration: %s", err.Error()))
+		}
+		if len(modifications) > 0 {
+			setHeaders, removeHeaders, appendHeaders = p.applyHeaderModifications(modifications)
+		}
 	}
 
-	// Parse modifications
-	modifications, err := p.parseHeaderModifications(responseHeadersRaw)
+	// Headers produced by the template are evaluated and applied as a unit
+	tmpl, err := headerTemplate(params, "response")
 	if err != nil {
-		// Configuration error - return error response by modifying upstream response
-		statusCode := 500
-		errBody, _ := json.Marshal(map[string]string{
-			"error":   "Configuration Error",
-			"message": fmt.Sprintf("Invalid responseHeaders configuration: %s", err.Error()),
-		})
-		return policy.UpstreamResponseModifications{
-			StatusCode: &statusCode,
-			Body:       errBody,
-			SetHeaders: map[string]string{
-				"content-type": "application/json",
-			},
-		}
+		return responseConfigError(fmt.Sprintf("Invalid template configuration: %s", err.Error()))
 	}
-	if len(modifications) == 0 {
-		return policy.UpstreamResponseModifications{}
+	if tmpl != nil {
+		data := templateData{
+			Method:         ctx.RequestMethod,
+			Path:           ctx.RequestPath,
+			Status:         ctx.ResponseStatus,
+			headers:        ctx.ResponseHeaders,
+			requestHeaders: ctx.RequestHeaders,
+		}
+		data.setShared(ctx.SharedContext)
+		templated, err := evaluateTemplate(tmpl, data)
+		if err != nil {
+			return responseConfigError(fmt.Sprintf("Invalid response template: %s", err.Error()))
+		}
+		setHeaders = mergeTemplated(setHeaders, templated)
 	}
 
-	// Apply modifications
-	setHeaders, removeHeaders, appendHeaders := p.applyHeaderModifications(modifications)
-
 	return policy.UpstreamResponseModifications{
 		SetHeaders:    setHeaders,
 		RemoveHeaders: removeHeaders,
 		AppendHeaders: appendHeaders,
 	}
 }
+
+// requestConfigError rejects the request with a 500 describing the configuration problem
+func requestConfigError(message string) policy.RequestAction {
+	errBody, _ := json.Marshal(map[string]string{
+		"error":   "Configuration Error",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: 500,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: errBody,
+	}
+}
+
+// responseConfigError replaces the upstream response with a 500 describing the configuration problem
+func responseConfigError(message string) policy.ResponseAction {
+	statusCode := 500
+	errBody, _ := json.Marshal(map[string]string{
+		"error":   "Configuration Error",
+		"message": message,
+	})
+	return policy.UpstreamResponseModifications{
+		StatusCode: &statusCode,
+		Body:       errBody,
+		SetHeaders: map[string]string{
+			"content-type": "application/json",
+		},
+	}
+}
+
+// timeNow is the clock used by the template "now" function (injectable for testing)
+var timeNow = time.Now
+
+// templateCache holds parsed header templates keyed by their source text
+var templateCache sync.Map
+
+var headerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
+
+// templateFuncs are the helper functions available to header templates. Digest functions
+// return raw bytes so they can be piped into hex or base64.
+var templateFuncs = template.FuncMap{
+	"now": func() time.Time { return timeNow().UTC() },
+	"httpDate": func(t time.Time) string {
+		return t.UTC().Format(http.TimeFormat)
+	},
+	"sha256": func(data string) string {
+		sum := sha256.Sum256([]byte(data))
+		return string(sum[:])
+	},
+	"hmacSHA256": func(key, data string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(data))
+		return string(mac.Sum(nil))
+	},
+	"hex": func(data string) string {
+		return hex.EncodeToString([]byte(data))
+	},
+	"base64": func(data string) string {
+		return base64.StdEncoding.EncodeToString([]byte(data))
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// templateData is the context a header template is evaluated against
+type templateData struct {
+	Method     string
+	Path       string
+	Authority  string
+	Scheme     string
+	Status     int // Response status (response phase only)
+	RequestID  string
+	APIName    string
+	APIVersion string
+	Metadata   map[string]interface{}
+
+	headers        *policy.Headers // Headers of the current phase
+	requestHeaders *policy.Headers
+}
+
+func (d *templateData) setShared(shared *policy.SharedContext) {
+	if shared == nil {
+		return
+	}
+	d.RequestID = shared.RequestID
+	d.APIName = shared.APIName
+	d.APIVersion = shared.APIVersion
+	d.Metadata = shared.Metadata
+}
+
+// Header returns the first value of a header in the current phase
+func (d templateData) Header(name string) string {
+	return firstValue(d.headers, name)
+}
+
+// RequestHeader returns the first value of a request header, also in the response phase
+func (d templateData) RequestHeader(name string) string {
+	return firstValue(d.requestHeaders, name)
+}
+
+func firstValue(headers *policy.Headers, name string) string {
+	if headers == nil {
+		return ""
+	}
+	if values := headers.Get(strings.ToLower(name)); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// headerTemplate returns the parsed template configured for a phase, or nil when there is none
+func headerTemplate(params map[string]interface{}, phase string) (*template.Template, error) {
+	raw, ok := params["template"]
+	if !ok {
+		return nil, nil
+	}
+	templates, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("template must be an object")
+	}
+	sourceRaw, ok := templates[phase]
+	if !ok {
+		return nil, nil
+	}
+	source, ok := sourceRaw.(string)
+	if !ok {
+		return nil, fmt.Errorf("template.%s must be a string", phase)
+	}
+	if source == "" {
+		return nil, nil
+	}
+	if cached, ok := templateCache.Load(source); ok {
+		return cached.(*template.Template), nil
+	}
+	tmpl, err := template.New("headers").Funcs(templateFuncs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("template.%s is not a valid template: %w", phase, err)
+	}
+	templateCache.Store(source, tmpl)
+	return tmpl, nil
+}
+
+// evaluateTemplate renders a header template and parses its output as "name: value" lines.
+// Any execution or output error fails the whole template so that interdependent headers are
+// never applied partially.
+func evaluateTemplate(tmpl *template.Template, data templateData) (map[string]string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	for i, line := range strings.Split(buf.String(), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !found || !headerNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("output line %d is not a valid 'name: value' header line", i+1)
+		}
+		name = strings.ToLower(name)
+		if _, exists := headers[name]; exists {
+			return nil, fmt.Errorf("output sets header '%s' more than once", name)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// mergeTemplated adds template output to the configured SET headers; template values win
+func mergeTemplated(setHeaders map[string]string, templated map[string]string) map[string]string {
+	if len(templated) == 0 {
+		return setHeaders
+	}
+	if setHeaders == nil {
+		setHeaders = make(map[string]string, len(templated))
+	}
+	for name, value := range templated {
+		setHeaders[name] = value
+	}
+	return setHeaders
+}
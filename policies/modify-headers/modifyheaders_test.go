@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package modifyheaders
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+func newRequestContext(headers map[string][]string) *policy.RequestContext {
+	return &policy.RequestContext{
+		SharedContext: &policy.SharedContext{RequestID: "req-1", Metadata: map[string]interface{}{"tenant": "acme"}},
+		Headers:       policy.NewHeaders(headers),
+		Method:        "GET",
+		Path:          "/orders?limit=5",
+		Authority:     "api.example.com",
+	}
+}
+
+func expectConfigError(t *testing.T, action policy.RequestAction) {
+	t.Helper()
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != 500 {
+		t.Fatalf("Expected 500 configuration error, got %+v", action)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil || body["error"] != "Configuration Error" {
+		t.Errorf("Expected Configuration Error body, got %s", resp.Body)
+	}
+}
+
+func TestModifyHeadersPolicy_RequestHeaders(t *testing.T) {
+	p, _ := GetPolicy(policy.PolicyMetadata{}, nil)
+	params := map[string]interface{}{
+		"requestHeaders": []interface{}{
+			map[string]interface{}{"action": "set", "name": "X-Env", "value": "prod"},
+			map[string]interface{}{"action": "DELETE", "name": "x-debug"},
+			map[string]interface{}{"action": "APPEND", "name": "x-tag", "value": "a"},
+			map[string]interface{}{"action": "APPEND", "name": "x-tag", "value": "b"},
+		},
+	}
+
+	mods := p.OnRequest(newRequestContext(nil), params).(policy.UpstreamRequestModifications)
+	if mods.SetHeaders["x-env"] != "prod" {
+		t.Errorf("Expected x-env to be set, got %v", mods.SetHeaders)
+	}
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-debug" {
+		t.Errorf("Expected x-debug to be removed, got %v", mods.RemoveHeaders)
+	}
+	if tags := mods.AppendHeaders["x-tag"]; len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Errorf("Expected x-tag values [a b], got %v", tags)
+	}
+}
+
+func TestModifyHeadersPolicy_MultiHeaderTemplate(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	p, _ := GetPolicy(policy.PolicyMetadata{}, nil)
+	params := map[string]interface{}{
+		"requestHeaders": []interface{}{
+			map[string]interface{}{"action": "SET", "name": "x-date", "value": "overridden"},
+		},
+		"template": map[string]interface{}{
+			"request": `{{ $date := httpDate now -}}
+authorization: Bearer {{ .Header "x-client-token" }}
+x-date: {{ $date }}
+
+x-signature: {{ hmacSHA256 "secret" (print .Method " " .Path " " $date) | hex }}
+x-tenant: {{ .Metadata.tenant }}`,
+		},
+	}
+
+	ctx := newRequestContext(map[string][]string{"x-client-token": {"abc"}})
+	mods := p.OnRequest(ctx, params).(policy.UpstreamRequestModifications)
+
+	date := "Fri, 02 Jan 2026 03:04:05 GMT"
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("GET /orders?limit=5 " + date))
+	expected := map[string]string{
+		"authorization": "Bearer abc",
+		"x-date":        date,
+		"x-signature":   hex.EncodeToString(mac.Sum(nil)),
+		"x-tenant":      "acme",
+	}
+	if len(mods.SetHeaders) != len(expected) {
+		t.Errorf("Expected %d headers, got %v", len(expected), mods.SetHeaders)
+	}
+	for name, value := range expected {
+		if got := mods.SetHeaders[name]; got != value {
+			t.Errorf("Expected %s %q, got %q", name, value, got)
+		}
+	}
+}
+
+func TestModifyHeadersPolicy_ResponseTemplate(t *testing.T) {
+	p, _ := GetPolicy(policy.PolicyMetadata{}, nil)
+	params := map[string]interface{}{
+		"template": map[string]interface{}{
+			"response": `x-upstream-status: {{ .Status }}
+x-request-tenant: {{ .RequestHeader "x-tenant" | upper }}`,
+		},
+	}
+
+	ctx := &policy.ResponseContext{
+		RequestHeaders:  policy.NewHeaders(map[string][]string{"x-tenant": {"acme"}}),
+		ResponseHeaders: policy.NewHeaders(nil),
+		ResponseStatus:  201,
+	}
+	mods := p.OnResponse(ctx, params).(policy.UpstreamResponseModifications)
+	if mods.SetHeaders["x-upstream-status"] != "201" || mods.SetHeaders["x-request-tenant"] != "ACME" {
+		t.Errorf("Expected templated response headers, got %v", mods.SetHeaders)
+	}
+}
+
+func TestModifyHeadersPolicy_TemplateErrorsFailFast(t *testing.T) {
+	// Templates that cannot be parsed are rejected when the policy is loaded
+	for _, tmpl := range []interface{}{
+		`x-a: {{ .Header "x-a" `, // Parse error
+		`x-a: {{ unknownFunc }}`, // Undefined function
+		42,                       // Not a string
+	} {
+		for _, phase := range []string{"request", "response"} {
+			params := map[string]interface{}{"template": map[string]interface{}{phase: tmpl}}
+			if _, err := GetPolicy(policy.PolicyMetadata{}, params); err == nil {
+				t.Errorf("Expected GetPolicy to reject %s template %v", phase, tmpl)
+			}
+		}
+	}
+	if _, err := GetPolicy(policy.PolicyMetadata{}, map[string]interface{}{"template": "x-a: 1"}); err == nil {
+		t.Error("Expected GetPolicy to reject a template that is not an object")
+	}
+
+	p, _ := GetPolicy(policy.PolicyMetadata{}, nil)
+
+	templates := []interface{}{
+		`x-a: {{ .Metadata.missing }}`,           // Missing metadata key
+		`x-a: {{ hmacSHA256 "only-key" }}`,       // Wrong argument count
+		"x-a: 1\nnot a header line",              // Malformed output
+		"x-a: 1\nx-A: 2",                         // Duplicate header
+		"x-a: {{ .Header \"x-a\" }}\nx a: value", // Invalid header name
+	}
+	for _, tmpl := range templates {
+		params := map[string]interface{}{
+			"requestHeaders": []interface{}{
+				map[string]interface{}{"action": "SET", "name": "x-other", "value": "1"},
+			},
+			"template": map[string]interface{}{"request": tmpl},
+		}
+		expectConfigError(t, p.OnRequest(newRequestContext(nil), params))
+	}
+
+	// A response template error replaces the response rather than applying some headers
+	params := map[string]interface{}{"template": map[string]interface{}{"response": "{{ .Nope }}"}}
+	ctx := &policy.ResponseContext{ResponseHeaders: policy.NewHeaders(nil)}
+	mods := p.OnResponse(ctx, params).(policy.UpstreamResponseModifications)
+	if mods.StatusCode == nil || *mods.StatusCode != 500 {
+		t.Errorf("Expected response to be replaced with a 500, got %+v", mods)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package multipartnormalize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const (
+	// Ordering of parts in the re-serialized body
+	OrderingFilesLast = "filesLast"
+	OrderingPreserve  = "preserve"
+)
+
+const (
+	// Handling of parts whose field name is not allowed
+	DisallowedStrip  = "strip"
+	DisallowedReject = "reject"
+)
+
+// part is one buffered multipart part
+type part struct {
+	header textproto.MIMEHeader
+	name   string
+	isFile bool
+	body   []byte
+}
+
+// MultipartNormalizePolicy reorders and filters the parts of multipart/form-data request bodies
+type MultipartNormalizePolicy struct {
+	ordering      string
+	allowedFields map[string]bool // nil when every field name is allowed
+	onDisallowed  string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &MultipartNormalizePolicy{
+		ordering:     OrderingFilesLast,
+		onDisallowed: DisallowedStrip,
+	}
+
+	if raw, ok := params["ordering"]; ok {
+		ordering, ok := raw.(string)
+		if !ok || (ordering != OrderingFilesLast && ordering != OrderingPreserve) {
+			return nil, fmt.Errorf("'ordering' must be one of %s, %s", OrderingFilesLast, OrderingPreserve)
+		}
+		p.ordering = ordering
+	}
+
+	if raw, ok := params["allowedFields"]; ok {
+		fields, ok := raw.([]interface{})
+		if !ok || len(fields) == 0 {
+			return nil, fmt.Errorf("'allowedFields' must be a non-empty array")
+		}
+		p.allowedFields = make(map[string]bool, len(fields))
+		for i, f := range fields {
+			name, ok := f.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("allowedFields[%d] must be a non-empty string", i)
+			}
+			p.allowedFields[name] = true
+		}
+	}
+
+	if raw, ok := params["onDisallowed"]; ok {
+		onDisallowed, ok := raw.(string)
+		if !ok || (onDisallowed != DisallowedStrip && onDisallowed != DisallowedReject) {
+			return nil, fmt.Errorf("'onDisallowed' must be one of %s, %s", DisallowedStrip, DisallowedReject)
+		}
+		p.onDisallowed = onDisallowed
+	}
+
+	if p.ordering == OrderingPreserve && p.allowedFields == nil {
+		return nil, fmt.Errorf("'allowedFields' is required when 'ordering' is %s", OrderingPreserve)
+	}
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *MultipartNormalizePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type and boundary
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to re-serialize it
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest parses multipart/form-data bodies, drops or rejects disallowed fields and moves file
+// parts after the other fields. Changed bodies are re-serialized with a fresh boundary.
+func (p *MultipartNormalizePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+	if bodyutil.MediaType(ctx.Headers) != "multipart/form-data" {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Bodies that can't be parsed are only passed through when normalizing; when disallowed
+	// fields must be rejected they would otherwise reach the upstream unchecked
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		if p.enforcing() {
+			return errorResponse(http.StatusUnsupportedMediaType, "Unsupported Media Type",
+				"Encoded multipart/form-data bodies are not accepted; send the body without a Content-Encoding")
+		}
+		return policy.UpstreamRequestModifications{}
+	}
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, bodyutil.Options{}); pass {
+		if p.enforcing() {
+			slog.Debug("MultipartNormalize: Rejecting body that cannot be inspected", "reason", reason)
+			return badRequest("The multipart/form-data body could not be inspected: " + reason)
+		}
+		slog.Debug("MultipartNormalize: Skipping request body", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	_, mediaParams, err := mime.ParseMediaType(ctx.Headers.Get("content-type")[0])
+	if err != nil || mediaParams["boundary"] == "" {
+		return badRequest("The multipart/form-data Content-Type must declare a boundary")
+	}
+	parts, err := readParts(ctx.Body.Content, mediaParams["boundary"])
+	if err != nil {
+		slog.Debug("MultipartNormalize: Malformed multipart body", "error", err)
+		return badRequest("The multipart/form-data body is malformed")
+	}
+
+	changed := false
+	kept := parts[:0]
+	for _, pt := range parts {
+		if p.allowedFields != nil && !p.allowedFields[pt.name] {
+			if p.onDisallowed == DisallowedReject {
+				return badRequest(fmt.Sprintf("Field '%s' is not allowed", pt.name))
+			}
+			slog.Debug("MultipartNormalize: Stripping disallowed field", "name", pt.name)
+			changed = true
+			continue
+		}
+		kept = append(kept, pt)
+	}
+	if p.ordering == OrderingFilesLast {
+		sorted := sort.SliceIsSorted(kept, func(i, j int) bool { return !kept[i].isFile && kept[j].isFile })
+		if !sorted {
+			sort.SliceStable(kept, func(i, j int) bool { return !kept[i].isFile && kept[j].isFile })
+			changed = true
+		}
+	}
+	if !changed {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	body, boundary, err := writeParts(kept)
+	if err != nil {
+		slog.Debug("MultipartNormalize: Failed to re-serialize body", "error", err)
+		return policy.UpstreamRequestModifications{}
+	}
+	return policy.UpstreamRequestModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-type":   mime.FormatMediaType("multipart/form-data", map[string]string{"boundary": boundary}),
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// enforcing reports whether disallowed fields are rejected rather than stripped
+func (p *MultipartNormalizePolicy) enforcing() bool {
+	return p.allowedFields != nil && p.onDisallowed == DisallowedReject
+}
+
+// OnResponse is not used by this policy
+func (p *MultipartNormalizePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// readParts buffers every part of a multipart body. Raw parts are used so content transfer
+// encodings are preserved byte for byte.
+func readParts(content []byte, boundary string) ([]part, error) {
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+	var parts []part
+	for {
+		raw, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(raw)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part{
+			header: raw.Header,
+			name:   raw.FormName(),
+			isFile: raw.FileName() != "",
+			body:   body,
+		})
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("no parts found")
+	}
+	return parts, nil
+}
+
+// writeParts serializes parts with a new random boundary
+func writeParts(parts []part) ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, pt := range parts {
+		w, err := writer.CreatePart(pt.header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := w.Write(pt.body); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.Boundary(), nil
+}
+
+// badRequest returns a 400 JSON error response
+func badRequest(message string) policy.RequestAction {
+	return errorResponse(http.StatusBadRequest, "Bad Request", message)
+}
+
+// errorResponse returns a JSON error response
+func errorResponse(status int, errorText, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   errorText,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
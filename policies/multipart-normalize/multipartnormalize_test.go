@@ -0,0 +1,207 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package multipartnormalize
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// formPart describes a part of a test body; parts with a filename are file parts
+type formPart struct {
+	name, filename, content string
+}
+
+func encode(t *testing.T, parts []formPart) ([]byte, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, pt := range parts {
+		var w io.Writer
+		var err error
+		if pt.filename != "" {
+			w, err = writer.CreateFormFile(pt.name, pt.filename)
+		} else {
+			w, err = writer.CreateFormField(pt.name)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(pt.content))
+	}
+	writer.Close()
+	return buf.Bytes(), writer.FormDataContentType()
+}
+
+func decode(t *testing.T, body []byte, contentType string) []formPart {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("Expected a valid content type, got %q", contentType)
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	var parts []formPart
+	for {
+		pt, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("Expected a valid multipart body, got %v", err)
+		}
+		content, _ := io.ReadAll(pt)
+		parts = append(parts, formPart{pt.FormName(), pt.FileName(), string(content)})
+	}
+}
+
+func onRequest(t *testing.T, p policy.Policy, parts []formPart) (policy.RequestAction, string) {
+	t.Helper()
+	body, contentType := encode(t, parts)
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: body, Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil), contentType
+}
+
+func expectParts(t *testing.T, action policy.RequestAction, originalType string, expected []formPart) {
+	t.Helper()
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok || mods.Body == nil {
+		t.Fatalf("Expected a rewritten body, got %+v", action)
+	}
+	contentType := mods.SetHeaders["content-type"]
+	if contentType == originalType {
+		t.Errorf("Expected a fresh boundary, got %q", contentType)
+	}
+	if mods.SetHeaders["content-length"] != strconv.Itoa(len(mods.Body)) {
+		t.Errorf("Expected content-length %d, got %v", len(mods.Body), mods.SetHeaders)
+	}
+	if got := decode(t, mods.Body, contentType); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected parts %v, got %v", expected, got)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"ordering": "filesFirst"},
+		{"ordering": "preserve"},
+		{"allowedFields": []interface{}{}},
+		{"allowedFields": []interface{}{""}},
+		{"allowedFields": []interface{}{"a"}, "onDisallowed": "drop"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestMultipartNormalizePolicy_MovesFilesLast(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	action, contentType := onRequest(t, p, []formPart{
+		{"avatar", "me.png", "\x89PNG"},
+		{"title", "", "Profile"},
+		{"resume", "cv.pdf", "%PDF"},
+		{"email", "", "a@example.com"},
+	})
+	expectParts(t, action, contentType, []formPart{
+		{"title", "", "Profile"},
+		{"email", "", "a@example.com"},
+		{"avatar", "me.png", "\x89PNG"},
+		{"resume", "cv.pdf", "%PDF"},
+	})
+
+	// Bodies that are already ordered pass through unchanged
+	action, _ = onRequest(t, p, []formPart{{"title", "", "Profile"}, {"avatar", "me.png", "\x89PNG"}})
+	if mods := action.(policy.UpstreamRequestModifications); mods.Body != nil {
+		t.Errorf("Expected an ordered body to be unchanged, got %s", mods.Body)
+	}
+}
+
+func TestMultipartNormalizePolicy_StripsDisallowedField(t *testing.T) {
+	parts := []formPart{
+		{"title", "", "Profile"},
+		{"isAdmin", "", "true"},
+		{"avatar", "me.png", "\x89PNG"},
+	}
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"ordering":      "preserve",
+		"allowedFields": []interface{}{"title", "avatar"},
+	})
+	action, contentType := onRequest(t, p, parts)
+	expectParts(t, action, contentType, []formPart{
+		{"title", "", "Profile"},
+		{"avatar", "me.png", "\x89PNG"},
+	})
+
+	reject := policytest.New(t, GetPolicy, map[string]interface{}{
+		"allowedFields": []interface{}{"title", "avatar"},
+		"onDisallowed":  "reject",
+	})
+	action, _ = onRequest(t, reject, parts)
+	if resp, ok := action.(policy.ImmediateResponse); !ok || resp.StatusCode != 400 {
+		t.Errorf("Expected status 400, got %+v", action)
+	}
+}
+
+func TestMultipartNormalizePolicy_MalformedBody(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {"multipart/form-data; boundary=xyz"}}),
+		Body:    &policy.Body{Content: []byte("--abc\r\nnot a part"), Present: true, EndOfStream: true},
+	}
+	if resp, ok := p.OnRequest(ctx, nil).(policy.ImmediateResponse); !ok || resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for a malformed body, got %+v", resp)
+	}
+}
+
+func TestMultipartNormalizePolicy_UninspectableBodies(t *testing.T) {
+	parts := []formPart{{"title", "", "Profile"}, {"isAdmin", "", "true"}}
+	body, contentType := encode(t, parts)
+	request := func(p policy.Policy, headers map[string][]string, endOfStream bool) policy.RequestAction {
+		headers["content-type"] = []string{contentType}
+		return p.OnRequest(&policy.RequestContext{
+			Headers: policy.NewHeaders(headers),
+			Body:    &policy.Body{Content: body, Present: true, EndOfStream: endOfStream},
+		}, nil)
+	}
+
+	// With reject, bodies that can't be checked must not reach the upstream
+	reject := policytest.New(t, GetPolicy, map[string]interface{}{
+		"allowedFields": []interface{}{"title"},
+		"onDisallowed":  "reject",
+	})
+	policytest.ExpectStatus(t, request(reject, map[string][]string{"content-encoding": {"gzip"}}, true), 415)
+	policytest.ExpectStatus(t, request(reject, map[string][]string{"transfer-encoding": {"chunked"}}, false), 400)
+
+	// When stripping, they are forwarded unchanged
+	strip := policytest.New(t, GetPolicy, map[string]interface{}{"allowedFields": []interface{}{"title"}})
+	for _, headers := range []map[string][]string{{"content-encoding": {"gzip"}}, {"transfer-encoding": {"chunked"}}} {
+		action := request(strip, headers, false)
+		if mods, ok := action.(policy.UpstreamRequestModifications); !ok || mods.Body != nil {
+			t.Errorf("Expected %v body to pass through unchanged when stripping, got %+v", headers, action)
+		}
+	}
+}
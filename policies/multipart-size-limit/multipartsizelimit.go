@@ -0,0 +1,209 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package multipartsizelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+// MultipartSizeLimitPolicy rejects multipart uploads whose parts, taken individually or
+// together, exceed configured limits
+type MultipartSizeLimitPolicy struct {
+	maxPartBytes  int64 // 0 disables the per-part check
+	maxTotalBytes int64 // 0 disables the aggregate check
+	maxParts      int   // 0 disables the part count check
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &MultipartSizeLimitPolicy{}
+
+	if raw, ok := params["maxPartBytes"]; ok {
+		maxPartBytes, err := extractInt(raw)
+		if err != nil || maxPartBytes < 1 {
+			return nil, fmt.Errorf("'maxPartBytes' must be a positive integer")
+		}
+		p.maxPartBytes = int64(maxPartBytes)
+	}
+
+	if raw, ok := params["maxTotalBytes"]; ok {
+		maxTotalBytes, err := extractInt(raw)
+		if err != nil || maxTotalBytes < 1 {
+			return nil, fmt.Errorf("'maxTotalBytes' must be a positive integer")
+		}
+		p.maxTotalBytes = int64(maxTotalBytes)
+	}
+
+	if raw, ok := params["maxParts"]; ok {
+		maxParts, err := extractInt(raw)
+		if err != nil || maxParts < 1 {
+			return nil, fmt.Errorf("'maxParts' must be a positive integer")
+		}
+		p.maxParts = maxParts
+	}
+
+	if p.maxPartBytes == 0 && p.maxTotalBytes == 0 && p.maxParts == 0 {
+		return nil, fmt.Errorf("at least one of 'maxPartBytes', 'maxTotalBytes' or 'maxParts' must be specified")
+	}
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy. The body is buffered because the SDK
+// declares BodyModeStream but does not yet define the StreamingPolicy interface that would
+// deliver chunks, so parts can only be counted once the kernel has the complete body.
+func (p *MultipartSizeLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type and boundary
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to measure parts
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest walks the parts of multipart bodies and rejects the request with 413 as soon as a
+// limit is exceeded. Part contents are counted as they are read rather than copied, so the
+// policy adds no memory use beyond the body the kernel has already buffered.
+func (p *MultipartSizeLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+	if !strings.HasPrefix(bodyutil.MediaType(ctx.Headers), "multipart/") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be measured without decoding them, so they are refused rather
+	// than forwarded unchecked
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		return errorResponse(http.StatusUnsupportedMediaType, "Encoded multipart bodies are not accepted")
+	}
+
+	_, mediaParams, err := mime.ParseMediaType(ctx.Headers.Get("content-type")[0])
+	if err != nil || mediaParams["boundary"] == "" {
+		return badRequest("The multipart Content-Type must declare a boundary")
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(ctx.Body.Content), mediaParams["boundary"])
+	var total int64
+	for count := 1; ; count++ {
+		part, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			slog.Debug("MultipartSizeLimit: Malformed multipart body", "error", err)
+			return badRequest("The multipart body is malformed")
+		}
+		if p.maxParts > 0 && count > p.maxParts {
+			return tooLarge(fmt.Sprintf("The upload has more than %d parts", p.maxParts))
+		}
+
+		// Read at most one byte past the tightest remaining limit
+		limit := int64(math.MaxInt64 - 1)
+		if p.maxPartBytes > 0 {
+			limit = p.maxPartBytes
+		}
+		if p.maxTotalBytes > 0 {
+			limit = min(limit, p.maxTotalBytes-total)
+		}
+		size, err := io.Copy(io.Discard, io.LimitReader(part, limit+1))
+		if err != nil {
+			slog.Debug("MultipartSizeLimit: Malformed multipart body", "error", err)
+			return badRequest("The multipart body is malformed")
+		}
+		if p.maxPartBytes > 0 && size > p.maxPartBytes {
+			return tooLarge(fmt.Sprintf("Part '%s' exceeds the limit of %d bytes", partName(part), p.maxPartBytes))
+		}
+		total += size
+		if p.maxTotalBytes > 0 && total > p.maxTotalBytes {
+			return tooLarge(fmt.Sprintf("The upload exceeds the total limit of %d bytes", p.maxTotalBytes))
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *MultipartSizeLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// partName names a part by its file name, falling back to its form field name
+func partName(part *multipart.Part) string {
+	if name := part.FileName(); name != "" {
+		return name
+	}
+	return part.FormName()
+}
+
+func tooLarge(message string) policy.RequestAction {
+	return errorResponse(http.StatusRequestEntityTooLarge, message)
+}
+
+func badRequest(message string) policy.RequestAction {
+	return errorResponse(http.StatusBadRequest, message)
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package multipartsizelimit
+
+import (
+	"bytes"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// onRequest uploads one file part per size, named file0, file1, ...
+func onRequest(p policy.Policy, sizes ...int) policy.RequestAction {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	writer.WriteField("title", "upload")
+	for i, size := range sizes {
+		w, _ := writer.CreateFormFile("file", "file"+strconv.Itoa(i))
+		w.Write([]byte(strings.Repeat("x", size)))
+	}
+	writer.Close()
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {writer.FormDataContentType()}}),
+		Body:    &policy.Body{Content: buf.Bytes(), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxPartBytes": 0},
+		{"maxTotalBytes": "big"},
+		{"maxParts": 1.5},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestMultipartSizeLimitPolicy_PartOverLimit(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxPartBytes": 100})
+
+	resp := policytest.ExpectStatus(t, onRequest(p, 50, 101), 413)
+	if !strings.Contains(string(resp.Body), "file1") {
+		t.Errorf("Expected the oversized part to be named, got %s", resp.Body)
+	}
+}
+
+func TestMultipartSizeLimitPolicy_AggregateOverLimit(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxPartBytes": 100, "maxTotalBytes": 200})
+
+	// Every part is within its limit but together they are not; "upload" adds 6 bytes
+	policytest.ExpectStatus(t, onRequest(p, 100, 95), 413)
+
+	count := policytest.New(t, GetPolicy, map[string]interface{}{"maxParts": 3})
+	policytest.ExpectStatus(t, onRequest(count, 1, 1, 1), 413)
+}
+
+func TestMultipartSizeLimitPolicy_WithinLimitsPasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxPartBytes": 100, "maxTotalBytes": 200, "maxParts": 3})
+
+	policytest.ExpectStatus(t, onRequest(p, 100, 94), 0)
+
+	// Non-multipart bodies are not inspected
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {"application/json"}}),
+		Body:    &policy.Body{Content: []byte(strings.Repeat("x", 500)), Present: true, EndOfStream: true},
+	}
+	policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 0)
+}
+
+func TestMultipartSizeLimitPolicy_EncodedBodyRejected(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxTotalBytes": 200})
+
+	for _, encoding := range [][]string{{"gzip"}, {"identity", "br"}, {"identity, deflate"}} {
+		ctx := &policy.RequestContext{
+			Headers: policy.NewHeaders(map[string][]string{
+				"content-type":     {"multipart/form-data; boundary=x"},
+				"content-encoding": encoding,
+			}),
+			Body: &policy.Body{Content: []byte("\x1f\x8b compressed"), Present: true, EndOfStream: true},
+		}
+		policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 415)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package nocacheauth
+
+import (
+	"fmt"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// NoCacheAuthPolicy marks responses to authenticated requests as uncacheable by shared caches
+type NoCacheAuthPolicy struct {
+	headers      []string        // Lower-cased request headers that mark a request as authenticated
+	cookies      map[string]bool // Cookie names that mark a request as authenticated
+	cacheControl string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &NoCacheAuthPolicy{
+		headers:      []string{"authorization"},
+		cookies:      make(map[string]bool),
+		cacheControl: "private, no-store",
+	}
+
+	if raw, ok := params["headers"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'headers' must be an array")
+		}
+		p.headers = nil
+		for i, item := range list {
+			name, ok := item.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("headers[%d] must be a non-empty string", i)
+			}
+			p.headers = append(p.headers, strings.ToLower(strings.TrimSpace(name)))
+		}
+	}
+
+	if raw, ok := params["cookies"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'cookies' must be an array")
+		}
+		for i, item := range list {
+			name, ok := item.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("cookies[%d] must be a non-empty string", i)
+			}
+			p.cookies[strings.TrimSpace(name)] = true
+		}
+	}
+
+	if len(p.headers) == 0 && len(p.cookies) == 0 {
+		return nil, fmt.Errorf("at least one of 'headers' or 'cookies' must be non-empty")
+	}
+
+	if raw, ok := params["cacheControl"]; ok {
+		value, ok := raw.(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("'cacheControl' must be a non-empty string")
+		}
+		p.cacheControl = strings.TrimSpace(value)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *NoCacheAuthPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need credentials in request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Sets Cache-Control
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *NoCacheAuthPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse replaces Cache-Control on responses to authenticated requests. Anonymous
+// requests are left untouched.
+func (p *NoCacheAuthPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.RequestHeaders == nil || !p.authenticated(ctx.RequestHeaders) {
+		return policy.UpstreamResponseModifications{}
+	}
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{"cache-control": p.cacheControl},
+		// The upstream's freshness information no longer applies
+		RemoveHeaders: []string{"expires"},
+	}
+}
+
+// authenticated reports whether the request carries a non-empty credential header or one of
+// the configured cookies
+func (p *NoCacheAuthPolicy) authenticated(headers *policy.Headers) bool {
+	for _, name := range p.headers {
+		for _, value := range headers.Get(name) {
+			if strings.TrimSpace(value) != "" {
+				return true
+			}
+		}
+	}
+	if len(p.cookies) == 0 {
+		return false
+	}
+	for _, line := range headers.Get("cookie") {
+		for _, pair := range strings.Split(line, ";") {
+			name, _, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			if p.cookies[name] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package nocacheauth
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, requestHeaders map[string][]string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		RequestHeaders:  policy.NewHeaders(requestHeaders),
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"cache-control": {"public, max-age=600"}}),
+		ResponseStatus:  200,
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"headers": "authorization"},
+		{"headers": []interface{}{""}},
+		{"headers": []interface{}{}},
+		{"cookies": []interface{}{1}},
+		{"cacheControl": " "},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestNoCacheAuthPolicy_AuthenticatedRequestGetsNoStore(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"cookies": []interface{}{"SESSION"}})
+
+	for _, headers := range []map[string][]string{
+		{"authorization": {"Bearer abc"}},
+		{"cookie": {"theme=dark; SESSION=xyz"}},
+	} {
+		mods := onResponse(p, headers)
+		if mods.SetHeaders["cache-control"] != "private, no-store" {
+			t.Errorf("Expected no-store for request headers %v, got %v", headers, mods.SetHeaders)
+		}
+		if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "expires" {
+			t.Errorf("Expected expires to be removed, got %v", mods.RemoveHeaders)
+		}
+	}
+
+	custom := policytest.New(t, GetPolicy, map[string]interface{}{"headers": []interface{}{"X-API-Key"}, "cacheControl": "no-store"})
+	if mods := onResponse(custom, map[string][]string{"x-api-key": {"k1"}}); mods.SetHeaders["cache-control"] != "no-store" {
+		t.Errorf("Expected custom cache-control, got %v", mods.SetHeaders)
+	}
+}
+
+func TestNoCacheAuthPolicy_AnonymousRequestUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"cookies": []interface{}{"SESSION"}})
+
+	for _, headers := range []map[string][]string{
+		nil,
+		{"authorization": {""}},
+		{"cookie": {"theme=dark; NOTSESSION=1"}},
+	} {
+		if mods := onResponse(p, headers); mods.SetHeaders != nil || mods.RemoveHeaders != nil {
+			t.Errorf("Expected request headers %v to be untouched, got %+v", headers, mods)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package nonceguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	defaultNonceHeader = "x-nonce"
+	defaultTTL         = 5 * time.Minute
+
+	// maxNonceLength bounds the size of stored nonces
+	maxNonceLength = 256
+
+	OnMissingPassthrough = "passthrough"
+	OnMissingReject      = "reject"
+)
+
+// NonceGuardPolicy rejects requests that reuse a nonce seen within the TTL
+type NonceGuardPolicy struct {
+	nonceHeader string
+	ttl         time.Duration
+	onMissing   string
+
+	mu        sync.Mutex
+	now       func() time.Time // Injectable clock (for testing)
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &NonceGuardPolicy{
+		nonceHeader: defaultNonceHeader,
+		ttl:         defaultTTL,
+		onMissing:   OnMissingReject,
+		now:         time.Now,
+		seen:        make(map[string]time.Time),
+	}
+
+	if raw, ok := params["nonceHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'nonceHeader' must be a non-empty string")
+		}
+		p.nonceHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["ttlSeconds"]; ok {
+		ttl, err := extractInt(raw)
+		if err != nil || ttl < 1 {
+			return nil, fmt.Errorf("'ttlSeconds' must be a positive integer")
+		}
+		p.ttl = time.Duration(ttl) * time.Second
+	}
+
+	if raw, ok := params["onMissingNonce"]; ok {
+		onMissing, ok := raw.(string)
+		if !ok || (onMissing != OnMissingPassthrough && onMissing != OnMissingReject) {
+			return nil, fmt.Errorf("'onMissingNonce' must be one of: passthrough, reject")
+		}
+		p.onMissing = onMissing
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *NonceGuardPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the nonce header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest records the request nonce and rejects it if it was already used within the TTL
+func (p *NonceGuardPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	nonce := ""
+	if values := ctx.Headers.Get(p.nonceHeader); len(values) > 0 {
+		nonce = strings.TrimSpace(values[0])
+	}
+	if nonce == "" {
+		if p.onMissing == OnMissingReject {
+			return errorResponse(http.StatusBadRequest, "Bad Request",
+				fmt.Sprintf("Required header '%s' is missing", p.nonceHeader))
+		}
+		return policy.UpstreamRequestModifications{}
+	}
+	if len(nonce) > maxNonceLength {
+		return errorResponse(http.StatusBadRequest, "Bad Request",
+			fmt.Sprintf("Header '%s' must not exceed %d characters", p.nonceHeader, maxNonceLength))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.sweep(now)
+
+	if usedAt, ok := p.seen[nonce]; ok && now.Sub(usedAt) < p.ttl {
+		slog.Debug("NonceGuard: Rejecting replayed nonce", "header", p.nonceHeader)
+		return errorResponse(http.StatusConflict, "Conflict", "Nonce has already been used")
+	}
+
+	p.seen[nonce] = now
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *NonceGuardPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// sweep evicts nonces older than the TTL. It runs at most once per TTL so the cost is amortized
+// across requests. Callers must hold p.mu.
+func (p *NonceGuardPolicy) sweep(now time.Time) {
+	if p.lastSweep.IsZero() {
+		p.lastSweep = now
+		return
+	}
+	if now.Sub(p.lastSweep) < p.ttl {
+		return
+	}
+	for nonce, usedAt := range p.seen {
+		if now.Sub(usedAt) >= p.ttl {
+			delete(p.seen, nonce)
+		}
+	}
+	p.lastSweep = now
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, title, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package nonceguard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) (*NonceGuardPolicy, *time.Time) {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	ng := p.(*NonceGuardPolicy)
+	now := time.Unix(1700000000, 0)
+	ng.now = func() time.Time { return now }
+	return ng, &now
+}
+
+func onRequest(p policy.Policy, nonce string) policy.RequestAction {
+	headers := map[string][]string{}
+	if nonce != "" {
+		headers["x-nonce"] = []string{nonce}
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"nonceHeader": " "},
+		{"ttlSeconds": -1},
+		{"ttlSeconds": "soon"},
+		{"onMissingNonce": "allow"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestNonceGuardPolicy_FirstUseAccepted(t *testing.T) {
+	p, _ := newPolicy(t, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "n-1"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "n-2"), 0)
+}
+
+func TestNonceGuardPolicy_ReplayRejectedWithinTTL(t *testing.T) {
+	p, now := newPolicy(t, map[string]interface{}{"ttlSeconds": 60})
+
+	policytest.ExpectStatus(t, onRequest(p, "n-1"), 0)
+	*now = now.Add(59 * time.Second)
+	policytest.ExpectStatus(t, onRequest(p, "n-1"), 409)
+}
+
+func TestNonceGuardPolicy_AcceptedAfterTTL(t *testing.T) {
+	p, now := newPolicy(t, map[string]interface{}{"ttlSeconds": 60})
+
+	policytest.ExpectStatus(t, onRequest(p, "n-1"), 0)
+	*now = now.Add(61 * time.Second)
+	policytest.ExpectStatus(t, onRequest(p, "n-2"), 0)
+	if _, ok := p.seen["n-1"]; ok {
+		t.Error("Expected expired nonce to be evicted")
+	}
+	policytest.ExpectStatus(t, onRequest(p, "n-1"), 0)
+}
+
+func TestNonceGuardPolicy_MissingOrOversizedNonce(t *testing.T) {
+	p, _ := newPolicy(t, map[string]interface{}{})
+	policytest.ExpectStatus(t, onRequest(p, ""), 400)
+	policytest.ExpectStatus(t, onRequest(p, strings.Repeat("n", 257)), 400)
+
+	p, _ = newPolicy(t, map[string]interface{}{"onMissingNonce": OnMissingPassthrough})
+	policytest.ExpectStatus(t, onRequest(p, ""), 0)
+}
@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package normalizeacceptencoding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const identity = "identity"
+
+// NormalizeAcceptEncodingPolicy collapses the request Accept-Encoding header to a single
+// canonical encoding to reduce cache key fragmentation
+type NormalizeAcceptEncodingPolicy struct {
+	encodings []string // Supported encodings in preference order
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &NormalizeAcceptEncodingPolicy{
+		encodings: []string{"gzip"},
+	}
+
+	if raw, ok := params["encodings"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'encodings' must be a non-empty array")
+		}
+		p.encodings = nil
+		for i, item := range list {
+			encoding, ok := item.(string)
+			if !ok || strings.TrimSpace(encoding) == "" {
+				return nil, fmt.Errorf("encodings[%d] must be a non-empty string", i)
+			}
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+			if encoding == "*" || encoding == identity {
+				return nil, fmt.Errorf("encodings[%d]: '%s' cannot be configured; identity is always the fallback", i, encoding)
+			}
+			p.encodings = append(p.encodings, encoding)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *NormalizeAcceptEncodingPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need and rewrite Accept-Encoding
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest replaces Accept-Encoding with the most preferred supported encoding the client accepts
+func (p *NormalizeAcceptEncodingPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get("accept-encoding")
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	canonical := p.normalize(parseAcceptEncoding(values))
+	if len(values) == 1 && strings.TrimSpace(values[0]) == canonical {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{
+			"accept-encoding": canonical,
+		},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *NormalizeAcceptEncodingPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// normalize picks the first configured encoding that the client accepts, or identity
+func (p *NormalizeAcceptEncodingPolicy) normalize(accepted map[string]float64) string {
+	wildcard, hasWildcard := accepted["*"]
+	for _, encoding := range p.encodings {
+		if q, ok := accepted[encoding]; ok {
+			if q > 0 {
+				return encoding
+			}
+			continue
+		}
+		if hasWildcard && wildcard > 0 {
+			return encoding
+		}
+	}
+	return identity
+}
+
+// parseAcceptEncoding returns the quality value for each listed coding (RFC 9110 section 12.5.3)
+func parseAcceptEncoding(values []string) map[string]float64 {
+	accepted := make(map[string]float64)
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			coding, paramsPart, _ := strings.Cut(item, ";")
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			if coding == "" {
+				continue
+			}
+			q := 1.0
+			for _, param := range strings.Split(paramsPart, ";") {
+				name, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if ok && strings.EqualFold(strings.TrimSpace(name), "q") {
+					if parsed, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+			// x-gzip is an alias for gzip
+			if coding == "x-gzip" {
+				coding = "gzip"
+			}
+			accepted[coding] = q
+		}
+	}
+	return accepted
+}
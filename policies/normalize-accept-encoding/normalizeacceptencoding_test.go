@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package normalizeacceptencoding
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, acceptEncoding ...string) policy.UpstreamRequestModifications {
+	headers := map[string][]string{}
+	if len(acceptEncoding) > 0 {
+		headers["accept-encoding"] = acceptEncoding
+	}
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"encodings": []interface{}{}},
+		{"encodings": []interface{}{""}},
+		{"encodings": []interface{}{"*"}},
+		{"encodings": []interface{}{"identity"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestNormalizeAcceptEncodingPolicy_BrowserHeader(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	mods := onRequest(p, "gzip, deflate, br, zstd")
+	if got := mods.SetHeaders["accept-encoding"]; got != "gzip" {
+		t.Errorf("Expected 'gzip', got %q", got)
+	}
+}
+
+func TestNormalizeAcceptEncodingPolicy_PreferenceOrder(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"encodings": []interface{}{"br", "gzip"}})
+
+	if got := onRequest(p, "gzip, deflate, br").SetHeaders["accept-encoding"]; got != "br" {
+		t.Errorf("Expected configured preference 'br', got %q", got)
+	}
+	if got := onRequest(p, "gzip;q=0.8, br;q=0").SetHeaders["accept-encoding"]; got != "gzip" {
+		t.Errorf("Expected 'gzip' when br is refused, got %q", got)
+	}
+	if got := onRequest(p, "*;q=0.5").SetHeaders["accept-encoding"]; got != "br" {
+		t.Errorf("Expected wildcard to accept 'br', got %q", got)
+	}
+}
+
+func TestNormalizeAcceptEncodingPolicy_FallbackToIdentity(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	if got := onRequest(p, "deflate, br").SetHeaders["accept-encoding"]; got != "identity" {
+		t.Errorf("Expected 'identity', got %q", got)
+	}
+	if got := onRequest(p, "gzip;q=0, *").SetHeaders["accept-encoding"]; got != "identity" {
+		t.Errorf("Expected explicitly refused gzip to fall back to 'identity', got %q", got)
+	}
+}
+
+func TestNormalizeAcceptEncodingPolicy_CanonicalUntouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	if mods := onRequest(p, "gzip"); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected canonical header to be untouched, got %v", mods.SetHeaders)
+	}
+	if mods := onRequest(p); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected absent header to stay absent, got %v", mods.SetHeaders)
+	}
+}
@@ -0,0 +1,362 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package normalizeforwarded
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Header the hop chain is read from
+	SourceXForwarded = "x-forwarded"
+	SourceForwarded  = "forwarded"
+)
+
+// NormalizeForwardedPolicy rebuilds Forwarded and X-Forwarded-* from a single source of truth,
+// appending the current hop and trimming entries the client prepended before the first
+// trusted proxy
+type NormalizeForwardedPolicy struct {
+	source       string
+	peerHeader   string
+	trustedCount int // Number of rightmost hops that are trusted proxies; -1 when unset
+	trustedCIDRs []netip.Prefix
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &NormalizeForwardedPolicy{
+		source:       SourceXForwarded,
+		trustedCount: -1,
+	}
+
+	if raw, ok := params["source"]; ok {
+		source, ok := raw.(string)
+		if !ok || (source != SourceXForwarded && source != SourceForwarded) {
+			return nil, fmt.Errorf("'source' must be one of %s, %s", SourceXForwarded, SourceForwarded)
+		}
+		p.source = source
+	}
+
+	if raw, ok := params["peerAddressHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'peerAddressHeader' must be a non-empty string")
+		}
+		p.peerHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	_, hasCount := params["trustedProxyCount"]
+	_, hasCIDRs := params["trustedProxyCidrs"]
+	if hasCount && hasCIDRs {
+		return nil, fmt.Errorf("'trustedProxyCount' and 'trustedProxyCidrs' cannot be configured together")
+	}
+	if hasCount {
+		count, err := extractInt(params["trustedProxyCount"])
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("'trustedProxyCount' must be a non-negative integer")
+		}
+		p.trustedCount = count
+	}
+	if hasCIDRs {
+		cidrs, ok := params["trustedProxyCidrs"].([]interface{})
+		if !ok || len(cidrs) == 0 {
+			return nil, fmt.Errorf("'trustedProxyCidrs' must be a non-empty array")
+		}
+		for i, raw := range cidrs {
+			cidr, _ := raw.(string)
+			prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+			if err != nil {
+				return nil, fmt.Errorf("trustedProxyCidrs[%d] must be a CIDR such as '10.0.0.0/8'", i)
+			}
+			p.trustedCIDRs = append(p.trustedCIDRs, prefix.Masked())
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *NormalizeForwardedPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Rewrites forwarding headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rewrites the forwarding headers into a consistent, trimmed set
+func (p *NormalizeForwardedPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	// hosts and protos hold what was recorded for each hop, or "" when unknown
+	var hops, hosts, protos []string
+	if p.source == SourceForwarded {
+		hops, hosts, protos = parseForwarded(ctx.Headers.Get("forwarded"))
+	} else {
+		hops = splitList(ctx.Headers.Get("x-forwarded-for"))
+		hosts = alignRight(splitList(ctx.Headers.Get("x-forwarded-host")), len(hops))
+		protos = alignRight(splitList(ctx.Headers.Get("x-forwarded-proto")), len(hops))
+	}
+
+	if p.peerHeader != "" {
+		if peer := firstEntry(ctx.Headers.Get(p.peerHeader)); peer != "" {
+			if len(hops) == 0 || !sameNode(hops[len(hops)-1], peer) {
+				hops = append(hops, peer)
+				hosts = append(hosts, "")
+				protos = append(protos, "")
+			}
+		}
+	}
+
+	// Host and proto come from the same hop as the client address, so entries the client
+	// prepended can't override them
+	var host, proto string
+	if len(hops) > 0 {
+		idx := p.clientIndex(hops)
+		host, proto = hosts[idx], strings.ToLower(protos[idx])
+		hops = hops[idx:]
+	}
+
+	if host == "" {
+		host = ctx.Authority
+	}
+	if proto == "" {
+		proto = strings.ToLower(ctx.Scheme)
+	}
+
+	mods := policy.UpstreamRequestModifications{SetHeaders: map[string]string{}}
+	if len(hops) > 0 {
+		xff := make([]string, len(hops))
+		for i, hop := range hops {
+			xff[i] = nodeAddress(hop)
+		}
+		mods.SetHeaders["x-forwarded-for"] = strings.Join(xff, ", ")
+		mods.SetHeaders["forwarded"] = formatForwarded(hops, host, proto)
+	} else {
+		mods.RemoveHeaders = []string{"x-forwarded-for", "forwarded"}
+	}
+	if host != "" {
+		mods.SetHeaders["x-forwarded-host"] = host
+	}
+	if proto != "" {
+		mods.SetHeaders["x-forwarded-proto"] = proto
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *NormalizeForwardedPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// clientIndex returns the index of the client hop. Hops are ordered client first; the
+// rightmost trusted proxies are skipped and whatever precedes the client was supplied by the
+// client itself and can't be trusted. Without a trust configuration the whole chain is kept.
+func (p *NormalizeForwardedPolicy) clientIndex(hops []string) int {
+	switch {
+	case p.trustedCount >= 0:
+		if idx := len(hops) - 1 - p.trustedCount; idx > 0 {
+			return idx
+		}
+		return 0
+	case len(p.trustedCIDRs) > 0:
+		for i := len(hops) - 1; i >= 0; i-- {
+			if !p.isTrusted(hops[i]) {
+				return i
+			}
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+// isTrusted reports whether a hop is an IP inside one of the trusted CIDRs
+func (p *NormalizeForwardedPolicy) isTrusted(hop string) bool {
+	addr, err := netip.ParseAddr(nodeAddress(hop))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p.trustedCIDRs {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseForwarded extracts the for= chain from RFC 7239 Forwarded values, along with the host
+// and proto recorded in the same element as each hop
+func parseForwarded(values []string) ([]string, []string, []string) {
+	var hops, hosts, protos []string
+	for _, value := range values {
+		for _, element := range splitQuoted(value, ',') {
+			var node, host, proto string
+			for _, pair := range splitQuoted(element, ';') {
+				name, val, ok := strings.Cut(pair, "=")
+				if !ok {
+					continue
+				}
+				val = strings.Trim(strings.TrimSpace(val), `"`)
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "for":
+					node = val
+				case "host":
+					host = val
+				case "proto":
+					proto = val
+				}
+			}
+			if node != "" {
+				hops = append(hops, node)
+				hosts = append(hosts, host)
+				protos = append(protos, proto)
+			}
+		}
+	}
+	return hops, hosts, protos
+}
+
+// alignRight pairs X-Forwarded-Host or X-Forwarded-Proto entries with n X-Forwarded-For hops.
+// Each proxy appends to all of them, so the lists line up from the right; hops without a
+// matching entry get "".
+func alignRight(entries []string, n int) []string {
+	aligned := make([]string, n)
+	offset := len(entries) - n
+	for i := range aligned {
+		if offset+i >= 0 {
+			aligned[i] = entries[offset+i]
+		}
+	}
+	return aligned
+}
+
+// formatForwarded renders the hop chain as a Forwarded header, attaching host and proto to the
+// client element
+func formatForwarded(hops []string, host, proto string) string {
+	elements := make([]string, len(hops))
+	for i, hop := range hops {
+		element := "for=" + forwardedNode(hop)
+		if i == 0 {
+			if host != "" {
+				element += ";host=" + quoteIfNeeded(host)
+			}
+			if proto != "" {
+				element += ";proto=" + proto
+			}
+		}
+		elements[i] = element
+	}
+	return strings.Join(elements, ", ")
+}
+
+// forwardedNode formats a node for Forwarded; IPv6 addresses and ports must be quoted
+func forwardedNode(hop string) string {
+	addr := nodeAddress(hop)
+	if ip, err := netip.ParseAddr(addr); err == nil && ip.Is6() && !ip.Is4In6() {
+		return `"[` + addr + `]"`
+	}
+	return quoteIfNeeded(addr)
+}
+
+func quoteIfNeeded(value string) string {
+	if strings.ContainsAny(value, `:[]" ,;=`) {
+		return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+	}
+	return value
+}
+
+// nodeAddress strips any port and IPv6 brackets from a node, leaving obfuscated identifiers
+// such as "unknown" or "_proxy1" unchanged
+func nodeAddress(hop string) string {
+	hop = strings.TrimSpace(hop)
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]")
+}
+
+// sameNode reports whether two nodes have the same address, ignoring ports
+func sameNode(a, b string) bool {
+	return strings.EqualFold(nodeAddress(a), nodeAddress(b))
+}
+
+// splitQuoted splits on sep outside double-quoted strings
+func splitQuoted(value string, sep byte) []string {
+	var parts []string
+	inQuotes, start := false, 0
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '"':
+			inQuotes = !inQuotes
+		case value[i] == sep && !inQuotes:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// splitList splits comma-separated header values into trimmed, non-empty entries
+func splitList(values []string) []string {
+	var entries []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// firstEntry returns the first entry of a possibly comma-separated header
+func firstEntry(values []string) string {
+	if entries := splitList(values); len(entries) > 0 {
+		return entries[0]
+	}
+	return ""
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
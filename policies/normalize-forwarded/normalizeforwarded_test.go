@@ -0,0 +1,137 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package normalizeforwarded
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, headers map[string][]string) map[string]string {
+	ctx := &policy.RequestContext{
+		Headers:   policy.NewHeaders(headers),
+		Authority: "api.example.com",
+		Scheme:    "https",
+	}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications).SetHeaders
+}
+
+func expectHeaders(t *testing.T, got map[string]string, expected map[string]string) {
+	t.Helper()
+	for name, value := range expected {
+		if got[name] != value {
+			t.Errorf("Expected %s %q, got %q", name, value, got[name])
+		}
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"source": "x-real-ip"},
+		{"peerAddressHeader": ""},
+		{"trustedProxyCount": -1},
+		{"trustedProxyCidrs": []interface{}{"10.0.0.0"}},
+		{"trustedProxyCount": 1, "trustedProxyCidrs": []interface{}{"10.0.0.0/8"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestNormalizeForwardedPolicy_AppendsHop(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"peerAddressHeader": "x-peer"})
+
+	got := onRequest(p, map[string][]string{
+		"x-forwarded-for": {"203.0.113.7"},
+		"x-peer":          {"10.0.0.5"},
+	})
+	expectHeaders(t, got, map[string]string{
+		"x-forwarded-for":   "203.0.113.7, 10.0.0.5",
+		"forwarded":         "for=203.0.113.7;host=api.example.com;proto=https, for=10.0.0.5",
+		"x-forwarded-host":  "api.example.com",
+		"x-forwarded-proto": "https",
+	})
+
+	// A hop already recorded by the proxy is not duplicated
+	got = onRequest(p, map[string][]string{"x-forwarded-for": {"203.0.113.7, 10.0.0.5"}, "x-peer": {"10.0.0.5"}})
+	expectHeaders(t, got, map[string]string{"x-forwarded-for": "203.0.113.7, 10.0.0.5"})
+}
+
+func TestNormalizeForwardedPolicy_TrimsSpoofedEntries(t *testing.T) {
+	spoofed := map[string][]string{"x-forwarded-for": {"1.1.1.1, 2.2.2.2", "203.0.113.7, 10.0.0.5, 10.0.0.6"}}
+
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"trustedProxyCount": 2})
+	expectHeaders(t, onRequest(p, spoofed), map[string]string{"x-forwarded-for": "203.0.113.7, 10.0.0.5, 10.0.0.6"})
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"trustedProxyCidrs": []interface{}{"10.0.0.0/24"}})
+	expectHeaders(t, onRequest(p, spoofed), map[string]string{"x-forwarded-for": "203.0.113.7, 10.0.0.5, 10.0.0.6"})
+
+	// A chain shorter than the trusted count is kept
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"trustedProxyCount": 5})
+	expectHeaders(t, onRequest(p, spoofed), map[string]string{"x-forwarded-for": "1.1.1.1, 2.2.2.2, 203.0.113.7, 10.0.0.5, 10.0.0.6"})
+}
+
+func TestNormalizeForwardedPolicy_ForwardedConsistency(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"source": "forwarded", "trustedProxyCount": 1})
+
+	got := onRequest(p, map[string][]string{
+		"forwarded":       {`for=198.51.100.1, for="[2001:db8::1]:4711";host=shop.example.com;proto=HTTP`, "for=10.0.0.5"},
+		"x-forwarded-for": {"6.6.6.6"},
+	})
+	expectHeaders(t, got, map[string]string{
+		"x-forwarded-for":   "2001:db8::1, 10.0.0.5",
+		"forwarded":         `for="[2001:db8::1]";host=shop.example.com;proto=http, for=10.0.0.5`,
+		"x-forwarded-host":  "shop.example.com",
+		"x-forwarded-proto": "http",
+	})
+
+	got = onRequest(p, map[string][]string{"forwarded": {`for=198.51.100.1;host=shop.example.com;proto=http`}})
+	expectHeaders(t, got, map[string]string{
+		"x-forwarded-for":   "198.51.100.1",
+		"forwarded":         "for=198.51.100.1;host=shop.example.com;proto=http",
+		"x-forwarded-host":  "shop.example.com",
+		"x-forwarded-proto": "http",
+	})
+}
+
+func TestNormalizeForwardedPolicy_HostAndProtoFromClientHop(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"trustedProxyCount": 1})
+
+	// The client prepended its own hop, host and proto; the proxy that saw it appended the rest
+	got := onRequest(p, map[string][]string{
+		"x-forwarded-for":   {"6.6.6.6, 203.0.113.7, 10.0.0.5"},
+		"x-forwarded-host":  {"evil.example", "shop.example.com, internal.example"},
+		"x-forwarded-proto": {"http, https, http"},
+	})
+	expectHeaders(t, got, map[string]string{
+		"x-forwarded-for":   "203.0.113.7, 10.0.0.5",
+		"forwarded":         "for=203.0.113.7;host=shop.example.com;proto=https, for=10.0.0.5",
+		"x-forwarded-host":  "shop.example.com",
+		"x-forwarded-proto": "https",
+	})
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"source": "forwarded", "trustedProxyCount": 1})
+	got = onRequest(p, map[string][]string{
+		"forwarded": {"for=6.6.6.6;host=evil.example;proto=http, for=203.0.113.7;proto=https, for=10.0.0.5;host=internal.example"},
+	})
+	expectHeaders(t, got, map[string]string{
+		"forwarded":         "for=203.0.113.7;host=api.example.com;proto=https, for=10.0.0.5",
+		"x-forwarded-host":  "api.example.com",
+		"x-forwarded-proto": "https",
+	})
+}
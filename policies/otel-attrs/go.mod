@@ -0,0 +1,13 @@
+module github.com/wso2/gateway-controllers/policies/otel-attrs
+
+go 1.25.1
+
+require (
+	github.com/wso2/api-platform/sdk v0.3.1
+	github.com/wso2/gateway-controllers/policies/trace-context v0.1.0
+	github.com/wso2/gateway-controllers/utils v0.1.0
+)
+
+replace github.com/wso2/gateway-controllers/policies/trace-context => ../trace-context
+
+replace github.com/wso2/gateway-controllers/utils => ../../utils
@@ -0,0 +1,252 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package otelattrs
+
+import (
+	"fmt"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	tracecontext "github.com/wso2/gateway-controllers/policies/trace-context"
+)
+
+const (
+	// Supported attributes, named after the OpenTelemetry semantic conventions
+	AttrHTTPMethod    = "http.request.method"
+	AttrHTTPRoute     = "http.route"
+	AttrURLPath       = "url.path"
+	AttrURLScheme     = "url.scheme"
+	AttrServerAddress = "server.address"
+	AttrTraceID       = "trace_id"
+	AttrSpanID        = "span_id"
+)
+
+// attributeHeaders maps each attribute to the header suffix it is emitted under
+var attributeHeaders = map[string]string{
+	AttrHTTPMethod:    "http-method",
+	AttrHTTPRoute:     "http-route",
+	AttrURLPath:       "url-path",
+	AttrURLScheme:     "url-scheme",
+	AttrServerAddress: "server-address",
+	AttrTraceID:       "trace-id",
+	AttrSpanID:        "span-id",
+}
+
+// OtelAttrsPolicy sets request headers carrying OpenTelemetry span attributes derived from the
+// request, so downstream collectors can enrich traces
+type OtelAttrsPolicy struct {
+	attributes   []string
+	headerPrefix string
+	routes       [][]string // route templates split into segments
+	normalizeIDs bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &OtelAttrsPolicy{
+		attributes:   []string{AttrHTTPMethod, AttrHTTPRoute},
+		headerPrefix: "x-otel-",
+		normalizeIDs: true,
+	}
+
+	if raw, ok := params["attributes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'attributes' must be a non-empty array")
+		}
+		p.attributes = nil
+		seen := make(map[string]bool, len(list))
+		for i, item := range list {
+			attribute, _ := item.(string)
+			if _, ok := attributeHeaders[attribute]; !ok {
+				return nil, fmt.Errorf("attributes[%d] must be one of %s, %s, %s, %s, %s, %s, %s", i,
+					AttrHTTPMethod, AttrHTTPRoute, AttrURLPath, AttrURLScheme, AttrServerAddress, AttrTraceID, AttrSpanID)
+			}
+			if !seen[attribute] {
+				seen[attribute] = true
+				p.attributes = append(p.attributes, attribute)
+			}
+		}
+	}
+
+	if raw, ok := params["headerPrefix"]; ok {
+		prefix, ok := raw.(string)
+		if !ok || strings.TrimSpace(prefix) == "" {
+			return nil, fmt.Errorf("'headerPrefix' must be a non-empty string")
+		}
+		p.headerPrefix = strings.ToLower(strings.TrimSpace(prefix))
+	}
+
+	if raw, ok := params["routes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'routes' must be an array")
+		}
+		for i, item := range list {
+			template, ok := item.(string)
+			if !ok || !strings.HasPrefix(template, "/") {
+				return nil, fmt.Errorf("routes[%d] must be a route template starting with '/'", i)
+			}
+			p.routes = append(p.routes, splitPath(template))
+		}
+	}
+
+	if raw, ok := params["normalizeIds"]; ok {
+		normalizeIDs, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'normalizeIds' must be a boolean")
+		}
+		p.normalizeIDs = normalizeIDs
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *OtelAttrsPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request line and traceparent, sets attribute headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sets a header for each configured attribute. Attribute headers sent by the client
+// are replaced, or removed when the attribute has no value for the request.
+func (p *OtelAttrsPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, _, _ := strings.Cut(ctx.Path, "?")
+	if reqPath == "" {
+		reqPath = "/"
+	}
+
+	var traceID, spanID string
+	if values := ctx.Headers.Get(tracecontext.TraceparentHeader); len(values) == 1 && tracecontext.IsValidTraceparent(values[0]) {
+		parts := strings.Split(strings.TrimSpace(values[0]), "-")
+		traceID, spanID = parts[1], parts[2]
+	}
+
+	mods := policy.UpstreamRequestModifications{SetHeaders: make(map[string]string, len(p.attributes))}
+	for _, attribute := range p.attributes {
+		var value string
+		switch attribute {
+		case AttrHTTPMethod:
+			value = strings.ToUpper(ctx.Method)
+		case AttrHTTPRoute:
+			value = p.route(reqPath)
+		case AttrURLPath:
+			value = reqPath
+		case AttrURLScheme:
+			value = strings.ToLower(ctx.Scheme)
+		case AttrServerAddress:
+			value = strings.ToLower(ctx.Authority)
+		case AttrTraceID:
+			value = traceID
+		case AttrSpanID:
+			value = spanID
+		}
+
+		header := p.headerPrefix + attributeHeaders[attribute]
+		if value != "" {
+			mods.SetHeaders[header] = value
+		} else if ctx.Headers.Has(header) {
+			mods.RemoveHeaders = append(mods.RemoveHeaders, header)
+		}
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *OtelAttrsPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// route returns the low-cardinality route for a path: the first configured template that
+// matches it, otherwise the path with ID-like segments replaced by "{id}" when normalizeIds is
+// enabled, otherwise the path itself
+func (p *OtelAttrsPolicy) route(reqPath string) string {
+	segments := splitPath(reqPath)
+	for _, template := range p.routes {
+		if matchTemplate(template, segments) {
+			return "/" + strings.Join(template, "/")
+		}
+	}
+	if !p.normalizeIDs {
+		return reqPath
+	}
+	normalized := make([]string, len(segments))
+	for i, segment := range segments {
+		if isID(segment) {
+			normalized[i] = "{id}"
+		} else {
+			normalized[i] = segment
+		}
+	}
+	return "/" + strings.Join(normalized, "/")
+}
+
+// matchTemplate reports whether path segments match a template, where a "{name}" segment
+// matches any single segment
+func matchTemplate(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, segment := range template {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitPath splits a path into its non-empty segments
+func splitPath(p string) []string {
+	var segments []string
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// isID reports whether a path segment looks like an identifier: a decimal number, a UUID or a
+// hex string of at least 16 characters
+func isID(segment string) bool {
+	if strings.Trim(segment, "0123456789") == "" {
+		return true
+	}
+	if len(segment) == 36 && segment[8] == '-' && segment[13] == '-' && segment[18] == '-' && segment[23] == '-' {
+		return isHex(strings.ReplaceAll(segment, "-", ""))
+	}
+	return len(segment) >= 16 && isHex(segment)
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
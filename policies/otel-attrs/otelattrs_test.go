@@ -0,0 +1,108 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package otelattrs
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, params map[string]interface{}, method, path string, headers map[string][]string) policy.UpstreamRequestModifications {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	ctx := &policy.RequestContext{
+		Headers:   policy.NewHeaders(headers),
+		Method:    method,
+		Path:      path,
+		Scheme:    "https",
+		Authority: "API.example.com",
+	}
+	mods, _ := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+	return mods
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"attributes": []interface{}{}},
+		{"attributes": []interface{}{"http.status_code"}},
+		{"headerPrefix": ""},
+		{"routes": []interface{}{"users/{id}"}},
+		{"normalizeIds": "yes"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestOtelAttrsPolicy_DerivesAttributes(t *testing.T) {
+	params := map[string]interface{}{
+		"attributes": []interface{}{"http.request.method", "url.path", "url.scheme", "server.address", "trace_id", "span_id"},
+	}
+	mods := onRequest(t, params, "get", "/users/42?expand=true", map[string][]string{
+		"traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	})
+
+	want := map[string]string{
+		"x-otel-http-method":    "GET",
+		"x-otel-url-path":       "/users/42",
+		"x-otel-url-scheme":     "https",
+		"x-otel-server-address": "api.example.com",
+		"x-otel-trace-id":       "4bf92f3577b34da6a3ce929d0e0e4736",
+		"x-otel-span-id":        "00f067aa0ba902b7",
+	}
+	for name, value := range want {
+		if mods.SetHeaders[name] != value {
+			t.Errorf("Expected %s %q, got %q", name, value, mods.SetHeaders[name])
+		}
+	}
+
+	// Without a valid traceparent, spoofed trace headers are removed
+	mods = onRequest(t, params, "GET", "/", map[string][]string{
+		"traceparent":     {"invalid"},
+		"x-otel-trace-id": {"spoofed"},
+	})
+	if _, ok := mods.SetHeaders["x-otel-trace-id"]; ok || len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-otel-trace-id" {
+		t.Errorf("Expected spoofed trace id to be removed, got %+v", mods)
+	}
+}
+
+func TestOtelAttrsPolicy_RouteNormalization(t *testing.T) {
+	params := map[string]interface{}{
+		"routes": []interface{}{"/users/{userId}/orders/{orderId}"},
+	}
+	cases := map[string]string{
+		"/users/42":                "/users/{id}",
+		"/users/42/":               "/users/{id}",
+		"/users/alice/orders/A-17": "/users/{userId}/orders/{orderId}",
+		"/items/3f2b8c1e-9d4a-4e6b-8a7f-1c2d3e4f5a6b": "/items/{id}",
+		"/blobs/deadbeefcafef00d1234":                 "/blobs/{id}",
+		"/users/me":                                   "/users/me",
+		"/":                                           "/",
+	}
+	for path, want := range cases {
+		mods := onRequest(t, params, "GET", path, nil)
+		if got := mods.SetHeaders["x-otel-http-route"]; got != want {
+			t.Errorf("Expected route %q for %s, got %q", want, path, got)
+		}
+	}
+
+	mods := onRequest(t, map[string]interface{}{"normalizeIds": false}, "GET", "/users/42", nil)
+	if got := mods.SetHeaders["x-otel-http-route"]; got != "/users/42" {
+		t.Errorf("Expected raw path without normalization, got %q", got)
+	}
+}
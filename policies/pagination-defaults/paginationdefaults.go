@@ -0,0 +1,201 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package paginationdefaults
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// PaginationDefaultsPolicy ensures page and limit query parameters are present and bounded
+type PaginationDefaultsPolicy struct {
+	pageParam    string
+	limitParam   string
+	defaultPage  int
+	defaultLimit int
+	maxLimit     int
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &PaginationDefaultsPolicy{
+		pageParam:    "page",
+		limitParam:   "limit",
+		defaultPage:  1,
+		defaultLimit: 20,
+		maxLimit:     100,
+	}
+
+	var err error
+	if raw, ok := params["pageParam"]; ok {
+		if p.pageParam, err = parseName(raw, "pageParam"); err != nil {
+			return nil, err
+		}
+	}
+	if raw, ok := params["limitParam"]; ok {
+		if p.limitParam, err = parseName(raw, "limitParam"); err != nil {
+			return nil, err
+		}
+	}
+	if p.pageParam == p.limitParam {
+		return nil, fmt.Errorf("'pageParam' and 'limitParam' must be different")
+	}
+
+	if raw, ok := params["defaultPage"]; ok {
+		if p.defaultPage, err = extractInt(raw); err != nil || p.defaultPage < 0 {
+			return nil, fmt.Errorf("'defaultPage' must be a non-negative integer")
+		}
+	}
+	if raw, ok := params["maxLimit"]; ok {
+		if p.maxLimit, err = extractInt(raw); err != nil || p.maxLimit < 1 {
+			return nil, fmt.Errorf("'maxLimit' must be a positive integer")
+		}
+	}
+	if raw, ok := params["defaultLimit"]; ok {
+		if p.defaultLimit, err = extractInt(raw); err != nil || p.defaultLimit < 1 {
+			return nil, fmt.Errorf("'defaultLimit' must be a positive integer")
+		}
+	} else if p.defaultLimit > p.maxLimit {
+		p.defaultLimit = p.maxLimit
+	}
+	if p.defaultLimit > p.maxLimit {
+		return nil, fmt.Errorf("'defaultLimit' cannot exceed 'maxLimit'")
+	}
+
+	return p, nil
+}
+
+// parseName validates a query parameter name
+func parseName(raw interface{}, param string) (string, error) {
+	name, ok := raw.(string)
+	if !ok || strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("'%s' must be a non-empty string", param)
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *PaginationDefaultsPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Rewrites request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest adds missing pagination parameters, replaces invalid ones and clamps the limit
+func (p *PaginationDefaultsPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, query, _ := strings.Cut(ctx.Path, "?")
+
+	var pairs []string
+	seenPage, seenLimit := false, false
+	if query != "" {
+		for _, pair := range strings.Split(query, "&") {
+			if pair == "" {
+				continue
+			}
+			rawKey, rawValue, _ := strings.Cut(pair, "=")
+			key, err := url.QueryUnescape(rawKey)
+			if err != nil {
+				key = rawKey
+			}
+			switch key {
+			case p.pageParam:
+				// Repeated parameters are collapsed to the first so the upstream sees one value
+				if seenPage {
+					continue
+				}
+				seenPage = true
+				page, ok := parseValue(rawValue)
+				if !ok || page < 0 {
+					page = p.defaultPage
+				}
+				pair = rawKey + "=" + strconv.Itoa(page)
+			case p.limitParam:
+				if seenLimit {
+					continue
+				}
+				seenLimit = true
+				limit, ok := parseValue(rawValue)
+				if !ok || limit < 1 {
+					limit = p.defaultLimit
+				}
+				pair = rawKey + "=" + strconv.Itoa(min(limit, p.maxLimit))
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+
+	if !seenPage {
+		pairs = append(pairs, url.QueryEscape(p.pageParam)+"="+strconv.Itoa(p.defaultPage))
+	}
+	if !seenLimit {
+		pairs = append(pairs, url.QueryEscape(p.limitParam)+"="+strconv.Itoa(p.defaultLimit))
+	}
+
+	newPath := reqPath + "?" + strings.Join(pairs, "&")
+	if newPath == ctx.Path {
+		return policy.UpstreamRequestModifications{}
+	}
+	return policy.UpstreamRequestModifications{Path: &newPath}
+}
+
+// OnResponse is not used by this policy
+func (p *PaginationDefaultsPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// parseValue parses a raw query value as a decimal integer
+func parseValue(rawValue string) (int, bool) {
+	value, err := url.QueryUnescape(rawValue)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package paginationdefaults
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, path string) *string {
+	ctx := &policy.RequestContext{Path: path}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications).Path
+}
+
+func expectPath(t *testing.T, got *string, expected string) {
+	t.Helper()
+	if got == nil || *got != expected {
+		t.Errorf("Expected path %q, got %v", expected, got)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"pageParam": ""},
+		{"pageParam": "size", "limitParam": "size"},
+		{"defaultPage": -1},
+		{"defaultLimit": 0},
+		{"maxLimit": 0},
+		{"defaultLimit": 50, "maxLimit": 10},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestPaginationDefaultsPolicy_DefaultsApplied(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	expectPath(t, onRequest(p, "/orders"), "/orders?page=1&limit=20")
+	expectPath(t, onRequest(p, "/orders?status=open"), "/orders?status=open&page=1&limit=20")
+
+	// A default larger than a configured maximum is capped
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"maxLimit": 10, "pageParam": "offset", "defaultPage": 0})
+	expectPath(t, onRequest(p, "/orders"), "/orders?offset=0&limit=10")
+}
+
+func TestPaginationDefaultsPolicy_OverMaxClamped(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxLimit": float64(50), "limitParam": "per_page"})
+
+	expectPath(t, onRequest(p, "/orders?per_page=100000&page=3"), "/orders?per_page=50&page=3")
+	// Invalid and repeated values are normalized
+	expectPath(t, onRequest(p, "/orders?page=abc&per_page=-5&page=9"), "/orders?page=1&per_page=20")
+}
+
+func TestPaginationDefaultsPolicy_ValidValuesPreserved(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	if got := onRequest(p, "/orders?page=4&q=a%20b&limit=100"); got != nil {
+		t.Errorf("Expected valid values to be preserved, got %q", *got)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathallowlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/pathmatch"
+)
+
+// PathAllowlistPolicy rejects requests whose path doesn't match any allowed pattern
+type PathAllowlistPolicy struct {
+	patterns     []*regexp.Regexp
+	rejectStatus int
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	patternsRaw, ok := params["allowedPatterns"].([]interface{})
+	if !ok || len(patternsRaw) == 0 {
+		return nil, fmt.Errorf("'allowedPatterns' parameter is required and must be a non-empty array")
+	}
+
+	p := &PathAllowlistPolicy{rejectStatus: http.StatusNotFound}
+	for i, raw := range patternsRaw {
+		pattern, ok := raw.(string)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("allowedPatterns[%d] must be a non-empty string", i)
+		}
+		// Patterns must match the whole path so "/public" doesn't admit "/public-admin"
+		regex, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("allowedPatterns[%d] is not a valid regex: %w", i, err)
+		}
+		p.patterns = append(p.patterns, regex)
+	}
+
+	if raw, ok := params["rejectStatus"]; ok {
+		status, err := extractInt(raw)
+		if err != nil || status < 400 || status > 499 {
+			return nil, fmt.Errorf("'rejectStatus' must be a 4xx status code")
+		}
+		p.rejectStatus = status
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *PathAllowlistPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects the request unless its path matches one of the allowed patterns
+func (p *PathAllowlistPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, ok := pathmatch.Normalize(ctx.Path)
+	if !ok {
+		slog.Debug("PathAllowlist: Rejecting unnormalizable path", "path", ctx.Path)
+		return errorResponse(http.StatusBadRequest, "The request path contains encoded slashes or invalid escapes")
+	}
+	for _, pattern := range p.patterns {
+		if pattern.MatchString(reqPath) {
+			return policy.UpstreamRequestModifications{}
+		}
+	}
+
+	slog.Debug("PathAllowlist: Rejecting path outside the allow-list", "path", reqPath)
+	return errorResponse(p.rejectStatus, "The requested path is not available")
+}
+
+// OnResponse is not used by this policy
+func (p *PathAllowlistPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
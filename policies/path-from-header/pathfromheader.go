@@ -0,0 +1,206 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathfromheader
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	PositionPrefix   = "prefix"
+	PositionSuffix   = "suffix"
+	PositionTemplate = "template"
+
+	OnMissingPassthrough = "passthrough"
+	OnMissingReject      = "reject"
+
+	PlaceholderValue = "{value}"
+	PlaceholderPath  = "{path}"
+)
+
+// PathFromHeaderPolicy rewrites the upstream request path using the value of a request header
+type PathFromHeaderPolicy struct {
+	headerName      string
+	position        string
+	template        string
+	onMissingHeader string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &PathFromHeaderPolicy{
+		position:        PositionPrefix,
+		onMissingHeader: OnMissingPassthrough,
+	}
+
+	headerName, ok := params["headerName"].(string)
+	if !ok || strings.TrimSpace(headerName) == "" {
+		return nil, fmt.Errorf("'headerName' parameter is required and must be a non-empty string")
+	}
+	p.headerName = strings.ToLower(strings.TrimSpace(headerName))
+
+	if positionRaw, ok := params["position"]; ok {
+		position, ok := positionRaw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'position' must be a string")
+		}
+		switch position {
+		case PositionPrefix, PositionSuffix, PositionTemplate:
+			p.position = position
+		default:
+			return nil, fmt.Errorf("'position' must be one of: prefix, suffix, template")
+		}
+	}
+
+	if p.position == PositionTemplate {
+		template, ok := params["template"].(string)
+		if !ok || template == "" {
+			return nil, fmt.Errorf("'template' parameter is required when position is 'template'")
+		}
+		if !strings.Contains(template, PlaceholderValue) {
+			return nil, fmt.Errorf("'template' must contain the %s placeholder", PlaceholderValue)
+		}
+		if !strings.HasPrefix(template, "/") {
+			return nil, fmt.Errorf("'template' must start with '/'")
+		}
+		p.template = template
+	}
+
+	if onMissingRaw, ok := params["onMissingHeader"]; ok {
+		onMissing, ok := onMissingRaw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'onMissingHeader' must be a string")
+		}
+		switch onMissing {
+		case OnMissingPassthrough, OnMissingReject:
+			p.onMissingHeader = onMissing
+		default:
+			return nil, fmt.Errorf("'onMissingHeader' must be one of: passthrough, reject")
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *PathFromHeaderPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers to read the value
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rewrites the request path using the configured header value
+func (p *PathFromHeaderPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	value := ""
+	if values := ctx.Headers.Get(p.headerName); len(values) > 0 {
+		value = strings.TrimSpace(values[0])
+	}
+
+	if value == "" {
+		if p.onMissingHeader == OnMissingReject {
+			slog.Debug("PathFromHeader: Required header missing", "header", p.headerName)
+			return p.badRequest(fmt.Sprintf("Required header '%s' is missing", p.headerName))
+		}
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Escape the value so that it always forms exactly one path segment
+	segment := url.PathEscape(value)
+	if segment == "." || segment == ".." {
+		return p.badRequest(fmt.Sprintf("Header '%s' contains an invalid path segment", p.headerName))
+	}
+
+	path, query := splitPathAndQuery(ctx.Path)
+	newPath := p.buildPath(path, segment)
+	if query != "" {
+		newPath = newPath + "?" + query
+	}
+
+	slog.Debug("PathFromHeader: Rewriting path", "original", ctx.Path, "rewritten", newPath)
+
+	return policy.UpstreamRequestModifications{
+		Path: &newPath,
+	}
+}
+
+// buildPath constructs the rewritten path (without the query string)
+func (p *PathFromHeaderPolicy) buildPath(path, segment string) string {
+	switch p.position {
+	case PositionSuffix:
+		return strings.TrimSuffix(path, "/") + "/" + segment
+	case PositionTemplate:
+		result := strings.ReplaceAll(p.template, PlaceholderValue, segment)
+		result = strings.ReplaceAll(result, PlaceholderPath, strings.TrimPrefix(path, "/"))
+		return collapseSlashes(result)
+	default:
+		if path == "/" {
+			return "/" + segment
+		}
+		return "/" + segment + path
+	}
+}
+
+// splitPathAndQuery separates the path from the query string
+func splitPathAndQuery(fullPath string) (string, string) {
+	path, query, _ := strings.Cut(fullPath, "?")
+	if path == "" {
+		path = "/"
+	} else if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path, query
+}
+
+// collapseSlashes replaces repeated slashes with a single slash
+func collapseSlashes(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return path
+}
+
+// badRequest builds a 400 Bad Request response
+func (p *PathFromHeaderPolicy) badRequest(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Bad Request",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: 400,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *PathFromHeaderPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathfromheader
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// Helper function to create test headers
+func createTestHeaders(headers map[string]string) *policy.Headers {
+	headerMap := make(map[string][]string)
+	for k, v := range headers {
+		headerMap[k] = []string{v}
+	}
+	return policy.NewHeaders(headerMap)
+}
+
+func rewrittenPath(t *testing.T, result policy.RequestAction) string {
+	t.Helper()
+	mods, ok := result.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamRequestModifications, got %T", result)
+	}
+	if mods.Path == nil {
+		t.Fatal("Expected path to be rewritten, got nil")
+	}
+	return *mods.Path
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"headerName": "x-tenant", "position": "middle"},
+		{"headerName": "x-tenant", "position": "template"},
+		{"headerName": "x-tenant", "position": "template", "template": "/tenants/{path}"},
+		{"headerName": "x-tenant", "onMissingHeader": "ignore"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestPathFromHeaderPolicy_PrefixInjection(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headerName": "X-Tenant"})
+	ctx := &policy.RequestContext{
+		Headers: createTestHeaders(map[string]string{"x-tenant": "acme"}),
+		Path:    "/api/users?page=2",
+	}
+
+	if got := rewrittenPath(t, p.OnRequest(ctx, nil)); got != "/acme/api/users?page=2" {
+		t.Errorf("Expected '/acme/api/users?page=2', got %q", got)
+	}
+}
+
+func TestPathFromHeaderPolicy_SuffixInjection(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headerName": "x-tenant", "position": "suffix"})
+	ctx := &policy.RequestContext{
+		Headers: createTestHeaders(map[string]string{"x-tenant": "acme"}),
+		Path:    "/api/users/",
+	}
+
+	if got := rewrittenPath(t, p.OnRequest(ctx, nil)); got != "/api/users/acme" {
+		t.Errorf("Expected '/api/users/acme', got %q", got)
+	}
+}
+
+func TestPathFromHeaderPolicy_TemplatedPath(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"headerName": "x-tenant",
+		"position":   "template",
+		"template":   "/tenants/{value}/{path}",
+	})
+	ctx := &policy.RequestContext{
+		Headers: createTestHeaders(map[string]string{"x-tenant": "acme"}),
+		Path:    "/api/users?active=true",
+	}
+
+	if got := rewrittenPath(t, p.OnRequest(ctx, nil)); got != "/tenants/acme/api/users?active=true" {
+		t.Errorf("Expected '/tenants/acme/api/users?active=true', got %q", got)
+	}
+}
+
+func TestPathFromHeaderPolicy_ValueIsEscaped(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headerName": "x-tenant"})
+	ctx := &policy.RequestContext{
+		Headers: createTestHeaders(map[string]string{"x-tenant": "../admin"}),
+		Path:    "/api/users",
+	}
+
+	if got := rewrittenPath(t, p.OnRequest(ctx, nil)); got != "/..%2Fadmin/api/users" {
+		t.Errorf("Expected header value to be escaped into a single segment, got %q", got)
+	}
+
+	ctx.Headers = createTestHeaders(map[string]string{"x-tenant": ".."})
+	if resp, ok := p.OnRequest(ctx, nil).(policy.ImmediateResponse); !ok || resp.StatusCode != 400 {
+		t.Errorf("Expected 400 for dot-dot segment, got %+v", resp)
+	}
+}
+
+func TestPathFromHeaderPolicy_MissingHeaderPassthrough(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headerName": "x-tenant"})
+	ctx := &policy.RequestContext{
+		Headers: createTestHeaders(map[string]string{}),
+		Path:    "/api/users",
+	}
+
+	mods, ok := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatal("Expected UpstreamRequestModifications")
+	}
+	if mods.Path != nil {
+		t.Errorf("Expected path to be unchanged, got %q", *mods.Path)
+	}
+}
+
+func TestPathFromHeaderPolicy_MissingHeaderReject(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headerName": "x-tenant", "onMissingHeader": "reject"})
+	ctx := &policy.RequestContext{
+		Headers: createTestHeaders(map[string]string{}),
+		Path:    "/api/users",
+	}
+
+	resp, ok := p.OnRequest(ctx, nil).(policy.ImmediateResponse)
+	if !ok {
+		t.Fatal("Expected ImmediateResponse")
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathprefix
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	OnMissingPassthrough = "passthrough"
+	OnMissingReject      = "reject"
+)
+
+// PathPrefixPolicy strips and/or adds a leading path prefix before forwarding the request
+type PathPrefixPolicy struct {
+	stripPrefix     string
+	addPrefix       string
+	onPrefixMissing string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &PathPrefixPolicy{
+		onPrefixMissing: OnMissingPassthrough,
+	}
+
+	var err error
+	if p.stripPrefix, err = parsePrefix(params, "stripPrefix"); err != nil {
+		return nil, err
+	}
+	if p.addPrefix, err = parsePrefix(params, "addPrefix"); err != nil {
+		return nil, err
+	}
+	if p.stripPrefix == "" && p.addPrefix == "" {
+		return nil, fmt.Errorf("at least one of 'stripPrefix' or 'addPrefix' must be set")
+	}
+
+	if raw, ok := params["onPrefixMissing"]; ok {
+		onMissing, ok := raw.(string)
+		if !ok || (onMissing != OnMissingPassthrough && onMissing != OnMissingReject) {
+			return nil, fmt.Errorf("'onPrefixMissing' must be one of: passthrough, reject")
+		}
+		p.onPrefixMissing = onMissing
+	}
+
+	return p, nil
+}
+
+// parsePrefix reads an optional prefix parameter and normalizes it to "/segment[/segment...]"
+// without a trailing slash. A prefix of "/" or "" is treated as unset.
+func parsePrefix(params map[string]interface{}, name string) (string, error) {
+	raw, ok := params[name]
+	if !ok {
+		return "", nil
+	}
+	prefix, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("'%s' must be a string", name)
+	}
+	if strings.ContainsAny(prefix, "?#") {
+		return "", fmt.Errorf("'%s' must not contain a query string or fragment", name)
+	}
+	prefix = strings.Trim(collapseSlashes(strings.TrimSpace(prefix)), "/")
+	if prefix == "" {
+		return "", nil
+	}
+	for _, segment := range strings.Split(prefix, "/") {
+		if segment == "." || segment == ".." {
+			return "", fmt.Errorf("'%s' must not contain '.' or '..' segments", name)
+		}
+	}
+	return "/" + prefix, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *PathPrefixPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rewrites the request path by stripping and then adding the configured prefixes
+func (p *PathPrefixPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	path, query, hasQuery := strings.Cut(ctx.Path, "?")
+	path = collapseSlashes("/" + path)
+
+	if p.stripPrefix != "" {
+		stripped, ok := strip(path, p.stripPrefix)
+		if !ok {
+			if p.onPrefixMissing == OnMissingReject {
+				slog.Debug("PathPrefix: Required prefix missing", "path", path, "prefix", p.stripPrefix)
+				return notFound()
+			}
+			if p.addPrefix == "" {
+				return policy.UpstreamRequestModifications{}
+			}
+		} else {
+			path = stripped
+		}
+	}
+
+	if p.addPrefix != "" {
+		if path == "/" {
+			path = p.addPrefix
+		} else {
+			path = p.addPrefix + path
+		}
+	}
+
+	if hasQuery {
+		path = path + "?" + query
+	}
+	if path == ctx.Path {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("PathPrefix: Rewriting path", "original", ctx.Path, "rewritten", path)
+	return policy.UpstreamRequestModifications{
+		Path: &path,
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *PathPrefixPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// strip removes prefix from path when it matches on a segment boundary, so "/gateway" strips
+// "/gateway/users" but not "/gateways/users"
+func strip(path, prefix string) (string, bool) {
+	if path == prefix {
+		return "/", true
+	}
+	if strings.HasPrefix(path, prefix+"/") {
+		return path[len(prefix):], true
+	}
+	return "", false
+}
+
+// collapseSlashes replaces repeated slashes with a single slash
+func collapseSlashes(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return path
+}
+
+// notFound builds a 404 Not Found response
+func notFound() policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Not Found",
+		"message": "The requested path was not found",
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusNotFound,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
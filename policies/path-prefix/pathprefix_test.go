@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathprefix
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, path string) policy.RequestAction {
+	return p.OnRequest(&policy.RequestContext{Path: path}, nil)
+}
+
+func rewrittenPath(t *testing.T, result policy.RequestAction) string {
+	t.Helper()
+	mods, ok := result.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamRequestModifications, got %T", result)
+	}
+	if mods.Path == nil {
+		return ""
+	}
+	return *mods.Path
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"stripPrefix": "/"},
+		{"stripPrefix": 1},
+		{"addPrefix": "/v1?x=1"},
+		{"addPrefix": "/../admin"},
+		{"stripPrefix": "/gateway", "onPrefixMissing": "ignore"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestPathPrefixPolicy_Strip(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"stripPrefix": "/gateway/"})
+
+	tests := map[string]string{
+		"/gateway/users?page=2": "/users?page=2",
+		"/gateway":              "/",
+		"//gateway//users":      "/users",
+	}
+	for in, want := range tests {
+		if got := rewrittenPath(t, onRequest(p, in)); got != want {
+			t.Errorf("Expected %q for %q, got %q", want, in, got)
+		}
+	}
+}
+
+func TestPathPrefixPolicy_Add(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"addPrefix": "v1"})
+
+	if got := rewrittenPath(t, onRequest(p, "/users?id=1")); got != "/v1/users?id=1" {
+		t.Errorf("Expected '/v1/users?id=1', got %q", got)
+	}
+	if got := rewrittenPath(t, onRequest(p, "/")); got != "/v1" {
+		t.Errorf("Expected '/v1', got %q", got)
+	}
+}
+
+func TestPathPrefixPolicy_StripAndAdd(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"stripPrefix": "/gateway", "addPrefix": "/internal"})
+
+	if got := rewrittenPath(t, onRequest(p, "/gateway/users")); got != "/internal/users" {
+		t.Errorf("Expected '/internal/users', got %q", got)
+	}
+}
+
+func TestPathPrefixPolicy_PrefixNotPresent(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"stripPrefix": "/gateway"})
+
+	// Prefixes match on segment boundaries only
+	if got := rewrittenPath(t, onRequest(p, "/gateways/users")); got != "" {
+		t.Errorf("Expected no rewrite, got %q", got)
+	}
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"stripPrefix": "/gateway", "onPrefixMissing": OnMissingReject})
+	resp, ok := onRequest(p, "/users").(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != 404 {
+		t.Errorf("Expected 404 when prefix is missing, got %+v", resp)
+	}
+}
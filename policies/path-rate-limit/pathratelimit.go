@@ -0,0 +1,254 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	ratelimit "github.com/wso2/gateway-controllers/policies/advanced-ratelimit"
+	"github.com/wso2/gateway-controllers/utils/pathmatch"
+)
+
+// ruleMetadataKey records which rule handled the request so the response phase uses the same
+// delegate
+const ruleMetadataKey = "pathratelimit:rule"
+
+// defaultRule is the rule index used for the default bucket
+const defaultRule = -1
+
+// unitDurations maps supported units to Go duration strings understood by the ratelimit policy
+var unitDurations = map[string]string{
+	"second": "1s",
+	"minute": "1m",
+	"hour":   "1h",
+	"day":    "24h",
+}
+
+// pathRule is a path pattern with its own rate limit bucket
+type pathRule struct {
+	pattern  string
+	delegate policy.Policy
+}
+
+// PathRateLimitPolicy applies a different rate limit to each configured path pattern. Each
+// pattern delegates to its own instance of the core ratelimit policy, so buckets are keyed by
+// pattern and client.
+type PathRateLimitPolicy struct {
+	rules       []pathRule
+	defaultRule policy.Policy // nil when unmatched paths are not limited
+}
+
+// GetPolicy creates one core ratelimit delegate per configured path pattern, plus an optional
+// delegate for paths that match no pattern.
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	rulesRaw, ok := params["rules"].([]interface{})
+	if !ok || len(rulesRaw) == 0 {
+		return nil, fmt.Errorf("'rules' parameter is required and must be a non-empty array")
+	}
+
+	p := &PathRateLimitPolicy{}
+	for i, raw := range rulesRaw {
+		ruleMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("rules[%d] must be an object", i)
+		}
+		pattern, ok := ruleMap["pattern"].(string)
+		if !ok || !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("rules[%d].pattern is required and must start with '/'", i)
+		}
+		if err := pathmatch.Validate(pattern); err != nil {
+			return nil, fmt.Errorf("rules[%d].pattern is invalid: %w", i, err)
+		}
+
+		delegate, err := newDelegate(metadata, params, "path:"+pattern, ruleMap)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		p.rules = append(p.rules, pathRule{pattern: pattern, delegate: delegate})
+	}
+
+	if raw, ok := params["default"]; ok {
+		defaultMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'default' must be an object")
+		}
+		delegate, err := newDelegate(metadata, params, "default", defaultMap)
+		if err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+		p.defaultRule = delegate
+	}
+
+	return p, nil
+}
+
+// newDelegate builds a core ratelimit policy with a single named quota for a {limit, unit}
+// configuration. The quota name keeps the delegate's buckets separate from other patterns.
+func newDelegate(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+	quotaName string,
+	limitMap map[string]interface{},
+) (policy.Policy, error) {
+	limit, err := extractInt(limitMap["limit"])
+	if err != nil || limit < 1 {
+		return nil, fmt.Errorf("'limit' is required and must be a positive integer")
+	}
+	unit, _ := limitMap["unit"].(string)
+	duration, ok := unitDurations[unit]
+	if !ok {
+		return nil, fmt.Errorf("'unit' must be one of: second, minute, hour, day")
+	}
+
+	keyExtraction, ok := params["keyExtraction"]
+	if !ok {
+		keyExtraction = []interface{}{
+			map[string]interface{}{"type": "ip"},
+		}
+	}
+
+	rlParams := map[string]interface{}{
+		"quotas": []interface{}{
+			map[string]interface{}{
+				"name": quotaName,
+				"limits": []interface{}{
+					map[string]interface{}{
+						"limit":    float64(limit),
+						"duration": duration,
+					},
+				},
+				"keyExtraction": keyExtraction,
+			},
+		},
+	}
+
+	// Pass through system parameters
+	for _, name := range []string{"algorithm", "backend", "redis", "memory"} {
+		if value, ok := params[name]; ok {
+			rlParams[name] = value
+		}
+	}
+
+	return ratelimit.GetPolicy(metadata, rlParams)
+}
+
+// Mode returns the processing mode for this policy
+func (p *PathRateLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the path and key headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need to add rate limit headers to response
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest applies the rate limit of the first rule whose pattern matches the request path
+func (p *PathRateLimitPolicy) OnRequest(
+	ctx *policy.RequestContext,
+	params map[string]interface{},
+) policy.RequestAction {
+	reqPath, ok := pathmatch.Normalize(ctx.Path)
+	if !ok {
+		slog.Debug("PathRateLimit: Rejecting unnormalizable path", "path", ctx.Path)
+		return errorResponse(http.StatusBadRequest, "The request path contains encoded slashes or invalid escapes")
+	}
+
+	index, delegate := p.match(reqPath)
+	if delegate == nil {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	ctx.Metadata[ruleMetadataKey] = index
+	return delegate.OnRequest(ctx, params)
+}
+
+// OnResponse delegates to the rule that handled the request to add rate limit headers
+func (p *PathRateLimitPolicy) OnResponse(
+	ctx *policy.ResponseContext,
+	params map[string]interface{},
+) policy.ResponseAction {
+	index, ok := ctx.Metadata[ruleMetadataKey].(int)
+	if !ok {
+		return nil
+	}
+
+	if index == defaultRule {
+		if p.defaultRule == nil {
+			return nil
+		}
+		return p.defaultRule.OnResponse(ctx, params)
+	}
+	if index < 0 || index >= len(p.rules) {
+		return nil
+	}
+	return p.rules[index].delegate.OnResponse(ctx, params)
+}
+
+// match returns the first rule matching the path, falling back to the default rule
+func (p *PathRateLimitPolicy) match(reqPath string) (int, policy.Policy) {
+	for i, rule := range p.rules {
+		if pathmatch.Match(rule.pattern, reqPath) {
+			return i, rule.delegate
+		}
+	}
+	return defaultRule, p.defaultRule
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
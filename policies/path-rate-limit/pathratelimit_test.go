@@ -0,0 +1,149 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathratelimit
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// newPolicy creates a policy on a route unique to the test, since memory limiters are cached
+// per route
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	p := policytest.NewWithMetadata(t, GetPolicy, policy.PolicyMetadata{RouteName: t.Name()}, params)
+	return p
+}
+
+func onRequest(p policy.Policy, path, client string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}},
+		Headers:       policy.NewHeaders(map[string][]string{"x-forwarded-for": {client}}),
+		Path:          path,
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func isLimited(action policy.RequestAction) bool {
+	resp, ok := action.(policy.ImmediateResponse)
+	return ok && resp.StatusCode == 429
+}
+
+func rule(pattern string, limit int, unit string) map[string]interface{} {
+	return map[string]interface{}{"pattern": pattern, "limit": limit, "unit": unit}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"rules": []interface{}{}},
+		{"rules": []interface{}{rule("login", 1, "minute")}},
+		{"rules": []interface{}{rule("/login[", 1, "minute")}},
+		{"rules": []interface{}{rule("/login", 0, "minute")}},
+		{"rules": []interface{}{rule("/login", 1, "week")}},
+		{"rules": []interface{}{rule("/login", 1, "minute")}, "default": map[string]interface{}{"limit": 1}},
+	}
+	for _, params := range invalid {
+		if _, err := GetPolicy(policy.PolicyMetadata{RouteName: t.Name()}, params); err == nil {
+			t.Errorf("Expected error for params %v", params)
+		}
+	}
+}
+
+func TestPathRateLimitPolicy_IndependentPathLimits(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"rules": []interface{}{
+			rule("/login", 1, "minute"),
+			rule("/search/**", 3, "minute"),
+		},
+	})
+
+	if isLimited(onRequest(p, "/login", "10.0.0.1")) {
+		t.Fatal("Expected first login request to pass")
+	}
+	if !isLimited(onRequest(p, "/login", "10.0.0.1")) {
+		t.Error("Expected second login request to be limited")
+	}
+
+	// The search bucket is unaffected by the exhausted login bucket
+	for i := 0; i < 3; i++ {
+		if isLimited(onRequest(p, "/search/books?q=go", "10.0.0.1")) {
+			t.Fatalf("Expected search request %d to pass", i+1)
+		}
+	}
+	if !isLimited(onRequest(p, "/search", "10.0.0.1")) {
+		t.Error("Expected fourth search request to be limited")
+	}
+
+	// Buckets are per client
+	if isLimited(onRequest(p, "/login", "10.0.0.2")) {
+		t.Error("Expected another client's login request to pass")
+	}
+}
+
+func TestPathRateLimitPolicy_NormalizedPaths(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"rules":   []interface{}{rule("/api/login", 1, "minute")},
+		"default": map[string]interface{}{"limit": 100, "unit": "minute"},
+	})
+
+	if isLimited(onRequest(p, "/api/login", "10.0.0.1")) {
+		t.Fatal("Expected first login request to pass")
+	}
+	// Alternative spellings of the path must not fall through to the default bucket
+	for _, reqPath := range []string{"/api//login", "/api/./login", "/api/%6cogin", "/x/../api/login?a=1"} {
+		if !isLimited(onRequest(p, reqPath, "10.0.0.1")) {
+			t.Errorf("Expected %q to use the exhausted login bucket", reqPath)
+		}
+	}
+	for _, reqPath := range []string{"/api%2Flogin", "/api/%zz"} {
+		policytest.ExpectStatus(t, onRequest(p, reqPath, "10.0.0.1"), 400)
+	}
+}
+
+func TestPathRateLimitPolicy_DefaultBucket(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"rules":   []interface{}{rule("/login", 5, "minute")},
+		"default": map[string]interface{}{"limit": 2, "unit": "minute"},
+	})
+
+	// Unmatched paths share the default bucket
+	if isLimited(onRequest(p, "/users", "10.0.0.1")) || isLimited(onRequest(p, "/orders/1", "10.0.0.1")) {
+		t.Fatal("Expected requests within the default limit to pass")
+	}
+	if !isLimited(onRequest(p, "/users", "10.0.0.1")) {
+		t.Error("Expected request beyond the default limit to be limited")
+	}
+	if isLimited(onRequest(p, "/login", "10.0.0.1")) {
+		t.Error("Expected matched path to use its own bucket")
+	}
+}
+
+func TestPathRateLimitPolicy_UnmatchedWithoutDefault(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"rules": []interface{}{rule("/login", 1, "minute")},
+	})
+
+	for i := 0; i < 3; i++ {
+		if isLimited(onRequest(p, "/users", "10.0.0.1")) {
+			t.Fatal("Expected unmatched path not to be limited")
+		}
+	}
+}
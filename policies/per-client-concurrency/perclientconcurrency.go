@@ -0,0 +1,229 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package perclientconcurrency
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/clientkey"
+)
+
+const (
+	defaultLeaseTimeout = 60 * time.Second
+
+	// slotMetadataKey stores the acquired slot so OnResponse can release it
+	slotMetadataKey = "perclientconcurrency:slot"
+)
+
+// slot identifies an in-flight request holding a concurrency slot
+type slot struct {
+	key string
+	id  uint64
+}
+
+// PerClientConcurrencyPolicy caps the number of simultaneous in-flight requests per client key.
+// Slots are released in OnResponse; slots that are never released (for example when the
+// response phase does not run) expire after the lease timeout so they cannot leak.
+type PerClientConcurrencyPolicy struct {
+	maxConcurrent int
+	clientKey     clientkey.Key
+	leaseTimeout  time.Duration
+
+	mu        sync.Mutex
+	now       func() time.Time // Injectable clock (for testing)
+	nextID    uint64
+	inFlight  map[string]map[uint64]time.Time // key -> slot id -> acquired at
+	lastSweep time.Time
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &PerClientConcurrencyPolicy{
+		leaseTimeout: defaultLeaseTimeout,
+		now:          time.Now,
+		inFlight:     make(map[string]map[uint64]time.Time),
+	}
+
+	maxConcurrent, err := extractInt(params["maxConcurrentPerKey"])
+	if err != nil || maxConcurrent < 1 {
+		return nil, fmt.Errorf("'maxConcurrentPerKey' parameter is required and must be a positive integer")
+	}
+	p.maxConcurrent = maxConcurrent
+
+	clientKey, err := clientkey.Parse(params["clientKey"])
+	if err != nil {
+		return nil, err
+	}
+	p.clientKey = clientKey
+
+	if raw, ok := params["leaseTimeoutSeconds"]; ok {
+		timeout, err := extractInt(raw)
+		if err != nil || timeout < 1 {
+			return nil, fmt.Errorf("'leaseTimeoutSeconds' must be a positive integer")
+		}
+		p.leaseTimeout = time.Duration(timeout) * time.Second
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *PerClientConcurrencyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the client key
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Release the slot when the response arrives
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest acquires a concurrency slot for the client or rejects the request with 429
+func (p *PerClientConcurrencyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	key := p.clientKey.Resolve(ctx)
+
+	p.mu.Lock()
+	now := p.now()
+	p.sweep(now)
+	slots := p.inFlight[key]
+	p.reclaim(key, slots, now)
+
+	if len(slots) >= p.maxConcurrent {
+		p.mu.Unlock()
+		slog.Debug("PerClientConcurrency: Concurrency limit reached", "key", key, "limit", p.maxConcurrent)
+		return tooManyRequests(p.maxConcurrent)
+	}
+
+	if slots == nil {
+		slots = make(map[uint64]time.Time)
+		p.inFlight[key] = slots
+	}
+	p.nextID++
+	id := p.nextID
+	slots[id] = now
+	p.mu.Unlock()
+
+	if ctx.SharedContext != nil {
+		if ctx.Metadata == nil {
+			ctx.Metadata = make(map[string]interface{})
+		}
+		ctx.Metadata[slotMetadataKey] = slot{key: key, id: id}
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse releases the slot acquired for the request
+func (p *PerClientConcurrencyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.SharedContext == nil {
+		return nil
+	}
+	s, ok := ctx.Metadata[slotMetadataKey].(slot)
+	if !ok {
+		return nil
+	}
+	delete(ctx.Metadata, slotMetadataKey)
+	p.release(s)
+	return nil
+}
+
+// reclaim removes a key's slots held for longer than the lease timeout. Callers must hold p.mu.
+func (p *PerClientConcurrencyPolicy) reclaim(key string, slots map[uint64]time.Time, now time.Time) {
+	for id, acquiredAt := range slots {
+		if now.Sub(acquiredAt) >= p.leaseTimeout {
+			slog.Debug("PerClientConcurrency: Reclaiming expired slot", "key", key)
+			delete(slots, id)
+		}
+	}
+}
+
+// sweep reclaims expired slots of all keys and drops keys without slots. It runs at most once
+// per lease timeout so the cost is amortized across requests. Callers must hold p.mu.
+func (p *PerClientConcurrencyPolicy) sweep(now time.Time) {
+	if p.lastSweep.IsZero() {
+		p.lastSweep = now
+		return
+	}
+	if now.Sub(p.lastSweep) < p.leaseTimeout {
+		return
+	}
+	for key, slots := range p.inFlight {
+		p.reclaim(key, slots, now)
+		if len(slots) == 0 {
+			delete(p.inFlight, key)
+		}
+	}
+	p.lastSweep = now
+}
+
+// release frees a slot. Releasing an expired or unknown slot is a no-op.
+func (p *PerClientConcurrencyPolicy) release(s slot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slots, ok := p.inFlight[s.key]
+	if !ok {
+		return
+	}
+	delete(slots, s.id)
+	if len(slots) == 0 {
+		delete(p.inFlight, s.key)
+	}
+}
+
+// tooManyRequests builds a 429 response with a JSON error body
+func tooManyRequests(limit int) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Too Many Requests",
+		"message": fmt.Sprintf("Too many concurrent requests, the limit is %d per client", limit),
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
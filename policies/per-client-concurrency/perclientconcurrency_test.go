@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package perclientconcurrency
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *PerClientConcurrencyPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*PerClientConcurrencyPolicy)
+}
+
+// onRequest runs the request phase and returns the action with the shared context, which the
+// caller passes to onResponse to release the slot
+func onRequest(p policy.Policy, client string) (policy.RequestAction, *policy.SharedContext) {
+	shared := &policy.SharedContext{Metadata: map[string]interface{}{}}
+	ctx := &policy.RequestContext{
+		SharedContext: shared,
+		Headers:       policy.NewHeaders(map[string][]string{"x-forwarded-for": {client}}),
+	}
+	return p.OnRequest(ctx, nil), shared
+}
+
+func onResponse(p policy.Policy, shared *policy.SharedContext) {
+	p.OnResponse(&policy.ResponseContext{SharedContext: shared}, nil)
+}
+
+func isRejected(action policy.RequestAction) bool {
+	resp, ok := action.(policy.ImmediateResponse)
+	return ok && resp.StatusCode == 429
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxConcurrentPerKey": 0},
+		{"maxConcurrentPerKey": 2, "clientKey": map[string]interface{}{"type": "cookie"}},
+		{"maxConcurrentPerKey": 2, "clientKey": map[string]interface{}{"type": "header"}},
+		{"maxConcurrentPerKey": 2, "leaseTimeoutSeconds": 0},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestPerClientConcurrencyPolicy_SaturatedClientOtherAdmitted(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"maxConcurrentPerKey": 2})
+
+	for i := 0; i < 2; i++ {
+		if action, _ := onRequest(p, "10.0.0.1"); isRejected(action) {
+			t.Fatalf("Expected request %d to be admitted", i+1)
+		}
+	}
+	if action, _ := onRequest(p, "10.0.0.1"); !isRejected(action) {
+		t.Error("Expected third concurrent request to be rejected")
+	}
+	if action, _ := onRequest(p, "10.0.0.2"); isRejected(action) {
+		t.Error("Expected another client to be admitted")
+	}
+}
+
+func TestPerClientConcurrencyPolicy_ReleaseOnResponse(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"maxConcurrentPerKey": 1})
+
+	_, shared := onRequest(p, "10.0.0.1")
+	if action, _ := onRequest(p, "10.0.0.1"); !isRejected(action) {
+		t.Fatal("Expected second concurrent request to be rejected")
+	}
+
+	onResponse(p, shared)
+	// Releasing twice must not free another request's slot
+	onResponse(p, shared)
+
+	if action, _ := onRequest(p, "10.0.0.1"); isRejected(action) {
+		t.Error("Expected request to be admitted after the slot was released")
+	}
+	if action, _ := onRequest(p, "10.0.0.1"); !isRejected(action) {
+		t.Error("Expected the released slot to be reused only once")
+	}
+}
+
+func TestPerClientConcurrencyPolicy_LeakedSlotExpires(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"maxConcurrentPerKey": 1, "leaseTimeoutSeconds": 30})
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	_, shared := onRequest(p, "10.0.0.1")
+	now = now.Add(31 * time.Second)
+	if action, _ := onRequest(p, "10.0.0.1"); isRejected(action) {
+		t.Fatal("Expected leaked slot to be reclaimed after the lease timeout")
+	}
+
+	// A late release of the reclaimed slot does not free the new request's slot
+	onResponse(p, shared)
+	if action, _ := onRequest(p, "10.0.0.1"); !isRejected(action) {
+		t.Error("Expected late release not to affect the current slot")
+	}
+
+	// Keys whose slots all expired are dropped by the periodic sweep
+	now = now.Add(31 * time.Second)
+	onRequest(p, "10.0.0.2")
+	if _, ok := p.inFlight["10.0.0.1"]; ok {
+		t.Error("Expected expired key to be swept")
+	}
+}
@@ -20,12 +20,15 @@ package piimaskingregex
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"regexp"
 	"sort"
 	"strings"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
 	utils "github.com/wso2/api-platform/sdk/utils"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
 )
 
 const (
@@ -171,6 +174,14 @@ func (p *PIIMaskingRegexPolicy) OnRequest(ctx *policy.RequestContext, params map
 	if ctx.Body == nil || ctx.Body.Content == nil {
 		return policy.UpstreamRequestModifications{}
 	}
+
+	// PII in a compressed body can't be masked, so it must not reach the upstream
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("PIIMaskingRegex: Rejecting encoded request body")
+		resp := p.buildErrorResponse("encoded request bodies are not supported").(policy.ImmediateResponse)
+		resp.StatusCode = http.StatusUnsupportedMediaType
+		return resp
+	}
 	payload := ctx.Body.Content
 
 	// Extract value using JSONPath
@@ -227,6 +238,16 @@ func (p *PIIMaskingRegexPolicy) OnResponse(ctx *policy.ResponseContext, params m
 	if ctx.ResponseBody == nil || ctx.ResponseBody.Content == nil {
 		return policy.UpstreamResponseModifications{}
 	}
+
+	// Placeholders can't be restored in streaming or compressed bodies
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("PIIMaskingRegex: Skipping response body restoration", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+	if bodyutil.IsContentEncoded(ctx.ResponseHeaders) {
+		slog.Debug("PIIMaskingRegex: Skipping encoded response body restoration")
+		return policy.UpstreamResponseModifications{}
+	}
 	payload := ctx.ResponseBody.Content
 
 	// Restore PII in response
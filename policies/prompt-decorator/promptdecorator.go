@@ -21,12 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
 	utils "github.com/wso2/api-platform/sdk/utils"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
 )
 
 var arrayIndexRegex = regexp.MustCompile(`^([a-zA-Z0-9_]+)\[(-?\d+)\]$`)
@@ -182,6 +184,20 @@ func (p *PromptDecoratorPolicy) OnRequest(ctx *policy.RequestContext, params map
 		return p.buildErrorResponse("Empty request body", nil)
 	}
 
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, bodyutil.Options{}); pass {
+		slog.Debug("PromptDecorator: Skipping request body decoration", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be decorated without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("PromptDecorator: Rejecting encoded request body")
+		resp := p.buildErrorResponse("Encoded request bodies are not supported", nil).(policy.ImmediateResponse)
+		resp.StatusCode = http.StatusUnsupportedMediaType
+		return resp
+	}
+
 	// Parse JSON payload
 	var payloadData map[string]interface{}
 	if err := json.Unmarshal(content, &payloadData); err != nil {
@@ -254,8 +270,8 @@ func (p *PromptDecoratorPolicy) OnRequest(ctx *policy.RequestContext, params map
 
 		// If malformed entries found, return error without modifying the slice
 		if len(malformedEntries) > 0 {
-			errorDetails := fmt.Sprintf("malformed entries at %s", strings.Join(malformedEntries, "; "))
-			return p.buildErrorResponse("Array contains non-map elements", fmt.Errorf(errorDetails))
+			errorDetails := fmt.Errorf("malformed entries at %s", strings.Join(malformedEntries, "; "))
+			return p.buildErrorResponse("Array contains non-map elements", errorDetails)
 		}
 
 		// Create decoration messages from decoration config
@@ -21,11 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
 )
 
 var (
@@ -155,6 +157,20 @@ func (p *PromptTemplatePolicy) OnRequest(ctx *policy.RequestContext, params map[
 		return policy.UpstreamRequestModifications{}
 	}
 
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, bodyutil.Options{}); pass {
+		slog.Debug("PromptTemplate: Skipping request body templating", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be templated without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("PromptTemplate: Rejecting encoded request body")
+		resp := p.buildErrorResponse("Encoded request bodies are not supported", nil).(policy.ImmediateResponse)
+		resp.StatusCode = http.StatusUnsupportedMediaType
+		return resp
+	}
+
 	updatedJsonContent := jsonContent
 
 	// Find all template://<template-name>?<params> patterns (query params are optional)
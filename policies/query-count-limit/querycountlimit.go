@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package querycountlimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const defaultMessage = "Too many query parameters"
+
+// QueryCountLimitPolicy rejects requests carrying more query parameters than allowed, guarding
+// upstreams against parameter pollution and parsing DoS
+type QueryCountLimitPolicy struct {
+	maxParams int
+	message   string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	raw, ok := params["maxParams"]
+	if !ok {
+		return nil, fmt.Errorf("'maxParams' parameter is required")
+	}
+	maxParams, err := extractInt(raw)
+	if err != nil || maxParams < 0 {
+		return nil, fmt.Errorf("'maxParams' must be a non-negative integer")
+	}
+
+	p := &QueryCountLimitPolicy{maxParams: maxParams, message: defaultMessage}
+
+	if raw, ok := params["message"]; ok {
+		message, ok := raw.(string)
+		if !ok || strings.TrimSpace(message) == "" {
+			return nil, fmt.Errorf("'message' must be a non-empty string")
+		}
+		p.message = message
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *QueryCountLimitPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests with more than maxParams query parameters
+func (p *QueryCountLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	_, query, _ := strings.Cut(ctx.Path, "?")
+	if count := countParams(query); count > p.maxParams {
+		body, _ := json.Marshal(map[string]string{
+			"error":   "Bad Request",
+			"message": fmt.Sprintf("%s: %d provided, at most %d allowed", p.message, count, p.maxParams),
+		})
+		return policy.ImmediateResponse{
+			StatusCode: 400,
+			Headers: map[string]string{
+				"content-type": "application/json",
+			},
+			Body: body,
+		}
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *QueryCountLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// countParams counts the parameters in a raw query string. Every occurrence of a repeated key
+// counts individually; empty elements such as "a=1&&b=2" are not parameters.
+func countParams(query string) int {
+	// Strip a fragment that a client may have sent by mistake
+	query, _, _ = strings.Cut(query, "#")
+	count := 0
+	for _, pair := range strings.Split(query, "&") {
+		if pair != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
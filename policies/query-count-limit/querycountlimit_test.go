@@ -0,0 +1,67 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package querycountlimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, path string) policy.RequestAction {
+	return p.OnRequest(&policy.RequestContext{Path: path}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"maxParams": -1},
+		{"maxParams": 1.5},
+		{"maxParams": 10, "message": ""},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestQueryCountLimitPolicy_UnderLimit(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxParams": 3})
+
+	for _, path := range []string{"/orders", "/orders?", "/orders?a=1&b=2&c=3", "/orders?a=1&&b=2&"} {
+		if _, ok := onRequest(p, path).(policy.UpstreamRequestModifications); !ok {
+			t.Errorf("Expected %q to pass", path)
+		}
+	}
+}
+
+func TestQueryCountLimitPolicy_OverLimit(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxParams": float64(2), "message": "Simplify your query"})
+
+	resp := policytest.ExpectStatus(t, onRequest(p, "/orders?a=1&b=2&c"), http.StatusBadRequest)
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil || body["message"] != "Simplify your query: 3 provided, at most 2 allowed" {
+		t.Errorf("Expected custom message, got %q", body["message"])
+	}
+}
+
+func TestQueryCountLimitPolicy_RepeatedKeysCountIndividually(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxParams": 2})
+
+	policytest.ExpectStatus(t, onRequest(p, "/orders?id=1&id=2&id=3"), http.StatusBadRequest)
+}
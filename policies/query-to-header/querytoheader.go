@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package querytoheader
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+var headerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
+
+// QueryToHeaderPolicy lifts query parameters into request headers for upstreams that only
+// read headers
+type QueryToHeaderPolicy struct {
+	mappings   map[string]string // Query parameter name -> lower-cased header name
+	stripAfter bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["mappings"].(map[string]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'mappings' parameter is required and must be a non-empty object")
+	}
+
+	p := &QueryToHeaderPolicy{mappings: make(map[string]string)}
+	names := make([]string, 0, len(mappingsRaw))
+	for name := range mappingsRaw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("mappings cannot contain an empty query parameter name")
+		}
+		header, ok := mappingsRaw[name].(string)
+		header = strings.TrimSpace(header)
+		if !ok || !headerNameRegex.MatchString(header) {
+			return nil, fmt.Errorf("mappings.%s must be a valid header name", name)
+		}
+		p.mappings[name] = strings.ToLower(header)
+	}
+
+	if raw, ok := params["stripAfter"]; ok {
+		stripAfter, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'stripAfter' must be a boolean")
+		}
+		p.stripAfter = stripAfter
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *QueryToHeaderPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Sets request headers and path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest copies mapped query parameters into headers and optionally removes them from the
+// URL. Values that decode to control characters are rejected, since they can't be carried in a
+// header safely.
+func (p *QueryToHeaderPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, query, ok := strings.Cut(ctx.Path, "?")
+	if !ok || query == "" {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	setHeaders := make(map[string]string)
+	var kept []string
+	for _, pair := range strings.Split(query, "&") {
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		header, mapped := p.mappings[key]
+		if !mapped {
+			kept = append(kept, pair)
+			continue
+		}
+		// The first occurrence wins when a parameter is repeated
+		if _, exists := setHeaders[header]; !exists {
+			value, err := url.QueryUnescape(rawValue)
+			if err != nil {
+				value = rawValue
+			}
+			if hasControlChar(value) {
+				slog.Debug("QueryToHeader: Rejecting query parameter with control characters", "param", key)
+				return errorResponse(http.StatusBadRequest,
+					fmt.Sprintf("Query parameter '%s' contains characters that are not allowed in a header", key))
+			}
+			setHeaders[header] = value
+		}
+		if !p.stripAfter {
+			kept = append(kept, pair)
+		}
+	}
+
+	if len(setHeaders) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	mods := policy.UpstreamRequestModifications{SetHeaders: setHeaders}
+	if p.stripAfter {
+		newPath := reqPath
+		if len(kept) > 0 {
+			newPath += "?" + strings.Join(kept, "&")
+		}
+		mods.Path = &newPath
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *QueryToHeaderPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// hasControlChar reports whether a value contains a control character other than horizontal tab,
+// which are invalid in header values and could be used to inject headers
+func hasControlChar(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; (c < 0x20 && c != '\t') || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
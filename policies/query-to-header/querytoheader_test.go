@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package querytoheader
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, stripAfter bool) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"mappings":   map[string]interface{}{"apikey": "X-API-Key", "tenant": "x-tenant"},
+		"stripAfter": stripAfter,
+	})
+	return p
+}
+
+func onRequest(p policy.Policy, path string) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(nil), Path: path}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"mappings": map[string]interface{}{}},
+		{"mappings": map[string]interface{}{"apikey": "x api key"}},
+		{"mappings": map[string]interface{}{"apikey": 1}},
+		{"mappings": map[string]interface{}{"apikey": "x-api-key"}, "stripAfter": "yes"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestQueryToHeaderPolicy_LiftParam(t *testing.T) {
+	p := newPolicy(t, false)
+
+	mods := onRequest(p, "/orders?apikey=a%2Bb&apikey=second")
+	if got := mods.SetHeaders["x-api-key"]; got != "a+b" {
+		t.Errorf("Expected decoded first value 'a+b', got %q", got)
+	}
+	if mods.Path != nil {
+		t.Errorf("Expected path to be unchanged, got %q", *mods.Path)
+	}
+}
+
+func TestQueryToHeaderPolicy_StripAfter(t *testing.T) {
+	p := newPolicy(t, true)
+
+	mods := onRequest(p, "/orders?limit=5&apikey=secret&tenant=acme")
+	if mods.SetHeaders["x-api-key"] != "secret" || mods.SetHeaders["x-tenant"] != "acme" {
+		t.Errorf("Expected both params lifted, got %v", mods.SetHeaders)
+	}
+	if mods.Path == nil || *mods.Path != "/orders?limit=5" {
+		t.Errorf("Expected lifted params to be stripped, got %v", mods.Path)
+	}
+
+	mods = onRequest(p, "/orders?apikey=secret")
+	if mods.Path == nil || *mods.Path != "/orders" {
+		t.Errorf("Expected empty query to be removed, got %v", mods.Path)
+	}
+}
+
+func TestQueryToHeaderPolicy_UnrelatedParams(t *testing.T) {
+	p := newPolicy(t, true)
+
+	mods := onRequest(p, "/orders?APIKEY=x&q=a%20b")
+	if len(mods.SetHeaders) != 0 || mods.Path != nil {
+		t.Errorf("Expected request to be unchanged, got %+v", mods)
+	}
+	if mods := onRequest(p, "/orders"); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no headers without a query, got %v", mods.SetHeaders)
+	}
+}
+
+func TestQueryToHeaderPolicy_ControlCharactersRejected(t *testing.T) {
+	p := newPolicy(t, true)
+
+	for _, query := range []string{"apikey=a%0d%0aX-Admin:%20true", "tenant=a%00b", "apikey=a%7f"} {
+		ctx := &policy.RequestContext{Headers: policy.NewHeaders(nil), Path: "/orders?" + query}
+		policytest.ExpectStatus(t, p.OnRequest(ctx, nil), 400)
+	}
+	// Tabs are valid in header values
+	if mods := onRequest(p, "/orders?apikey=a%09b"); mods.SetHeaders["x-api-key"] != "a\tb" {
+		t.Errorf("Expected tab to be allowed, got %v", mods.SetHeaders)
+	}
+}
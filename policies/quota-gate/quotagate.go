@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package quotagate
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	SourceHeader  = "header"
+	SourceCounter = "counter"
+
+	defaultHeaderName = "x-plan-quota-remaining"
+)
+
+// QuotaGatePolicy returns 402 Payment Required once a quota is exhausted. The quota is either
+// reported by an earlier step in a request header or tracked by an in-memory counter.
+type QuotaGatePolicy struct {
+	source     string
+	headerName string
+	quota      int
+	window     time.Duration
+
+	mu          sync.Mutex
+	now         func() time.Time // Injectable clock (for testing)
+	windowStart time.Time
+	used        int
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &QuotaGatePolicy{
+		source:     SourceHeader,
+		headerName: defaultHeaderName,
+		now:        time.Now,
+	}
+
+	if raw, ok := params["source"]; ok {
+		source, ok := raw.(string)
+		if !ok || (source != SourceHeader && source != SourceCounter) {
+			return nil, fmt.Errorf("'source' must be one of: header, counter")
+		}
+		p.source = source
+	}
+
+	switch p.source {
+	case SourceHeader:
+		if raw, ok := params["headerName"]; ok {
+			name, ok := raw.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("'headerName' must be a non-empty string")
+			}
+			p.headerName = strings.ToLower(strings.TrimSpace(name))
+		}
+	case SourceCounter:
+		quota, err := extractInt(params["quota"])
+		if err != nil || quota < 1 {
+			return nil, fmt.Errorf("'quota' parameter is required and must be a positive integer when source is 'counter'")
+		}
+		windowSeconds, err := extractInt(params["windowSeconds"])
+		if err != nil || windowSeconds < 1 {
+			return nil, fmt.Errorf("'windowSeconds' parameter is required and must be a positive integer when source is 'counter'")
+		}
+		p.quota = quota
+		p.window = time.Duration(windowSeconds) * time.Second
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *QuotaGatePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the quota header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects the request with 402 when the quota is exhausted
+func (p *QuotaGatePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if p.source == SourceCounter {
+		return p.consume()
+	}
+
+	values := ctx.Headers.Get(p.headerName)
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+	remaining, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 64)
+	if err != nil {
+		slog.Debug("QuotaGate: Ignoring invalid quota header", "header", p.headerName, "value", values[0])
+		return policy.UpstreamRequestModifications{}
+	}
+	if remaining <= 0 {
+		slog.Debug("QuotaGate: Quota exhausted", "header", p.headerName, "remaining", remaining)
+		return paymentRequired(map[string]interface{}{
+			"message": fmt.Sprintf("Quota exhausted: %s is %d", p.headerName, remaining),
+		}, nil)
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *QuotaGatePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// consume takes one unit from the in-memory quota, resetting it at the start of each window
+func (p *QuotaGatePolicy) consume() policy.RequestAction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.windowStart.IsZero() || !now.Before(p.windowStart.Add(p.window)) {
+		p.windowStart = now
+		p.used = 0
+	}
+
+	if p.used < p.quota {
+		p.used++
+		return policy.UpstreamRequestModifications{}
+	}
+
+	reset := p.windowStart.Add(p.window)
+	retryAfter := int(math.Ceil(reset.Sub(now).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	slog.Debug("QuotaGate: Quota exhausted", "quota", p.quota, "reset", reset)
+	return paymentRequired(map[string]interface{}{
+		"message": fmt.Sprintf("Quota of %d requests per %s exhausted", p.quota, p.window),
+		"limit":   p.quota,
+		"window":  int(p.window.Seconds()),
+		"reset":   reset.Unix(),
+	}, map[string]string{
+		"retry-after": strconv.Itoa(retryAfter),
+	})
+}
+
+// paymentRequired builds a 402 response with a JSON error body
+func paymentRequired(fields map[string]interface{}, headers map[string]string) policy.RequestAction {
+	fields["error"] = "Payment Required"
+	body, _ := json.Marshal(fields)
+
+	respHeaders := map[string]string{
+		"content-type": "application/json",
+	}
+	for name, value := range headers {
+		respHeaders[name] = value
+	}
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusPaymentRequired,
+		Headers:    respHeaders,
+		Body:       body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
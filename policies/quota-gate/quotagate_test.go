@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package quotagate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *QuotaGatePolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*QuotaGatePolicy)
+}
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.RequestAction {
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"source": "jwt"},
+		{"headerName": ""},
+		{"source": "counter"},
+		{"source": "counter", "quota": 10},
+		{"source": "counter", "quota": 0, "windowSeconds": 60},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestQuotaGatePolicy_HeaderUnderQuota(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	for _, headers := range []map[string][]string{
+		{"x-plan-quota-remaining": {"3"}},
+		nil,
+		{"x-plan-quota-remaining": {"unknown"}},
+	} {
+		if _, ok := onRequest(p, headers).(policy.UpstreamRequestModifications); !ok {
+			t.Errorf("Expected request with headers %v to pass", headers)
+		}
+	}
+}
+
+func TestQuotaGatePolicy_HeaderExhausted(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	resp, ok := onRequest(p, map[string][]string{"x-plan-quota-remaining": {"0"}}).(policy.ImmediateResponse)
+	if !ok {
+		t.Fatal("Expected ImmediateResponse for exhausted quota")
+	}
+	if resp.StatusCode != 402 {
+		t.Errorf("Expected status 402, got %d", resp.StatusCode)
+	}
+	if resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", resp.Headers["content-type"])
+	}
+}
+
+func TestQuotaGatePolicy_CounterExhaustedAndReset(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"source": SourceCounter, "quota": 2, "windowSeconds": 60})
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, ok := onRequest(p, nil).(policy.UpstreamRequestModifications); !ok {
+			t.Fatalf("Expected request %d to pass", i+1)
+		}
+	}
+
+	now = now.Add(15 * time.Second)
+	resp, ok := onRequest(p, nil).(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != 402 {
+		t.Fatalf("Expected 402 once quota is exhausted, got %+v", resp)
+	}
+	if resp.Headers["retry-after"] != "45" {
+		t.Errorf("Expected retry-after 45, got %q", resp.Headers["retry-after"])
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		t.Fatalf("Expected JSON body, got %v", err)
+	}
+	if body["limit"] != float64(2) || body["window"] != float64(60) {
+		t.Errorf("Expected limit and window in body, got %v", body)
+	}
+
+	now = now.Add(45 * time.Second)
+	if _, ok := onRequest(p, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected quota to reset after the window")
+	}
+}
@@ -0,0 +1,277 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package rangeguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Actions for requests with more ranges than allowed
+	OnTooManyReject = "reject"
+	OnTooManyDrop   = "drop"
+)
+
+// byteRange is a single range spec. first is -1 for suffix ranges and last is -1 for
+// open-ended ranges.
+type byteRange struct {
+	first int64
+	last  int64
+}
+
+func (r byteRange) String() string {
+	switch {
+	case r.first < 0:
+		return fmt.Sprintf("-%d", r.last)
+	case r.last < 0:
+		return fmt.Sprintf("%d-", r.first)
+	default:
+		return fmt.Sprintf("%d-%d", r.first, r.last)
+	}
+}
+
+// RangeGuardPolicy validates and normalizes Range request headers to protect upstreams from
+// malformed and abusive multi-range requests
+type RangeGuardPolicy struct {
+	maxRanges     int
+	maxTotalBytes int64 // 0 disables the limit
+	onTooMany     string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &RangeGuardPolicy{
+		maxRanges: 5,
+		onTooMany: OnTooManyReject,
+	}
+
+	if raw, ok := params["maxRanges"]; ok {
+		maxRanges, err := extractInt(raw)
+		if err != nil || maxRanges < 1 {
+			return nil, fmt.Errorf("'maxRanges' must be a positive integer")
+		}
+		p.maxRanges = maxRanges
+	}
+
+	if raw, ok := params["maxTotalBytes"]; ok {
+		maxTotalBytes, err := extractInt(raw)
+		if err != nil || maxTotalBytes < 1 {
+			return nil, fmt.Errorf("'maxTotalBytes' must be a positive integer")
+		}
+		p.maxTotalBytes = int64(maxTotalBytes)
+	}
+
+	if raw, ok := params["onTooManyRanges"]; ok {
+		onTooMany, ok := raw.(string)
+		if !ok || (onTooMany != OnTooManyReject && onTooMany != OnTooManyDrop) {
+			return nil, fmt.Errorf("'onTooManyRanges' must be one of %s, %s", OnTooManyReject, OnTooManyDrop)
+		}
+		p.onTooMany = onTooMany
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RangeGuardPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need Range header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates the Range header and forwards it in canonical form
+func (p *RangeGuardPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get("range")
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+	if len(values) > 1 {
+		return rangeNotSatisfiable("Multiple Range headers are not allowed")
+	}
+
+	unit, specs, found := strings.Cut(strings.TrimSpace(values[0]), "=")
+	if !found {
+		return rangeNotSatisfiable("Malformed Range header")
+	}
+	// Other range units are ignored by servers that don't support them
+	if !strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	ranges, err := parseRanges(specs)
+	if err != nil {
+		return rangeNotSatisfiable(fmt.Sprintf("Malformed Range header: %s", err.Error()))
+	}
+
+	if len(ranges) > p.maxRanges {
+		if p.onTooMany == OnTooManyDrop {
+			// Without a Range header the upstream serves the full representation
+			return policy.UpstreamRequestModifications{RemoveHeaders: []string{"range"}}
+		}
+		return rangeNotSatisfiable(fmt.Sprintf("Too many ranges requested: at most %d allowed", p.maxRanges))
+	}
+
+	if p.maxTotalBytes > 0 && totalBytes(ranges, p.maxTotalBytes) > p.maxTotalBytes {
+		return rangeNotSatisfiable(fmt.Sprintf("Requested ranges exceed %d bytes", p.maxTotalBytes))
+	}
+
+	specStrings := make([]string, len(ranges))
+	for i, r := range ranges {
+		specStrings[i] = r.String()
+	}
+	canonical := "bytes=" + strings.Join(specStrings, ",")
+	if canonical == values[0] {
+		return policy.UpstreamRequestModifications{}
+	}
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{"range": canonical},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *RangeGuardPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// parseRanges parses a comma-separated list of byte range specs: "first-last", "first-" or
+// "-suffixLength". Empty list elements are ignored.
+func parseRanges(specs string) ([]byteRange, error) {
+	var ranges []byteRange
+	for _, spec := range strings.Split(specs, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		firstStr, lastStr, found := strings.Cut(spec, "-")
+		if !found {
+			return nil, fmt.Errorf("range '%s' has no '-'", spec)
+		}
+
+		r := byteRange{first: -1, last: -1}
+		if firstStr != "" {
+			first, err := parseOffset(firstStr)
+			if err != nil {
+				return nil, fmt.Errorf("range '%s' has an invalid start", spec)
+			}
+			r.first = first
+		}
+		if lastStr != "" {
+			last, err := parseOffset(lastStr)
+			if err != nil {
+				return nil, fmt.Errorf("range '%s' has an invalid end", spec)
+			}
+			r.last = last
+		}
+
+		switch {
+		case r.first < 0 && r.last < 0:
+			return nil, fmt.Errorf("range '%s' has neither start nor end", spec)
+		case r.first < 0 && r.last == 0:
+			return nil, fmt.Errorf("suffix range '%s' must be non-zero", spec)
+		case r.first >= 0 && r.last >= 0 && r.last < r.first:
+			return nil, fmt.Errorf("range '%s' ends before it starts", spec)
+		}
+		ranges = append(ranges, r)
+	}
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("no ranges specified")
+	}
+	return ranges, nil
+}
+
+// parseOffset parses a non-negative decimal byte offset
+func parseOffset(s string) (int64, error) {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid offset")
+		}
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// totalBytes sums the lengths of the ranges, stopping once the limit is exceeded. Overlapping
+// ranges are counted repeatedly. An open-ended range has no known length: on its own it asks for
+// no more than the representation, but combined with other ranges its length cannot be bounded,
+// so it counts as exceeding the limit.
+func totalBytes(ranges []byteRange, limit int64) int64 {
+	var total int64
+	for _, r := range ranges {
+		var length int64
+		switch {
+		case r.first < 0:
+			length = r.last
+		case r.last < 0:
+			if len(ranges) == 1 {
+				continue
+			}
+			return limit + 1
+		default:
+			length = r.last - r.first + 1
+		}
+		if length > limit-total {
+			return limit + 1
+		}
+		total += length
+	}
+	return total
+}
+
+// rangeNotSatisfiable rejects the request with a 416 JSON error
+func rangeNotSatisfiable(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Range Not Satisfiable",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: 416,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
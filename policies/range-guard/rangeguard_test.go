@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package rangeguard
+
+import (
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, ranges ...string) policy.RequestAction {
+	headers := map[string][]string{}
+	if len(ranges) > 0 {
+		headers["range"] = ranges
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"maxRanges": 0},
+		{"maxRanges": "many"},
+		{"maxTotalBytes": -1},
+		{"onTooManyRanges": "truncate"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRangeGuardPolicy_ValidSingleRange(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxTotalBytes": 1024})
+
+	for _, header := range []string{"bytes=0-1023", "bytes=500-", "bytes=-100"} {
+		if mods := policytest.ExpectForwarded(t, onRequest(p, header)); len(mods.SetHeaders) != 0 {
+			t.Errorf("Expected %q to be forwarded unchanged, got %v", header, mods.SetHeaders)
+		}
+	}
+
+	// Requests without a Range header are untouched
+	policytest.ExpectForwarded(t, onRequest(p))
+}
+
+func TestRangeGuardPolicy_Canonicalized(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := policytest.ExpectForwarded(t, onRequest(p, "Bytes = 0-9 , ,20-29"))
+	if got := mods.SetHeaders["range"]; got != "bytes=0-9,20-29" {
+		t.Errorf("Expected canonical range, got %q", got)
+	}
+}
+
+func TestRangeGuardPolicy_MalformedRange(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	for _, header := range []string{
+		"bytes",
+		"bytes=",
+		"bytes=abc",
+		"bytes=10-5",
+		"bytes=-",
+		"bytes=-0",
+		"bytes=+1-5",
+		"bytes=0-99999999999999999999",
+	} {
+		policytest.ExpectStatus(t, onRequest(p, header), http.StatusRequestedRangeNotSatisfiable)
+	}
+	policytest.ExpectStatus(t, onRequest(p, "bytes=0-1", "bytes=2-3"), http.StatusRequestedRangeNotSatisfiable)
+
+	// Unknown units are left for the upstream to ignore
+	policytest.ExpectForwarded(t, onRequest(p, "items=0-9"))
+}
+
+func TestRangeGuardPolicy_TooManyRanges(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxRanges": 2})
+
+	policytest.ExpectForwarded(t, onRequest(p, "bytes=0-1,5-6"))
+	policytest.ExpectStatus(t, onRequest(p, "bytes=0-1,5-6,10-11"), http.StatusRequestedRangeNotSatisfiable)
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"maxRanges": 2, "onTooManyRanges": "drop"})
+	mods := policytest.ExpectForwarded(t, onRequest(p, "bytes=0-1,5-6,10-11"))
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "range" {
+		t.Errorf("Expected Range header to be dropped, got %+v", mods)
+	}
+}
+
+func TestRangeGuardPolicy_MaxTotalBytes(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"maxTotalBytes": 100})
+
+	policytest.ExpectForwarded(t, onRequest(p, "bytes=0-49,-50"))
+	policytest.ExpectStatus(t, onRequest(p, "bytes=0-49,-51"), http.StatusRequestedRangeNotSatisfiable)
+	// Overlapping ranges count repeatedly
+	policytest.ExpectStatus(t, onRequest(p, "bytes=0-60,0-60"), http.StatusRequestedRangeNotSatisfiable)
+	// Open-ended ranges cannot be bounded once there is more than one range
+	policytest.ExpectStatus(t, onRequest(p, "bytes=0-,0-,0-,0-"), http.StatusRequestedRangeNotSatisfiable)
+	policytest.ExpectStatus(t, onRequest(p, "bytes=0-9,500-"), http.StatusRequestedRangeNotSatisfiable)
+	policytest.ExpectForwarded(t, onRequest(p, "bytes=500-"))
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package reasonphrase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// DefaultHeaderName is the response header that carries the overridden reason phrase
+const DefaultHeaderName = "x-status-reason"
+
+// ReasonPhrasePolicy attaches operator-defined reason phrases to responses by status code.
+//
+// The policy SDK cannot rewrite the HTTP status line (UpstreamResponseModifications only exposes
+// the status code, and HTTP/2 has no reason phrase), so the phrase is delivered in a header.
+type ReasonPhrasePolicy struct {
+	phrases    map[int]string
+	headerName string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	phrasesRaw, ok := params["phrases"].(map[string]interface{})
+	if !ok || len(phrasesRaw) == 0 {
+		return nil, fmt.Errorf("'phrases' parameter is required and must be a non-empty object")
+	}
+
+	p := &ReasonPhrasePolicy{
+		phrases:    make(map[int]string, len(phrasesRaw)),
+		headerName: DefaultHeaderName,
+	}
+	for statusRaw, phraseRaw := range phrasesRaw {
+		status, err := strconv.Atoi(statusRaw)
+		if err != nil || status < 100 || status > 599 {
+			return nil, fmt.Errorf("phrases key '%s' must be an HTTP status code between 100 and 599", statusRaw)
+		}
+		phrase, ok := phraseRaw.(string)
+		if !ok || strings.TrimSpace(phrase) == "" {
+			return nil, fmt.Errorf("phrases['%s'] must be a non-empty string", statusRaw)
+		}
+		if strings.ContainsAny(phrase, "\r\n") {
+			return nil, fmt.Errorf("phrases['%s'] must not contain line breaks", statusRaw)
+		}
+		p.phrases[status] = phrase
+	}
+
+	if raw, ok := params["headerName"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'headerName' must be a non-empty string")
+		}
+		p.headerName = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ReasonPhrasePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need response status
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *ReasonPhrasePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse sets the configured reason phrase for the response status
+func (p *ReasonPhrasePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	phrase, ok := p.phrases[ctx.ResponseStatus]
+	if !ok {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.headerName: phrase,
+		},
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package reasonphrase
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, status int) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(nil),
+		ResponseStatus:  status,
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"phrases": map[string]interface{}{}},
+		{"phrases": map[string]interface{}{"OK": "All Good"}},
+		{"phrases": map[string]interface{}{"700": "Nope"}},
+		{"phrases": map[string]interface{}{"200": ""}},
+		{"phrases": map[string]interface{}{"200": "a\r\nb"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestReasonPhrasePolicy_ConfiguredStatus(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"phrases": map[string]interface{}{"200": "All Good", "404": "Nothing Here"},
+	})
+
+	if got := onResponse(p, 200).SetHeaders[DefaultHeaderName]; got != "All Good" {
+		t.Errorf("Expected 'All Good', got %q", got)
+	}
+	if got := onResponse(p, 404).SetHeaders[DefaultHeaderName]; got != "Nothing Here" {
+		t.Errorf("Expected 'Nothing Here', got %q", got)
+	}
+}
+
+func TestReasonPhrasePolicy_CustomHeader(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"phrases":    map[string]interface{}{"201": "Resource Created"},
+		"headerName": "X-Reason",
+	})
+
+	if got := onResponse(p, 201).SetHeaders["x-reason"]; got != "Resource Created" {
+		t.Errorf("Expected phrase in custom header, got %q", got)
+	}
+}
+
+func TestReasonPhrasePolicy_PassThroughOtherStatuses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"phrases": map[string]interface{}{"200": "All Good"},
+	})
+
+	if mods := onResponse(p, 500); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no headers for unconfigured status, got %v", mods.SetHeaders)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package redactforlog
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// MetadataKeyRedactedPath is the shared metadata key holding the redacted path and query.
+	// Logging policies such as log-message read it in place of the raw request path.
+	MetadataKeyRedactedPath = "log:redacted-path"
+
+	defaultMask = "***"
+)
+
+// RedactForLogPolicy computes a redacted view of the request path for logging without changing
+// what is forwarded upstream
+type RedactForLogPolicy struct {
+	sensitive map[string]bool
+	mask      string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	namesRaw, ok := params["sensitiveParams"].([]interface{})
+	if !ok || len(namesRaw) == 0 {
+		return nil, fmt.Errorf("'sensitiveParams' parameter is required and must be a non-empty array")
+	}
+
+	p := &RedactForLogPolicy{
+		sensitive: make(map[string]bool),
+		mask:      defaultMask,
+	}
+	for i, raw := range namesRaw {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("sensitiveParams[%d] must be a non-empty string", i)
+		}
+		p.sensitive[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	if raw, ok := params["mask"]; ok {
+		mask, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'mask' must be a string")
+		}
+		p.mask = mask
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RedactForLogPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest stores the redacted path in the shared metadata. The upstream request is unchanged.
+func (p *RedactForLogPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.SharedContext == nil {
+		return policy.UpstreamRequestModifications{}
+	}
+	if ctx.Metadata == nil {
+		ctx.Metadata = make(map[string]interface{})
+	}
+	ctx.Metadata[MetadataKeyRedactedPath] = p.redact(ctx.Path)
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *RedactForLogPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// redact masks the values of sensitive query parameters, keeping parameter order and the
+// encoding of everything else intact
+func (p *RedactForLogPolicy) redact(fullPath string) string {
+	path, query, ok := strings.Cut(fullPath, "?")
+	if !ok || query == "" {
+		return fullPath
+	}
+
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		rawKey, _, hasValue := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		if hasValue && p.sensitive[strings.ToLower(key)] {
+			pairs[i] = rawKey + "=" + p.mask
+		}
+	}
+	return path + "?" + strings.Join(pairs, "&")
+}
@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package redactforlog
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, p policy.Policy, path string) (policy.UpstreamRequestModifications, interface{}) {
+	t.Helper()
+	ctx := &policy.RequestContext{
+		SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{}},
+		Path:          path,
+	}
+	mods := p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+	if ctx.Path != path {
+		t.Errorf("Expected request path to be unchanged, got %q", ctx.Path)
+	}
+	return mods, ctx.Metadata[MetadataKeyRedactedPath]
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"sensitiveParams": []interface{}{}},
+		{"sensitiveParams": []interface{}{""}},
+		{"sensitiveParams": []interface{}{"token"}, "mask": 1},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRedactForLogPolicy_UpstreamUnchangedLogRedacted(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"sensitiveParams": []interface{}{"token", "API_KEY"}})
+
+	mods, logged := onRequest(t, p, "/orders?id=7&token=s3cr3t&api_key=abc&page=2")
+	if mods.Path != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no upstream modifications, got %+v", mods)
+	}
+	if logged != "/orders?id=7&token=***&api_key=***&page=2" {
+		t.Errorf("Expected redacted path, got %v", logged)
+	}
+}
+
+func TestRedactForLogPolicy_EncodedAndRepeatedParams(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"sensitiveParams": []interface{}{"access token"}, "mask": "REDACTED"})
+
+	_, logged := onRequest(t, p, "/a?access%20token=x&access+token=y&flag")
+	if logged != "/a?access%20token=REDACTED&access+token=REDACTED&flag" {
+		t.Errorf("Expected every occurrence to be redacted, got %v", logged)
+	}
+}
+
+func TestRedactForLogPolicy_NoQuery(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"sensitiveParams": []interface{}{"token"}})
+
+	if _, logged := onRequest(t, p, "/orders"); logged != "/orders" {
+		t.Errorf("Expected path without query to be stored as is, got %v", logged)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package regexreplaceresponse
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+// defaultContentTypes are the media types rewritten when 'contentTypes' is not configured
+var defaultContentTypes = []string{"text/*", "application/javascript", "application/ecmascript"}
+
+// operation is a single compiled find/replace step
+type operation struct {
+	pattern     *regexp.Regexp
+	replacement []byte
+}
+
+// RegexReplaceResponsePolicy applies an ordered list of regex find/replace operations to text
+// response bodies
+type RegexReplaceResponsePolicy struct {
+	operations   []operation
+	contentTypes []string // path.Match patterns over lower-cased media types
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	operationsRaw, ok := params["operations"].([]interface{})
+	if !ok || len(operationsRaw) == 0 {
+		return nil, fmt.Errorf("'operations' parameter is required and must be a non-empty array")
+	}
+
+	p := &RegexReplaceResponsePolicy{contentTypes: defaultContentTypes}
+	for i, raw := range operationsRaw {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("operations[%d] must be an object", i)
+		}
+		pattern, ok := entry["pattern"].(string)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("operations[%d].pattern must be a non-empty string", i)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("operations[%d].pattern is not a valid regex: %w", i, err)
+		}
+		replacement, ok := entry["replacement"].(string)
+		if !ok {
+			return nil, fmt.Errorf("operations[%d].replacement must be a string", i)
+		}
+		p.operations = append(p.operations, operation{pattern: re, replacement: []byte(replacement)})
+	}
+
+	if raw, ok := params["contentTypes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'contentTypes' must be a non-empty array")
+		}
+		p.contentTypes = make([]string, 0, len(list))
+		for i, item := range list {
+			pattern, ok := item.(string)
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if !ok || !strings.Contains(pattern, "/") || strings.ContainsAny(pattern, "; ") {
+				return nil, fmt.Errorf("contentTypes[%d] must be a media type pattern like 'text/html' or 'text/*'", i)
+			}
+			if _, err := path.Match(pattern, "/"); err != nil {
+				return nil, fmt.Errorf("contentTypes[%d] is not a valid pattern: %w", i, err)
+			}
+			p.contentTypes = append(p.contentTypes, pattern)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RegexReplaceResponsePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to rewrite it
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *RegexReplaceResponsePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse applies the configured operations in order to matching text response bodies
+func (p *RegexReplaceResponsePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	if !p.matches(bodyutil.MediaType(ctx.ResponseHeaders)) {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Compressed bodies can't be rewritten without decoding them
+	if bodyutil.IsContentEncoded(ctx.ResponseHeaders) {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("RegexReplaceResponse: Skipping response body rewrite", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	body := ctx.ResponseBody.Content
+	changed := false
+	for _, op := range p.operations {
+		if !op.pattern.Match(body) {
+			continue
+		}
+		body = op.pattern.ReplaceAll(body, op.replacement)
+		changed = true
+	}
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": strconv.Itoa(len(body)),
+		},
+	}
+}
+
+// matches reports whether a media type matches one of the configured patterns
+func (p *RegexReplaceResponsePolicy) matches(mediaType string) bool {
+	if mediaType == "" {
+		return false
+	}
+	for _, pattern := range p.contentTypes {
+		if matched, _ := path.Match(pattern, mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
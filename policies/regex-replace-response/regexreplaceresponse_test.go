@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package regexreplaceresponse
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, contentType, body string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	mods, _ := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	return mods
+}
+
+func op(pattern, replacement string) map[string]interface{} {
+	return map[string]interface{}{"pattern": pattern, "replacement": replacement}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"operations": []interface{}{}},
+		{"operations": []interface{}{op("(", "x")}},
+		{"operations": []interface{}{map[string]interface{}{"pattern": "a"}}},
+		{"operations": []interface{}{op("a", "b")}, "contentTypes": []interface{}{"html"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRegexReplaceResponsePolicy_MultiplePatterns(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"operations": []interface{}{
+		op(`internal\.example\.com`, "api.example.com"),
+		op(`http://api`, "https://api"),
+	}})
+
+	mods := onResponse(p, "text/html; charset=utf-8", `<a href="http://internal.example.com/x">x</a>`)
+	want := `<a href="https://api.example.com/x">x</a>`
+	if string(mods.Body) != want {
+		t.Errorf("Expected body %q, got %q", want, mods.Body)
+	}
+	if mods.SetHeaders["content-length"] != "41" {
+		t.Errorf("Expected content-length 41, got %q", mods.SetHeaders["content-length"])
+	}
+
+	// Nothing to replace leaves the body unchanged
+	if mods := onResponse(p, "text/css", "body { color: red }"); mods.Body != nil {
+		t.Errorf("Expected no body change, got %q", mods.Body)
+	}
+}
+
+func TestRegexReplaceResponsePolicy_CaptureGroups(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"operations": []interface{}{
+		op(`(\d{4})-(\d{2})-(\d{2})`, "$3/$2/$1"),
+		op(`v(?P<major>\d+)\.js`, "v${major}.min.js"),
+	}})
+
+	mods := onResponse(p, "application/javascript", `load("lib-v2.js", "2026-10-14")`)
+	want := `load("lib-v2.min.js", "14/10/2026")`
+	if string(mods.Body) != want {
+		t.Errorf("Expected body %q, got %q", want, mods.Body)
+	}
+}
+
+func TestRegexReplaceResponsePolicy_BinarySkipped(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"operations": []interface{}{op("PNG", "GIF")}})
+
+	if mods := onResponse(p, "image/png", "\x89PNG\r\n"); mods.Body != nil {
+		t.Errorf("Expected binary body to be skipped, got %q", mods.Body)
+	}
+}
+
+func TestRegexReplaceResponsePolicy_EncodedSkipped(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"operations": []interface{}{op("secret", "***")}})
+
+	// Any coding other than identity in the list means the bytes are compressed
+	for _, encoding := range []string{"gzip", "identity, br"} {
+		ctx := &policy.ResponseContext{
+			ResponseHeaders: policy.NewHeaders(map[string][]string{
+				"content-type":     {"text/plain"},
+				"content-encoding": {encoding},
+			}),
+			ResponseBody: &policy.Body{Content: []byte("secret"), Present: true, EndOfStream: true},
+		}
+		if mods := policytest.ExpectResponseStatus(t, p.OnResponse(ctx, nil), 0); mods.Body != nil {
+			t.Errorf("Expected %q body to be skipped, got %q", encoding, mods.Body)
+		}
+	}
+}
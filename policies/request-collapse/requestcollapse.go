@@ -0,0 +1,387 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package requestcollapse
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	defaultWaitTimeout = 5 * time.Second
+
+	// maxVaryEntries bounds the number of resources whose Vary headers are remembered
+	maxVaryEntries = 10000
+
+	// flightMetadataKey marks the request that leads a flight so OnResponse can publish its result
+	flightMetadataKey = "requestcollapse:flight"
+)
+
+// excludedHeaders are response headers that are not copied into shared responses
+var excludedHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"keep-alive":        true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// credentialHeaders identify the client; requests carrying them are only collapsed when the
+// header is part of the key
+var credentialHeaders = []string{"authorization", "cookie"}
+
+// sharedResponse is the leader's response replayed to waiting duplicates
+type sharedResponse struct {
+	status  int
+	headers map[string]string
+	body    []byte
+	vary    []string // Request headers named in the response's Vary header
+}
+
+// flight is an in-progress upstream request that identical requests wait on
+type flight struct {
+	key     string
+	base    string            // Key without the learned Vary headers
+	request map[string]string // Leader's request headers, to check waiters against Vary
+	started time.Time
+	done    chan struct{}
+	once    sync.Once
+	result  *sharedResponse // Set before done is closed; nil when the result cannot be shared
+	waiters int
+}
+
+// RequestCollapsePolicy coalesces identical concurrent requests so that only one reaches the
+// upstream while duplicates wait for and share its response
+type RequestCollapsePolicy struct {
+	methods     map[string]bool
+	keyHeaders  []string
+	waitTimeout time.Duration
+
+	mu      sync.Mutex
+	now     func() time.Time // Injectable clock (for testing)
+	flights map[string]*flight
+	vary    map[string][]string // Vary headers last seen for each base key
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &RequestCollapsePolicy{
+		methods:     map[string]bool{"GET": true, "HEAD": true},
+		keyHeaders:  []string{"authorization"},
+		waitTimeout: defaultWaitTimeout,
+		now:         time.Now,
+		flights:     make(map[string]*flight),
+		vary:        make(map[string][]string),
+	}
+
+	if raw, ok := params["methods"]; ok {
+		methodsRaw, ok := raw.([]interface{})
+		if !ok || len(methodsRaw) == 0 {
+			return nil, fmt.Errorf("'methods' must be a non-empty array")
+		}
+		p.methods = make(map[string]bool)
+		for i, m := range methodsRaw {
+			method, ok := m.(string)
+			if !ok || strings.TrimSpace(method) == "" {
+				return nil, fmt.Errorf("methods[%d] must be a non-empty string", i)
+			}
+			p.methods[strings.ToUpper(strings.TrimSpace(method))] = true
+		}
+	}
+
+	if raw, ok := params["keyHeaders"]; ok {
+		headersRaw, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'keyHeaders' must be an array")
+		}
+		p.keyHeaders = nil
+		for i, h := range headersRaw {
+			name, ok := h.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("keyHeaders[%d] must be a non-empty string", i)
+			}
+			p.keyHeaders = append(p.keyHeaders, strings.ToLower(strings.TrimSpace(name)))
+		}
+	}
+
+	if raw, ok := params["waitTimeoutMs"]; ok {
+		timeout, err := extractInt(raw)
+		if err != nil || timeout < 1 {
+			return nil, fmt.Errorf("'waitTimeoutMs' must be a positive integer")
+		}
+		p.waitTimeout = time.Duration(timeout) * time.Millisecond
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RequestCollapsePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need method, path and key headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need response headers to share
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to share
+	}
+}
+
+// OnRequest lets the first of a set of identical requests through and makes the others wait for
+// its response. Waiting requests that time out are forwarded upstream.
+func (p *RequestCollapsePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if !p.methods[strings.ToUpper(ctx.Method)] || ctx.SharedContext == nil {
+		return policy.UpstreamRequestModifications{}
+	}
+	if name := p.unkeyedCredential(ctx.Headers); name != "" {
+		// Responses to credentialed requests may be specific to the client
+		slog.Debug("RequestCollapse: Not collapsing request with unkeyed credentials", "header", name)
+		return policy.UpstreamRequestModifications{}
+	}
+	base := p.requestKey(ctx)
+
+	p.mu.Lock()
+	key := base + varyKey(ctx.Headers, p.vary[base])
+	now := p.now()
+	f, ok := p.flights[key]
+	if !ok || now.Sub(f.started) >= p.waitTimeout {
+		// Lead a new flight; a stale flight whose leader never responded is replaced
+		f = &flight{key: key, base: base, request: snapshot(ctx.Headers), started: now, done: make(chan struct{})}
+		p.flights[key] = f
+		p.mu.Unlock()
+
+		if ctx.Metadata == nil {
+			ctx.Metadata = make(map[string]interface{})
+		}
+		ctx.Metadata[flightMetadataKey] = f
+		return policy.UpstreamRequestModifications{}
+	}
+	f.waiters++
+	remaining := p.waitTimeout - now.Sub(f.started)
+	p.mu.Unlock()
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+	select {
+	case <-f.done:
+	case <-timer.C:
+		slog.Debug("RequestCollapse: Timed out waiting for in-flight request", "key", key)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	if f.result == nil {
+		return policy.UpstreamRequestModifications{}
+	}
+	for _, name := range f.result.vary {
+		// The response varies on a header this request does not share with the leader
+		if f.request[name] != strings.Join(ctx.Headers.Get(name), ",") {
+			slog.Debug("RequestCollapse: Response varies on a differing header", "key", key, "header", name)
+			return policy.UpstreamRequestModifications{}
+		}
+	}
+
+	headers := make(map[string]string, len(f.result.headers)+1)
+	for name, value := range f.result.headers {
+		headers[name] = value
+	}
+	headers["x-request-collapsed"] = "true"
+	return policy.ImmediateResponse{
+		StatusCode: f.result.status,
+		Headers:    headers,
+		Body:       f.result.body,
+	}
+}
+
+// OnResponse publishes the leader's response to the requests waiting on its flight
+func (p *RequestCollapsePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.SharedContext == nil {
+		return nil
+	}
+	f, ok := ctx.Metadata[flightMetadataKey].(*flight)
+	if !ok {
+		return nil
+	}
+	delete(ctx.Metadata, flightMetadataKey)
+
+	p.complete(f, shareable(ctx), parseVary(ctx.ResponseHeaders))
+	return nil
+}
+
+// complete ends a flight, remembers the response's Vary headers for later flights and wakes its
+// waiters
+func (p *RequestCollapsePolicy) complete(f *flight, result *sharedResponse, vary []string) {
+	f.once.Do(func() {
+		p.mu.Lock()
+		if p.flights[f.key] == f {
+			delete(p.flights, f.key)
+		}
+		if len(vary) == 0 {
+			delete(p.vary, f.base)
+		} else if _, ok := p.vary[f.base]; ok || len(p.vary) < maxVaryEntries {
+			p.vary[f.base] = vary
+		}
+		waiters := f.waiters
+		p.mu.Unlock()
+
+		f.result = result
+		close(f.done)
+		slog.Debug("RequestCollapse: Completed flight", "key", f.key, "waiters", waiters, "shared", result != nil)
+	})
+}
+
+// shareable captures the response for replay. Responses that set cookies, are marked private or
+// no-store, or vary on every request header are specific to the client and are not shared.
+func shareable(ctx *policy.ResponseContext) *sharedResponse {
+	if ctx.ResponseHeaders.Has("set-cookie") {
+		return nil
+	}
+	for _, directive := range splitList(ctx.ResponseHeaders.Get("cache-control")) {
+		name, _, _ := strings.Cut(directive, "=")
+		if name == "private" || name == "no-store" {
+			return nil
+		}
+	}
+	vary := parseVary(ctx.ResponseHeaders)
+	for _, name := range vary {
+		if name == "*" {
+			return nil
+		}
+	}
+
+	result := &sharedResponse{
+		status:  ctx.ResponseStatus,
+		headers: make(map[string]string),
+		vary:    vary,
+	}
+	if result.status == 0 {
+		result.status = 200
+	}
+	ctx.ResponseHeaders.Iterate(func(name string, values []string) {
+		name = strings.ToLower(name)
+		if excludedHeaders[name] || strings.HasPrefix(name, ":") {
+			return
+		}
+		result.headers[name] = strings.Join(values, ", ")
+	})
+	if ctx.ResponseBody != nil && ctx.ResponseBody.Present {
+		result.body = append([]byte(nil), ctx.ResponseBody.Content...)
+	}
+	return result
+}
+
+// unkeyedCredential returns the first credential header present on the request that is not part
+// of the key, or "" when the request can be collapsed
+func (p *RequestCollapsePolicy) unkeyedCredential(headers *policy.Headers) string {
+	for _, name := range credentialHeaders {
+		if !headers.Has(name) {
+			continue
+		}
+		keyed := false
+		for _, keyHeader := range p.keyHeaders {
+			if keyHeader == name {
+				keyed = true
+				break
+			}
+		}
+		if !keyed {
+			return name
+		}
+	}
+	return ""
+}
+
+// requestKey identifies identical requests by method, authority, path and the key headers
+func (p *RequestCollapsePolicy) requestKey(ctx *policy.RequestContext) string {
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(ctx.Method))
+	b.WriteString(" ")
+	b.WriteString(ctx.Authority)
+	b.WriteString(ctx.Path)
+	for _, name := range p.keyHeaders {
+		b.WriteString("\n")
+		b.WriteString(name)
+		b.WriteString(":")
+		b.WriteString(strings.Join(ctx.Headers.Get(name), ","))
+	}
+	return b.String()
+}
+
+// varyKey folds the values of the request headers a resource varies on into its key
+func varyKey(headers *policy.Headers, vary []string) string {
+	var b strings.Builder
+	for _, name := range vary {
+		b.WriteString("\nvary ")
+		b.WriteString(name)
+		b.WriteString(":")
+		b.WriteString(strings.Join(headers.Get(name), ","))
+	}
+	return b.String()
+}
+
+// snapshot copies request header values so they can be compared after the request has moved on
+func snapshot(headers *policy.Headers) map[string]string {
+	values := make(map[string]string)
+	headers.Iterate(func(name string, v []string) {
+		values[strings.ToLower(name)] = strings.Join(v, ",")
+	})
+	return values
+}
+
+// parseVary returns the lower-cased header names listed in the Vary response header
+func parseVary(headers *policy.Headers) []string {
+	return splitList(headers.Get("vary"))
+}
+
+// splitList splits comma-separated header values into trimmed, lower-cased elements
+func splitList(values []string) []string {
+	var elements []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.ToLower(strings.TrimSpace(element)); element != "" {
+				elements = append(elements, element)
+			}
+		}
+	}
+	return elements
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package requestcollapse
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *RequestCollapsePolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*RequestCollapsePolicy)
+}
+
+func onRequest(p policy.Policy, method, path string, headers map[string][]string) (policy.RequestAction, *policy.SharedContext) {
+	shared := &policy.SharedContext{Metadata: map[string]interface{}{}}
+	ctx := &policy.RequestContext{
+		SharedContext: shared,
+		Headers:       policy.NewHeaders(headers),
+		Method:        method,
+		Path:          path,
+	}
+	return p.OnRequest(ctx, nil), shared
+}
+
+func onResponse(p policy.Policy, shared *policy.SharedContext, headers map[string][]string, body string) {
+	p.OnResponse(&policy.ResponseContext{
+		SharedContext:   shared,
+		ResponseHeaders: policy.NewHeaders(headers),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+		ResponseStatus:  200,
+	}, nil)
+}
+
+func isForwarded(action policy.RequestAction) bool {
+	_, ok := action.(policy.UpstreamRequestModifications)
+	return ok
+}
+
+// waitForWaiters blocks until n requests are waiting on the flight for key
+func waitForWaiters(t *testing.T, p *RequestCollapsePolicy, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		p.mu.Lock()
+		f := p.flights[key]
+		waiting := f != nil && f.waiters == n
+		p.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d waiters", n)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"methods": []interface{}{}},
+		{"methods": []interface{}{1}},
+		{"keyHeaders": "accept"},
+		{"waitTimeoutMs": 0},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRequestCollapsePolicy_SingleUpstreamInvocation(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	const duplicates = 5
+
+	leaderAction, leaderShared := onRequest(p, "GET", "/products?page=1", nil)
+	if _, ok := leaderAction.(policy.UpstreamRequestModifications); !ok {
+		t.Fatalf("Expected leader to be forwarded, got %T", leaderAction)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]policy.RequestAction, duplicates)
+	for i := 0; i < duplicates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = onRequest(p, "GET", "/products?page=1", nil)
+		}(i)
+	}
+
+	waitForWaiters(t, p, "GET /products?page=1\nauthorization:", duplicates)
+	onResponse(p, leaderShared, map[string][]string{
+		"content-type":   {"application/json"},
+		"content-length": {"13"},
+	}, `{"items":[1]}`)
+	wg.Wait()
+
+	upstreamCalls := 1
+	for i, action := range results {
+		resp, ok := action.(policy.ImmediateResponse)
+		if !ok {
+			upstreamCalls++
+			continue
+		}
+		if resp.StatusCode != 200 || string(resp.Body) != `{"items":[1]}` {
+			t.Errorf("Expected duplicate %d to share the response, got %d %s", i, resp.StatusCode, resp.Body)
+		}
+		if resp.Headers["content-type"] != "application/json" || resp.Headers["x-request-collapsed"] != "true" {
+			t.Errorf("Expected shared headers, got %v", resp.Headers)
+		}
+		if _, ok := resp.Headers["content-length"]; ok {
+			t.Errorf("Expected content-length not to be copied, got %v", resp.Headers)
+		}
+	}
+	if upstreamCalls != 1 {
+		t.Errorf("Expected a single upstream invocation, got %d", upstreamCalls)
+	}
+
+	// The flight is finished, so the next request goes upstream again
+	if action, _ := onRequest(p, "GET", "/products?page=1", nil); !isForwarded(action) {
+		t.Errorf("Expected new request to be forwarded, got %T", action)
+	}
+}
+
+func TestRequestCollapsePolicy_DistinctRequestsNotCollapsed(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"keyHeaders": []interface{}{"Accept"}})
+
+	onRequest(p, "GET", "/products", map[string][]string{"accept": {"application/json"}})
+	requests := []struct {
+		method, path string
+		headers      map[string][]string
+	}{
+		{"GET", "/products?page=2", map[string][]string{"accept": {"application/json"}}},
+		{"GET", "/products", map[string][]string{"accept": {"text/html"}}},
+		{"POST", "/products", map[string][]string{"accept": {"application/json"}}},
+	}
+	for _, r := range requests {
+		if action, _ := onRequest(p, r.method, r.path, r.headers); !isForwarded(action) {
+			t.Errorf("Expected %s %s %v to be forwarded, got %T", r.method, r.path, r.headers, action)
+		}
+	}
+}
+
+func TestRequestCollapsePolicy_CookieResponseNotShared(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	_, leaderShared := onRequest(p, "GET", "/session", nil)
+	done := make(chan policy.RequestAction)
+	go func() {
+		action, _ := onRequest(p, "GET", "/session", nil)
+		done <- action
+	}()
+
+	waitForWaiters(t, p, "GET /session\nauthorization:", 1)
+	onResponse(p, leaderShared, map[string][]string{"set-cookie": {"id=1"}}, "hello")
+	if action := <-done; !isForwarded(action) {
+		t.Errorf("Expected duplicate to be forwarded when the response sets cookies, got %T", action)
+	}
+}
+
+func TestRequestCollapsePolicy_WaitTimeout(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"waitTimeoutMs": 20})
+
+	onRequest(p, "GET", "/slow", nil)
+	action, _ := onRequest(p, "GET", "/slow", nil)
+	if !isForwarded(action) {
+		t.Errorf("Expected duplicate to be forwarded after the wait timeout, got %T", action)
+	}
+}
+
+func TestRequestCollapsePolicy_DifferentAuthorizationNotCollapsed(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	alice := map[string][]string{"authorization": {"Bearer alice"}}
+	bob := map[string][]string{"authorization": {"Bearer bob"}}
+	_, leaderShared := onRequest(p, "GET", "/me", alice)
+	if action, _ := onRequest(p, "GET", "/me", bob); !isForwarded(action) {
+		t.Fatalf("Expected a request with different credentials to be forwarded, got %T", action)
+	}
+
+	// The same credentials still collapse
+	done := make(chan policy.RequestAction)
+	go func() {
+		action, _ := onRequest(p, "GET", "/me", alice)
+		done <- action
+	}()
+	waitForWaiters(t, p, "GET /me\nauthorization:Bearer alice", 1)
+	onResponse(p, leaderShared, nil, `{"user":"alice"}`)
+	if resp, ok := (<-done).(policy.ImmediateResponse); !ok || string(resp.Body) != `{"user":"alice"}` {
+		t.Error("Expected a request with the same credentials to share the response")
+	}
+}
+
+func TestRequestCollapsePolicy_UnkeyedCredentialsNotCollapsed(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"keyHeaders": []interface{}{"accept"}})
+
+	for _, headers := range []map[string][]string{
+		{"authorization": {"Bearer alice"}},
+		{"cookie": {"session=1"}},
+	} {
+		onRequest(p, "GET", "/me", headers)
+		if action, _ := onRequest(p, "GET", "/me", headers); !isForwarded(action) {
+			t.Errorf("Expected request with %v to be forwarded, got %T", headers, action)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.flights) != 0 {
+		t.Errorf("Expected credentialed requests not to start flights, got %d", len(p.flights))
+	}
+}
+
+func TestRequestCollapsePolicy_PrivateResponseNotShared(t *testing.T) {
+	for _, headers := range []map[string][]string{
+		{"cache-control": {"max-age=60, private"}},
+		{"cache-control": {"no-store"}},
+		{"vary": {"*"}},
+	} {
+		p := newPolicy(t, map[string]interface{}{})
+
+		_, leaderShared := onRequest(p, "GET", "/account", nil)
+		done := make(chan policy.RequestAction)
+		go func() {
+			action, _ := onRequest(p, "GET", "/account", nil)
+			done <- action
+		}()
+
+		waitForWaiters(t, p, "GET /account\nauthorization:", 1)
+		onResponse(p, leaderShared, headers, "secret")
+		if action := <-done; !isForwarded(action) {
+			t.Errorf("Expected duplicate to be forwarded when the response has %v, got %T", headers, action)
+		}
+	}
+}
+
+func TestRequestCollapsePolicy_VaryHeaders(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	english := map[string][]string{"accept-language": {"en"}}
+	french := map[string][]string{"accept-language": {"fr"}}
+
+	// A waiter whose varied header differs from the leader's is forwarded, not answered
+	_, leaderShared := onRequest(p, "GET", "/greeting", english)
+	done := make(chan policy.RequestAction)
+	go func() {
+		action, _ := onRequest(p, "GET", "/greeting", french)
+		done <- action
+	}()
+	waitForWaiters(t, p, "GET /greeting\nauthorization:", 1)
+	onResponse(p, leaderShared, map[string][]string{"vary": {"Accept-Language"}}, "hello")
+	if action := <-done; !isForwarded(action) {
+		t.Fatalf("Expected a request with a different Accept-Language to be forwarded, got %T", action)
+	}
+
+	// Once the Vary header is known, each variant gets its own flight
+	onRequest(p, "GET", "/greeting", english)
+	if action, _ := onRequest(p, "GET", "/greeting", french); !isForwarded(action) {
+		t.Errorf("Expected the other variant to lead its own flight, got %T", action)
+	}
+	p.mu.Lock()
+	_, enFlight := p.flights["GET /greeting\nauthorization:\nvary accept-language:en"]
+	_, frFlight := p.flights["GET /greeting\nauthorization:\nvary accept-language:fr"]
+	p.mu.Unlock()
+	if !enFlight || !frFlight {
+		t.Error("Expected the Vary header values to be folded into the flight keys")
+	}
+}
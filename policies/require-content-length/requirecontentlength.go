@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package requirecontentlength
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// RequireContentLengthPolicy rejects requests without a Content-Length header for upstreams
+// that cannot handle chunked request bodies
+type RequireContentLengthPolicy struct {
+	methods     map[string]bool
+	exemptTypes []string // path.Match patterns over lower-cased media types
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &RequireContentLengthPolicy{
+		methods: map[string]bool{"POST": true, "PUT": true, "PATCH": true},
+	}
+
+	if raw, ok := params["methods"]; ok {
+		methodsRaw, ok := raw.([]interface{})
+		if !ok || len(methodsRaw) == 0 {
+			return nil, fmt.Errorf("'methods' must be a non-empty array")
+		}
+		p.methods = make(map[string]bool)
+		for i, m := range methodsRaw {
+			method, ok := m.(string)
+			if !ok || strings.TrimSpace(method) == "" {
+				return nil, fmt.Errorf("methods[%d] must be a non-empty string", i)
+			}
+			p.methods[strings.ToUpper(strings.TrimSpace(method))] = true
+		}
+	}
+
+	if raw, ok := params["exemptContentTypes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'exemptContentTypes' must be an array")
+		}
+		for i, item := range list {
+			pattern, ok := item.(string)
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if !ok || !strings.Contains(pattern, "/") || strings.ContainsAny(pattern, "; ") {
+				return nil, fmt.Errorf("exemptContentTypes[%d] must be a media type pattern like 'application/grpc' or 'video/*'", i)
+			}
+			if _, err := path.Match(pattern, "/"); err != nil {
+				return nil, fmt.Errorf("exemptContentTypes[%d] is not a valid pattern: %w", i, err)
+			}
+			p.exemptTypes = append(p.exemptTypes, pattern)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RequireContentLengthPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need body framing headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests for the configured methods that don't declare a Content-Length
+func (p *RequireContentLengthPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if !p.methods[strings.ToUpper(ctx.Method)] || p.isExempt(ctx.Headers) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	values := ctx.Headers.Get("content-length")
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		slog.Debug("RequireContentLength: Rejecting request without content-length", "method", ctx.Method)
+		return errorResponse(http.StatusLengthRequired, "Length Required",
+			fmt.Sprintf("A Content-Length header is required for %s requests", strings.ToUpper(ctx.Method)))
+	}
+
+	if n, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 64); err != nil || n < 0 {
+		return errorResponse(http.StatusBadRequest, "Bad Request", "Content-Length header must be a non-negative integer")
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *RequireContentLengthPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// isExempt reports whether the request content type matches one of the exempt patterns
+func (p *RequireContentLengthPolicy) isExempt(headers *policy.Headers) bool {
+	if len(p.exemptTypes) == 0 {
+		return false
+	}
+	values := headers.Get("content-type")
+	if len(values) == 0 {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(values[0])
+	if err != nil {
+		return false
+	}
+	for _, pattern := range p.exemptTypes {
+		if matched, _ := path.Match(pattern, mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, title, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package requirecontentlength
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, method string, headers map[string][]string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Method:  method,
+		Headers: policy.NewHeaders(headers),
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"methods": []interface{}{}},
+		{"methods": []interface{}{""}},
+		{"exemptContentTypes": "video/*"},
+		{"exemptContentTypes": []interface{}{"video"}},
+		{"exemptContentTypes": []interface{}{"video/[a"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRequireContentLengthPolicy_MissingOnPostRejected(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "POST", map[string][]string{"transfer-encoding": {"chunked"}}), 411)
+	policytest.ExpectStatus(t, onRequest(p, "put", map[string][]string{}), 411)
+	policytest.ExpectStatus(t, onRequest(p, "POST", map[string][]string{"content-length": {"ten"}}), 400)
+}
+
+func TestRequireContentLengthPolicy_PresentPasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "POST", map[string][]string{"content-length": {"42"}}), 0)
+	policytest.ExpectStatus(t, onRequest(p, "PATCH", map[string][]string{"content-length": {"0"}}), 0)
+}
+
+func TestRequireContentLengthPolicy_ExemptPasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"methods":            []interface{}{"post"},
+		"exemptContentTypes": []interface{}{"video/*", "application/grpc"},
+	})
+
+	policytest.ExpectStatus(t, onRequest(p, "POST", map[string][]string{"content-type": {"Video/MP4"}}), 0)
+	policytest.ExpectStatus(t, onRequest(p, "POST", map[string][]string{"content-type": {"application/grpc; proto=x"}}), 0)
+	// Methods outside the list are not checked
+	policytest.ExpectStatus(t, onRequest(p, "PUT", map[string][]string{}), 0)
+	policytest.ExpectStatus(t, onRequest(p, "POST", map[string][]string{"content-type": {"application/json"}}), 411)
+}
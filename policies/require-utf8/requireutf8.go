@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package requireutf8
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+// defaultContentTypes are the media types checked when 'contentTypes' is not configured
+var defaultContentTypes = []string{"application/json", "application/*+json", "text/*"}
+
+// RequireUTF8Policy rejects text and JSON request bodies that are not valid UTF-8
+type RequireUTF8Policy struct {
+	contentTypes []string // path.Match patterns over lower-cased media types
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &RequireUTF8Policy{contentTypes: defaultContentTypes}
+
+	if raw, ok := params["contentTypes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'contentTypes' must be a non-empty array")
+		}
+		p.contentTypes = make([]string, 0, len(list))
+		for i, item := range list {
+			pattern, ok := item.(string)
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if !ok || !strings.Contains(pattern, "/") || strings.ContainsAny(pattern, "; ") {
+				return nil, fmt.Errorf("contentTypes[%d] must be a media type pattern like 'application/json' or 'text/*'", i)
+			}
+			if _, err := path.Match(pattern, "/"); err != nil {
+				return nil, fmt.Errorf("contentTypes[%d] is not a valid pattern: %w", i, err)
+			}
+			p.contentTypes = append(p.contentTypes, pattern)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RequireUTF8Policy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to validate its encoding
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests with a matching content type whose body is not valid UTF-8. The
+// check fails closed: encoded bodies are rejected rather than skipped, and streaming or partial
+// bodies are validated as far as they are buffered.
+func (p *RequireUTF8Policy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	if !p.matches(bodyutil.MediaType(ctx.Headers)) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be validated without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("RequireUTF8: Rejecting encoded request body")
+		return errorResponse(http.StatusUnsupportedMediaType, "Unsupported Media Type",
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	if validUTF8(ctx.Body.Content, ctx.Body.EndOfStream) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("RequireUTF8: Rejecting request body with invalid UTF-8")
+	return errorResponse(http.StatusBadRequest, "Bad Request", "Request body is not valid UTF-8")
+}
+
+// OnResponse is not used by this policy
+func (p *RequireUTF8Policy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// validUTF8 reports whether content is valid UTF-8. When more of the body is still to come, a
+// character split across the end of the buffered content is allowed.
+func validUTF8(content []byte, complete bool) bool {
+	if !complete {
+		for i := len(content) - 1; i >= 0 && i >= len(content)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(content[i]) {
+				if !utf8.FullRune(content[i:]) {
+					content = content[:i]
+				}
+				break
+			}
+		}
+	}
+	return utf8.Valid(content)
+}
+
+// errorResponse returns a JSON error response
+func errorResponse(status int, errorText, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   errorText,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// matches reports whether a media type matches one of the configured patterns
+func (p *RequireUTF8Policy) matches(mediaType string) bool {
+	if mediaType == "" {
+		return false
+	}
+	for _, pattern := range p.contentTypes {
+		if matched, _ := path.Match(pattern, mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package requireutf8
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, contentType string, body []byte) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: body, Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+var invalidUTF8 = []byte("{\"name\":\"caf\xe9\"}")
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"contentTypes": "text/*"},
+		{"contentTypes": []interface{}{}},
+		{"contentTypes": []interface{}{"json"}},
+		{"contentTypes": []interface{}{"text/[a"}},
+		{"contentTypes": []interface{}{"text/plain; charset=utf-8"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRequireUTF8Policy_ValidBodyPasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", []byte(`{"name":"café 日本"}`)), 0)
+	policytest.ExpectStatus(t, onRequest(p, "text/plain; charset=utf-8", []byte("plain ascii")), 0)
+}
+
+func TestRequireUTF8Policy_InvalidBytesRejected(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/json", invalidUTF8), 400)
+	policytest.ExpectStatus(t, onRequest(p, "application/vnd.api+json", invalidUTF8), 400)
+	policytest.ExpectStatus(t, onRequest(p, "Text/CSV", []byte("a,b\n\xff\xfe")), 400)
+}
+
+func TestRequireUTF8Policy_BinaryContentTypeSkipped(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	policytest.ExpectStatus(t, onRequest(p, "application/octet-stream", invalidUTF8), 0)
+	policytest.ExpectStatus(t, onRequest(p, "image/png", []byte{0x89, 'P', 'N', 'G', 0xff}), 0)
+
+	// Only the configured types are checked
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"contentTypes": []interface{}{"application/xml"}})
+	policytest.ExpectStatus(t, onRequest(p, "application/json", invalidUTF8), 0)
+	policytest.ExpectStatus(t, onRequest(p, "application/xml", invalidUTF8), 400)
+}
+
+func TestRequireUTF8Policy_EncodedAndStreamingBodiesFailClosed(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	request := func(headers map[string][]string, body []byte, endOfStream bool) policy.RequestAction {
+		return p.OnRequest(&policy.RequestContext{
+			Headers: policy.NewHeaders(headers),
+			Body:    &policy.Body{Content: body, Present: true, EndOfStream: endOfStream},
+		}, nil)
+	}
+
+	// Declaring a content coding must not skip validation
+	gzipJSON := map[string][]string{"content-type": {"application/json"}, "content-encoding": {"gzip"}}
+	policytest.ExpectStatus(t, request(gzipJSON, invalidUTF8, true), 415)
+	policytest.ExpectStatus(t, request(gzipJSON, []byte(`{"valid":true}`), true), 415)
+	// Encoded bodies of unchecked types still pass through
+	policytest.ExpectStatus(t, request(map[string][]string{"content-type": {"image/png"}, "content-encoding": {"gzip"}}, invalidUTF8, true), 0)
+
+	// Streaming types are validated as far as they are buffered
+	sse := map[string][]string{"content-type": {"text/event-stream"}}
+	policytest.ExpectStatus(t, request(sse, []byte("data: caf\xe9\n\n"), true), 400)
+	policytest.ExpectStatus(t, request(sse, []byte("data: café\n\n"), true), 0)
+
+	// A partial chunked body may end in the middle of a character, but not in invalid bytes
+	chunked := map[string][]string{"content-type": {"text/plain"}, "transfer-encoding": {"chunked"}}
+	policytest.ExpectStatus(t, request(chunked, []byte("caf\xc3"), false), 0)
+	policytest.ExpectStatus(t, request(chunked, []byte("caf\xc3"), true), 400)
+	policytest.ExpectStatus(t, request(chunked, []byte("\xff caf\xc3"), false), 400)
+}
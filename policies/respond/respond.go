@@ -18,8 +18,12 @@
 package respond
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"text/template"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
 )
@@ -34,9 +38,115 @@ func GetPolicy(
 	metadata policy.PolicyMetadata,
 	params map[string]interface{},
 ) (policy.Policy, error) {
+	// Body templates are parsed up front so that syntax errors fail at load time
+	templated, err := isTemplated(params)
+	if err != nil {
+		return nil, err
+	}
+	if !templated {
+		return ins, nil
+	}
+	if _, err := bodyTemplate(params); err != nil {
+		return nil, err
+	}
+	if rules, ok := params["rules"].([]interface{}); ok {
+		for i, ruleRaw := range rules {
+			if rule, ok := ruleRaw.(map[string]interface{}); ok {
+				if _, err := bodyTemplate(rule); err != nil {
+					return nil, fmt.Errorf("rules[%d].%s", i, err.Error())
+				}
+			}
+		}
+	}
 	return ins, nil
 }
 
+// templateCache holds parsed body templates keyed by their source text
+var templateCache sync.Map
+
+// templateFuncs are the helper functions available to body templates
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// templateData is the context a body template is evaluated against
+type templateData struct {
+	Method     string
+	Path       string // Request path without the query string
+	Query      string // Raw query string
+	Authority  string
+	Scheme     string
+	RequestID  string
+	APIName    string
+	APIVersion string
+	Metadata   map[string]interface{}
+	Headers    map[string][]string // Request headers keyed by lower-cased name
+}
+
+// Header returns the first value of a request header
+func (d templateData) Header(name string) string {
+	if values := d.Headers[strings.ToLower(name)]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func newTemplateData(ctx *policy.RequestContext) templateData {
+	path, query, _ := strings.Cut(ctx.Path, "?")
+	data := templateData{
+		Method:    ctx.Method,
+		Path:      path,
+		Query:     query,
+		Authority: ctx.Authority,
+		Scheme:    ctx.Scheme,
+		Headers:   ctx.Headers.GetAll(),
+	}
+	if ctx.SharedContext != nil {
+		data.RequestID = ctx.RequestID
+		data.APIName = ctx.APIName
+		data.APIVersion = ctx.APIVersion
+		data.Metadata = ctx.Metadata
+	}
+	return data
+}
+
+// isTemplated reports whether bodies are evaluated as Go text/template
+func isTemplated(params map[string]interface{}) (bool, error) {
+	raw, ok := params["template"]
+	if !ok {
+		return false, nil
+	}
+	templated, ok := raw.(bool)
+	if !ok {
+		return false, fmt.Errorf("template must be a boolean")
+	}
+	return templated, nil
+}
+
+// bodyTemplate returns the parsed template for a config's body, or nil when there is no body
+func bodyTemplate(config map[string]interface{}) (*template.Template, error) {
+	source, ok := config["body"].(string)
+	if !ok {
+		return nil, nil
+	}
+	if cached, ok := templateCache.Load(source); ok {
+		return cached.(*template.Template), nil
+	}
+	tmpl, err := template.New("body").Funcs(templateFuncs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("body is not a valid template: %w", err)
+	}
+	templateCache.Store(source, tmpl)
+	return tmpl, nil
+}
+
 // configError returns a 500 error response for configuration issues
 func configError(message string) policy.ImmediateResponse {
 	errBody, _ := json.Marshal(map[string]string{
@@ -62,11 +172,52 @@ func (p *RespondPolicy) Mode() policy.ProcessingMode {
 	}
 }
 
-// OnRequest returns an immediate response to the client
+// OnRequest returns an immediate response to the client.
+// When "rules" are configured, the first rule whose match block matches the request determines
+// the response; otherwise the top-level statusCode, headers and body are used.
 func (p *RespondPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	templated, err := isTemplated(params)
+	if err != nil {
+		return configError(err.Error())
+	}
+
+	if rulesRaw, ok := params["rules"]; ok {
+		rules, ok := rulesRaw.([]interface{})
+		if !ok {
+			return configError("rules must be an array")
+		}
+		for i, ruleRaw := range rules {
+			rule, ok := ruleRaw.(map[string]interface{})
+			if !ok {
+				return configError(fmt.Sprintf("rules[%d] must be an object", i))
+			}
+			matched, err := matches(ctx, rule["match"])
+			if err != nil {
+				return configError(fmt.Sprintf("rules[%d].match: %s", i, err.Error()))
+			}
+			if matched {
+				resp, err := buildResponse(ctx, rule, templated)
+				if err != nil {
+					return configError(fmt.Sprintf("rules[%d].%s", i, err.Error()))
+				}
+				return resp
+			}
+		}
+	}
+
+	resp, err := buildResponse(ctx, params, templated)
+	if err != nil {
+		return configError(err.Error())
+	}
+	return resp
+}
+
+// buildResponse builds an immediate response from statusCode, body and headers fields. When
+// templated is set, the body is evaluated as a Go text/template against the request.
+func buildResponse(ctx *policy.RequestContext, config map[string]interface{}, templated bool) (policy.ImmediateResponse, error) {
 	// Extract statusCode (default to 200 OK)
 	statusCode := 200
-	if statusCodeRaw, ok := params["statusCode"]; ok {
+	if statusCodeRaw, ok := config["statusCode"]; ok {
 		switch v := statusCodeRaw.(type) {
 		case float64:
 			statusCode = int(v)
@@ -77,7 +228,7 @@ func (p *RespondPolicy) OnRequest(ctx *policy.RequestContext, params map[string]
 
 	// Extract body
 	var body []byte
-	if bodyRaw, ok := params["body"]; ok {
+	if bodyRaw, ok := config["body"]; ok {
 		switch v := bodyRaw.(type) {
 		case string:
 			body = []byte(v)
@@ -85,43 +236,32 @@ func (p *RespondPolicy) OnRequest(ctx *policy.RequestContext, params map[string]
 			body = v
 		}
 	}
+	if templated {
+		tmpl, err := bodyTemplate(config)
+		if err != nil {
+			return policy.ImmediateResponse{}, err
+		}
+		if tmpl != nil {
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, newTemplateData(ctx)); err != nil {
+				return policy.ImmediateResponse{}, fmt.Errorf("body template failed: %w", err)
+			}
+			body = buf.Bytes()
+		}
+	}
 
 	// Extract headers with fail-fast validation
 	headers := make(map[string]string)
-	if headersRaw, ok := params["headers"]; ok {
+	if headersRaw, ok := config["headers"]; ok {
 		headersList, ok := headersRaw.([]interface{})
 		if !ok {
-			return configError("headers must be an array")
+			return policy.ImmediateResponse{}, fmt.Errorf("headers must be an array")
 		}
 		for i, headerRaw := range headersList {
-			headerMap, ok := headerRaw.(map[string]interface{})
-			if !ok {
-				return configError(fmt.Sprintf("headers[%d] must be an object", i))
-			}
-
-			// Safe type assertion for name
-			nameRaw, ok := headerMap["name"]
-			if !ok {
-				return configError(fmt.Sprintf("headers[%d] missing required 'name' field", i))
-			}
-			name, ok := nameRaw.(string)
-			if !ok {
-				return configError(fmt.Sprintf("headers[%d].name must be a string", i))
-			}
-			if name == "" {
-				return configError(fmt.Sprintf("headers[%d].name cannot be empty", i))
-			}
-
-			// Safe type assertion for value
-			valueRaw, ok := headerMap["value"]
-			if !ok {
-				return configError(fmt.Sprintf("headers[%d] missing required 'value' field", i))
-			}
-			value, ok := valueRaw.(string)
-			if !ok {
-				return configError(fmt.Sprintf("headers[%d].value must be a string", i))
+			name, value, err := parseHeader(headerRaw, fmt.Sprintf("headers[%d]", i), true)
+			if err != nil {
+				return policy.ImmediateResponse{}, err
 			}
-
 			headers[name] = value
 		}
 	}
@@ -131,7 +271,128 @@ func (p *RespondPolicy) OnRequest(ctx *policy.RequestContext, params map[string]
 		StatusCode: statusCode,
 		Headers:    headers,
 		Body:       body,
+	}, nil
+}
+
+// parseHeader extracts the name and value of a header entry; location identifies the entry
+// in error messages
+func parseHeader(headerRaw interface{}, location string, valueRequired bool) (string, string, error) {
+	headerMap, ok := headerRaw.(map[string]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("%s must be an object", location)
+	}
+
+	// Safe type assertion for name
+	nameRaw, ok := headerMap["name"]
+	if !ok {
+		return "", "", fmt.Errorf("%s missing required 'name' field", location)
+	}
+	name, ok := nameRaw.(string)
+	if !ok {
+		return "", "", fmt.Errorf("%s.name must be a string", location)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("%s.name cannot be empty", location)
+	}
+
+	// Safe type assertion for value
+	valueRaw, ok := headerMap["value"]
+	if !ok {
+		if valueRequired {
+			return "", "", fmt.Errorf("%s missing required 'value' field", location)
+		}
+		return name, "", nil
+	}
+	value, ok := valueRaw.(string)
+	if !ok {
+		return "", "", fmt.Errorf("%s.value must be a string", location)
+	}
+
+	return name, value, nil
+}
+
+// matches evaluates a rule's match block against the request. All configured predicates must
+// match; a missing or empty match block matches every request.
+func matches(ctx *policy.RequestContext, matchRaw interface{}) (bool, error) {
+	if matchRaw == nil {
+		return true, nil
+	}
+	match, ok := matchRaw.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("must be an object")
+	}
+
+	if methodRaw, ok := match["method"]; ok {
+		method, ok := methodRaw.(string)
+		if !ok {
+			return false, fmt.Errorf("method must be a string")
+		}
+		if !strings.EqualFold(method, ctx.Method) {
+			return false, nil
+		}
+	}
+
+	// Path predicates ignore the query string
+	path, _, _ := strings.Cut(ctx.Path, "?")
+
+	if pathRaw, ok := match["path"]; ok {
+		expected, ok := pathRaw.(string)
+		if !ok {
+			return false, fmt.Errorf("path must be a string")
+		}
+		if path != expected {
+			return false, nil
+		}
+	}
+
+	if prefixRaw, ok := match["pathPrefix"]; ok {
+		prefix, ok := prefixRaw.(string)
+		if !ok {
+			return false, fmt.Errorf("pathPrefix must be a string")
+		}
+		if !strings.HasPrefix(path, prefix) {
+			return false, nil
+		}
+	}
+
+	if headersRaw, ok := match["headers"]; ok {
+		headersList, ok := headersRaw.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("headers must be an array")
+		}
+		for i, headerRaw := range headersList {
+			name, value, err := parseHeader(headerRaw, fmt.Sprintf("headers[%d]", i), false)
+			if err != nil {
+				return false, err
+			}
+			if !headerMatches(ctx.Headers, name, value) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// headerMatches reports whether the request has the header and, when a value is given,
+// whether one of its values equals it
+func headerMatches(headers *policy.Headers, name, value string) bool {
+	if headers == nil {
+		return false
+	}
+	values := headers.Get(strings.ToLower(name))
+	if len(values) == 0 {
+		return false
+	}
+	if value == "" {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
 }
 
 // OnResponse is not used by this policy (returns immediate response in request phase)
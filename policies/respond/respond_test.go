@@ -0,0 +1,216 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package respond
+
+import (
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+func newRequest(method, path string, headers map[string][]string) *policy.RequestContext {
+	return &policy.RequestContext{
+		Method:  method,
+		Path:    path,
+		Headers: policy.NewHeaders(headers),
+	}
+}
+
+func respond(t *testing.T, ctx *policy.RequestContext, params map[string]interface{}) policy.ImmediateResponse {
+	t.Helper()
+	p, err := GetPolicy(policy.PolicyMetadata{}, params)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp, ok := p.OnRequest(ctx, params).(policy.ImmediateResponse)
+	if !ok {
+		t.Fatal("Expected ImmediateResponse")
+	}
+	return resp
+}
+
+func rule(match map[string]interface{}, statusCode int, body string) map[string]interface{} {
+	return map[string]interface{}{
+		"match":      match,
+		"statusCode": float64(statusCode),
+		"body":       body,
+	}
+}
+
+func TestRespondPolicy_SingleResponse(t *testing.T) {
+	params := map[string]interface{}{
+		"statusCode": float64(201),
+		"body":       `{"ok":true}`,
+		"headers": []interface{}{
+			map[string]interface{}{"name": "content-type", "value": "application/json"},
+		},
+	}
+
+	resp := respond(t, newRequest("GET", "/", nil), params)
+	if resp.StatusCode != 201 || string(resp.Body) != `{"ok":true}` {
+		t.Errorf("Unexpected response: %d %s", resp.StatusCode, resp.Body)
+	}
+	if resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected content-type header, got %v", resp.Headers)
+	}
+}
+
+func TestRespondPolicy_FirstMatchWins(t *testing.T) {
+	params := map[string]interface{}{
+		"rules": []interface{}{
+			rule(map[string]interface{}{"pathPrefix": "/pets"}, 200, "first"),
+			rule(map[string]interface{}{"path": "/pets/1"}, 200, "second"),
+		},
+	}
+
+	resp := respond(t, newRequest("GET", "/pets/1", nil), params)
+	if string(resp.Body) != "first" {
+		t.Errorf("Expected first matching rule, got %s", resp.Body)
+	}
+}
+
+func TestRespondPolicy_DefaultFallback(t *testing.T) {
+	params := map[string]interface{}{
+		"statusCode": float64(404),
+		"body":       "not mocked",
+		"rules": []interface{}{
+			rule(map[string]interface{}{"path": "/pets"}, 200, "pets"),
+		},
+	}
+
+	resp := respond(t, newRequest("GET", "/owners", nil), params)
+	if resp.StatusCode != 404 || string(resp.Body) != "not mocked" {
+		t.Errorf("Expected default response, got %d %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestRespondPolicy_MethodAndPathPredicates(t *testing.T) {
+	params := map[string]interface{}{
+		"rules": []interface{}{
+			rule(map[string]interface{}{"method": "post", "path": "/pets"}, 201, "created"),
+			rule(map[string]interface{}{"method": "GET", "path": "/pets"}, 200, "listed"),
+		},
+	}
+
+	if resp := respond(t, newRequest("POST", "/pets", nil), params); resp.StatusCode != 201 {
+		t.Errorf("Expected 201 for POST, got %d", resp.StatusCode)
+	}
+	if resp := respond(t, newRequest("GET", "/pets?limit=10", nil), params); string(resp.Body) != "listed" {
+		t.Errorf("Expected path match to ignore the query string, got %s", resp.Body)
+	}
+	if resp := respond(t, newRequest("DELETE", "/pets", nil), params); resp.StatusCode != http.StatusOK || len(resp.Body) != 0 {
+		t.Errorf("Expected empty default response, got %d %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestRespondPolicy_HeaderPredicates(t *testing.T) {
+	params := map[string]interface{}{
+		"rules": []interface{}{
+			rule(map[string]interface{}{
+				"headers": []interface{}{
+					map[string]interface{}{"name": "X-Scenario", "value": "error"},
+				},
+			}, 500, "failure"),
+			rule(map[string]interface{}{
+				"headers": []interface{}{
+					map[string]interface{}{"name": "authorization"},
+				},
+			}, 200, "authorized"),
+		},
+		"statusCode": float64(401),
+	}
+
+	resp := respond(t, newRequest("GET", "/", map[string][]string{"x-scenario": {"error"}}), params)
+	if resp.StatusCode != 500 {
+		t.Errorf("Expected header value match, got %d", resp.StatusCode)
+	}
+	resp = respond(t, newRequest("GET", "/", map[string][]string{"authorization": {"Bearer x"}}), params)
+	if string(resp.Body) != "authorized" {
+		t.Errorf("Expected header presence match, got %s", resp.Body)
+	}
+	resp = respond(t, newRequest("GET", "/", map[string][]string{"x-scenario": {"ok"}}), params)
+	if resp.StatusCode != 401 {
+		t.Errorf("Expected default response, got %d", resp.StatusCode)
+	}
+}
+
+func TestRespondPolicy_InvalidRules(t *testing.T) {
+	params := map[string]interface{}{
+		"rules": []interface{}{
+			rule(map[string]interface{}{"method": 1}, 200, ""),
+		},
+	}
+
+	resp := respond(t, newRequest("GET", "/", nil), params)
+	if resp.StatusCode != 500 {
+		t.Errorf("Expected configuration error, got %d", resp.StatusCode)
+	}
+}
+
+func TestRespondPolicy_TemplatedBody(t *testing.T) {
+	body := `{"method":"{{ .Method }}","user":{{ json (.Header "x-user") }}` +
+		`{{ if .Headers.accept }},"accept":[{{ range $i, $v := .Headers.accept }}{{ if $i }},{{ end }}{{ json $v }}{{ end }}]{{ end }}}`
+	params := map[string]interface{}{
+		"template": true,
+		"body":     "default",
+		"rules": []interface{}{
+			rule(map[string]interface{}{"pathPrefix": "/users"}, 200, body),
+		},
+	}
+
+	resp := respond(t, newRequest("POST", "/users?page=1", map[string][]string{
+		"x-user": {`ann "a"`},
+		"accept": {"application/json", "text/plain"},
+	}), params)
+	expected := `{"method":"POST","user":"ann \"a\"","accept":["application/json","text/plain"]}`
+	if string(resp.Body) != expected {
+		t.Errorf("Expected body %s, got %s", expected, resp.Body)
+	}
+
+	resp = respond(t, newRequest("GET", "/users", nil), params)
+	if string(resp.Body) != `{"method":"GET","user":""}` {
+		t.Errorf("Expected body without accept, got %s", resp.Body)
+	}
+
+	// Without the opt-in the body is returned verbatim
+	delete(params, "template")
+	resp = respond(t, newRequest("GET", "/users", nil), params)
+	if string(resp.Body) != body {
+		t.Errorf("Expected untemplated body, got %s", resp.Body)
+	}
+}
+
+func TestRespondPolicy_TemplateErrors(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"template": "yes", "body": "x"},
+		{"template": true, "body": "{{ if .Method }}"},
+		{"template": true, "rules": []interface{}{rule(nil, 200, "{{ .Method ")}},
+	}
+	for _, params := range invalid {
+		if _, err := GetPolicy(policy.PolicyMetadata{}, params); err == nil {
+			t.Errorf("Expected error for params %v", params)
+		}
+	}
+
+	// Execution errors surface as configuration errors
+	resp := respond(t, newRequest("GET", "/", nil), map[string]interface{}{"template": true, "body": "{{ .Missing }}"})
+	if resp.StatusCode != 500 {
+		t.Errorf("Expected configuration error, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package resttoquery
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// placeholderPattern matches {name} placeholders in targets
+var placeholderPattern = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// segment is one path segment of a pattern; either a literal or a named capture
+type segment struct {
+	literal string
+	capture string
+}
+
+// mapping rewrites paths matching a pattern to a target path and query
+type mapping struct {
+	segments    []segment
+	targetPath  string
+	targetQuery string
+}
+
+// RestToQueryPolicy maps RESTful path segments into query parameters for legacy upstreams
+type RestToQueryPolicy struct {
+	mappings []mapping
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["mappings"].([]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'mappings' parameter is required and must be a non-empty array")
+	}
+
+	p := &RestToQueryPolicy{}
+	for i, raw := range mappingsRaw {
+		m, err := parseMapping(raw)
+		if err != nil {
+			return nil, fmt.Errorf("mappings[%d]: %w", i, err)
+		}
+		p.mappings = append(p.mappings, m)
+	}
+
+	return p, nil
+}
+
+// parseMapping validates a {pattern, target} entry
+func parseMapping(raw interface{}) (mapping, error) {
+	entry, ok := raw.(map[string]interface{})
+	if !ok {
+		return mapping{}, fmt.Errorf("must be an object")
+	}
+	pattern, ok := entry["pattern"].(string)
+	if !ok || !strings.HasPrefix(pattern, "/") {
+		return mapping{}, fmt.Errorf("'pattern' is required and must start with '/'")
+	}
+	target, ok := entry["target"].(string)
+	if !ok || !strings.HasPrefix(target, "/") {
+		return mapping{}, fmt.Errorf("'target' is required and must start with '/'")
+	}
+
+	var m mapping
+	captures := make(map[string]bool)
+	for _, part := range splitPath(pattern) {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			name := part[1 : len(part)-1]
+			if !placeholderPattern.MatchString(part) || placeholderPattern.FindString(part) != part {
+				return mapping{}, fmt.Errorf("invalid capture '%s' in 'pattern'", part)
+			}
+			if captures[name] {
+				return mapping{}, fmt.Errorf("duplicate capture '%s' in 'pattern'", name)
+			}
+			captures[name] = true
+			m.segments = append(m.segments, segment{capture: name})
+			continue
+		}
+		if strings.ContainsAny(part, "{}") {
+			return mapping{}, fmt.Errorf("captures in 'pattern' must span a whole path segment")
+		}
+		m.segments = append(m.segments, segment{literal: part})
+	}
+
+	for _, match := range placeholderPattern.FindAllStringSubmatch(target, -1) {
+		if !captures[match[1]] {
+			return mapping{}, fmt.Errorf("'target' references unknown capture '%s'", match[1])
+		}
+	}
+	m.targetPath, m.targetQuery, _ = strings.Cut(target, "?")
+
+	return m, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RestToQueryPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rewrites the path using the first mapping whose pattern matches
+func (p *RestToQueryPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	path, query, _ := strings.Cut(ctx.Path, "?")
+	parts := splitPath(path)
+
+	for _, m := range p.mappings {
+		captures, ok := m.match(parts)
+		if !ok {
+			continue
+		}
+
+		newPath := m.build(captures, query)
+		slog.Debug("RestToQuery: Rewriting path", "original", ctx.Path, "rewritten", newPath)
+		return policy.UpstreamRequestModifications{
+			Path: &newPath,
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *RestToQueryPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// match returns the decoded captured values when the path segments match the pattern
+func (m mapping) match(parts []string) (map[string]string, bool) {
+	if len(parts) != len(m.segments) {
+		return nil, false
+	}
+	captures := make(map[string]string)
+	for i, seg := range m.segments {
+		if seg.capture == "" {
+			if parts[i] != seg.literal {
+				return nil, false
+			}
+			continue
+		}
+		value, err := url.PathUnescape(parts[i])
+		if err != nil || value == "" {
+			return nil, false
+		}
+		captures[seg.capture] = value
+	}
+	return captures, true
+}
+
+// build renders the target with captured values escaped for their position, appending the
+// original query string
+func (m mapping) build(captures map[string]string, originalQuery string) string {
+	substitute := func(template string, escape func(string) string) string {
+		return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+			return escape(captures[placeholder[1:len(placeholder)-1]])
+		})
+	}
+
+	result := substitute(m.targetPath, url.PathEscape)
+	query := substitute(m.targetQuery, url.QueryEscape)
+	if originalQuery != "" {
+		if query != "" {
+			query += "&"
+		}
+		query += originalQuery
+	}
+	if query != "" {
+		result += "?" + query
+	}
+	return result
+}
+
+// splitPath splits a path into segments, ignoring leading, trailing and repeated slashes
+func splitPath(path string) []string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
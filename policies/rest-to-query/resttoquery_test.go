@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package resttoquery
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, mappings ...map[string]interface{}) policy.Policy {
+	t.Helper()
+	raw := make([]interface{}, len(mappings))
+	for i, m := range mappings {
+		raw[i] = m
+	}
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"mappings": raw})
+	return p
+}
+
+func rewrittenPath(t *testing.T, p policy.Policy, path string) string {
+	t.Helper()
+	mods, ok := p.OnRequest(&policy.RequestContext{Path: path}, nil).(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatal("Expected UpstreamRequestModifications")
+	}
+	if mods.Path == nil {
+		return ""
+	}
+	return *mods.Path
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"mappings": []interface{}{}},
+		{"mappings": []interface{}{map[string]interface{}{"pattern": "users/{id}", "target": "/users"}}},
+		{"mappings": []interface{}{map[string]interface{}{"pattern": "/users/{id}", "target": "/users?id={uid}"}}},
+		{"mappings": []interface{}{map[string]interface{}{"pattern": "/users/u{id}", "target": "/users"}}},
+		{"mappings": []interface{}{map[string]interface{}{"pattern": "/a/{id}/{id}", "target": "/a"}}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRestToQueryPolicy_CaptureExtraction(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"pattern": "/users/{userId}/orders/{orderId}",
+		"target":  "/legacy/order.php?user={userId}&order={orderId}",
+	})
+
+	if got := rewrittenPath(t, p, "/users/42/orders/a%20b"); got != "/legacy/order.php?user=42&order=a+b" {
+		t.Errorf("Expected captures in query, got %q", got)
+	}
+}
+
+func TestRestToQueryPolicy_TargetConstruction(t *testing.T) {
+	p := newPolicy(t,
+		map[string]interface{}{"pattern": "/users/{id}", "target": "/users?id={id}"},
+		map[string]interface{}{"pattern": "/files/{name}", "target": "/download/{name}?inline=true"},
+	)
+
+	tests := map[string]string{
+		"/users/42":           "/users?id=42",
+		"/users/42/?fields=a": "/users?id=42&fields=a",
+		"/files/a&b.txt":      "/download/a&b.txt?inline=true",
+		"/files/x%2Fy":        "/download/x%2Fy?inline=true",
+	}
+	for in, want := range tests {
+		if got := rewrittenPath(t, p, in); got != want {
+			t.Errorf("Expected %q for %q, got %q", want, in, got)
+		}
+	}
+}
+
+func TestRestToQueryPolicy_NonMatchingPassesThrough(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"pattern": "/users/{id}", "target": "/users?id={id}"})
+
+	for _, path := range []string{"/users", "/users/42/orders", "/accounts/42"} {
+		if got := rewrittenPath(t, p, path); got != "" {
+			t.Errorf("Expected %q to pass through, got %q", path, got)
+		}
+	}
+}
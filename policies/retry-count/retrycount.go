@@ -0,0 +1,166 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package retrycount
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// RetryCountPolicy surfaces the number of upstream retries reported by the data plane on the
+// client response and flags responses that needed more retries than a threshold
+type RetryCountPolicy struct {
+	sourceHeader   string
+	countsAttempts bool // Source counts attempts (first try included) rather than retries
+	removeSource   bool
+	exposeHeader   string
+	threshold      int // -1 disables the threshold marker
+	alertHeader    string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &RetryCountPolicy{
+		sourceHeader:   "x-envoy-attempt-count",
+		countsAttempts: true,
+		removeSource:   true,
+		exposeHeader:   "x-retry-count",
+		threshold:      -1,
+		alertHeader:    "x-retry-threshold-exceeded",
+	}
+
+	headerParams := []struct {
+		name   string
+		target *string
+	}{
+		{"sourceHeader", &p.sourceHeader},
+		{"exposeHeader", &p.exposeHeader},
+		{"alertHeader", &p.alertHeader},
+	}
+	for _, hp := range headerParams {
+		if raw, ok := params[hp.name]; ok {
+			name, ok := raw.(string)
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("'%s' must be a non-empty string", hp.name)
+			}
+			*hp.target = strings.ToLower(strings.TrimSpace(name))
+		}
+	}
+
+	if raw, ok := params["sourceCountsAttempts"]; ok {
+		countsAttempts, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'sourceCountsAttempts' must be a boolean")
+		}
+		p.countsAttempts = countsAttempts
+	}
+
+	if raw, ok := params["removeSource"]; ok {
+		removeSource, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'removeSource' must be a boolean")
+		}
+		p.removeSource = removeSource
+	}
+
+	if raw, ok := params["threshold"]; ok {
+		threshold, err := extractInt(raw)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("'threshold' must be a non-negative integer")
+		}
+		p.threshold = threshold
+	}
+
+	if p.exposeHeader == p.alertHeader {
+		return nil, fmt.Errorf("'exposeHeader' and 'alertHeader' must be different headers")
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RetryCountPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Reads the retry indicator and sets headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *RetryCountPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse copies the retry count onto the exposed header and sets the alert header when the
+// count exceeds the threshold
+func (p *RetryCountPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	values := ctx.ResponseHeaders.Get(p.sourceHeader)
+	if len(values) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(values[0]))
+	if err != nil || count < 0 {
+		slog.Debug("RetryCount: Ignoring malformed retry indicator", "header", p.sourceHeader, "value", values[0])
+		return policy.UpstreamResponseModifications{}
+	}
+	if p.countsAttempts && count > 0 {
+		count--
+	}
+
+	mods := policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.exposeHeader: strconv.Itoa(count),
+		},
+	}
+	if p.threshold >= 0 && count > p.threshold {
+		mods.SetHeaders[p.alertHeader] = "true"
+	}
+	if p.removeSource && p.sourceHeader != p.exposeHeader && p.sourceHeader != p.alertHeader {
+		mods.RemoveHeaders = []string{p.sourceHeader}
+	}
+	return mods
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
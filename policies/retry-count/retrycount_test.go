@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package retrycount
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, headers map[string][]string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{ResponseHeaders: policy.NewHeaders(headers)}
+	mods, _ := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	return mods
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"sourceHeader": ""},
+		{"threshold": -1},
+		{"threshold": "many"},
+		{"removeSource": "yes"},
+		{"exposeHeader": "x-retries", "alertHeader": "x-retries"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRetryCountPolicy_SurfacesCount(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onResponse(p, map[string][]string{"x-envoy-attempt-count": {"3"}})
+	if got := mods.SetHeaders["x-retry-count"]; got != "2" {
+		t.Errorf("Expected x-retry-count 2, got %q", got)
+	}
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-envoy-attempt-count" {
+		t.Errorf("Expected source header to be removed, got %v", mods.RemoveHeaders)
+	}
+
+	// A source that already counts retries is copied as-is
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"sourceHeader": "X-Retries", "sourceCountsAttempts": false, "removeSource": false})
+	mods = onResponse(p, map[string][]string{"x-retries": {"1"}})
+	if got := mods.SetHeaders["x-retry-count"]; got != "1" {
+		t.Errorf("Expected x-retry-count 1, got %q", got)
+	}
+	if len(mods.RemoveHeaders) != 0 {
+		t.Errorf("Expected no removed headers, got %v", mods.RemoveHeaders)
+	}
+
+	// Missing and malformed indicators leave the response unchanged
+	for _, headers := range []map[string][]string{{}, {"x-envoy-attempt-count": {"lots"}}} {
+		if mods := onResponse(policytest.New(t, GetPolicy, nil), headers); len(mods.SetHeaders) != 0 {
+			t.Errorf("Expected no headers for %v, got %v", headers, mods.SetHeaders)
+		}
+	}
+}
+
+func TestRetryCountPolicy_ThresholdExceeded(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"threshold": float64(1)})
+
+	mods := onResponse(p, map[string][]string{"x-envoy-attempt-count": {"2"}})
+	if _, ok := mods.SetHeaders["x-retry-threshold-exceeded"]; ok {
+		t.Errorf("Expected no alert for 1 retry, got %v", mods.SetHeaders)
+	}
+
+	mods = onResponse(p, map[string][]string{"x-envoy-attempt-count": {"3"}})
+	if got := mods.SetHeaders["x-retry-threshold-exceeded"]; got != "true" {
+		t.Errorf("Expected alert header true, got %q", got)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package retrysafety
+
+import (
+	"fmt"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// RetrySafetyPolicy tags responses with whether the request can be retried without risking
+// duplicate side effects
+type RetrySafetyPolicy struct {
+	safeMethods          map[string]bool
+	idempotencyKeyHeader string
+	responseHeader       string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &RetrySafetyPolicy{
+		// Idempotent methods as defined by RFC 9110
+		safeMethods: map[string]bool{
+			"GET": true, "HEAD": true, "OPTIONS": true, "TRACE": true, "PUT": true, "DELETE": true,
+		},
+		idempotencyKeyHeader: "idempotency-key",
+		responseHeader:       "x-retriable",
+	}
+
+	if raw, ok := params["safeMethods"]; ok {
+		methodsRaw, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'safeMethods' must be an array")
+		}
+		p.safeMethods = make(map[string]bool)
+		for i, m := range methodsRaw {
+			method, ok := m.(string)
+			if !ok || strings.TrimSpace(method) == "" {
+				return nil, fmt.Errorf("safeMethods[%d] must be a non-empty string", i)
+			}
+			p.safeMethods[strings.ToUpper(strings.TrimSpace(method))] = true
+		}
+	}
+
+	if raw, ok := params["idempotencyKeyHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'idempotencyKeyHeader' must be a non-empty string")
+		}
+		p.idempotencyKeyHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["responseHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'responseHeader' must be a non-empty string")
+		}
+		p.responseHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RetrySafetyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need Idempotency-Key header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Sets the retriable header
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *RetrySafetyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse marks the response as retriable when the request method is idempotent or the
+// client supplied an idempotency key
+func (p *RetrySafetyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	retriable := p.safeMethods[strings.ToUpper(ctx.RequestMethod)]
+	if !retriable && ctx.RequestHeaders != nil {
+		if values := ctx.RequestHeaders.Get(p.idempotencyKeyHeader); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+			retriable = true
+		}
+	}
+
+	value := "false"
+	if retriable {
+		value = "true"
+	}
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.responseHeader: value,
+		},
+	}
+}
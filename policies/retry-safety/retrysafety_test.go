@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package retrysafety
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func retriable(p policy.Policy, method string, headers map[string][]string) string {
+	ctx := &policy.ResponseContext{
+		RequestMethod:   method,
+		RequestHeaders:  policy.NewHeaders(headers),
+		ResponseHeaders: policy.NewHeaders(nil),
+	}
+	mods := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	return mods.SetHeaders["x-retriable"]
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"safeMethods": "GET"},
+		{"safeMethods": []interface{}{""}},
+		{"idempotencyKeyHeader": " "},
+		{"responseHeader": 1},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRetrySafetyPolicy_GetRetriable(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	if got := retriable(p, "GET", nil); got != "true" {
+		t.Errorf("Expected GET to be retriable, got %q", got)
+	}
+}
+
+func TestRetrySafetyPolicy_PostWithoutKeyNotRetriable(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	if got := retriable(p, "POST", nil); got != "false" {
+		t.Errorf("Expected POST to be non-retriable, got %q", got)
+	}
+	if got := retriable(p, "POST", map[string][]string{"idempotency-key": {" "}}); got != "false" {
+		t.Errorf("Expected blank idempotency key to be ignored, got %q", got)
+	}
+}
+
+func TestRetrySafetyPolicy_PostWithKeyRetriable(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	if got := retriable(p, "POST", map[string][]string{"idempotency-key": {"8e03978e"}}); got != "true" {
+		t.Errorf("Expected POST with idempotency key to be retriable, got %q", got)
+	}
+}
+
+func TestRetrySafetyPolicy_CustomConfiguration(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"safeMethods":          []interface{}{"get"},
+		"idempotencyKeyHeader": "X-Request-Key",
+		"responseHeader":       "X-Retriable",
+	})
+
+	if got := retriable(p, "DELETE", nil); got != "false" {
+		t.Errorf("Expected DELETE to be non-retriable when not configured, got %q", got)
+	}
+	if got := retriable(p, "PATCH", map[string][]string{"x-request-key": {"k1"}}); got != "true" {
+		t.Errorf("Expected custom idempotency key header to be honored, got %q", got)
+	}
+}
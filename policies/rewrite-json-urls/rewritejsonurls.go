@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package rewritejsonurls
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+	"github.com/wso2/gateway-controllers/utils/jsonpath"
+)
+
+// hostMapping rewrites URLs for an internal host to a public host
+type hostMapping struct {
+	from   string // host or host:port, lower-cased
+	to     string
+	scheme string // optional replacement scheme
+}
+
+// RewriteJSONURLsPolicy rewrites absolute URLs in JSON response bodies from internal hosts
+// to public gateway hosts
+type RewriteJSONURLsPolicy struct {
+	mappings []hostMapping
+	paths    []jsonpath.Path // optional scopes
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["hostMappings"].([]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'hostMappings' parameter is required and must be a non-empty array")
+	}
+
+	p := &RewriteJSONURLsPolicy{}
+	for i, raw := range mappingsRaw {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("hostMappings[%d] must be an object", i)
+		}
+		from, _ := entry["from"].(string)
+		to, _ := entry["to"].(string)
+		from = strings.ToLower(strings.TrimSpace(from))
+		to = strings.TrimSpace(to)
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("hostMappings[%d] requires non-empty 'from' and 'to' hosts", i)
+		}
+		if strings.Contains(from, "/") || strings.Contains(to, "/") {
+			return nil, fmt.Errorf("hostMappings[%d] 'from' and 'to' must be hosts without scheme or path", i)
+		}
+
+		mapping := hostMapping{from: from, to: to}
+		if schemeRaw, ok := entry["scheme"]; ok {
+			scheme, ok := schemeRaw.(string)
+			if !ok || (scheme != "http" && scheme != "https") {
+				return nil, fmt.Errorf("hostMappings[%d].scheme must be 'http' or 'https'", i)
+			}
+			mapping.scheme = scheme
+		}
+		p.mappings = append(p.mappings, mapping)
+	}
+
+	if pathsRaw, ok := params["paths"]; ok {
+		paths, ok := pathsRaw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'paths' must be an array")
+		}
+		for i, raw := range paths {
+			path, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("paths[%d] must be a string", i)
+			}
+			segments, err := jsonpath.Parse(path)
+			if err != nil {
+				return nil, fmt.Errorf("paths[%d]: %w", i, err)
+			}
+			p.paths = append(p.paths, segments)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RewriteJSONURLsPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need content type
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to rewrite URLs
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *RewriteJSONURLsPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse rewrites internal URLs in JSON response bodies
+func (p *RewriteJSONURLsPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseBody == nil || !ctx.ResponseBody.Present || len(ctx.ResponseBody.Content) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	if !strings.Contains(bodyutil.MediaType(ctx.ResponseHeaders), "json") {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, bodyutil.Options{}); pass {
+		slog.Debug("RewriteJSONURLs: Skipping response body rewrite", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(ctx.ResponseBody.Content))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		slog.Debug("RewriteJSONURLs: Skipping invalid JSON body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	result, changed := jsonpath.Walk(data, func(path []string, value interface{}) jsonpath.Transform {
+		if _, ok := value.(string); ok && p.inScope(path) {
+			return func(value interface{}) (interface{}, bool) {
+				return p.rewrite(value.(string))
+			}
+		}
+		return nil
+	})
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(result); err != nil {
+		slog.Debug("RewriteJSONURLs: Failed to encode rewritten body", "error", err)
+		return policy.UpstreamResponseModifications{}
+	}
+
+	body := bytes.TrimRight(buf.Bytes(), "\n")
+	return policy.UpstreamResponseModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// inScope reports whether a node is covered by one of the configured paths. A path covers the
+// node it selects and everything beneath it. Without configured paths every node is in scope.
+func (p *RewriteJSONURLsPolicy) inScope(path []string) bool {
+	if len(p.paths) == 0 {
+		return true
+	}
+	for _, pattern := range p.paths {
+		if pattern.Covers(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// rewrite replaces the host of an absolute http(s) URL when it matches a mapping
+func (p *RewriteJSONURLsPolicy) rewrite(value string) (string, bool) {
+	lower := strings.ToLower(value)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return value, false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value, false
+	}
+
+	host := strings.ToLower(u.Host)
+	for _, mapping := range p.mappings {
+		// Mappings without a port match the host on any port
+		if host != mapping.from && (strings.Contains(mapping.from, ":") || strings.ToLower(u.Hostname()) != mapping.from) {
+			continue
+		}
+		u.Host = mapping.to
+		if mapping.scheme != "" {
+			u.Scheme = mapping.scheme
+		}
+		return u.String(), true
+	}
+	return value, false
+}
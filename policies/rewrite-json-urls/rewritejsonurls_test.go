@@ -0,0 +1,151 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package rewritejsonurls
+
+import (
+	"encoding/json"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	if _, ok := params["hostMappings"]; !ok {
+		params["hostMappings"] = []interface{}{
+			map[string]interface{}{"from": "orders.internal:8080", "to": "api.example.com", "scheme": "https"},
+		}
+	}
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onResponse(t *testing.T, p policy.Policy, body string) map[string]interface{} {
+	t.Helper()
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/hal+json"}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	mods, ok := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	if !ok {
+		t.Fatal("Expected UpstreamResponseModifications")
+	}
+	if mods.Body == nil {
+		mods.Body = []byte(body)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(mods.Body, &result); err != nil {
+		t.Fatalf("Expected JSON body, got %v", err)
+	}
+	return result
+}
+
+func href(result map[string]interface{}, rel string) string {
+	links := result["_links"].(map[string]interface{})
+	return links[rel].(map[string]interface{})["href"].(string)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "a"}}},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "http://a", "to": "b"}}},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "a", "to": "b", "scheme": "ftp"}}},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "a", "to": "b"}}, "paths": []interface{}{"_links"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRewriteJSONURLsPolicy_RewritesSelfLink(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	result := onResponse(t, p, `{"id":1,"_links":{"self":{"href":"http://orders.internal:8080/orders/1?expand=items&x=1"}}}`)
+	if got := href(result, "self"); got != "https://api.example.com/orders/1?expand=items&x=1" {
+		t.Errorf("Expected rewritten self link, got %s", got)
+	}
+}
+
+func TestRewriteJSONURLsPolicy_LeavesExternalURLs(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	body := `{"_links":{"docs":{"href":"https://docs.example.org/orders"},"other":{"href":"http://orders.internal:9090/x"}},"note":"see http://orders.internal:8080 for details"}`
+	result := onResponse(t, p, body)
+	if got := href(result, "docs"); got != "https://docs.example.org/orders" {
+		t.Errorf("Expected external URL to be untouched, got %s", got)
+	}
+	if got := href(result, "other"); got != "http://orders.internal:9090/x" {
+		t.Errorf("Expected URL on a different port to be untouched, got %s", got)
+	}
+	if result["note"] != "see http://orders.internal:8080 for details" {
+		t.Errorf("Expected free text to be untouched, got %v", result["note"])
+	}
+}
+
+func TestRewriteJSONURLsPolicy_HostWithoutPortMatchesAnyPort(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"hostMappings": []interface{}{
+			map[string]interface{}{"from": "Orders.Internal", "to": "api.example.com"},
+		},
+	})
+
+	result := onResponse(t, p, `{"_links":{"self":{"href":"http://orders.internal:8080/orders"}}}`)
+	if got := href(result, "self"); got != "http://api.example.com/orders" {
+		t.Errorf("Expected rewritten link keeping the scheme, got %s", got)
+	}
+}
+
+func TestRewriteJSONURLsPolicy_ScopedPaths(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"paths": []interface{}{"$._links.*.href", "$.items[*].url"},
+	})
+
+	body := `{
+		"callback":"http://orders.internal:8080/hooks",
+		"_links":{"self":{"href":"http://orders.internal:8080/orders"}},
+		"items":[{"url":"http://orders.internal:8080/items/1","source":"http://orders.internal:8080/raw"}]
+	}`
+	result := onResponse(t, p, body)
+
+	if got := href(result, "self"); got != "https://api.example.com/orders" {
+		t.Errorf("Expected scoped link to be rewritten, got %s", got)
+	}
+	item := result["items"].([]interface{})[0].(map[string]interface{})
+	if item["url"] != "https://api.example.com/items/1" {
+		t.Errorf("Expected scoped array field to be rewritten, got %v", item["url"])
+	}
+	if item["source"] != "http://orders.internal:8080/raw" {
+		t.Errorf("Expected unscoped array field to be untouched, got %v", item["source"])
+	}
+	if result["callback"] != "http://orders.internal:8080/hooks" {
+		t.Errorf("Expected unscoped field to be untouched, got %v", result["callback"])
+	}
+}
+
+func TestRewriteJSONURLsPolicy_NonJSONPassthrough(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"text/html"}}),
+		ResponseBody:    &policy.Body{Content: []byte(`http://orders.internal:8080/`), Present: true, EndOfStream: true},
+	}
+
+	mods := p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+	if mods.Body != nil {
+		t.Errorf("Expected non-JSON body to be untouched, got %s", mods.Body)
+	}
+}
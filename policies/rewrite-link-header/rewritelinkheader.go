@@ -0,0 +1,219 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package rewritelinkheader
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// hostMapping rewrites URLs for an internal host to a public host
+type hostMapping struct {
+	from   string // host or host:port, lower-cased
+	to     string
+	scheme string // optional replacement scheme
+}
+
+// linkValue is one entry of a Link header: a target URI and its raw parameters
+type linkValue struct {
+	target string
+	params string // Everything after the closing '>', e.g. `; rel="next"`
+}
+
+// RewriteLinkHeaderPolicy rewrites Link response header targets from internal hosts to public
+// gateway hosts
+type RewriteLinkHeaderPolicy struct {
+	mappings []hostMapping
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	mappingsRaw, ok := params["hostMappings"].([]interface{})
+	if !ok || len(mappingsRaw) == 0 {
+		return nil, fmt.Errorf("'hostMappings' parameter is required and must be a non-empty array")
+	}
+
+	p := &RewriteLinkHeaderPolicy{}
+	for i, raw := range mappingsRaw {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("hostMappings[%d] must be an object", i)
+		}
+		from, _ := entry["from"].(string)
+		to, _ := entry["to"].(string)
+		from = strings.ToLower(strings.TrimSpace(from))
+		to = strings.TrimSpace(to)
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("hostMappings[%d] requires non-empty 'from' and 'to' hosts", i)
+		}
+		if strings.Contains(from, "/") || strings.Contains(to, "/") {
+			return nil, fmt.Errorf("hostMappings[%d] 'from' and 'to' must be hosts without scheme or path", i)
+		}
+
+		mapping := hostMapping{from: from, to: to}
+		if schemeRaw, ok := entry["scheme"]; ok {
+			scheme, ok := schemeRaw.(string)
+			if !ok || (scheme != "http" && scheme != "https") {
+				return nil, fmt.Errorf("hostMappings[%d].scheme must be 'http' or 'https'", i)
+			}
+			mapping.scheme = scheme
+		}
+		p.mappings = append(p.mappings, mapping)
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *RewriteLinkHeaderPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Rewrites the Link header
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *RewriteLinkHeaderPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse rewrites mapped link targets, keeping link parameters such as rel verbatim.
+// Repeated Link headers are combined into one comma-separated header when any is rewritten.
+func (p *RewriteLinkHeaderPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if ctx.ResponseHeaders == nil {
+		return policy.UpstreamResponseModifications{}
+	}
+	lines := ctx.ResponseHeaders.Get("link")
+	if len(lines) == 0 {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	changed := false
+	var values []string
+	for _, line := range lines {
+		links, err := parseLinks(line)
+		if err != nil {
+			// Malformed headers are forwarded as they are
+			slog.Debug("RewriteLinkHeader: Skipping malformed Link header", "error", err)
+			values = append(values, strings.TrimSpace(line))
+			continue
+		}
+		for _, link := range links {
+			target, ok := p.rewrite(link.target)
+			changed = changed || ok
+			values = append(values, "<"+target+">"+link.params)
+		}
+	}
+	if !changed {
+		return policy.UpstreamResponseModifications{}
+	}
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{"link": strings.Join(values, ", ")},
+	}
+}
+
+// parseLinks splits a Link header value into link values. Commas inside the target or inside
+// quoted parameter values don't separate links.
+func parseLinks(header string) ([]linkValue, error) {
+	var links []linkValue
+	rest := header
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+		if rest[0] != '<' {
+			return nil, fmt.Errorf("expected '<' at %q", rest)
+		}
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated link target")
+		}
+		link := linkValue{target: rest[1:end]}
+		rest = rest[end+1:]
+
+		end, err := paramsEnd(rest)
+		if err != nil {
+			return nil, err
+		}
+		link.params = strings.TrimRight(rest[:end], " \t")
+		links = append(links, link)
+		if end == len(rest) {
+			break
+		}
+		rest = rest[end+1:]
+	}
+	if len(links) == 0 {
+		return nil, fmt.Errorf("no links found")
+	}
+	return links, nil
+}
+
+// paramsEnd returns the index of the comma ending a link's parameters, or the length of s when
+// they run to the end
+func paramsEnd(s string) (int, error) {
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && inQuotes:
+			i++
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			return i, nil
+		}
+	}
+	if inQuotes {
+		return 0, fmt.Errorf("unterminated quoted string")
+	}
+	return len(s), nil
+}
+
+// rewrite replaces the host of an absolute http(s) URL when it matches a mapping
+func (p *RewriteLinkHeaderPolicy) rewrite(value string) (string, bool) {
+	lower := strings.ToLower(value)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return value, false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value, false
+	}
+
+	host := strings.ToLower(u.Host)
+	for _, mapping := range p.mappings {
+		// Mappings without a port match the host on any port
+		if host != mapping.from && (strings.Contains(mapping.from, ":") || strings.ToLower(u.Hostname()) != mapping.from) {
+			continue
+		}
+		u.Host = mapping.to
+		if mapping.scheme != "" {
+			u.Scheme = mapping.scheme
+		}
+		return u.String(), true
+	}
+	return value, false
+}
@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package rewritelinkheader
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T) policy.Policy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"hostMappings": []interface{}{
+			map[string]interface{}{"from": "orders.internal:8080", "to": "api.example.com", "scheme": "https"},
+		},
+	})
+	return p
+}
+
+func onResponse(p policy.Policy, links ...string) policy.UpstreamResponseModifications {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"link": links}),
+		ResponseStatus:  200,
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"hostMappings": []interface{}{}},
+		{"hostMappings": []interface{}{"orders.internal"}},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "orders.internal"}}},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "http://orders.internal", "to": "api.example.com"}}},
+		{"hostMappings": []interface{}{map[string]interface{}{"from": "a", "to": "b", "scheme": "ftp"}}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestRewriteLinkHeaderPolicy_RewritesLinksPreservingRels(t *testing.T) {
+	p := newPolicy(t)
+
+	mods := onResponse(p,
+		`<http://orders.internal:8080/orders?page=3&size=20>; rel="next", <http://orders.internal:8080/orders?page=1&size=20>; rel="prev"`,
+		`<http://orders.internal:8080/orders?page=9>; rel="last"; title="Last, final page"`,
+	)
+	expected := `<https://api.example.com/orders?page=3&size=20>; rel="next", ` +
+		`<https://api.example.com/orders?page=1&size=20>; rel="prev", ` +
+		`<https://api.example.com/orders?page=9>; rel="last"; title="Last, final page"`
+	if mods.SetHeaders["link"] != expected {
+		t.Errorf("Expected link %s, got %s", expected, mods.SetHeaders["link"])
+	}
+}
+
+func TestRewriteLinkHeaderPolicy_LeavesExternalLinksUnchanged(t *testing.T) {
+	p := newPolicy(t)
+
+	mods := onResponse(p, `<https://docs.example.com/orders>; rel="help", <http://orders.internal:8080/orders?page=2>; rel="next"`)
+	expected := `<https://docs.example.com/orders>; rel="help", <https://api.example.com/orders?page=2>; rel="next"`
+	if mods.SetHeaders["link"] != expected {
+		t.Errorf("Expected link %s, got %s", expected, mods.SetHeaders["link"])
+	}
+
+	// Nothing to rewrite leaves the header untouched
+	for _, link := range []string{
+		`<https://docs.example.com/orders>; rel="help"`,
+		`</orders?page=2>; rel="next"`,
+		`<http://orders.internal:9090/orders>; rel="next"`,
+		`http://orders.internal:8080/orders; rel="next"`,
+	} {
+		if mods := onResponse(p, link); mods.SetHeaders != nil {
+			t.Errorf("Expected %s to be unchanged, got %v", link, mods.SetHeaders)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sequenceguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/clientkey"
+)
+
+const (
+	defaultSequenceHeader = "x-sequence-number"
+	defaultTTL            = time.Hour
+
+	OnMissingPassthrough = "passthrough"
+	OnMissingReject      = "reject"
+)
+
+// clientState is the last accepted sequence number for a client key
+type clientState struct {
+	last     uint64
+	lastSeen time.Time
+}
+
+// SequenceGuardPolicy rejects replayed or out-of-order requests using a per-client monotonic
+// sequence number
+type SequenceGuardPolicy struct {
+	sequenceHeader string
+	clientKey      clientkey.Key
+	ttl            time.Duration
+	onMissing      string
+
+	mu        sync.Mutex
+	now       func() time.Time // Injectable clock (for testing)
+	clients   map[string]*clientState
+	lastSweep time.Time
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &SequenceGuardPolicy{
+		sequenceHeader: defaultSequenceHeader,
+		ttl:            defaultTTL,
+		onMissing:      OnMissingReject,
+		now:            time.Now,
+		clients:        make(map[string]*clientState),
+	}
+
+	if raw, ok := params["sequenceHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'sequenceHeader' must be a non-empty string")
+		}
+		p.sequenceHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	clientKey, err := clientkey.Parse(params["clientKey"])
+	if err != nil {
+		return nil, err
+	}
+	p.clientKey = clientKey
+
+	if raw, ok := params["ttlSeconds"]; ok {
+		ttl, err := extractInt(raw)
+		if err != nil || ttl < 1 {
+			return nil, fmt.Errorf("'ttlSeconds' must be a positive integer")
+		}
+		p.ttl = time.Duration(ttl) * time.Second
+	}
+
+	if raw, ok := params["onMissingSequence"]; ok {
+		onMissing, ok := raw.(string)
+		if !ok || (onMissing != OnMissingPassthrough && onMissing != OnMissingReject) {
+			return nil, fmt.Errorf("'onMissingSequence' must be one of: passthrough, reject")
+		}
+		p.onMissing = onMissing
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SequenceGuardPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the sequence and client key headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest accepts the request only if its sequence number is greater than the last accepted
+// sequence number for the client
+func (p *SequenceGuardPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get(p.sequenceHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		if p.onMissing == OnMissingReject {
+			return errorResponse(http.StatusBadRequest, "Bad Request",
+				fmt.Sprintf("Required header '%s' is missing", p.sequenceHeader))
+		}
+		return policy.UpstreamRequestModifications{}
+	}
+
+	seq, err := strconv.ParseUint(strings.TrimSpace(values[0]), 10, 64)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "Bad Request",
+			fmt.Sprintf("Header '%s' must be a non-negative integer", p.sequenceHeader))
+	}
+
+	key := p.clientKey.Resolve(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.sweep(now)
+
+	state, ok := p.clients[key]
+	if ok && now.Sub(state.lastSeen) < p.ttl {
+		if seq == state.last {
+			slog.Debug("SequenceGuard: Rejecting replayed sequence", "key", key, "sequence", seq)
+			return errorResponse(http.StatusConflict, "Conflict",
+				fmt.Sprintf("Sequence number %d has already been processed", seq))
+		}
+		if seq < state.last {
+			slog.Debug("SequenceGuard: Rejecting out-of-order sequence", "key", key, "sequence", seq, "last", state.last)
+			return errorResponse(http.StatusConflict, "Conflict",
+				fmt.Sprintf("Sequence number %d is out of order, last accepted is %d", seq, state.last))
+		}
+	}
+
+	p.clients[key] = &clientState{last: seq, lastSeen: now}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *SequenceGuardPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// sweep evicts client state idle for longer than the TTL. It runs at most once per TTL so the
+// cost is amortized across requests. Callers must hold p.mu.
+func (p *SequenceGuardPolicy) sweep(now time.Time) {
+	if p.lastSweep.IsZero() {
+		p.lastSweep = now
+		return
+	}
+	if now.Sub(p.lastSweep) < p.ttl {
+		return
+	}
+	for key, state := range p.clients {
+		if now.Sub(state.lastSeen) >= p.ttl {
+			delete(p.clients, key)
+		}
+	}
+	p.lastSweep = now
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, title, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sequenceguard
+
+import (
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *SequenceGuardPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*SequenceGuardPolicy)
+}
+
+func onRequest(p policy.Policy, client, seq string) policy.RequestAction {
+	headers := map[string][]string{"x-client-id": {client}}
+	if seq != "" {
+		headers["x-sequence-number"] = []string{seq}
+	}
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil)
+}
+
+var clientParams = map[string]interface{}{
+	"clientKey": map[string]interface{}{"type": "header", "key": "X-Client-Id"},
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"sequenceHeader": ""},
+		{"clientKey": "ip"},
+		{"clientKey": map[string]interface{}{"type": "cookie"}},
+		{"clientKey": map[string]interface{}{"type": "header"}},
+		{"ttlSeconds": 0},
+		{"onMissingSequence": "ignore"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSequenceGuardPolicy_InOrderAccepted(t *testing.T) {
+	p := newPolicy(t, clientParams)
+
+	policytest.ExpectStatus(t, onRequest(p, "a", "1"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "a", "2"), 0)
+	// Gaps are allowed as long as the sequence increases
+	policytest.ExpectStatus(t, onRequest(p, "a", "10"), 0)
+	// Clients are tracked independently
+	policytest.ExpectStatus(t, onRequest(p, "b", "1"), 0)
+}
+
+func TestSequenceGuardPolicy_ReplayRejected(t *testing.T) {
+	p := newPolicy(t, clientParams)
+
+	policytest.ExpectStatus(t, onRequest(p, "a", "5"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "a", "5"), 409)
+}
+
+func TestSequenceGuardPolicy_OutOfOrderRejected(t *testing.T) {
+	p := newPolicy(t, clientParams)
+
+	policytest.ExpectStatus(t, onRequest(p, "a", "5"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "a", "3"), 409)
+	// A rejected request does not move the last accepted sequence
+	policytest.ExpectStatus(t, onRequest(p, "a", "6"), 0)
+}
+
+func TestSequenceGuardPolicy_MissingOrInvalidSequence(t *testing.T) {
+	p := newPolicy(t, clientParams)
+	policytest.ExpectStatus(t, onRequest(p, "a", ""), 400)
+	policytest.ExpectStatus(t, onRequest(p, "a", "-1"), 400)
+
+	params := map[string]interface{}{"onMissingSequence": OnMissingPassthrough}
+	policytest.ExpectStatus(t, onRequest(newPolicy(t, params), "a", ""), 0)
+}
+
+func TestSequenceGuardPolicy_TTLEviction(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"clientKey":  clientParams["clientKey"],
+		"ttlSeconds": 60,
+	})
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	policytest.ExpectStatus(t, onRequest(p, "a", "5"), 0)
+	now = now.Add(2 * time.Minute)
+	// State has expired, so the client may start over
+	policytest.ExpectStatus(t, onRequest(p, "a", "1"), 0)
+
+	now = now.Add(2 * time.Minute)
+	onRequest(p, "b", "1")
+	if _, ok := p.clients["a"]; ok {
+		t.Error("Expected idle client state to be evicted")
+	}
+}
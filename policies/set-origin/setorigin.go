@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package setorigin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// SetOriginPolicy overrides the upstream origin (scheme, host and port) a request is forwarded
+// to while preserving its path and query. The SDK offers no way to change the upstream target
+// directly, so the chosen origin is passed to the data plane in the Host header and in an origin
+// header that a dynamic forward proxy cluster routes on.
+type SetOriginPolicy struct {
+	origin       string          // static origin, empty when only header-driven
+	sourceHeader string          // request header carrying the requested origin
+	allowed      map[string]bool // canonical origins permitted from the source header
+	originHeader string
+	preserveHost bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &SetOriginPolicy{originHeader: "x-upstream-origin"}
+
+	if raw, ok := params["origin"]; ok {
+		value, _ := raw.(string)
+		origin, err := canonicalOrigin(value)
+		if err != nil {
+			return nil, fmt.Errorf("'origin' %w", err)
+		}
+		p.origin = origin
+	}
+
+	if raw, ok := params["originFromHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'originFromHeader' must be a non-empty string")
+		}
+		p.sourceHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["allowedOrigins"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'allowedOrigins' must be a non-empty array")
+		}
+		p.allowed = make(map[string]bool, len(list))
+		for i, item := range list {
+			value, _ := item.(string)
+			origin, err := canonicalOrigin(value)
+			if err != nil {
+				return nil, fmt.Errorf("allowedOrigins[%d] %w", i, err)
+			}
+			p.allowed[origin] = true
+		}
+	}
+
+	if p.origin == "" && p.sourceHeader == "" {
+		return nil, fmt.Errorf("at least one of 'origin' or 'originFromHeader' must be configured")
+	}
+	if p.sourceHeader != "" && p.allowed == nil {
+		return nil, fmt.Errorf("'allowedOrigins' is required with 'originFromHeader'")
+	}
+
+	if raw, ok := params["originHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'originHeader' must be a non-empty string")
+		}
+		p.originHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+	if p.originHeader == p.sourceHeader || p.originHeader == "host" {
+		return nil, fmt.Errorf("'originHeader' must differ from 'originFromHeader' and host")
+	}
+
+	if raw, ok := params["preserveHost"]; ok {
+		preserveHost, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'preserveHost' must be a boolean")
+		}
+		p.preserveHost = preserveHost
+	}
+
+	return p, nil
+}
+
+// canonicalOrigin validates an http(s) origin and returns it with a lower-cased scheme and host
+// and without a default port
+func canonicalOrigin(value string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return "", fmt.Errorf("must be an http or https origin such as 'https://api.example.com:8443'")
+	}
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("must contain only a scheme, host and optional port")
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if (scheme == "https" && u.Port() == "443") || (scheme == "http" && u.Port() == "80") {
+		host = strings.TrimSuffix(host, ":"+u.Port())
+	}
+	return scheme + "://" + host, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SetOriginPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the origin header, sets routing headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest selects the origin from the source header, when present and allowed, or the static
+// origin, and sets the routing headers. A requested origin outside the allow-list is rejected
+// with 403. The source header is never forwarded upstream.
+func (p *SetOriginPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	mods := policy.UpstreamRequestModifications{}
+	origin := p.origin
+
+	if p.sourceHeader != "" {
+		if values := ctx.Headers.Get(p.sourceHeader); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+			requested, err := canonicalOrigin(values[0])
+			if err != nil || !p.allowed[requested] {
+				slog.Debug("SetOrigin: Rejecting disallowed origin", "origin", values[0])
+				return forbidden(fmt.Sprintf("Origin '%s' is not allowed", strings.TrimSpace(values[0])))
+			}
+			origin = requested
+		}
+		if ctx.Headers.Has(p.sourceHeader) {
+			mods.RemoveHeaders = []string{p.sourceHeader}
+		}
+	}
+
+	if origin == "" {
+		return mods
+	}
+
+	mods.SetHeaders = map[string]string{p.originHeader: origin}
+	if !p.preserveHost {
+		_, host, _ := strings.Cut(origin, "://")
+		mods.SetHeaders["host"] = host
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *SetOriginPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// forbidden builds a 403 response with a JSON error body
+func forbidden(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Forbidden",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusForbidden,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package setorigin
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, params map[string]interface{}, headers map[string][]string) policy.RequestAction {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers), Path: "/orders?id=1"}, nil)
+}
+
+func expectOrigin(t *testing.T, action policy.RequestAction, origin, host string) {
+	t.Helper()
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected request to pass, got %+v", action)
+	}
+	if mods.SetHeaders["x-upstream-origin"] != origin || mods.SetHeaders["host"] != host {
+		t.Errorf("Expected origin %q and host %q, got %v", origin, host, mods.SetHeaders)
+	}
+	if mods.Path != nil {
+		t.Errorf("Expected path to be preserved, got %q", *mods.Path)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"origin": "ftp://files.example.com"},
+		{"origin": "https://api.example.com/v1"},
+		{"origin": "api.example.com"},
+		{"originFromHeader": "x-target-origin"},
+		{"originFromHeader": "x-target-origin", "allowedOrigins": []interface{}{"https://a.example.com?x=1"}},
+		{"origin": "https://a.example.com", "originHeader": "host"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSetOriginPolicy_StaticOrigin(t *testing.T) {
+	action := onRequest(t, map[string]interface{}{"origin": "HTTPS://Backend.Internal:8443"}, nil)
+	expectOrigin(t, action, "https://backend.internal:8443", "backend.internal:8443")
+
+	// Default ports are dropped
+	action = onRequest(t, map[string]interface{}{"origin": "http://backend.internal:80/"}, nil)
+	expectOrigin(t, action, "http://backend.internal", "backend.internal")
+}
+
+func TestSetOriginPolicy_HeaderDrivenAllowList(t *testing.T) {
+	params := map[string]interface{}{
+		"origin":           "https://primary.internal",
+		"originFromHeader": "X-Target-Origin",
+		"allowedOrigins":   []interface{}{"https://primary.internal", "http://canary.internal:8080"},
+	}
+
+	action := onRequest(t, params, map[string][]string{"x-target-origin": {"http://CANARY.internal:8080"}})
+	expectOrigin(t, action, "http://canary.internal:8080", "canary.internal:8080")
+	if mods := action.(policy.UpstreamRequestModifications); len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-target-origin" {
+		t.Errorf("Expected source header to be removed, got %v", mods.RemoveHeaders)
+	}
+
+	// Without the header the static origin applies
+	expectOrigin(t, onRequest(t, params, nil), "https://primary.internal", "primary.internal")
+}
+
+func TestSetOriginPolicy_DisallowedOriginRejected(t *testing.T) {
+	params := map[string]interface{}{
+		"originFromHeader": "x-target-origin",
+		"allowedOrigins":   []interface{}{"https://primary.internal"},
+	}
+
+	for _, origin := range []string{"https://evil.example.com", "http://primary.internal", "not a url"} {
+		action := onRequest(t, params, map[string][]string{"x-target-origin": {origin}})
+		if resp, ok := action.(policy.ImmediateResponse); !ok || resp.StatusCode != 403 {
+			t.Errorf("Expected status 403 for %q, got %+v", origin, action)
+		}
+	}
+}
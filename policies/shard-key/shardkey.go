@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package shardkey
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Sources of the shard key
+	KeyTypeHeader      = "header"
+	KeyTypeQuery       = "query"
+	KeyTypePathSegment = "pathSegment"
+
+	// Hash algorithms
+	AlgorithmFNV1a  = "fnv1a"
+	AlgorithmCRC32  = "crc32"
+	AlgorithmSHA256 = "sha256"
+)
+
+// ShardKeyPolicy hashes a request key into a fixed number of shards and forwards the shard
+// number to the upstream
+type ShardKeyPolicy struct {
+	keyType   string
+	keyName   string // Header or query parameter name
+	keyIndex  int    // Path segment index
+	shards    uint64
+	algorithm string
+	header    string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &ShardKeyPolicy{
+		algorithm: AlgorithmFNV1a,
+		header:    "x-shard",
+	}
+
+	keyMap, ok := params["key"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'key' parameter is required and must be an object")
+	}
+	keyType, _ := keyMap["type"].(string)
+	switch keyType {
+	case KeyTypeHeader, KeyTypeQuery:
+		name, ok := keyMap["name"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'key.name' is required for type '%s'", keyType)
+		}
+		p.keyName = strings.TrimSpace(name)
+		if keyType == KeyTypeHeader {
+			p.keyName = strings.ToLower(p.keyName)
+		}
+	case KeyTypePathSegment:
+		raw, ok := keyMap["index"]
+		if !ok {
+			return nil, fmt.Errorf("'key.index' is required for type '%s'", keyType)
+		}
+		index, err := extractInt(raw)
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("'key.index' must be a non-negative integer")
+		}
+		p.keyIndex = index
+	default:
+		return nil, fmt.Errorf("'key.type' must be one of: header, query, pathSegment")
+	}
+	p.keyType = keyType
+
+	shards, err := extractInt(params["shards"])
+	if err != nil || shards < 1 {
+		return nil, fmt.Errorf("'shards' parameter is required and must be a positive integer")
+	}
+	p.shards = uint64(shards)
+
+	if raw, ok := params["algorithm"]; ok {
+		algorithm, ok := raw.(string)
+		if !ok || (algorithm != AlgorithmFNV1a && algorithm != AlgorithmCRC32 && algorithm != AlgorithmSHA256) {
+			return nil, fmt.Errorf("'algorithm' must be one of %s, %s, %s", AlgorithmFNV1a, AlgorithmCRC32, AlgorithmSHA256)
+		}
+		p.algorithm = algorithm
+	}
+
+	if raw, ok := params["header"]; ok {
+		header, ok := raw.(string)
+		if !ok || strings.TrimSpace(header) == "" {
+			return nil, fmt.Errorf("'header' must be a non-empty string")
+		}
+		p.header = strings.ToLower(strings.TrimSpace(header))
+	}
+	if p.keyType == KeyTypeHeader && p.keyName == p.header {
+		return nil, fmt.Errorf("'header' must differ from the key header")
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ShardKeyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the key and sets the shard header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sets the shard header to the key's shard, numbered from 0. A shard header sent by
+// the client is always replaced, and removed when the request has no key.
+func (p *ShardKeyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	key, ok := p.key(ctx)
+	if !ok {
+		return policy.UpstreamRequestModifications{RemoveHeaders: []string{p.header}}
+	}
+	return policy.UpstreamRequestModifications{
+		SetHeaders: map[string]string{p.header: strconv.FormatUint(p.shard(key), 10)},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *ShardKeyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// shard returns the shard a key maps to
+func (p *ShardKeyPolicy) shard(key string) uint64 {
+	var sum uint64
+	switch p.algorithm {
+	case AlgorithmCRC32:
+		sum = uint64(crc32.ChecksumIEEE([]byte(key)))
+	case AlgorithmSHA256:
+		digest := sha256.Sum256([]byte(key))
+		sum = binary.BigEndian.Uint64(digest[:8])
+	default:
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		sum = h.Sum64()
+	}
+	return sum % p.shards
+}
+
+// key extracts the shard key from the request
+func (p *ShardKeyPolicy) key(ctx *policy.RequestContext) (string, bool) {
+	reqPath, query, _ := strings.Cut(ctx.Path, "?")
+	switch p.keyType {
+	case KeyTypeHeader:
+		if values := ctx.Headers.Get(p.keyName); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+			return strings.TrimSpace(values[0]), true
+		}
+	case KeyTypeQuery:
+		values, err := url.ParseQuery(query)
+		if err == nil && values.Get(p.keyName) != "" {
+			return values.Get(p.keyName), true
+		}
+	default:
+		segments := strings.Split(strings.Trim(reqPath, "/"), "/")
+		if p.keyIndex < len(segments) && segments[p.keyIndex] != "" {
+			segment, err := url.PathUnescape(segments[p.keyIndex])
+			if err != nil {
+				segment = segments[p.keyIndex]
+			}
+			return segment, true
+		}
+	}
+	return "", false
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
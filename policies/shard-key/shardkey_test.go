@@ -0,0 +1,108 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package shardkey
+
+import (
+	"strconv"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *ShardKeyPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*ShardKeyPolicy)
+}
+
+func onRequest(p policy.Policy, path string, headers map[string][]string) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{Path: path, Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	key := map[string]interface{}{"type": "header", "name": "x-tenant"}
+	invalid := []map[string]interface{}{
+		{"shards": 4},
+		{"key": map[string]interface{}{"type": "cookie"}, "shards": 4},
+		{"key": map[string]interface{}{"type": "query"}, "shards": 4},
+		{"key": map[string]interface{}{"type": "pathSegment"}, "shards": 4},
+		{"key": map[string]interface{}{"type": "pathSegment", "index": -1}, "shards": 4},
+		{"key": key},
+		{"key": key, "shards": 0},
+		{"key": key, "shards": 4, "algorithm": "md5"},
+		{"key": key, "shards": 4, "header": "X-Tenant"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestShardKeyPolicy_SameKeySameShard(t *testing.T) {
+	header := newPolicy(t, map[string]interface{}{
+		"key":    map[string]interface{}{"type": "header", "name": "X-Tenant"},
+		"shards": 16,
+	})
+	path := newPolicy(t, map[string]interface{}{
+		"key":    map[string]interface{}{"type": "pathSegment", "index": 1},
+		"shards": 16,
+	})
+	query := newPolicy(t, map[string]interface{}{
+		"key":    map[string]interface{}{"type": "query", "name": "tenant"},
+		"shards": 16,
+	})
+
+	want := strconv.FormatUint(header.shard("acme"), 10)
+	for i := 0; i < 3; i++ {
+		for _, mods := range []policy.UpstreamRequestModifications{
+			onRequest(header, "/orders", map[string][]string{"x-tenant": {"acme"}, "x-shard": {"99"}}),
+			onRequest(path, "/tenants/acme/orders?page=2", nil),
+			onRequest(query, "/orders?tenant=acme", nil),
+		} {
+			if mods.SetHeaders["x-shard"] != want {
+				t.Errorf("Expected shard %s, got %v", want, mods.SetHeaders)
+			}
+		}
+	}
+
+	// Requests without a key lose any client-sent shard header
+	mods := onRequest(header, "/orders", map[string][]string{"x-shard": {"3"}})
+	if mods.SetHeaders != nil || len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != "x-shard" {
+		t.Errorf("Expected x-shard to be removed, got %+v", mods)
+	}
+}
+
+func TestShardKeyPolicy_EvenDistribution(t *testing.T) {
+	const shards, keys = 8, 8000
+	for _, algorithm := range []string{AlgorithmFNV1a, AlgorithmCRC32, AlgorithmSHA256} {
+		p := newPolicy(t, map[string]interface{}{
+			"key":       map[string]interface{}{"type": "header", "name": "x-user"},
+			"shards":    shards,
+			"algorithm": algorithm,
+		})
+		counts := make([]int, shards)
+		for i := 0; i < keys; i++ {
+			counts[p.shard("user-"+strconv.Itoa(i))]++
+		}
+		for shard, count := range counts {
+			// Allow 15% either side of the mean of 1000
+			if count < 850 || count > 1150 {
+				t.Errorf("Expected %s shard %d to hold about %d keys, got %d", algorithm, shard, keys/shards, count)
+			}
+		}
+	}
+}
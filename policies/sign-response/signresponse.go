@@ -0,0 +1,180 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package signresponse
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Signature algorithms
+	AlgorithmHMACSHA256 = "hmac-sha256"
+	AlgorithmHMACSHA512 = "hmac-sha512"
+
+	// Signed components that are not headers
+	ComponentBody   = "@body"
+	ComponentStatus = "@status"
+)
+
+// SignResponsePolicy attaches an HMAC signature over the response body and selected headers so
+// downstream caches and clients can verify the response was not altered
+type SignResponsePolicy struct {
+	secret          []byte
+	newHash         func() hash.Hash
+	signatureHeader string
+	timestampHeader string
+	components      []string
+	now             func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	secret, ok := params["secret"].(string)
+	if !ok || secret == "" {
+		return nil, fmt.Errorf("'secret' parameter is required and must be a non-empty string")
+	}
+
+	p := &SignResponsePolicy{
+		secret:          []byte(secret),
+		newHash:         sha256.New,
+		signatureHeader: "x-signature",
+		timestampHeader: "x-signature-timestamp",
+		components:      []string{"content-type", ComponentBody},
+		now:             time.Now,
+	}
+
+	if raw, ok := params["algorithm"]; ok {
+		algorithm, _ := raw.(string)
+		switch algorithm {
+		case AlgorithmHMACSHA256:
+			p.newHash = sha256.New
+		case AlgorithmHMACSHA512:
+			p.newHash = sha512.New
+		default:
+			return nil, fmt.Errorf("'algorithm' must be one of %s, %s", AlgorithmHMACSHA256, AlgorithmHMACSHA512)
+		}
+	}
+
+	if raw, ok := params["signatureHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'signatureHeader' must be a non-empty string")
+		}
+		p.signatureHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["timestampHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'timestampHeader' must be a non-empty string")
+		}
+		p.timestampHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if raw, ok := params["signedComponents"]; ok {
+		componentsRaw, ok := raw.([]interface{})
+		if !ok || len(componentsRaw) == 0 {
+			return nil, fmt.Errorf("'signedComponents' must be a non-empty array")
+		}
+		p.components = nil
+		for i, c := range componentsRaw {
+			component, ok := c.(string)
+			component = strings.ToLower(strings.TrimSpace(component))
+			if !ok || component == "" {
+				return nil, fmt.Errorf("signedComponents[%d] must be a non-empty string", i)
+			}
+			if strings.HasPrefix(component, "@") && component != ComponentBody && component != ComponentStatus {
+				return nil, fmt.Errorf("signedComponents[%d] must be a header name, %s or %s", i, ComponentBody, ComponentStatus)
+			}
+			if component == p.signatureHeader || component == p.timestampHeader {
+				return nil, fmt.Errorf("signedComponents[%d] cannot be a header set by this policy", i)
+			}
+			p.components = append(p.components, component)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SignResponsePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Signs selected response headers
+		ResponseBodyMode:   policy.BodyModeBuffer,    // Need response body to sign it
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *SignResponsePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse signs the response and attaches the signature and timestamp headers
+func (p *SignResponsePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	timestamp := strconv.FormatInt(p.now().Unix(), 10)
+
+	mac := hmac.New(p.newHash, p.secret)
+	mac.Write([]byte(p.signingString(ctx, timestamp)))
+
+	return policy.UpstreamResponseModifications{
+		SetHeaders: map[string]string{
+			p.signatureHeader: base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+			p.timestampHeader: timestamp,
+		},
+	}
+}
+
+// signingString builds the canonical string that is signed: a "@timestamp" line followed by one
+// "name: value" line per signed component, in configuration order. The body is represented by
+// its base64 SHA-256 digest and repeated headers are joined with ", ".
+func (p *SignResponsePolicy) signingString(ctx *policy.ResponseContext, timestamp string) string {
+	var b strings.Builder
+	b.WriteString("@timestamp: " + timestamp)
+	for _, component := range p.components {
+		var value string
+		switch component {
+		case ComponentBody:
+			var content []byte
+			if ctx.ResponseBody != nil {
+				content = ctx.ResponseBody.Content
+			}
+			digest := sha256.Sum256(content)
+			value = base64.StdEncoding.EncodeToString(digest[:])
+		case ComponentStatus:
+			value = strconv.Itoa(ctx.ResponseStatus)
+		default:
+			value = strings.Join(ctx.ResponseHeaders.Get(component), ", ")
+		}
+		b.WriteString("\n" + component + ": " + value)
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package signresponse
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *SignResponsePolicy {
+	t.Helper()
+	params["secret"] = "s3cr3t"
+	p := policytest.New(t, GetPolicy, params)
+	sp := p.(*SignResponsePolicy)
+	sp.now = func() time.Time { return time.Unix(1767225600, 0) }
+	return sp
+}
+
+func sign(p policy.Policy, body string, headers map[string][]string) map[string]string {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(headers),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+		ResponseStatus:  200,
+	}
+	return p.OnResponse(ctx, nil).(policy.UpstreamResponseModifications).SetHeaders
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"secret": ""},
+		{"secret": "k", "algorithm": "md5"},
+		{"secret": "k", "signatureHeader": " "},
+		{"secret": "k", "signedComponents": []interface{}{}},
+		{"secret": "k", "signedComponents": []interface{}{"@method"}},
+		{"secret": "k", "signedComponents": []interface{}{"x-signature"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSignResponsePolicy_DeterministicSignature(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"signedComponents": []interface{}{"Content-Type", "@status", "@body"},
+	})
+	headers := map[string][]string{"content-type": {"application/json"}}
+
+	first := sign(p, `{"id":1}`, headers)
+	if first["x-signature-timestamp"] != "1767225600" {
+		t.Errorf("Expected timestamp 1767225600, got %q", first["x-signature-timestamp"])
+	}
+
+	digest := sha256.Sum256([]byte(`{"id":1}`))
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write([]byte("@timestamp: 1767225600\ncontent-type: application/json\n@status: 200\n@body: " +
+		base64.StdEncoding.EncodeToString(digest[:])))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if first["x-signature"] != expected {
+		t.Errorf("Expected signature %q, got %q", expected, first["x-signature"])
+	}
+
+	if second := sign(p, `{"id":1}`, headers); second["x-signature"] != first["x-signature"] {
+		t.Errorf("Expected signing to be deterministic, got %q and %q", first["x-signature"], second["x-signature"])
+	}
+}
+
+func TestSignResponsePolicy_ModifiedBodyChangesSignature(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	headers := map[string][]string{"content-type": {"application/json"}}
+
+	original := sign(p, `{"amount":10}`, headers)["x-signature"]
+	if tampered := sign(p, `{"amount":99}`, headers)["x-signature"]; tampered == original {
+		t.Error("Expected a modified body to change the signature")
+	}
+	// Signed headers are covered too
+	changed := sign(p, `{"amount":10}`, map[string][]string{"content-type": {"text/plain"}})["x-signature"]
+	if changed == original {
+		t.Error("Expected a modified signed header to change the signature")
+	}
+}
+
+func TestSignResponsePolicy_SHA512(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"algorithm": "hmac-sha512", "signatureHeader": "X-Body-Signature"})
+
+	signature, err := base64.StdEncoding.DecodeString(sign(p, `{}`, nil)["x-body-signature"])
+	if err != nil || len(signature) != 64 {
+		t.Errorf("Expected a 64-byte HMAC-SHA512 signature, got %d bytes (%v)", len(signature), err)
+	}
+}
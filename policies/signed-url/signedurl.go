@@ -0,0 +1,225 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// SignedURLPolicy only admits requests carrying a valid, unexpired HMAC signature in the query
+// string. The signature is the hex HMAC-SHA256 of "<path>\n<expiry>" under a shared secret,
+// where the path excludes the query string and the expiry is a Unix timestamp in seconds.
+type SignedURLPolicy struct {
+	secret         []byte
+	signatureParam string
+	expiryParam    string
+	clockSkew      time.Duration
+	stripParams    bool
+	now            func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	secret, ok := params["secret"].(string)
+	if !ok || secret == "" {
+		return nil, fmt.Errorf("'secret' parameter is required and must be a non-empty string")
+	}
+
+	p := &SignedURLPolicy{
+		secret:         []byte(secret),
+		signatureParam: "sig",
+		expiryParam:    "exp",
+		stripParams:    true,
+		now:            time.Now,
+	}
+
+	if raw, ok := params["signatureParam"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'signatureParam' must be a non-empty string")
+		}
+		p.signatureParam = strings.TrimSpace(name)
+	}
+
+	if raw, ok := params["expiryParam"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'expiryParam' must be a non-empty string")
+		}
+		p.expiryParam = strings.TrimSpace(name)
+	}
+
+	if p.signatureParam == p.expiryParam {
+		return nil, fmt.Errorf("'signatureParam' and 'expiryParam' must be different")
+	}
+
+	if raw, ok := params["clockSkewSeconds"]; ok {
+		skew, err := extractInt(raw)
+		if err != nil || skew < 0 {
+			return nil, fmt.Errorf("'clockSkewSeconds' must be a non-negative integer")
+		}
+		p.clockSkew = time.Duration(skew) * time.Second
+	}
+
+	if raw, ok := params["stripParams"]; ok {
+		strip, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'stripParams' must be a boolean")
+		}
+		p.stripParams = strip
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SignedURLPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request path and query
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest verifies the signature before the expiry so a tampered expiry is reported as an
+// invalid signature rather than an expired link
+func (p *SignedURLPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, query, _ := strings.Cut(ctx.Path, "?")
+
+	var signature, expiry string
+	var hasSignature, hasExpiry bool
+	var rest []string
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			value = rawValue
+		}
+		switch key {
+		case p.signatureParam, p.expiryParam:
+			// A second copy could be read by the upstream instead of the verified one
+			if (key == p.signatureParam && hasSignature) || (key == p.expiryParam && hasExpiry) {
+				return errorResponse(http.StatusBadRequest, fmt.Sprintf("Query parameter '%s' must appear only once", key))
+			}
+			if key == p.signatureParam {
+				signature, hasSignature = value, true
+			} else {
+				expiry, hasExpiry = value, true
+			}
+		default:
+			rest = append(rest, pair)
+		}
+	}
+
+	if !hasSignature || !hasExpiry {
+		return errorResponse(http.StatusForbidden, "URL is not signed")
+	}
+
+	provided, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(provided, p.sign(reqPath, expiry)) {
+		slog.Debug("SignedURL: Invalid signature", "path", reqPath)
+		return errorResponse(http.StatusForbidden, "URL signature is invalid")
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return errorResponse(http.StatusForbidden, "URL expiry is invalid")
+	}
+	if p.now().Add(-p.clockSkew).Unix() > expiresAt {
+		slog.Debug("SignedURL: Link expired", "path", reqPath, "expiry", expiresAt)
+		return errorResponse(http.StatusGone, "URL has expired")
+	}
+
+	if !p.stripParams {
+		return policy.UpstreamRequestModifications{}
+	}
+	newPath := reqPath
+	if len(rest) > 0 {
+		newPath += "?" + strings.Join(rest, "&")
+	}
+	return policy.UpstreamRequestModifications{Path: &newPath}
+}
+
+// OnResponse is not used by this policy
+func (p *SignedURLPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// sign computes the HMAC-SHA256 of the path and expiry
+func (p *SignedURLPolicy) sign(reqPath, expiry string) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(reqPath + "\n" + expiry))
+	return mac.Sum(nil)
+}
+
+// errorResponse builds an immediate response with a JSON error body
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
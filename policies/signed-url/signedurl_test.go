@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var testNow = time.Unix(1_800_000_000, 0)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *SignedURLPolicy {
+	t.Helper()
+	params["secret"] = "s3cret"
+	p := policytest.New(t, GetPolicy, params)
+	sp := p.(*SignedURLPolicy)
+	sp.now = func() time.Time { return testNow }
+	return sp
+}
+
+func signature(path, expiry string) string {
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(path + "\n" + expiry))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func onRequest(p policy.Policy, path string) policy.RequestAction {
+	return p.OnRequest(&policy.RequestContext{Path: path}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"secret": ""},
+		{"secret": "x", "signatureParam": ""},
+		{"secret": "x", "signatureParam": "s", "expiryParam": "s"},
+		{"secret": "x", "clockSkewSeconds": -5},
+		{"secret": "x", "stripParams": "no"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSignedURLPolicy_ValidSignature(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	action := onRequest(p, "/files/report.pdf?v=2&sig="+signature("/files/report.pdf", "1800000060")+"&exp=1800000060")
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected request to pass, got %+v", action)
+	}
+	if mods.Path == nil || *mods.Path != "/files/report.pdf?v=2" {
+		t.Errorf("Expected signing params to be stripped, got %v", mods.Path)
+	}
+}
+
+func TestSignedURLPolicy_TamperedPath(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	sig := signature("/files/report.pdf", "1800000060")
+
+	policytest.ExpectStatus(t, onRequest(p, "/files/secret.pdf?sig="+sig+"&exp=1800000060"), 403)
+	// Extending the expiry invalidates the signature rather than reviving the link
+	policytest.ExpectStatus(t, onRequest(p, "/files/report.pdf?sig="+sig+"&exp=1900000000"), 403)
+	policytest.ExpectStatus(t, onRequest(p, "/files/report.pdf?exp=1800000060"), 403)
+	policytest.ExpectStatus(t, onRequest(p, "/files/report.pdf?sig=zz&exp=1800000060"), 403)
+}
+
+func TestSignedURLPolicy_ExpiredURL(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	path := "/files/report.pdf?sig=" + signature("/files/report.pdf", "1799999990") + "&exp=1799999990"
+
+	policytest.ExpectStatus(t, onRequest(p, path), 410)
+
+	// A link within the clock skew is still accepted
+	p = newPolicy(t, map[string]interface{}{"clockSkewSeconds": 30, "stripParams": false})
+	if _, ok := onRequest(p, path).(policy.UpstreamRequestModifications); !ok {
+		t.Errorf("Expected request within clock skew to pass")
+	}
+}
+
+func TestSignedURLPolicy_DuplicateParamsRejected(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	sig := signature("/files/report.pdf", "1800000060")
+
+	// The extra copy would otherwise be forwarded and could be read by the upstream
+	policytest.ExpectStatus(t, onRequest(p, "/files/report.pdf?sig="+sig+"&exp=1800000060&exp=1900000000"), 400)
+	policytest.ExpectStatus(t, onRequest(p, "/files/report.pdf?sig="+sig+"&sig=other&exp=1800000060"), 400)
+	// Encoded parameter names are duplicates too
+	policytest.ExpectStatus(t, onRequest(p, "/files/report.pdf?sig="+sig+"&exp=1800000060&%65xp=1"), 400)
+}
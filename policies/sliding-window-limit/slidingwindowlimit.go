@@ -0,0 +1,277 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package slidingwindowlimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// SlidingWindowLimitPolicy limits requests with a sliding window log. Unlike a token bucket it
+// never admits more than limit requests in any window of windowSeconds, so there are no bursts
+// at window boundaries.
+type SlidingWindowLimitPolicy struct {
+	limit        int
+	window       time.Duration
+	includeXRL   bool
+	includeRetry bool
+
+	mu    sync.Mutex
+	now   func() time.Time // Injectable clock (for testing)
+	log   []time.Time      // Ring buffer of admitted request times, grown on demand up to limit entries
+	head  int              // Index of the oldest entry
+	count int              // Number of live entries
+}
+
+// decision is the outcome of a single admission check
+type decision struct {
+	allowed    bool
+	remaining  int
+	reset      time.Time // When the oldest admitted request leaves the window
+	retryAfter time.Duration
+}
+
+// maxLimit bounds the request log, which holds one timestamp per admitted request
+const maxLimit = 1000000
+
+// initialLogSize is the capacity of the request log before it grows on demand
+const initialLogSize = 64
+
+// Metadata key for passing the admission decision from the request to the response phase
+const rateLimitResultKey = "slidingwindowlimit:result"
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	limitRaw, ok := params["limit"]
+	if !ok {
+		return nil, fmt.Errorf("'limit' parameter is required")
+	}
+	limit, err := extractInt(limitRaw)
+	if err != nil {
+		return nil, fmt.Errorf("'limit' must be an integer: %w", err)
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, fmt.Errorf("'limit' must be between 1 and %d", maxLimit)
+	}
+
+	windowRaw, ok := params["windowSeconds"]
+	if !ok {
+		return nil, fmt.Errorf("'windowSeconds' parameter is required")
+	}
+	windowSeconds, err := extractInt(windowRaw)
+	if err != nil {
+		return nil, fmt.Errorf("'windowSeconds' must be an integer: %w", err)
+	}
+	if windowSeconds < 1 {
+		return nil, fmt.Errorf("'windowSeconds' must be at least 1")
+	}
+
+	slog.Debug("SlidingWindowLimit: Policy initialized",
+		"route", metadata.RouteName,
+		"limit", limit,
+		"windowSeconds", windowSeconds)
+
+	return &SlidingWindowLimitPolicy{
+		limit:        limit,
+		window:       time.Duration(windowSeconds) * time.Second,
+		includeXRL:   getBoolParam(params, "headers.includeXRateLimit", true),
+		includeRetry: getBoolParam(params, "headers.includeRetryAfter", true),
+		now:          time.Now,
+		log:          make([]time.Time, min(limit, initialLogSize)),
+	}, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SlidingWindowLimitPolicy) Mode() policy.ProcessingMode {
+	// Response headers are only needed to decorate allowed responses
+	responseHeaderMode := policy.HeaderModeSkip
+	if p.includeXRL {
+		responseHeaderMode = policy.HeaderModeProcess
+	}
+
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Count requests
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: responseHeaderMode,
+		ResponseBodyMode:   policy.BodyModeSkip, // Don't need response body
+	}
+}
+
+// OnRequest admits the request if fewer than limit requests were admitted in the last window
+func (p *SlidingWindowLimitPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	d := p.take()
+	if d.allowed {
+		// Store the decision in metadata for the response phase
+		if p.includeXRL && ctx.SharedContext != nil && ctx.Metadata != nil {
+			ctx.Metadata[rateLimitResultKey] = d
+		}
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("SlidingWindowLimit: Rate limit exceeded", "limit", p.limit, "window", p.window)
+
+	headers := p.buildRateLimitHeaders(d)
+	headers["content-type"] = "application/json"
+	// X-RateLimit-Remaining is always reported on rejections
+	headers["x-ratelimit-remaining"] = "0"
+	if p.includeRetry {
+		retryAfter := int64(math.Ceil(d.retryAfter.Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		headers["retry-after"] = strconv.FormatInt(retryAfter, 10)
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Too Many Requests",
+		"message": "Rate limit exceeded. Please try again later.",
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+// OnResponse adds rate limit headers to allowed responses
+func (p *SlidingWindowLimitPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if !p.includeXRL || ctx.SharedContext == nil {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	// Retrieve the stored decision from the request phase
+	d, ok := ctx.Metadata[rateLimitResultKey].(decision)
+	if !ok {
+		return policy.UpstreamResponseModifications{}
+	}
+
+	return policy.UpstreamResponseModifications{
+		SetHeaders: p.buildRateLimitHeaders(d),
+	}
+}
+
+// buildRateLimitHeaders creates the X-RateLimit-* headers for a decision
+func (p *SlidingWindowLimitPolicy) buildRateLimitHeaders(d decision) map[string]string {
+	headers := make(map[string]string)
+	if !p.includeXRL {
+		return headers
+	}
+
+	headers["x-ratelimit-limit"] = strconv.Itoa(p.limit)
+	headers["x-ratelimit-remaining"] = strconv.Itoa(d.remaining)
+	headers["x-ratelimit-reset"] = strconv.FormatInt(d.reset.Unix(), 10)
+	return headers
+}
+
+// take prunes expired entries and records the request if the window has capacity
+func (p *SlidingWindowLimitPolicy) take() decision {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	cutoff := now.Add(-p.window)
+	for p.count > 0 && !p.log[p.head].After(cutoff) {
+		p.head = (p.head + 1) % len(p.log)
+		p.count--
+	}
+
+	if p.count >= p.limit {
+		// The next slot frees up when the oldest admitted request leaves the window
+		reset := p.log[p.head].Add(p.window)
+		return decision{
+			allowed:    false,
+			remaining:  0,
+			reset:      reset,
+			retryAfter: reset.Sub(now),
+		}
+	}
+
+	if p.count == len(p.log) {
+		p.grow()
+	}
+	p.log[(p.head+p.count)%len(p.log)] = now
+	p.count++
+	return decision{
+		allowed:   true,
+		remaining: p.limit - p.count,
+		reset:     p.log[p.head].Add(p.window),
+	}
+}
+
+// grow doubles the request log, capped at limit, and unwraps the ring so the oldest entry is first
+func (p *SlidingWindowLimitPolicy) grow() {
+	log := make([]time.Time, min(len(p.log)*2, p.limit))
+	for i := 0; i < p.count; i++ {
+		log[i] = p.log[(p.head+i)%len(p.log)]
+	}
+	p.log = log
+	p.head = 0
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
+
+// getBoolParam reads a boolean from a dot-separated parameter path (e.g. "headers.includeXRateLimit")
+func getBoolParam(params map[string]interface{}, key string, defaultVal bool) bool {
+	keys := strings.Split(key, ".")
+	current := params
+
+	for i, k := range keys {
+		if i == len(keys)-1 {
+			if val, ok := current[k].(bool); ok {
+				return val
+			}
+			return defaultVal
+		}
+
+		if next, ok := current[k].(map[string]interface{}); ok {
+			current = next
+		} else {
+			return defaultVal
+		}
+	}
+
+	return defaultVal
+}
@@ -0,0 +1,279 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package slidingwindowlimit
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+// fakeClock is a manually advanced clock
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newPolicy(t *testing.T, limit, windowSeconds int) (*SlidingWindowLimitPolicy, *fakeClock) {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"limit":         float64(limit),
+		"windowSeconds": float64(windowSeconds),
+	})
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	swp := p.(*SlidingWindowLimitPolicy)
+	swp.now = clock.Now
+	return swp, clock
+}
+
+func allowed(p *SlidingWindowLimitPolicy) bool {
+	_, ok := p.OnRequest(&policy.RequestContext{}, nil).(policy.UpstreamRequestModifications)
+	return ok
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"limit": 10},
+		{"limit": 0, "windowSeconds": 1},
+		{"limit": 10, "windowSeconds": 0},
+		{"limit": 1.5, "windowSeconds": 1},
+		{"limit": 1000001, "windowSeconds": 1},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSlidingWindowLimitPolicy_RejectsOverLimit(t *testing.T) {
+	p, clock := newPolicy(t, 3, 10)
+
+	for i := 0; i < 3; i++ {
+		if !allowed(p) {
+			t.Fatalf("Expected request %d to be allowed", i+1)
+		}
+		clock.Advance(time.Second)
+	}
+
+	action := p.OnRequest(&policy.RequestContext{}, nil)
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", resp.StatusCode)
+	}
+	// The first request was at t=0 and leaves the window at t=10; now is t=3
+	if resp.Headers["retry-after"] != "7" {
+		t.Errorf("Expected retry-after 7, got %q", resp.Headers["retry-after"])
+	}
+	if resp.Headers["x-ratelimit-remaining"] != "0" {
+		t.Errorf("Expected x-ratelimit-remaining 0, got %q", resp.Headers["x-ratelimit-remaining"])
+	}
+}
+
+func TestSlidingWindowLimitPolicy_NoBurstAtWindowBoundary(t *testing.T) {
+	p, clock := newPolicy(t, 4, 10)
+
+	// Fill the limit at the end of the first window
+	clock.Advance(9 * time.Second)
+	for i := 0; i < 4; i++ {
+		if !allowed(p) {
+			t.Fatalf("Expected request %d to be allowed", i+1)
+		}
+	}
+
+	// A fixed window would reset at t=10 and admit a second burst; the sliding window must not
+	clock.Advance(2 * time.Second)
+	if allowed(p) {
+		t.Error("Expected request just after the fixed-window boundary to be rejected")
+	}
+
+	// Once the earlier requests are a full window old, capacity returns
+	clock.Advance(8 * time.Second)
+	for i := 0; i < 4; i++ {
+		if !allowed(p) {
+			t.Fatalf("Expected request %d in the next window to be allowed", i+1)
+		}
+	}
+	if allowed(p) {
+		t.Error("Expected limit to apply again in the next window")
+	}
+}
+
+func TestSlidingWindowLimitPolicy_PrunesGradually(t *testing.T) {
+	p, clock := newPolicy(t, 2, 10)
+
+	allowed(p) // t=0
+	clock.Advance(5 * time.Second)
+	allowed(p) // t=5
+
+	clock.Advance(4 * time.Second) // t=9
+	if allowed(p) {
+		t.Error("Expected request at t=9 to be rejected")
+	}
+
+	clock.Advance(time.Second) // t=10, the t=0 entry expires
+	if !allowed(p) {
+		t.Error("Expected request at t=10 to be allowed after the oldest entry expired")
+	}
+	if allowed(p) {
+		t.Error("Expected only one slot to free up")
+	}
+	if p.count != 2 {
+		t.Errorf("Expected 2 live entries, got %d", p.count)
+	}
+}
+
+func TestSlidingWindowLimitPolicy_Concurrent(t *testing.T) {
+	p, _ := newPolicy(t, 50, 60)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	admitted := 0
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if allowed(p) {
+				mu.Lock()
+				admitted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if admitted != 50 {
+		t.Errorf("Expected exactly 50 admitted requests, got %d", admitted)
+	}
+}
+
+func TestSlidingWindowLimitPolicy_HeadersOnAllowedResponse(t *testing.T) {
+	p, clock := newPolicy(t, 5, 60)
+	shared := &policy.SharedContext{Metadata: map[string]interface{}{}}
+
+	if _, ok := p.OnRequest(&policy.RequestContext{SharedContext: shared}, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Fatal("Expected request to be allowed")
+	}
+	mods := p.OnResponse(&policy.ResponseContext{SharedContext: shared}, nil).(policy.UpstreamResponseModifications)
+
+	expected := map[string]string{
+		"x-ratelimit-limit":     "5",
+		"x-ratelimit-remaining": "4",
+		"x-ratelimit-reset":     "1767225660",
+	}
+	for name, value := range expected {
+		if mods.SetHeaders[name] != value {
+			t.Errorf("Expected %s %q, got %q", name, value, mods.SetHeaders[name])
+		}
+	}
+
+	// Reset tracks the oldest counted request, not the latest one
+	clock.Advance(10 * time.Second)
+	shared = &policy.SharedContext{Metadata: map[string]interface{}{}}
+	p.OnRequest(&policy.RequestContext{SharedContext: shared}, nil)
+	mods = p.OnResponse(&policy.ResponseContext{SharedContext: shared}, nil).(policy.UpstreamResponseModifications)
+	if mods.SetHeaders["x-ratelimit-remaining"] != "3" || mods.SetHeaders["x-ratelimit-reset"] != "1767225660" {
+		t.Errorf("Unexpected headers on second request: %v", mods.SetHeaders)
+	}
+}
+
+func TestSlidingWindowLimitPolicy_HeadersOnLimitedResponse(t *testing.T) {
+	p, _ := newPolicy(t, 1, 30)
+	allowed(p)
+
+	resp := p.OnRequest(&policy.RequestContext{}, nil).(policy.ImmediateResponse)
+	expected := map[string]string{
+		"x-ratelimit-limit":     "1",
+		"x-ratelimit-remaining": "0",
+		"x-ratelimit-reset":     "1767225630",
+		"retry-after":           "30",
+	}
+	for name, value := range expected {
+		if resp.Headers[name] != value {
+			t.Errorf("Expected %s %q, got %q", name, value, resp.Headers[name])
+		}
+	}
+}
+
+func TestSlidingWindowLimitPolicy_HeadersDisabled(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"limit":         1,
+		"windowSeconds": 30,
+		"headers":       map[string]interface{}{"includeXRateLimit": false, "includeRetryAfter": false},
+	})
+	shared := &policy.SharedContext{Metadata: map[string]interface{}{}}
+
+	p.OnRequest(&policy.RequestContext{SharedContext: shared}, nil)
+	if mods := p.OnResponse(&policy.ResponseContext{SharedContext: shared}, nil).(policy.UpstreamResponseModifications); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no headers on allowed response, got %v", mods.SetHeaders)
+	}
+	if p.Mode().ResponseHeaderMode != policy.HeaderModeSkip {
+		t.Error("Expected response headers to be skipped when X-RateLimit headers are disabled")
+	}
+
+	resp := p.OnRequest(&policy.RequestContext{}, nil).(policy.ImmediateResponse)
+	if _, ok := resp.Headers["x-ratelimit-limit"]; ok {
+		t.Error("Expected no x-ratelimit-limit header on 429")
+	}
+	if _, ok := resp.Headers["retry-after"]; ok {
+		t.Error("Expected no retry-after header on 429")
+	}
+	if resp.Headers["x-ratelimit-remaining"] != "0" {
+		t.Error("Expected x-ratelimit-remaining on 429")
+	}
+}
+
+func TestSlidingWindowLimitPolicy_GrowsLogOnDemand(t *testing.T) {
+	p, clock := newPolicy(t, 200, 10)
+	if len(p.log) >= 200 {
+		t.Fatalf("Expected the request log to start small, got %d entries", len(p.log))
+	}
+
+	admit := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			if !allowed(p) {
+				t.Fatalf("Expected request %d of %d to be allowed", i+1, n)
+			}
+		}
+	}
+
+	// Fill the initial log, then expire the oldest entries so later requests wrap the ring
+	// before it has to grow
+	admit(40) // t=0
+	clock.Advance(5 * time.Second)
+	admit(24) // t=5
+	clock.Advance(5 * time.Second)
+	admit(176) // t=10, the t=0 requests have left the window
+	if allowed(p) {
+		t.Fatal("Expected the limit to apply after the log has grown")
+	}
+
+	// Only the 24 requests from t=5 leave the window next
+	clock.Advance(5 * time.Second)
+	admit(24)
+	if allowed(p) {
+		t.Error("Expected only the expired requests to free capacity")
+	}
+}
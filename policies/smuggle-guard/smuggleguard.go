@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package smuggleguard
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// SmuggleGuardPolicy rejects requests with ambiguous message framing that can be used for
+// HTTP request smuggling
+type SmuggleGuardPolicy struct{}
+
+var ins = &SmuggleGuardPolicy{}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	return ins, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SmuggleGuardPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need framing headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates the Content-Length and Transfer-Encoding headers
+func (p *SmuggleGuardPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	contentLengths := ctx.Headers.Get("content-length")
+
+	if len(contentLengths) > 0 && isChunked(ctx.Headers.Get("transfer-encoding")) {
+		slog.Debug("SmuggleGuard: Rejecting request with both Content-Length and chunked Transfer-Encoding")
+		return badRequest("Request must not contain both Content-Length and Transfer-Encoding: chunked")
+	}
+
+	if len(contentLengths) > 0 && !consistentContentLength(contentLengths) {
+		slog.Debug("SmuggleGuard: Rejecting request with conflicting Content-Length values", "values", contentLengths)
+		return badRequest("Request contains invalid or conflicting Content-Length values")
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *SmuggleGuardPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// isChunked reports whether any Transfer-Encoding value lists the chunked coding
+func isChunked(values []string) bool {
+	for _, value := range values {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "chunked") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// consistentContentLength reports whether all Content-Length values, including comma-separated
+// lists within a single header, are valid and identical (RFC 9112 section 6.3)
+func consistentContentLength(values []string) bool {
+	first := ""
+	for _, value := range values {
+		for _, token := range strings.Split(value, ",") {
+			token = strings.TrimSpace(token)
+			if token == "" || strings.Trim(token, "0123456789") != "" {
+				return false
+			}
+			if first == "" {
+				first = token
+			} else if token != first {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// badRequest builds a 400 response with a JSON error body
+func badRequest(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(http.StatusBadRequest),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusBadRequest,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package smuggleguard
+
+import (
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, headers map[string][]string) policy.RequestAction {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, nil)
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestSmuggleGuardPolicy_ContentLengthAndChunked(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, map[string][]string{
+		"content-length":    {"10"},
+		"transfer-encoding": {"chunked"},
+	}), http.StatusBadRequest)
+	policytest.ExpectStatus(t, onRequest(t, map[string][]string{
+		"content-length":    {"10"},
+		"transfer-encoding": {"gzip, Chunked"},
+	}), http.StatusBadRequest)
+}
+
+func TestSmuggleGuardPolicy_ConflictingContentLength(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, map[string][]string{
+		"content-length": {"10", "20"},
+	}), http.StatusBadRequest)
+	policytest.ExpectStatus(t, onRequest(t, map[string][]string{
+		"content-length": {"10, 11"},
+	}), http.StatusBadRequest)
+	policytest.ExpectStatus(t, onRequest(t, map[string][]string{
+		"content-length": {"-1"},
+	}), http.StatusBadRequest)
+}
+
+func TestSmuggleGuardPolicy_DuplicateIdenticalContentLength(t *testing.T) {
+	action := onRequest(t, map[string][]string{
+		"content-length": {"10", "10"},
+	})
+	if _, ok := action.(policy.UpstreamRequestModifications); !ok {
+		t.Errorf("Expected identical Content-Length values to pass, got %T", action)
+	}
+}
+
+func TestSmuggleGuardPolicy_CleanRequest(t *testing.T) {
+	cases := []map[string][]string{
+		{"content-length": {"42"}},
+		{"transfer-encoding": {"chunked"}},
+		{},
+	}
+	for _, headers := range cases {
+		if _, ok := onRequest(t, headers).(policy.UpstreamRequestModifications); !ok {
+			t.Errorf("Expected request with headers %v to pass", headers)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sniffcontenttype
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+// equivalents lists, for generic sniffed types, the declared media type patterns they are
+// consistent with. http.DetectContentType reports only text/plain for JSON and other text
+// formats, and application/zip for zip-based document formats.
+var equivalents = map[string][]string{
+	"text/plain": {
+		"text/*", "multipart/*", "application/json", "application/*+json", "application/x-ndjson",
+		"application/xml", "application/*+xml", "application/javascript", "application/yaml",
+		"application/x-www-form-urlencoded", "application/graphql",
+	},
+	"text/xml":        {"application/xml", "application/*+xml", "image/svg+xml"},
+	"application/zip": {"application/*+zip", "application/java-archive", "application/vnd.openxmlformats-officedocument.*", "application/vnd.oasis.opendocument.*"},
+}
+
+// sniffable are declared types that http.DetectContentType recognizes, so a body of that type
+// which sniffs as application/octet-stream is not what it claims to be
+var sniffable = []string{
+	"text/*", "image/*", "audio/*", "video/*", "font/*", "application/pdf", "application/zip",
+	"application/x-gzip", "application/gzip", "application/wasm", "application/ogg",
+	"application/postscript", "application/x-rar-compressed",
+}
+
+// SniffContentTypePolicy detects the actual type of request bodies from their first bytes and
+// rejects bodies that are not of an allowed type or don't match the declared Content-Type
+type SniffContentTypePolicy struct {
+	allowed      []string // path.Match patterns over sniffed media types, nil allows any
+	enforceMatch bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &SniffContentTypePolicy{enforceMatch: true}
+
+	if raw, ok := params["allowedTypes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("'allowedTypes' must be a non-empty array")
+		}
+		for i, item := range list {
+			pattern, ok := item.(string)
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if !ok || !strings.Contains(pattern, "/") || strings.ContainsAny(pattern, "; ") {
+				return nil, fmt.Errorf("allowedTypes[%d] must be a media type pattern like 'image/png' or 'image/*'", i)
+			}
+			if _, err := path.Match(pattern, "/"); err != nil {
+				return nil, fmt.Errorf("allowedTypes[%d] is not a valid pattern: %w", i, err)
+			}
+			p.allowed = append(p.allowed, pattern)
+		}
+	}
+
+	if raw, ok := params["enforceMatch"]; ok {
+		enforceMatch, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'enforceMatch' must be a boolean")
+		}
+		p.enforceMatch = enforceMatch
+	}
+
+	if p.allowed == nil && !p.enforceMatch {
+		return nil, fmt.Errorf("'allowedTypes' is required when 'enforceMatch' is false")
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *SniffContentTypePolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need declared content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to sniff it
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest sniffs the body type and rejects it with 415 when it is not allowed or does not
+// match the declared Content-Type. The check fails closed: encoded bodies are rejected, and
+// streaming or partial bodies are sniffed from the bytes buffered so far.
+func (p *SniffContentTypePolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be sniffed without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("SniffContentType: Rejecting encoded request body")
+		return unsupported("Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	sniffed, _, _ := strings.Cut(http.DetectContentType(ctx.Body.Content), ";")
+	sniffed = strings.TrimSpace(sniffed)
+
+	if p.allowed != nil && !matchesAny(p.allowed, sniffed) {
+		slog.Debug("SniffContentType: Sniffed type not allowed", "sniffed", sniffed)
+		return unsupported(fmt.Sprintf("Request body of type '%s' is not allowed", sniffed))
+	}
+
+	if p.enforceMatch {
+		if declared := bodyutil.MediaType(ctx.Headers); declared != "" && !consistent(declared, sniffed) {
+			slog.Debug("SniffContentType: Declared type does not match body", "declared", declared, "sniffed", sniffed)
+			return unsupported(fmt.Sprintf("Request body of type '%s' does not match the declared Content-Type '%s'", sniffed, declared))
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *SniffContentTypePolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// consistent reports whether a sniffed type is consistent with the declared media type
+func consistent(declared, sniffed string) bool {
+	if declared == sniffed || declared == "application/octet-stream" {
+		return true
+	}
+	if sniffed == "application/octet-stream" {
+		// The sniffer didn't recognize the body, which only contradicts types it would recognize
+		return !matchesAny(sniffable, declared)
+	}
+	return matchesAny(equivalents[sniffed], declared)
+}
+
+func matchesAny(patterns []string, mediaType string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupported builds a 415 response with a JSON error body
+func unsupported(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Unsupported Media Type",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusUnsupportedMediaType,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
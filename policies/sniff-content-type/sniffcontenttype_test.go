@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sniffcontenttype
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+const (
+	pngBody = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	exeBody = "MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff"
+)
+
+func onRequest(p policy.Policy, contentType, body string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"enforceMatch": false},
+		{"enforceMatch": "yes"},
+		{"allowedTypes": []interface{}{}},
+		{"allowedTypes": []interface{}{"png"}},
+		{"allowedTypes": []interface{}{"image/["}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSniffContentTypePolicy_MatchingTypePasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "image/png", pngBody), 0)
+	policytest.ExpectStatus(t, onRequest(p, "application/json; charset=utf-8", `{"name":"report"}`), 0)
+	policytest.ExpectStatus(t, onRequest(p, "application/vnd.custom", exeBody), 0)
+}
+
+func TestSniffContentTypePolicy_MismatchRejected(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	policytest.ExpectStatus(t, onRequest(p, "image/png", exeBody), 415)
+	policytest.ExpectStatus(t, onRequest(p, "image/jpeg", pngBody), 415)
+	policytest.ExpectStatus(t, onRequest(p, "application/json", pngBody), 415)
+
+	// Without enforcement only the allow-list applies
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"enforceMatch": false, "allowedTypes": []interface{}{"image/*"}})
+	policytest.ExpectStatus(t, onRequest(p, "image/jpeg", pngBody), 0)
+}
+
+func TestSniffContentTypePolicy_DisallowedType(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"allowedTypes": []interface{}{"image/png", "image/jpeg"}})
+
+	policytest.ExpectStatus(t, onRequest(p, "application/octet-stream", exeBody), 415)
+	policytest.ExpectStatus(t, onRequest(p, "text/plain", "hello"), 415)
+	policytest.ExpectStatus(t, onRequest(p, "application/octet-stream", pngBody), 0)
+}
+
+func TestSniffContentTypePolicy_EncodedAndStreamingBodiesFailClosed(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"allowedTypes": []interface{}{"image/png", "text/plain"}})
+	request := func(headers map[string][]string, body string, endOfStream bool) policy.RequestAction {
+		return p.OnRequest(&policy.RequestContext{
+			Headers: policy.NewHeaders(headers),
+			Body:    &policy.Body{Content: []byte(body), Present: true, EndOfStream: endOfStream},
+		}, nil)
+	}
+	expect := func(action policy.RequestAction, status int, what string) {
+		t.Helper()
+		resp, ok := action.(policy.ImmediateResponse)
+		if status == 0 && ok || status != 0 && (!ok || resp.StatusCode != status) {
+			t.Errorf("Expected status %d for %s, got %+v", status, what, action)
+		}
+	}
+
+	// Declaring a content coding or a streaming type must not skip the checks
+	expect(request(map[string][]string{"content-type": {"image/png"}, "content-encoding": {"gzip"}}, exeBody, true), 415, "gzip body")
+	expect(request(map[string][]string{"content-type": {"text/event-stream"}}, exeBody, true), 415, "executable declared as an event stream")
+	expect(request(map[string][]string{"content-type": {"text/event-stream"}}, "data: hi\n\n", true), 0, "event stream")
+
+	// A partially received body is sniffed from its leading bytes
+	chunked := map[string][]string{"content-type": {"image/png"}, "transfer-encoding": {"chunked"}}
+	expect(request(chunked, exeBody, false), 415, "partial executable body")
+	expect(request(chunked, pngBody[:16], false), 0, "partial png body")
+}
@@ -0,0 +1,230 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package statusremap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// errorInBody detects error payloads delivered with a successful status
+type errorInBody struct {
+	path   []string        // Segments of the JSONPath that is inspected
+	values map[string]bool // Values of the field that signal an error; empty means any truthy value
+	status int             // Status returned to the client when the body signals an error
+}
+
+// StatusRemapPolicy translates upstream status codes into client-facing ones
+type StatusRemapPolicy struct {
+	mappings    map[int]int
+	errorInBody *errorInBody
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &StatusRemapPolicy{mappings: make(map[int]int)}
+
+	if raw, ok := params["mappings"]; ok {
+		mappings, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'mappings' must be an object")
+		}
+		for key, value := range mappings {
+			from, err := strconv.Atoi(strings.TrimSpace(key))
+			if err != nil || !validStatus(from) {
+				return nil, fmt.Errorf("mappings key '%s' must be a status code between 100 and 599", key)
+			}
+			to, err := extractInt(value)
+			if err != nil || !validStatus(to) {
+				return nil, fmt.Errorf("mappings.%s must be a status code between 100 and 599", key)
+			}
+			p.mappings[from] = to
+		}
+	}
+
+	if raw, ok := params["errorInBody"]; ok {
+		detector, err := parseErrorInBody(raw)
+		if err != nil {
+			return nil, err
+		}
+		p.errorInBody = detector
+	}
+
+	if len(p.mappings) == 0 && p.errorInBody == nil {
+		return nil, fmt.Errorf("at least one of 'mappings' or 'errorInBody' must be configured")
+	}
+	return p, nil
+}
+
+func parseErrorInBody(raw interface{}) (*errorInBody, error) {
+	config, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'errorInBody' must be an object")
+	}
+	detector := &errorInBody{values: make(map[string]bool), status: 502}
+
+	jsonPath, _ := config["jsonPath"].(string)
+	jsonPath = strings.TrimSpace(jsonPath)
+	if !strings.HasPrefix(jsonPath, "$.") {
+		return nil, fmt.Errorf("'errorInBody.jsonPath' must be a JSONPath starting with '$.'")
+	}
+	detector.path = strings.Split(strings.TrimPrefix(jsonPath, "$."), ".")
+	for _, segment := range detector.path {
+		if segment == "" {
+			return nil, fmt.Errorf("'errorInBody.jsonPath' contains an empty segment: %s", jsonPath)
+		}
+	}
+
+	if rawValues, ok := config["values"]; ok {
+		values, ok := rawValues.([]interface{})
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("'errorInBody.values' must be a non-empty array")
+		}
+		for i, value := range values {
+			switch v := value.(type) {
+			case string, bool, int, int64, float64:
+				detector.values[fmt.Sprint(v)] = true
+			default:
+				return nil, fmt.Errorf("errorInBody.values[%d] must be a string, number or boolean", i)
+			}
+		}
+	}
+
+	if rawStatus, ok := config["status"]; ok {
+		status, err := extractInt(rawStatus)
+		if err != nil || !validStatus(status) {
+			return nil, fmt.Errorf("'errorInBody.status' must be a status code between 100 and 599")
+		}
+		detector.status = status
+	}
+	return detector, nil
+}
+
+func validStatus(status int) bool {
+	return status >= 100 && status <= 599
+}
+
+// Mode returns the processing mode for this policy
+func (p *StatusRemapPolicy) Mode() policy.ProcessingMode {
+	responseBodyMode := policy.BodyModeSkip // Only status codes are remapped
+	if p.errorInBody != nil {
+		responseBodyMode = policy.BodyModeBuffer // Need response body to detect errors
+	}
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip,    // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Need response status
+		ResponseBodyMode:   responseBodyMode,
+	}
+}
+
+// OnRequest is not used by this policy
+func (p *StatusRemapPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+// OnResponse replaces the status of 2xx responses whose body signals an error, then applies
+// the configured status mappings. Bodies are left unchanged.
+func (p *StatusRemapPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	if p.errorInBody != nil && ctx.ResponseStatus >= 200 && ctx.ResponseStatus < 300 && p.errorInBody.matches(ctx) {
+		slog.Debug("StatusRemap: Error detected in successful response", "status", ctx.ResponseStatus)
+		status := p.errorInBody.status
+		return policy.UpstreamResponseModifications{StatusCode: &status}
+	}
+
+	if status, ok := p.mappings[ctx.ResponseStatus]; ok && status != ctx.ResponseStatus {
+		return policy.UpstreamResponseModifications{StatusCode: &status}
+	}
+	return policy.UpstreamResponseModifications{}
+}
+
+// matches reports whether the JSON body holds an error value at the configured path. Encoded
+// and non-JSON bodies never match.
+func (d *errorInBody) matches(ctx *policy.ResponseContext) bool {
+	if ctx.ResponseBody == nil || len(ctx.ResponseBody.Content) == 0 {
+		return false
+	}
+	if ctx.ResponseHeaders != nil {
+		if values := ctx.ResponseHeaders.Get("content-encoding"); len(values) > 0 && !strings.EqualFold(values[0], "identity") {
+			return false
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(ctx.ResponseBody.Content))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return false
+	}
+	for _, segment := range d.path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = object[segment]; !ok {
+			return false
+		}
+	}
+
+	if len(d.values) > 0 {
+		switch v := value.(type) {
+		case string, bool, json.Number:
+			return d.values[fmt.Sprint(v)]
+		default:
+			return false
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
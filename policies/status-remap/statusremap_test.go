@@ -0,0 +1,91 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package statusremap
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onResponse(p policy.Policy, status int, body string) policy.ResponseAction {
+	ctx := &policy.ResponseContext{
+		ResponseHeaders: policy.NewHeaders(map[string][]string{"content-type": {"application/json"}}),
+		ResponseBody:    &policy.Body{Content: []byte(body), Present: body != "", EndOfStream: true},
+		ResponseStatus:  status,
+	}
+	return p.OnResponse(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"mappings": []interface{}{418}},
+		{"mappings": map[string]interface{}{"teapot": 400}},
+		{"mappings": map[string]interface{}{"418": 600}},
+		{"errorInBody": map[string]interface{}{"jsonPath": "status"}},
+		{"errorInBody": map[string]interface{}{"jsonPath": "$.a..b"}},
+		{"errorInBody": map[string]interface{}{"jsonPath": "$.status", "values": []interface{}{}}},
+		{"errorInBody": map[string]interface{}{"jsonPath": "$.status", "status": 99}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestStatusRemapPolicy_MappedStatus(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"mappings": map[string]interface{}{"418": 400, "299": float64(200)},
+	})
+
+	policytest.ExpectResponseStatus(t, onResponse(p, 418, `{"message":"teapot"}`), 400)
+	policytest.ExpectResponseStatus(t, onResponse(p, 299, ""), 200)
+	if mode := p.Mode(); mode.ResponseBodyMode != policy.BodyModeSkip {
+		t.Errorf("Expected response body to be skipped without errorInBody, got %v", mode.ResponseBodyMode)
+	}
+}
+
+func TestStatusRemapPolicy_ErrorInSuccessfulResponse(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"errorInBody": map[string]interface{}{"jsonPath": "$.result.status", "values": []interface{}{"error", "failed"}},
+	})
+
+	policytest.ExpectResponseStatus(t, onResponse(p, 200, `{"result":{"status":"error","message":"backend down"}}`), 502)
+	policytest.ExpectResponseStatus(t, onResponse(p, 201, `{"result":{"status":"failed"}}`), 502)
+	policytest.ExpectResponseStatus(t, onResponse(p, 200, `{"result":{"status":"ok"}}`), 0)
+	policytest.ExpectResponseStatus(t, onResponse(p, 200, `not json`), 0)
+	// Only successful responses are inspected
+	policytest.ExpectResponseStatus(t, onResponse(p, 500, `{"result":{"status":"error"}}`), 0)
+
+	// Without values any truthy field signals an error
+	truthy := policytest.New(t, GetPolicy, map[string]interface{}{
+		"errorInBody": map[string]interface{}{"jsonPath": "$.error", "status": 500},
+	})
+	policytest.ExpectResponseStatus(t, onResponse(truthy, 200, `{"error":{"code":9}}`), 500)
+	policytest.ExpectResponseStatus(t, onResponse(truthy, 200, `{"error":null}`), 0)
+	policytest.ExpectResponseStatus(t, onResponse(truthy, 200, `{"error":false}`), 0)
+}
+
+func TestStatusRemapPolicy_UnmappedStatusPasses(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"mappings":    map[string]interface{}{"418": 400},
+		"errorInBody": map[string]interface{}{"jsonPath": "$.error"},
+	})
+
+	policytest.ExpectResponseStatus(t, onResponse(p, 200, `{"data":[1,2]}`), 0)
+	policytest.ExpectResponseStatus(t, onResponse(p, 404, `{"error":"missing"}`), 0)
+}
@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package strictacceptjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// mediaRange is one entry of an Accept header
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// StrictAcceptJSONPolicy rejects requests that don't explicitly accept JSON with 406
+type StrictAcceptJSONPolicy struct {
+	acceptable    map[string]bool // lower-cased media types that satisfy the policy
+	allowWildcard bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &StrictAcceptJSONPolicy{
+		acceptable: map[string]bool{"application/json": true},
+	}
+
+	if raw, ok := params["allowWildcard"]; ok {
+		allowWildcard, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'allowWildcard' must be a boolean")
+		}
+		p.allowWildcard = allowWildcard
+	}
+
+	if raw, ok := params["acceptableTypes"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'acceptableTypes' must be an array")
+		}
+		for i, item := range list {
+			value, _ := item.(string)
+			mediaType, _, err := mime.ParseMediaType(value)
+			if err != nil || !strings.Contains(mediaType, "/") || strings.Contains(mediaType, "*") {
+				return nil, fmt.Errorf("acceptableTypes[%d] must be a media type without wildcards, e.g. 'application/problem+json'", i)
+			}
+			p.acceptable[mediaType] = true
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *StrictAcceptJSONPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the Accept header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest accepts the request when its Accept header lists an acceptable type with a
+// non-zero q-value, or, when wildcards are allowed, a matching wildcard range or no Accept
+// header at all
+func (p *StrictAcceptJSONPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get("accept")
+	ranges, err := parseAccept(values)
+	if err != nil {
+		slog.Debug("StrictAcceptJSON: Malformed Accept header", "error", err)
+		return errorResponse(http.StatusBadRequest, "Bad Request", "Malformed Accept header")
+	}
+
+	if len(values) == 0 && p.allowWildcard {
+		return policy.UpstreamRequestModifications{}
+	}
+	for _, r := range ranges {
+		if p.acceptable[r.mediaType] {
+			return policy.UpstreamRequestModifications{}
+		}
+		if p.allowWildcard && p.matchesWildcard(r.mediaType) {
+			return policy.UpstreamRequestModifications{}
+		}
+	}
+
+	slog.Debug("StrictAcceptJSON: Request does not accept JSON", "accept", values)
+	return errorResponse(http.StatusNotAcceptable, "Not Acceptable",
+		fmt.Sprintf("The Accept header must explicitly include one of: %s", strings.Join(p.acceptableList(), ", ")))
+}
+
+// OnResponse is not used by this policy
+func (p *StrictAcceptJSONPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// matchesWildcard reports whether a "*/*" or "type/*" range covers an acceptable type
+func (p *StrictAcceptJSONPolicy) matchesWildcard(mediaRange string) bool {
+	if mediaRange == "*/*" {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	if !ok {
+		return false
+	}
+	for mediaType := range p.acceptable {
+		if strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *StrictAcceptJSONPolicy) acceptableList() []string {
+	types := make([]string, 0, len(p.acceptable))
+	for mediaType := range p.acceptable {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// parseAccept parses Accept header values into media ranges ordered by preference. Ranges with
+// q=0 are dropped.
+func parseAccept(values []string) ([]mediaRange, error) {
+	var ranges []mediaRange
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			mediaType, mediaParams, err := mime.ParseMediaType(part)
+			if err != nil {
+				return nil, err
+			}
+			q := 1.0
+			if raw, ok := mediaParams["q"]; ok {
+				q, err = strconv.ParseFloat(raw, 64)
+				if err != nil || q < 0 || q > 1 {
+					return nil, fmt.Errorf("invalid q-value %q", raw)
+				}
+			}
+			if q > 0 {
+				ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+			}
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges, nil
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, title, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package strictacceptjson
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(t *testing.T, params map[string]interface{}, accept []string) policy.RequestAction {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	headers := map[string][]string{}
+	if accept != nil {
+		headers["accept"] = accept
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"allowWildcard": "true"},
+		{"acceptableTypes": "application/json"},
+		{"acceptableTypes": []interface{}{"application/*"}},
+		{"acceptableTypes": []interface{}{"json"}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestStrictAcceptJSONPolicy_ExplicitJSONPasses(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, nil, []string{"application/json"}), 0)
+	policytest.ExpectStatus(t, onRequest(t, nil, []string{"text/html;q=0.9, Application/JSON;q=0.5"}), 0)
+	policytest.ExpectStatus(t, onRequest(t, map[string]interface{}{"acceptableTypes": []interface{}{"application/problem+json"}}, []string{"application/problem+json"}), 0)
+
+	// Explicitly refusing JSON does not count
+	policytest.ExpectStatus(t, onRequest(t, nil, []string{"application/json;q=0, text/html"}), 406)
+	policytest.ExpectStatus(t, onRequest(t, nil, []string{"application/json;q=2"}), 400)
+}
+
+func TestStrictAcceptJSONPolicy_WildcardRejectedWhenStrict(t *testing.T) {
+	policytest.ExpectStatus(t, onRequest(t, nil, []string{"*/*"}), 406)
+	policytest.ExpectStatus(t, onRequest(t, nil, []string{"application/*"}), 406)
+	policytest.ExpectStatus(t, onRequest(t, nil, nil), 406)
+}
+
+func TestStrictAcceptJSONPolicy_WildcardAllowed(t *testing.T) {
+	params := map[string]interface{}{"allowWildcard": true}
+
+	policytest.ExpectStatus(t, onRequest(t, params, []string{"*/*"}), 0)
+	policytest.ExpectStatus(t, onRequest(t, params, []string{"application/*"}), 0)
+	policytest.ExpectStatus(t, onRequest(t, params, nil), 0)
+	policytest.ExpectStatus(t, onRequest(t, params, []string{"text/*"}), 406)
+}
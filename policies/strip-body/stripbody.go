@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package stripbody
+
+import (
+	"fmt"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// StripBodyPolicy removes the request body for methods where a body is disallowed or has no
+// defined meaning, so upstreams see the same request regardless of the client
+type StripBodyPolicy struct {
+	methods map[string]bool
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &StripBodyPolicy{
+		methods: map[string]bool{"GET": true, "HEAD": true, "DELETE": true},
+	}
+
+	if raw, ok := params["methods"]; ok {
+		methodsRaw, ok := raw.([]interface{})
+		if !ok || len(methodsRaw) == 0 {
+			return nil, fmt.Errorf("'methods' must be a non-empty array")
+		}
+		p.methods = make(map[string]bool)
+		for i, m := range methodsRaw {
+			method, ok := m.(string)
+			if !ok || strings.TrimSpace(method) == "" {
+				return nil, fmt.Errorf("methods[%d] must be a non-empty string", i)
+			}
+			p.methods[strings.ToUpper(strings.TrimSpace(method))] = true
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *StripBodyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need body framing headers
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to remove it
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest drops the body and its entity headers for the configured methods
+func (p *StripBodyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if !p.methods[strings.ToUpper(ctx.Method)] {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	hasBody := ctx.Body != nil && ctx.Body.Present && len(ctx.Body.Content) > 0
+	if !hasBody && !ctx.Headers.Has("content-length") && !ctx.Headers.Has("content-type") {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	mods := policy.UpstreamRequestModifications{
+		RemoveHeaders: []string{"content-length", "content-type"},
+	}
+	if hasBody {
+		mods.Body = []byte{}
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *StripBodyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package stripbody
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, method string, body string) policy.UpstreamRequestModifications {
+	headers := map[string][]string{}
+	var b *policy.Body
+	if body != "" {
+		headers["content-type"] = []string{"application/json"}
+		headers["content-length"] = []string{"2"}
+		b = &policy.Body{Content: []byte(body), Present: true, EndOfStream: true}
+	}
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers), Body: b, Method: method}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"methods": []interface{}{}},
+		{"methods": "GET"},
+		{"methods": []interface{}{" "}},
+		{"methods": []interface{}{42}},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestStripBodyPolicy_GetBodyStripped(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onRequest(p, "GET", `{}`)
+	if mods.Body == nil || len(mods.Body) != 0 {
+		t.Errorf("Expected body to be cleared, got %v", mods.Body)
+	}
+	if len(mods.RemoveHeaders) != 2 || mods.RemoveHeaders[0] != "content-length" || mods.RemoveHeaders[1] != "content-type" {
+		t.Errorf("Expected content-length and content-type to be removed, got %v", mods.RemoveHeaders)
+	}
+}
+
+func TestStripBodyPolicy_PostIntact(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onRequest(p, "POST", `{}`)
+	if mods.Body != nil || len(mods.RemoveHeaders) != 0 {
+		t.Errorf("Expected POST to be forwarded unchanged, got %+v", mods)
+	}
+}
+
+func TestStripBodyPolicy_NoBodyUnchanged(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	mods := onRequest(p, "GET", "")
+	if mods.Body != nil || len(mods.RemoveHeaders) != 0 {
+		t.Errorf("Expected bodiless GET to be forwarded unchanged, got %+v", mods)
+	}
+}
+
+func TestStripBodyPolicy_CustomMethods(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"methods": []interface{}{"options"}})
+
+	if mods := onRequest(p, "OPTIONS", `{}`); mods.Body == nil {
+		t.Error("Expected OPTIONS body to be cleared")
+	}
+	if mods := onRequest(p, "GET", `{}`); mods.Body != nil {
+		t.Error("Expected GET to be left intact when not configured")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package striptrackingparams
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// defaultParams are tracking parameters added by common analytics and ad platforms
+var defaultParams = []string{"fbclid", "gclid", "dclid", "msclkid", "yclid", "igshid", "mc_cid", "mc_eid", "_ga"}
+
+// utmParams are the standard UTM parameters, used when prefix matching is disabled
+var utmParams = []string{"utm_source", "utm_medium", "utm_campaign", "utm_term", "utm_content", "utm_id"}
+
+// StripTrackingParamsPolicy removes tracking query parameters before forwarding, improving
+// cache hit rates and keeping tracking identifiers away from upstreams
+type StripTrackingParamsPolicy struct {
+	exact    map[string]bool // Lower-cased parameter names
+	prefixes []string        // Lower-cased prefixes from "name*" patterns
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	prefixMatching := true
+	if raw, ok := params["prefixMatching"]; ok {
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'prefixMatching' must be a boolean")
+		}
+		prefixMatching = value
+	}
+
+	p := &StripTrackingParamsPolicy{exact: make(map[string]bool)}
+	for _, name := range defaultParams {
+		p.exact[name] = true
+	}
+	if prefixMatching {
+		p.prefixes = append(p.prefixes, "utm_")
+	} else {
+		for _, name := range utmParams {
+			p.exact[name] = true
+		}
+	}
+
+	if raw, ok := params["additionalParams"]; ok {
+		patterns, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'additionalParams' must be an array")
+		}
+		for i, patternRaw := range patterns {
+			pattern, ok := patternRaw.(string)
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if !ok || pattern == "" || pattern == "*" {
+				return nil, fmt.Errorf("additionalParams[%d] must be a non-empty parameter name", i)
+			}
+			prefix, isPrefix := strings.CutSuffix(pattern, "*")
+			if strings.Contains(prefix, "*") {
+				return nil, fmt.Errorf("additionalParams[%d] may only contain '*' at the end", i)
+			}
+			if isPrefix {
+				if !prefixMatching {
+					return nil, fmt.Errorf("additionalParams[%d] uses a wildcard but 'prefixMatching' is disabled", i)
+				}
+				p.prefixes = append(p.prefixes, prefix)
+				continue
+			}
+			p.exact[pattern] = true
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *StripTrackingParamsPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Rewrites request path
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest removes tracking parameters from the request URL
+func (p *StripTrackingParamsPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	reqPath, query, ok := strings.Cut(ctx.Path, "?")
+	if !ok || query == "" {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	pairs := strings.Split(query, "&")
+	kept := pairs[:0]
+	for _, pair := range pairs {
+		rawKey, _, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		if !p.isTracking(strings.ToLower(key)) {
+			kept = append(kept, pair)
+		}
+	}
+	if len(kept) == len(pairs) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	newPath := reqPath
+	if len(kept) > 0 {
+		newPath += "?" + strings.Join(kept, "&")
+	}
+	return policy.UpstreamRequestModifications{Path: &newPath}
+}
+
+// OnResponse is not used by this policy
+func (p *StripTrackingParamsPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// isTracking reports whether a lower-cased parameter name is a tracking parameter
+func (p *StripTrackingParamsPolicy) isTracking(name string) bool {
+	if p.exact[name] {
+		return true
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package striptrackingparams
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, path string) *string {
+	ctx := &policy.RequestContext{Path: path}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications).Path
+}
+
+func expectPath(t *testing.T, got *string, expected string) {
+	t.Helper()
+	if got == nil || *got != expected {
+		t.Errorf("Expected path %q, got %v", expected, got)
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"additionalParams": "ref"},
+		{"additionalParams": []interface{}{""}},
+		{"additionalParams": []interface{}{"*"}},
+		{"additionalParams": []interface{}{"r*f"}},
+		{"additionalParams": []interface{}{"ref_*"}, "prefixMatching": false},
+		{"prefixMatching": "yes"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestStripTrackingParamsPolicy_StripUTM(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"prefixMatching": false})
+
+	expectPath(t, onRequest(p, "/products?utm_source=news&id=7&utm_medium=email"), "/products?id=7")
+	expectPath(t, onRequest(p, "/products?utm_source=news&gclid=abc"), "/products")
+	// Only the standard UTM names match without prefix matching
+	if got := onRequest(p, "/products?utm_custom=1"); got != nil {
+		t.Errorf("Expected utm_custom to be kept, got %q", *got)
+	}
+}
+
+func TestStripTrackingParamsPolicy_WildcardMatch(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"additionalParams": []interface{}{"ref_*", "Campaign"}})
+
+	expectPath(t, onRequest(p, "/products?UTM_Custom=1&ref_partner=x&campaign=y&page=2"), "/products?page=2")
+}
+
+func TestStripTrackingParamsPolicy_PreserveFunctionalParams(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	if got := onRequest(p, "/search?q=a%20b&sort=asc&utmost=1"); got != nil {
+		t.Errorf("Expected functional params to be untouched, got %q", *got)
+	}
+	expectPath(t, onRequest(p, "/search?q=a%20b&fbclid=1&sort=asc"), "/search?q=a%20b&sort=asc")
+}
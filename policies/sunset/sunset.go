@@ -0,0 +1,141 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sunset
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// SunsetPolicy advertises the deprecation of an API and rejects requests once its sunset date passes
+type SunsetPolicy struct {
+	sunsetDate time.Time
+	link       string           // Optional migration documentation URL
+	now        func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	dateRaw, ok := params["sunsetDate"].(string)
+	if !ok || strings.TrimSpace(dateRaw) == "" {
+		return nil, fmt.Errorf("'sunsetDate' parameter is required and must be a non-empty string")
+	}
+	sunsetDate, err := parseDate(strings.TrimSpace(dateRaw))
+	if err != nil {
+		return nil, fmt.Errorf("'sunsetDate' must be an RFC 3339 timestamp, a date (YYYY-MM-DD) or an HTTP date: %w", err)
+	}
+
+	p := &SunsetPolicy{
+		sunsetDate: sunsetDate,
+		now:        time.Now,
+	}
+
+	if linkRaw, ok := params["link"]; ok {
+		link, ok := linkRaw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'link' must be a string")
+		}
+		if u, err := url.Parse(link); err != nil || !u.IsAbs() {
+			return nil, fmt.Errorf("'link' must be an absolute URL")
+		}
+		p.link = link
+	}
+
+	return p, nil
+}
+
+// parseDate accepts RFC 3339 timestamps, plain dates (midnight UTC) and HTTP dates
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return http.ParseTime(value)
+}
+
+// Mode returns the processing mode for this policy
+func (p *SunsetPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Reject requests after the sunset date
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeProcess, // Add deprecation headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest returns 410 Gone once the sunset date has passed
+func (p *SunsetPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if p.now().Before(p.sunsetDate) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	slog.Debug("Sunset: Rejecting request to retired API", "sunsetDate", p.sunsetDate)
+
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Gone",
+		"message": fmt.Sprintf("This API was retired on %s", p.sunsetDate.UTC().Format(time.RFC3339)),
+	})
+	headers := p.headers()
+	headers["content-type"] = "application/json"
+	if p.link != "" {
+		headers["link"] = p.linkHeader()
+	}
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusGone,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+// OnResponse adds the Deprecation and Sunset headers, appending the optional Link so that
+// upstream links such as pagination are kept
+func (p *SunsetPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	mods := policy.UpstreamResponseModifications{
+		SetHeaders: p.headers(),
+	}
+	if p.link != "" {
+		mods.AppendHeaders = map[string][]string{
+			"link": {p.linkHeader()},
+		}
+	}
+	return mods
+}
+
+// headers builds the deprecation headers (RFC 8594)
+func (p *SunsetPolicy) headers() map[string]string {
+	return map[string]string{
+		"deprecation": "true",
+		"sunset":      p.sunsetDate.UTC().Format(http.TimeFormat),
+	}
+}
+
+// linkHeader builds the Link header value pointing at the migration documentation
+func (p *SunsetPolicy) linkHeader() string {
+	return fmt.Sprintf("<%s>; rel=\"deprecation\"", p.link)
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sunset
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}, now time.Time) *SunsetPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	sp := p.(*SunsetPolicy)
+	sp.now = func() time.Time { return now }
+	return sp
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"sunsetDate": "next year"},
+		{"sunsetDate": "2026-12-31", "link": "/docs/migration"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestSunsetPolicy_PreSunsetHeaders(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"sunsetDate": "2026-12-31T23:59:59Z"},
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	if _, ok := p.OnRequest(&policy.RequestContext{}, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Fatal("Expected request to be forwarded before the sunset date")
+	}
+
+	mods := p.OnResponse(&policy.ResponseContext{}, nil).(policy.UpstreamResponseModifications)
+	if mods.SetHeaders["deprecation"] != "true" {
+		t.Errorf("Expected Deprecation header, got %q", mods.SetHeaders["deprecation"])
+	}
+	if mods.SetHeaders["sunset"] != "Thu, 31 Dec 2026 23:59:59 GMT" {
+		t.Errorf("Expected Sunset header, got %q", mods.SetHeaders["sunset"])
+	}
+	if _, ok := mods.AppendHeaders["link"]; ok {
+		t.Error("Expected no Link header without a configured link")
+	}
+}
+
+func TestSunsetPolicy_PostSunsetGone(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"sunsetDate": "2026-12-31"},
+		time.Date(2026, 12, 31, 0, 0, 1, 0, time.UTC))
+
+	resp, ok := p.OnRequest(&policy.RequestContext{}, nil).(policy.ImmediateResponse)
+	if !ok {
+		t.Fatal("Expected ImmediateResponse after the sunset date")
+	}
+	if resp.StatusCode != http.StatusGone {
+		t.Errorf("Expected status 410, got %d", resp.StatusCode)
+	}
+	if resp.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", resp.Headers["content-type"])
+	}
+	if resp.Headers["sunset"] != "Thu, 31 Dec 2026 00:00:00 GMT" {
+		t.Errorf("Expected Sunset header on 410 response, got %q", resp.Headers["sunset"])
+	}
+}
+
+func TestSunsetPolicy_MigrationLink(t *testing.T) {
+	params := map[string]interface{}{
+		"sunsetDate": "Thu, 31 Dec 2026 23:59:59 GMT",
+		"link":       "https://developer.example.com/migrate-to-v2",
+	}
+	expected := `<https://developer.example.com/migrate-to-v2>; rel="deprecation"`
+
+	p := newPolicy(t, params, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	mods := p.OnResponse(&policy.ResponseContext{}, nil).(policy.UpstreamResponseModifications)
+	if _, ok := mods.SetHeaders["link"]; ok {
+		t.Error("Expected Link header to be appended rather than replacing upstream links")
+	}
+	if links := mods.AppendHeaders["link"]; len(links) != 1 || links[0] != expected {
+		t.Errorf("Expected appended Link header %q, got %v", expected, links)
+	}
+
+	p = newPolicy(t, params, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	resp := p.OnRequest(&policy.RequestContext{}, nil).(policy.ImmediateResponse)
+	if resp.Headers["link"] != expected {
+		t.Errorf("Expected Link header on 410 response, got %q", resp.Headers["link"])
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package tenantbudget
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Budget windows
+	WindowDaily   = "daily"
+	WindowMonthly = "monthly"
+
+	defaultKeyHeader = "x-tenant"
+)
+
+// usage is a tenant's consumption within the current window
+type usage struct {
+	window time.Time // Start of the window the count belongs to
+	used   int
+}
+
+// TenantBudgetPolicy enforces a per-tenant request budget that resets at daily or monthly
+// calendar boundaries
+type TenantBudgetPolicy struct {
+	budgets       map[string]int
+	defaultBudget int // 0 leaves unlisted tenants unmetered
+	window        string
+	location      *time.Location
+	keyHeader     string
+
+	mu    sync.Mutex
+	now   func() time.Time // Injectable clock (for testing)
+	usage map[string]*usage
+	swept time.Time // Window start of the last sweep
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &TenantBudgetPolicy{
+		budgets:   make(map[string]int),
+		window:    WindowMonthly,
+		location:  time.UTC,
+		keyHeader: defaultKeyHeader,
+		now:       time.Now,
+		usage:     make(map[string]*usage),
+	}
+
+	if raw, ok := params["budgets"]; ok {
+		budgets, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'budgets' must be a map of tenant to request budget")
+		}
+		for tenant, budgetRaw := range budgets {
+			budget, err := extractInt(budgetRaw)
+			if err != nil || budget < 0 {
+				return nil, fmt.Errorf("budgets.%s must be a non-negative integer", tenant)
+			}
+			p.budgets[tenant] = budget
+		}
+	}
+
+	if raw, ok := params["defaultBudget"]; ok {
+		budget, err := extractInt(raw)
+		if err != nil || budget < 1 {
+			return nil, fmt.Errorf("'defaultBudget' must be a positive integer")
+		}
+		p.defaultBudget = budget
+	}
+	if len(p.budgets) == 0 && p.defaultBudget == 0 {
+		return nil, fmt.Errorf("at least one of 'budgets' or 'defaultBudget' must be configured")
+	}
+
+	if raw, ok := params["window"]; ok {
+		window, ok := raw.(string)
+		if !ok || (window != WindowDaily && window != WindowMonthly) {
+			return nil, fmt.Errorf("'window' must be one of %s, %s", WindowDaily, WindowMonthly)
+		}
+		p.window = window
+	}
+
+	if raw, ok := params["timezone"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'timezone' must be a non-empty string")
+		}
+		location, err := time.LoadLocation(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'timezone': %w", err)
+		}
+		p.location = location
+	}
+
+	if raw, ok := params["keyHeader"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'keyHeader' must be a non-empty string")
+		}
+		p.keyHeader = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *TenantBudgetPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the tenant header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest charges the request to the tenant's budget and rejects it once the budget for the
+// current window is spent
+func (p *TenantBudgetPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get(p.keyHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return errorResponse(http.StatusBadRequest, "Bad Request",
+			fmt.Sprintf("Required header '%s' is missing", p.keyHeader), nil)
+	}
+	tenant := strings.TrimSpace(values[0])
+
+	budget, ok := p.budgets[tenant]
+	if !ok {
+		budget = p.defaultBudget
+	}
+	if !ok && budget == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now().In(p.location)
+	start, next := p.bounds(now)
+	p.sweep(start)
+
+	u, ok := p.usage[tenant]
+	if !ok || !u.window.Equal(start) {
+		u = &usage{window: start}
+		p.usage[tenant] = u
+	}
+	if u.used >= budget {
+		slog.Debug("TenantBudget: Budget exhausted", "tenant", tenant, "budget", budget, "window", p.window)
+		retryAfter := int64(math.Ceil(next.Sub(now).Seconds()))
+		return errorResponse(http.StatusTooManyRequests, "Too Many Requests",
+			fmt.Sprintf("The %s request budget for this tenant is exhausted", p.window),
+			map[string]string{"retry-after": strconv.FormatInt(max(retryAfter, 1), 10)})
+	}
+	u.used++
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *TenantBudgetPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// bounds returns the start of the window containing now and the start of the next one
+func (p *TenantBudgetPolicy) bounds(now time.Time) (time.Time, time.Time) {
+	year, month, day := now.Date()
+	if p.window == WindowDaily {
+		start := time.Date(year, month, day, 0, 0, 0, 0, p.location)
+		return start, start.AddDate(0, 0, 1)
+	}
+	start := time.Date(year, month, 1, 0, 0, 0, 0, p.location)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// sweep drops usage from earlier windows once per window so that tenants that stop sending
+// requests don't accumulate. Callers must hold p.mu.
+func (p *TenantBudgetPolicy) sweep(start time.Time) {
+	if p.swept.Equal(start) {
+		return
+	}
+	for tenant, u := range p.usage {
+		if !u.window.Equal(start) {
+			delete(p.usage, tenant)
+		}
+	}
+	p.swept = start
+}
+
+// errorResponse builds a JSON error response with optional extra headers
+func errorResponse(status int, title, message string, extra map[string]string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   title,
+		"message": message,
+	})
+	headers := map[string]string{
+		"content-type": "application/json",
+	}
+	for name, value := range extra {
+		headers[name] = value
+	}
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
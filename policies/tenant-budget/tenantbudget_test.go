@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package tenantbudget
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}, now *time.Time) *TenantBudgetPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	tp := p.(*TenantBudgetPolicy)
+	tp.now = func() time.Time { return *now }
+	return tp
+}
+
+func onRequest(p policy.Policy, tenant string) policy.RequestAction {
+	headers := map[string][]string{}
+	if tenant != "" {
+		headers["x-tenant"] = []string{tenant}
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"budgets": []interface{}{"acme"}},
+		{"budgets": map[string]interface{}{"acme": -1}},
+		{"defaultBudget": 0},
+		{"defaultBudget": 10, "window": "weekly"},
+		{"defaultBudget": 10, "timezone": "Mars/Base"},
+		{"defaultBudget": 10, "keyHeader": ""},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestTenantBudgetPolicy_WithinBudget(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	p := newPolicy(t, map[string]interface{}{"budgets": map[string]interface{}{"acme": float64(3), "blocked": 0}}, &now)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			policytest.ExpectStatus(t, onRequest(p, "acme"), 0)
+		}()
+	}
+	wg.Wait()
+	if used := p.usage["acme"].used; used != 3 {
+		t.Errorf("Expected 3 requests charged, got %d", used)
+	}
+
+	// Unlisted tenants are unmetered without a default budget
+	policytest.ExpectStatus(t, onRequest(p, "other"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "blocked"), 429)
+	policytest.ExpectStatus(t, onRequest(p, ""), 400)
+}
+
+func TestTenantBudgetPolicy_ExhaustedReturns429(t *testing.T) {
+	now := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	p := newPolicy(t, map[string]interface{}{"defaultBudget": 2}, &now)
+
+	policytest.ExpectStatus(t, onRequest(p, "acme"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "acme"), 0)
+	resp := policytest.ExpectStatus(t, onRequest(p, "acme"), 429)
+	if resp.Headers["retry-after"] != "3600" {
+		t.Errorf("Expected retry-after until the next month, got %q", resp.Headers["retry-after"])
+	}
+	// Budgets are per tenant
+	policytest.ExpectStatus(t, onRequest(p, "globex"), 0)
+}
+
+func TestTenantBudgetPolicy_ResetAtWindowBoundary(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2026, 3, 10, 23, 59, 0, 0, tokyo)
+	p := newPolicy(t, map[string]interface{}{
+		"budgets":  map[string]interface{}{"acme": 1},
+		"window":   "daily",
+		"timezone": "Asia/Tokyo",
+	}, &now)
+
+	policytest.ExpectStatus(t, onRequest(p, "acme"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "acme"), 429)
+
+	now = now.Add(time.Minute)
+	policytest.ExpectStatus(t, onRequest(p, "acme"), 0)
+	policytest.ExpectStatus(t, onRequest(p, "acme"), 429)
+}
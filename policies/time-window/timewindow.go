@@ -0,0 +1,203 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package timewindow
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const defaultMessage = "The service is not available at this time"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// TimeWindowPolicy rejects requests arriving outside a recurring weekly time window
+type TimeWindowPolicy struct {
+	location *time.Location
+	days     [7]bool // Days on which a window starts, indexed by time.Weekday
+	start    int     // Window start in minutes after midnight
+	end      int     // Window end in minutes after midnight; end <= start spans midnight
+	message  string
+	now      func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &TimeWindowPolicy{
+		location: time.UTC,
+		message:  defaultMessage,
+		now:      time.Now,
+	}
+
+	if raw, ok := params["timezone"]; ok {
+		name, ok := raw.(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("'timezone' must be a non-empty string")
+		}
+		location, err := time.LoadLocation(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'timezone': %w", err)
+		}
+		p.location = location
+	}
+
+	_, hasDays := params["days"]
+	if hasDays {
+		daysRaw, ok := params["days"].([]interface{})
+		if !ok || len(daysRaw) == 0 {
+			return nil, fmt.Errorf("'days' must be a non-empty array")
+		}
+		for i, raw := range daysRaw {
+			name, _ := raw.(string)
+			day, ok := weekdays[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return nil, fmt.Errorf("days[%d] must be a day of the week such as 'mon' or 'monday'", i)
+			}
+			p.days[day] = true
+		}
+	} else {
+		for day := range p.days {
+			p.days[day] = true
+		}
+	}
+
+	startRaw, hasStart := params["startTime"]
+	endRaw, hasEnd := params["endTime"]
+	if hasStart != hasEnd {
+		return nil, fmt.Errorf("'startTime' and 'endTime' must be configured together")
+	}
+	if hasStart {
+		var err error
+		if p.start, err = parseClock(startRaw); err != nil {
+			return nil, fmt.Errorf("invalid 'startTime': %w", err)
+		}
+		if p.end, err = parseClock(endRaw); err != nil {
+			return nil, fmt.Errorf("invalid 'endTime': %w", err)
+		}
+	}
+	if !hasDays && !hasStart {
+		return nil, fmt.Errorf("at least one of 'days' or 'startTime'/'endTime' must be configured")
+	}
+
+	if raw, ok := params["message"]; ok {
+		message, ok := raw.(string)
+		if !ok || strings.TrimSpace(message) == "" {
+			return nil, fmt.Errorf("'message' must be a non-empty string")
+		}
+		p.message = message
+	}
+
+	return p, nil
+}
+
+// parseClock parses an "HH:MM" time of day into minutes after midnight
+func parseClock(raw interface{}) (int, error) {
+	value, ok := raw.(string)
+	if !ok {
+		return 0, fmt.Errorf("must be a string in HH:MM format")
+	}
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("must be a string in HH:MM format")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *TimeWindowPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeSkip, // Don't process request headers
+		RequestBodyMode:    policy.BodyModeSkip,   // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip, // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,   // Don't need response body
+	}
+}
+
+// OnRequest rejects requests outside the allowed window with a 503
+func (p *TimeWindowPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	now := p.now().In(p.location)
+	if p.inWindow(now) {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Service Unavailable",
+		"message": p.message,
+	})
+	headers := map[string]string{
+		"content-type": "application/json",
+	}
+	if next, ok := p.nextOpening(now); ok {
+		seconds := int64(math.Ceil(next.Sub(now).Seconds()))
+		headers["retry-after"] = strconv.FormatInt(seconds, 10)
+	}
+	return policy.ImmediateResponse{
+		StatusCode: 503,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *TimeWindowPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// inWindow reports whether a local time falls in the window. A window spanning midnight
+// belongs to the day it starts on.
+func (p *TimeWindowPolicy) inWindow(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+	if p.start < p.end {
+		return p.days[now.Weekday()] && minute >= p.start && minute < p.end
+	}
+	if minute >= p.start {
+		return p.days[now.Weekday()]
+	}
+	if minute < p.end {
+		return p.days[(now.Weekday()+6)%7]
+	}
+	return false
+}
+
+// nextOpening returns the start of the next window after now
+func (p *TimeWindowPolicy) nextOpening(now time.Time) (time.Time, bool) {
+	for offset := 0; offset <= 7; offset++ {
+		day := now.AddDate(0, 0, offset)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), p.start/60, p.start%60, 0, 0, p.location)
+		if candidate.After(now) && p.days[candidate.Weekday()] {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package timewindow
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *TimeWindowPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	return p.(*TimeWindowPolicy)
+}
+
+func requestAt(p *TimeWindowPolicy, now time.Time) policy.RequestAction {
+	p.now = func() time.Time { return now }
+	return p.OnRequest(&policy.RequestContext{}, nil)
+}
+
+func businessHours() map[string]interface{} {
+	return map[string]interface{}{
+		"days":      []interface{}{"mon", "tue", "wed", "thu", "fri"},
+		"startTime": "09:00",
+		"endTime":   "17:00",
+	}
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"timezone": "Mars/Olympus_Mons", "days": []interface{}{"mon"}},
+		{"days": []interface{}{}},
+		{"days": []interface{}{"someday"}},
+		{"startTime": "09:00"},
+		{"startTime": "9am", "endTime": "17:00"},
+		{"startTime": "09:00", "endTime": "24:00"},
+		{"days": []interface{}{"mon"}, "message": ""},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestTimeWindowPolicy_InWindow(t *testing.T) {
+	p := newPolicy(t, businessHours())
+
+	// Tuesday 2026-03-03
+	policytest.ExpectForwarded(t, requestAt(p, time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)))
+	policytest.ExpectForwarded(t, requestAt(p, time.Date(2026, 3, 3, 16, 59, 59, 0, time.UTC)))
+}
+
+func TestTimeWindowPolicy_OutOfWindow(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"days":      []interface{}{"mon", "tue", "wed", "thu", "fri"},
+		"startTime": "09:00",
+		"endTime":   "17:00",
+		"message":   "Closed for maintenance",
+	})
+
+	// Tuesday 2026-03-03 at close
+	resp := policytest.ExpectStatus(t, requestAt(p, time.Date(2026, 3, 3, 17, 0, 0, 0, time.UTC)), http.StatusServiceUnavailable)
+	var body map[string]string
+	if err := json.Unmarshal(resp.Body, &body); err != nil || body["message"] != "Closed for maintenance" {
+		t.Errorf("Expected custom message, got %s", resp.Body)
+	}
+	if got := resp.Headers["retry-after"]; got != "57600" {
+		t.Errorf("Expected retry-after until Wednesday 09:00, got %q", got)
+	}
+
+	// Saturday 2026-03-07 reopens on Monday
+	resp = policytest.ExpectStatus(t, requestAt(p, time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)), http.StatusServiceUnavailable)
+	if got := resp.Headers["retry-after"]; got != "162000" {
+		t.Errorf("Expected retry-after until Monday 09:00, got %q", got)
+	}
+}
+
+func TestTimeWindowPolicy_TimezoneAcrossDayBoundary(t *testing.T) {
+	params := businessHours()
+	params["timezone"] = "Asia/Tokyo" // UTC+9
+	p := newPolicy(t, params)
+
+	// Sunday 23:30 UTC is Monday 08:30 in Tokyo, before opening
+	policytest.ExpectStatus(t, requestAt(p, time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)), http.StatusServiceUnavailable)
+	// Monday 00:30 UTC is Monday 09:30 in Tokyo
+	policytest.ExpectForwarded(t, requestAt(p, time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC)))
+	// Friday 16:00 UTC is already Saturday in Tokyo
+	policytest.ExpectStatus(t, requestAt(p, time.Date(2026, 3, 6, 16, 0, 0, 0, time.UTC)), http.StatusServiceUnavailable)
+}
+
+func TestTimeWindowPolicy_OvernightWindow(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"days":      []interface{}{"friday"},
+		"startTime": "22:00",
+		"endTime":   "02:00",
+	})
+
+	policytest.ExpectForwarded(t, requestAt(p, time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC)))
+	policytest.ExpectForwarded(t, requestAt(p, time.Date(2026, 3, 7, 1, 0, 0, 0, time.UTC)))
+	policytest.ExpectStatus(t, requestAt(p, time.Date(2026, 3, 8, 1, 0, 0, 0, time.UTC)), http.StatusServiceUnavailable)
+}
@@ -0,0 +1,179 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package timestampwindow
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Formats of the timestamp header
+	FormatUnix       = "unix"
+	FormatUnixMillis = "unixMillis"
+	FormatRFC3339    = "rfc3339"
+	FormatHTTPDate   = "httpDate"
+)
+
+// TimestampWindowPolicy rejects requests whose timestamp header is missing, malformed or
+// further than the allowed skew from the gateway clock
+type TimestampWindowPolicy struct {
+	header string
+	format string
+	skew   time.Duration
+	now    func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &TimestampWindowPolicy{
+		header: "x-timestamp",
+		format: FormatUnix,
+		skew:   300 * time.Second,
+		now:    time.Now,
+	}
+
+	if raw, ok := params["header"]; ok {
+		header, ok := raw.(string)
+		if !ok || strings.TrimSpace(header) == "" {
+			return nil, fmt.Errorf("'header' must be a non-empty string")
+		}
+		p.header = strings.ToLower(strings.TrimSpace(header))
+	}
+
+	if raw, ok := params["format"]; ok {
+		format, ok := raw.(string)
+		switch format {
+		case FormatUnix, FormatUnixMillis, FormatRFC3339, FormatHTTPDate:
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("'format' must be one of %s, %s, %s, %s", FormatUnix, FormatUnixMillis, FormatRFC3339, FormatHTTPDate)
+		}
+		p.format = format
+	}
+
+	if raw, ok := params["skewSeconds"]; ok {
+		skew, err := extractInt(raw)
+		if err != nil || skew < 1 {
+			return nil, fmt.Errorf("'skewSeconds' must be a positive integer")
+		}
+		p.skew = time.Duration(skew) * time.Second
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *TimestampWindowPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need the timestamp header
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest rejects requests with 401 unless their timestamp is within the skew of now
+func (p *TimestampWindowPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get(p.header)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return unauthorized(fmt.Sprintf("Missing %s header", p.header))
+	}
+
+	timestamp, err := p.parse(strings.TrimSpace(values[0]))
+	if err != nil {
+		slog.Debug("TimestampWindow: Malformed timestamp", "header", p.header, "error", err)
+		return unauthorized(fmt.Sprintf("Malformed %s header", p.header))
+	}
+
+	offset := p.now().Sub(timestamp)
+	if offset > p.skew || offset < -p.skew {
+		slog.Debug("TimestampWindow: Timestamp outside window", "offset", offset, "skew", p.skew)
+		return unauthorized(fmt.Sprintf("Request timestamp is outside the allowed window of %d seconds", int(p.skew.Seconds())))
+	}
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *TimestampWindowPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// parse parses a timestamp in the configured format
+func (p *TimestampWindowPolicy) parse(value string) (time.Time, error) {
+	switch p.format {
+	case FormatUnix, FormatUnixMillis:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("not an integer timestamp")
+		}
+		if p.format == FormatUnixMillis {
+			return time.UnixMilli(n), nil
+		}
+		return time.Unix(n, 0), nil
+	case FormatRFC3339:
+		return time.Parse(time.RFC3339, value)
+	default:
+		return http.ParseTime(value)
+	}
+}
+
+func unauthorized(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Unauthorized",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusUnauthorized,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
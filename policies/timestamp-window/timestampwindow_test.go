@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package timestampwindow
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+var now = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func newPolicy(t *testing.T, params map[string]interface{}) *TimestampWindowPolicy {
+	t.Helper()
+	p := policytest.New(t, GetPolicy, params)
+	tp := p.(*TimestampWindowPolicy)
+	tp.now = func() time.Time { return now }
+	return tp
+}
+
+func onRequest(p policy.Policy, header, value string) policy.RequestAction {
+	headers := map[string][]string{}
+	if value != "" {
+		headers[header] = []string{value}
+	}
+	return p.OnRequest(&policy.RequestContext{Headers: policy.NewHeaders(headers)}, nil)
+}
+
+func unixAt(offset time.Duration) string {
+	return strconv.FormatInt(now.Add(offset).Unix(), 10)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"header": ""},
+		{"format": "iso"},
+		{"format": 1},
+		{"skewSeconds": 0},
+		{"skewSeconds": 1.5},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestTimestampWindowPolicy_FreshTimestampPasses(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"skewSeconds": 60})
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", unixAt(0)), 0)
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", unixAt(-60*time.Second)), 0)
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", unixAt(60*time.Second)), 0)
+
+	millis := newPolicy(t, map[string]interface{}{"header": "X-Request-Time", "format": "unixMillis"})
+	policytest.ExpectStatus(t, onRequest(millis, "x-request-time", strconv.FormatInt(now.Add(-time.Minute).UnixMilli(), 10)), 0)
+
+	rfc := newPolicy(t, map[string]interface{}{"format": "rfc3339"})
+	policytest.ExpectStatus(t, onRequest(rfc, "x-timestamp", now.Add(time.Minute).Format(time.RFC3339)), 0)
+
+	httpDate := newPolicy(t, map[string]interface{}{"header": "date", "format": "httpDate"})
+	policytest.ExpectStatus(t, onRequest(httpDate, "date", now.Format(http.TimeFormat)), 0)
+}
+
+func TestTimestampWindowPolicy_TooOldRejected(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"skewSeconds": 60})
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", unixAt(-61*time.Second)), 401)
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", unixAt(-24*time.Hour)), 401)
+}
+
+func TestTimestampWindowPolicy_TooFarInFutureRejected(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"skewSeconds": 60})
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", unixAt(61*time.Second)), 401)
+}
+
+func TestTimestampWindowPolicy_MalformedTimestampRejected(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", "yesterday"), 401)
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", now.Format(time.RFC3339)), 401)
+	policytest.ExpectStatus(t, onRequest(p, "x-timestamp", ""), 401)
+
+	rfc := newPolicy(t, map[string]interface{}{"format": "rfc3339"})
+	policytest.ExpectStatus(t, onRequest(rfc, "x-timestamp", unixAt(0)), 401)
+}
@@ -0,0 +1,179 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package tracecontext
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+
+	// sampledFlags marks generated traces as sampled
+	sampledFlags = "01"
+)
+
+// TraceContextPolicy ensures requests carry a valid W3C Trace Context traceparent header
+type TraceContextPolicy struct {
+	generate bool
+	validate bool
+	randRead func([]byte) (int, error) // Injectable randomness source (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &TraceContextPolicy{
+		generate: true,
+		validate: true,
+		randRead: rand.Read,
+	}
+
+	if raw, ok := params["generate"]; ok {
+		generate, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'generate' must be a boolean")
+		}
+		p.generate = generate
+	}
+
+	if raw, ok := params["validate"]; ok {
+		validate, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'validate' must be a boolean")
+		}
+		p.validate = validate
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *TraceContextPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need trace context headers
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates or generates the traceparent header
+func (p *TraceContextPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	values := ctx.Headers.Get(TraceparentHeader)
+
+	if len(values) > 0 {
+		// A request may carry only one traceparent; duplicates are treated as invalid
+		if !p.validate || (len(values) == 1 && IsValidTraceparent(values[0])) {
+			return policy.UpstreamRequestModifications{}
+		}
+		slog.Debug("TraceContext: Discarding invalid traceparent", "traceparent", values)
+	}
+
+	mods := policy.UpstreamRequestModifications{}
+	if !p.generate {
+		// tracestate must not be propagated without a valid traceparent
+		if len(values) > 0 {
+			mods.RemoveHeaders = []string{TraceparentHeader, TracestateHeader}
+		}
+		return mods
+	}
+
+	traceparent, err := p.newTraceparent()
+	if err != nil {
+		slog.Debug("TraceContext: Failed to generate traceparent", "error", err)
+		return mods
+	}
+
+	mods.SetHeaders = map[string]string{TraceparentHeader: traceparent}
+	if len(values) > 0 {
+		mods.RemoveHeaders = []string{TracestateHeader}
+	}
+	return mods
+}
+
+// OnResponse is not used by this policy
+func (p *TraceContextPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// newTraceparent generates a version 00 traceparent with random trace and parent IDs
+func (p *TraceContextPolicy) newTraceparent() (string, error) {
+	ids := make([]byte, 24)
+	for {
+		if _, err := p.randRead(ids); err != nil {
+			return "", err
+		}
+		traceID := hex.EncodeToString(ids[:16])
+		parentID := hex.EncodeToString(ids[16:])
+		if !isZero(traceID) && !isZero(parentID) {
+			return "00-" + traceID + "-" + parentID + "-" + sampledFlags, nil
+		}
+	}
+}
+
+// IsValidTraceparent reports whether a value is a well-formed traceparent as defined by the
+// W3C Trace Context specification
+func IsValidTraceparent(value string) bool {
+	value = strings.TrimSpace(value)
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 {
+		return false
+	}
+
+	version := parts[0]
+	if len(version) != 2 || !isLowerHex(version) || version == "ff" {
+		return false
+	}
+	// Version 00 has exactly four fields; future versions may append more
+	if version == "00" && len(parts) != 4 {
+		return false
+	}
+
+	traceID, parentID, flags := parts[1], parts[2], parts[3]
+	if len(traceID) != 32 || !isLowerHex(traceID) || isZero(traceID) {
+		return false
+	}
+	if len(parentID) != 16 || !isLowerHex(parentID) || isZero(parentID) {
+		return false
+	}
+	return len(flags) == 2 && isLowerHex(flags)
+}
+
+// isLowerHex reports whether s consists only of lower-case hexadecimal digits
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isZero reports whether a hex ID is all zeros, which the specification forbids
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,147 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package tracecontext
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+const validTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestIsValidTraceparent(t *testing.T) {
+	valid := []string{
+		validTraceparent,
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-future",
+	}
+	for _, v := range valid {
+		if !IsValidTraceparent(v) {
+			t.Errorf("Expected %q to be valid", v)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"garbage",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+	}
+	for _, v := range invalid {
+		if IsValidTraceparent(v) {
+			t.Errorf("Expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestTraceContextPolicy_GenerateWhenAbsent(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	mods := onRequest(p, nil)
+	generated := mods.SetHeaders[TraceparentHeader]
+	if !IsValidTraceparent(generated) {
+		t.Fatalf("Expected a valid generated traceparent, got %q", generated)
+	}
+	if generated[len(generated)-2:] != "01" {
+		t.Errorf("Expected generated trace to be sampled, got %q", generated)
+	}
+	if len(mods.RemoveHeaders) != 0 {
+		t.Errorf("Expected no headers to be removed, got %v", mods.RemoveHeaders)
+	}
+
+	if other := onRequest(p, nil).SetHeaders[TraceparentHeader]; other == generated {
+		t.Error("Expected a new trace ID for each request")
+	}
+}
+
+func TestTraceContextPolicy_RegenerateMalformed(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	mods := onRequest(p, map[string][]string{
+		TraceparentHeader: {"00-not-a-trace-01"},
+		TracestateHeader:  {"vendor=value"},
+	})
+	if !IsValidTraceparent(mods.SetHeaders[TraceparentHeader]) {
+		t.Errorf("Expected malformed traceparent to be regenerated, got %q", mods.SetHeaders[TraceparentHeader])
+	}
+	if len(mods.RemoveHeaders) != 1 || mods.RemoveHeaders[0] != TracestateHeader {
+		t.Errorf("Expected tracestate to be discarded, got %v", mods.RemoveHeaders)
+	}
+}
+
+func TestTraceContextPolicy_PreserveValid(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil)
+
+	mods := onRequest(p, map[string][]string{
+		TraceparentHeader: {validTraceparent},
+		TracestateHeader:  {"vendor=value"},
+	})
+	if len(mods.SetHeaders) != 0 || len(mods.RemoveHeaders) != 0 {
+		t.Errorf("Expected valid trace context to be preserved, got %v %v", mods.SetHeaders, mods.RemoveHeaders)
+	}
+}
+
+func TestTraceContextPolicy_ValidationDisabled(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"validate": false})
+
+	mods := onRequest(p, map[string][]string{TraceparentHeader: {"custom-format"}})
+	if len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected traceparent to be preserved without validation, got %v", mods.SetHeaders)
+	}
+}
+
+func TestTraceContextPolicy_GenerationDisabled(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"generate": false})
+
+	if mods := onRequest(p, nil); len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no traceparent to be generated, got %v", mods.SetHeaders)
+	}
+
+	mods := onRequest(p, map[string][]string{TraceparentHeader: {"bad"}})
+	if len(mods.SetHeaders) != 0 || len(mods.RemoveHeaders) != 2 {
+		t.Errorf("Expected invalid trace context to be removed, got %v %v", mods.SetHeaders, mods.RemoveHeaders)
+	}
+}
+
+func TestTraceContextPolicy_RetriesZeroIDs(t *testing.T) {
+	p := policytest.New(t, GetPolicy, nil).(*TraceContextPolicy)
+	calls := 0
+	p.randRead = func(b []byte) (int, error) {
+		calls++
+		for i := range b {
+			b[i] = byte(calls - 1)
+		}
+		return len(b), nil
+	}
+
+	expected := "00-01010101010101010101010101010101-0101010101010101-01"
+	if got := onRequest(p, nil).SetHeaders[TraceparentHeader]; got != expected {
+		t.Errorf("Expected %s after retrying all-zero IDs, got %s", expected, got)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package transcodebody
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
+)
+
+const (
+	CharsetUTF8        = "utf-8"
+	CharsetISO88591    = "iso-8859-1"
+	CharsetWindows1252 = "windows-1252"
+)
+
+// charsetAliases maps accepted charset labels to their canonical name
+var charsetAliases = map[string]string{
+	"utf-8":        CharsetUTF8,
+	"utf8":         CharsetUTF8,
+	"iso-8859-1":   CharsetISO88591,
+	"iso8859-1":    CharsetISO88591,
+	"iso_8859-1":   CharsetISO88591,
+	"latin1":       CharsetISO88591,
+	"l1":           CharsetISO88591,
+	"windows-1252": CharsetWindows1252,
+	"cp1252":       CharsetWindows1252,
+}
+
+// windows1252 maps the 0x80-0x9F range of Windows-1252 to Unicode. Undefined positions map to
+// the C1 control character with the same value.
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+// TranscodeBodyPolicy converts request bodies from a single-byte charset to UTF-8
+type TranscodeBodyPolicy struct {
+	sourceCharset string // Used when the Content-Type declares no charset; empty to skip
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	p := &TranscodeBodyPolicy{}
+
+	if raw, ok := params["sourceCharset"]; ok {
+		label, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'sourceCharset' must be a string")
+		}
+		charset, ok := charsetAliases[strings.ToLower(strings.TrimSpace(label))]
+		if !ok || charset == CharsetUTF8 {
+			return nil, fmt.Errorf("'sourceCharset' must be one of: %s, %s", CharsetISO88591, CharsetWindows1252)
+		}
+		p.sourceCharset = charset
+	}
+
+	if raw, ok := params["targetCharset"]; ok {
+		label, ok := raw.(string)
+		if !ok || charsetAliases[strings.ToLower(strings.TrimSpace(label))] != CharsetUTF8 {
+			return nil, fmt.Errorf("'targetCharset' must be %s", CharsetUTF8)
+		}
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *TranscodeBodyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need content type
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need request body to transcode
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest transcodes the request body to UTF-8 and updates the Content-Type charset. Encoded
+// bodies that would need transcoding are rejected, since converting their bytes would corrupt
+// them.
+func (p *TranscodeBodyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	if ctx.Body == nil || !ctx.Body.Present || len(ctx.Body.Content) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Leave streaming payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, bodyutil.Options{}); pass {
+		slog.Debug("TranscodeBody: Skipping request body", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	values := ctx.Headers.Get("content-type")
+	if len(values) == 0 {
+		return policy.UpstreamRequestModifications{}
+	}
+	mediaType, mediaParams, err := mime.ParseMediaType(values[0])
+	if err != nil {
+		slog.Debug("TranscodeBody: Skipping request with invalid content type", "error", err)
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// A declared charset takes precedence; the configured source charset is only assumed for
+	// textual bodies that do not declare one. JSON without a charset is UTF-8 (RFC 8259).
+	source := p.sourceCharset
+	if declared, ok := mediaParams["charset"]; ok {
+		source, ok = charsetAliases[strings.ToLower(declared)]
+		if !ok {
+			slog.Debug("TranscodeBody: Skipping unsupported charset", "charset", declared)
+			return policy.UpstreamRequestModifications{}
+		}
+	} else if !isTextual(mediaType) {
+		return policy.UpstreamRequestModifications{}
+	}
+	if source == "" || source == CharsetUTF8 {
+		return policy.UpstreamRequestModifications{}
+	}
+
+	// Compressed bodies can't be transcoded without decoding them
+	if bodyutil.IsContentEncoded(ctx.Headers) {
+		slog.Debug("TranscodeBody: Rejecting encoded request body", "charset", source)
+		return errorResponse(http.StatusUnsupportedMediaType,
+			"Encoded request bodies are not accepted; send the body without a Content-Encoding")
+	}
+
+	body := decode(ctx.Body.Content, source)
+	mediaParams["charset"] = CharsetUTF8
+
+	return policy.UpstreamRequestModifications{
+		Body: body,
+		SetHeaders: map[string]string{
+			"content-type":   mime.FormatMediaType(mediaType, mediaParams),
+			"content-length": fmt.Sprintf("%d", len(body)),
+		},
+	}
+}
+
+// OnResponse is not used by this policy
+func (p *TranscodeBodyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// errorResponse builds a JSON error response
+func errorResponse(status int, message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: status,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
+
+// decode converts single-byte encoded content to UTF-8
+func decode(content []byte, charset string) []byte {
+	out := make([]byte, 0, len(content)+len(content)/4)
+	for _, b := range content {
+		r := rune(b)
+		if charset == CharsetWindows1252 && b >= 0x80 && b <= 0x9F {
+			r = windows1252[b-0x80]
+		}
+		out = utf8.AppendRune(out, r)
+	}
+	return out
+}
+
+// isTextual reports whether a media type carries text that an assumed charset applies to. JSON is
+// excluded because RFC 8259 defines it as UTF-8 unless a charset is declared explicitly.
+func isTextual(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.Contains(mediaType, "xml") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
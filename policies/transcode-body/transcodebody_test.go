@@ -0,0 +1,125 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package transcodebody
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, contentType string, body []byte) policy.UpstreamRequestModifications {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(map[string][]string{"content-type": {contentType}}),
+		Body:    &policy.Body{Content: body, Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil).(policy.UpstreamRequestModifications)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"sourceCharset": "shift_jis"},
+		{"sourceCharset": "utf-8"},
+		{"targetCharset": "iso-8859-1"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestTranscodeBodyPolicy_Latin1ToUTF8(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+
+	// "café naïve" in ISO-8859-1
+	latin1 := []byte{'c', 'a', 'f', 0xE9, ' ', 'n', 'a', 0xEF, 'v', 'e'}
+	mods := onRequest(p, "text/plain; charset=ISO-8859-1", latin1)
+
+	if string(mods.Body) != "café naïve" {
+		t.Errorf("Expected 'café naïve', got %q", mods.Body)
+	}
+	if got := mods.SetHeaders["content-type"]; got != "text/plain; charset=utf-8" {
+		t.Errorf("Expected charset to be updated, got %q", got)
+	}
+	if got := mods.SetHeaders["content-length"]; got != "12" {
+		t.Errorf("Expected content-length 12, got %q", got)
+	}
+}
+
+func TestTranscodeBodyPolicy_Windows1252(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"sourceCharset": "cp1252"})
+
+	// No declared charset, so the configured source charset is assumed
+	mods := onRequest(p, "text/csv", []byte{'"', 0x80, '5', 0x93, '"'})
+	if string(mods.Body) != "\"€5“\"" {
+		t.Errorf("Expected Windows-1252 characters to be mapped, got %q", mods.Body)
+	}
+	if got := mods.SetHeaders["content-type"]; got != "text/csv; charset=utf-8" {
+		t.Errorf("Expected charset to be added, got %q", got)
+	}
+}
+
+func TestTranscodeBodyPolicy_JSONDefaultsToUTF8(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"sourceCharset": "latin1"})
+
+	// JSON without a charset is UTF-8, so the configured source charset is not assumed
+	for _, contentType := range []string{"application/json", "application/problem+json"} {
+		mods := onRequest(p, contentType, []byte(`{"name":"café"}`))
+		if mods.Body != nil || len(mods.SetHeaders) != 0 {
+			t.Errorf("Expected %s body without charset to be untouched, got %q", contentType, mods.Body)
+		}
+	}
+
+	// An explicitly declared charset is still honoured
+	mods := onRequest(p, "application/json; charset=iso-8859-1", []byte{'"', 'c', 'a', 'f', 0xE9, '"'})
+	if string(mods.Body) != `"café"` {
+		t.Errorf("Expected declared charset to be transcoded, got %q", mods.Body)
+	}
+}
+
+func TestTranscodeBodyPolicy_UTF8Untouched(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"sourceCharset": "latin1"})
+
+	mods := onRequest(p, "text/plain; charset=utf-8", []byte("café"))
+	if mods.Body != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected UTF-8 body to be untouched, got %+v", mods)
+	}
+
+	// Without a declared or configured charset there is nothing to convert
+	mods = onRequest(policytest.New(t, GetPolicy, map[string]interface{}{}), "text/plain", []byte("café"))
+	if mods.Body != nil {
+		t.Errorf("Expected body without charset to be untouched, got %q", mods.Body)
+	}
+}
+
+func TestTranscodeBodyPolicy_EncodedBodyRejected(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{})
+	request := func(contentType string) policy.RequestAction {
+		return p.OnRequest(&policy.RequestContext{
+			Headers: policy.NewHeaders(map[string][]string{
+				"content-type":     {contentType},
+				"content-encoding": {"gzip"},
+			}),
+			Body: &policy.Body{Content: []byte{0x1f, 0x8b, 0xE9}, Present: true, EndOfStream: true},
+		}, nil)
+	}
+
+	policytest.ExpectStatus(t, request("text/plain; charset=iso-8859-1"), 415)
+	// Encoded bodies that need no conversion are forwarded unchanged
+	if mods := policytest.ExpectForwarded(t, request("text/plain; charset=utf-8")); mods.Body != nil {
+		t.Errorf("Expected encoded UTF-8 body to be untouched, got %q", mods.Body)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validateheaderenum
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	OnMissingPassthrough = "passthrough"
+	OnMissingReject      = "reject"
+)
+
+// headerRule lists the allowed values for a single header
+type headerRule struct {
+	name    string
+	allowed []string
+}
+
+// ValidateHeaderEnumPolicy rejects requests whose header values are not in a configured set
+type ValidateHeaderEnumPolicy struct {
+	rules         []headerRule
+	caseSensitive bool
+	onMissing     string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	headersRaw, ok := params["headers"].(map[string]interface{})
+	if !ok || len(headersRaw) == 0 {
+		return nil, fmt.Errorf("'headers' parameter is required and must be a non-empty map of header name to allowed values")
+	}
+
+	p := &ValidateHeaderEnumPolicy{
+		caseSensitive: true,
+		onMissing:     OnMissingPassthrough,
+	}
+
+	if raw, ok := params["caseSensitive"]; ok {
+		caseSensitive, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'caseSensitive' must be a boolean")
+		}
+		p.caseSensitive = caseSensitive
+	}
+
+	if raw, ok := params["onMissing"]; ok {
+		onMissing, ok := raw.(string)
+		if !ok || (onMissing != OnMissingPassthrough && onMissing != OnMissingReject) {
+			return nil, fmt.Errorf("'onMissing' must be one of %s, %s", OnMissingPassthrough, OnMissingReject)
+		}
+		p.onMissing = onMissing
+	}
+
+	for name, raw := range headersRaw {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("'headers' keys cannot be empty")
+		}
+		valuesRaw, ok := raw.([]interface{})
+		if !ok || len(valuesRaw) == 0 {
+			return nil, fmt.Errorf("headers.%s must be a non-empty array of strings", name)
+		}
+		rule := headerRule{name: name}
+		for i, v := range valuesRaw {
+			value, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("headers.%s[%d] must be a string", name, i)
+			}
+			rule.allowed = append(rule.allowed, value)
+		}
+		p.rules = append(p.rules, rule)
+	}
+
+	// Check headers in a stable order so error messages are deterministic
+	sort.Slice(p.rules, func(i, j int) bool { return p.rules[i].name < p.rules[j].name })
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ValidateHeaderEnumPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers to validate
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates each configured header against its allowed values
+func (p *ValidateHeaderEnumPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	for _, rule := range p.rules {
+		values := ctx.Headers.Get(rule.name)
+		if len(values) == 0 {
+			if p.onMissing == OnMissingReject {
+				slog.Debug("ValidateHeaderEnum: Rejecting request with missing header", "header", rule.name)
+				return badRequest(fmt.Sprintf("Missing required header '%s'", rule.name))
+			}
+			continue
+		}
+		for _, value := range values {
+			if !p.isAllowed(rule, strings.TrimSpace(value)) {
+				slog.Debug("ValidateHeaderEnum: Rejecting request with disallowed header value", "header", rule.name, "value", value)
+				return badRequest(fmt.Sprintf("Header '%s' must be one of: %s", rule.name, strings.Join(rule.allowed, ", ")))
+			}
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *ValidateHeaderEnumPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// isAllowed reports whether value is one of the rule's allowed values
+func (p *ValidateHeaderEnumPolicy) isAllowed(rule headerRule, value string) bool {
+	for _, allowed := range rule.allowed {
+		if p.caseSensitive && value == allowed {
+			return true
+		}
+		if !p.caseSensitive && strings.EqualFold(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// badRequest builds a 400 response with a JSON error body
+func badRequest(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Bad Request",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusBadRequest,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validateheaderenum
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	if _, ok := params["headers"]; !ok {
+		params["headers"] = map[string]interface{}{
+			"X-API-Version": []interface{}{"v1", "v2"},
+		}
+	}
+	p := policytest.New(t, GetPolicy, params)
+	return p
+}
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.RequestAction {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"headers": map[string]interface{}{}},
+		{"headers": map[string]interface{}{"x-a": []interface{}{}}},
+		{"headers": map[string]interface{}{"x-a": []interface{}{1}}},
+		{"headers": map[string]interface{}{"x-a": []interface{}{"a"}}, "caseSensitive": "no"},
+		{"headers": map[string]interface{}{"x-a": []interface{}{"a"}}, "onMissing": "ignore"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestValidateHeaderEnumPolicy_AllowedValue(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	action := onRequest(p, map[string][]string{"x-api-version": {"v2"}})
+	if _, ok := action.(policy.UpstreamRequestModifications); !ok {
+		t.Errorf("Expected request to pass, got %T", action)
+	}
+}
+
+func TestValidateHeaderEnumPolicy_DisallowedValue(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+
+	action := onRequest(p, map[string][]string{"x-api-version": {"v3"}})
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok {
+		t.Fatalf("Expected ImmediateResponse, got %T", action)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+
+	// Case-sensitive by default
+	if _, ok := onRequest(p, map[string][]string{"x-api-version": {"V1"}}).(policy.ImmediateResponse); !ok {
+		t.Error("Expected 'V1' to be rejected when matching is case-sensitive")
+	}
+}
+
+func TestValidateHeaderEnumPolicy_CaseInsensitive(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"caseSensitive": false})
+
+	action := onRequest(p, map[string][]string{"x-api-version": {"V1"}})
+	if _, ok := action.(policy.UpstreamRequestModifications); !ok {
+		t.Errorf("Expected 'V1' to be allowed, got %T", action)
+	}
+}
+
+func TestValidateHeaderEnumPolicy_MissingHeader(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{})
+	if _, ok := onRequest(p, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected missing header to pass through by default")
+	}
+
+	p = newPolicy(t, map[string]interface{}{"onMissing": OnMissingReject})
+	resp, ok := onRequest(p, nil).(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != 400 {
+		t.Errorf("Expected 400 for missing header, got %+v", resp)
+	}
+}
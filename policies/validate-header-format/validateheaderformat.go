@@ -0,0 +1,204 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validateheaderformat
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	OnMissingPassthrough = "passthrough"
+	OnMissingReject      = "reject"
+)
+
+const (
+	// Built-in header formats
+	FormatUUID      = "uuid"
+	FormatBase64    = "base64"
+	FormatBase64URL = "base64url"
+	FormatHex       = "hex"
+	FormatEmail     = "email"
+)
+
+var (
+	uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexRegex  = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+)
+
+// headerRule holds the expected format of a single header
+type headerRule struct {
+	name    string
+	format  string         // Built-in format name, or the source pattern for custom rules
+	pattern *regexp.Regexp // Set for custom rules
+}
+
+// ValidateHeaderFormatPolicy rejects requests whose header values don't match an expected format
+type ValidateHeaderFormatPolicy struct {
+	rules     []headerRule
+	onMissing string
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	headersRaw, ok := params["headers"].(map[string]interface{})
+	if !ok || len(headersRaw) == 0 {
+		return nil, fmt.Errorf("'headers' parameter is required and must be a non-empty map of header name to format")
+	}
+
+	p := &ValidateHeaderFormatPolicy{onMissing: OnMissingPassthrough}
+
+	if raw, ok := params["onMissing"]; ok {
+		onMissing, ok := raw.(string)
+		if !ok || (onMissing != OnMissingPassthrough && onMissing != OnMissingReject) {
+			return nil, fmt.Errorf("'onMissing' must be one of %s, %s", OnMissingPassthrough, OnMissingReject)
+		}
+		p.onMissing = onMissing
+	}
+
+	for name, raw := range headersRaw {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("'headers' keys cannot be empty")
+		}
+		rule := headerRule{name: name}
+		switch v := raw.(type) {
+		case string:
+			switch v {
+			case FormatUUID, FormatBase64, FormatBase64URL, FormatHex, FormatEmail:
+				rule.format = v
+			default:
+				return nil, fmt.Errorf("headers.%s must be one of %s, %s, %s, %s, %s or an object with a 'pattern'",
+					name, FormatUUID, FormatBase64, FormatBase64URL, FormatHex, FormatEmail)
+			}
+		case map[string]interface{}:
+			pattern, ok := v["pattern"].(string)
+			if !ok || pattern == "" {
+				return nil, fmt.Errorf("headers.%s.pattern must be a non-empty string", name)
+			}
+			// Patterns must match the whole value
+			compiled, err := regexp.Compile(`^(?:` + pattern + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("headers.%s.pattern is invalid: %w", name, err)
+			}
+			rule.format = pattern
+			rule.pattern = compiled
+		default:
+			return nil, fmt.Errorf("headers.%s must be a format name or an object with a 'pattern'", name)
+		}
+		p.rules = append(p.rules, rule)
+	}
+
+	// Check headers in a stable order so error messages are deterministic
+	sort.Slice(p.rules, func(i, j int) bool { return p.rules[i].name < p.rules[j].name })
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *ValidateHeaderFormatPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need request headers to validate
+		RequestBodyMode:    policy.BodyModeSkip,      // Don't need request body
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest validates each configured header against its format
+func (p *ValidateHeaderFormatPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	for _, rule := range p.rules {
+		values := ctx.Headers.Get(rule.name)
+		if len(values) == 0 {
+			if p.onMissing == OnMissingReject {
+				slog.Debug("ValidateHeaderFormat: Rejecting request with missing header", "header", rule.name)
+				return badRequest(fmt.Sprintf("Missing required header '%s'", rule.name))
+			}
+			continue
+		}
+		for _, value := range values {
+			if !rule.matches(strings.TrimSpace(value)) {
+				slog.Debug("ValidateHeaderFormat: Rejecting request with malformed header value", "header", rule.name)
+				if rule.pattern != nil {
+					return badRequest(fmt.Sprintf("Header '%s' must match the pattern %s", rule.name, rule.format))
+				}
+				return badRequest(fmt.Sprintf("Header '%s' must be a valid %s value", rule.name, rule.format))
+			}
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *ValidateHeaderFormatPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// matches reports whether value has the rule's format
+func (r headerRule) matches(value string) bool {
+	if r.pattern != nil {
+		return r.pattern.MatchString(value)
+	}
+	if value == "" {
+		return false
+	}
+	switch r.format {
+	case FormatUUID:
+		return uuidRegex.MatchString(value)
+	case FormatHex:
+		return hexRegex.MatchString(value)
+	case FormatBase64:
+		_, err := base64.StdEncoding.Strict().DecodeString(value)
+		return err == nil
+	case FormatBase64URL:
+		// Padding is commonly omitted in URL-safe values
+		_, err := base64.RawURLEncoding.Strict().DecodeString(strings.TrimRight(value, "="))
+		return err == nil
+	default:
+		// Only a bare address is accepted, not "Name <address>"
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	}
+}
+
+// badRequest builds a 400 response with a JSON error body
+func badRequest(message string) policy.RequestAction {
+	body, _ := json.Marshal(map[string]string{
+		"error":   "Bad Request",
+		"message": message,
+	})
+	return policy.ImmediateResponse{
+		StatusCode: http.StatusBadRequest,
+		Headers: map[string]string{
+			"content-type": "application/json",
+		},
+		Body: body,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validateheaderformat
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.RequestAction {
+	ctx := &policy.RequestContext{Headers: policy.NewHeaders(headers)}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{},
+		{"headers": map[string]interface{}{}},
+		{"headers": map[string]interface{}{"x-a": "ulid"}},
+		{"headers": map[string]interface{}{"x-a": 1}},
+		{"headers": map[string]interface{}{"x-a": map[string]interface{}{}}},
+		{"headers": map[string]interface{}{"x-a": map[string]interface{}{"pattern": "("}}},
+		{"headers": map[string]interface{}{"x-a": "uuid"}, "onMissing": "ignore"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestValidateHeaderFormatPolicy_BuiltInFormats(t *testing.T) {
+	cases := []struct {
+		format  string
+		valid   []string
+		invalid []string
+	}{
+		{FormatUUID, []string{"123e4567-e89b-12d3-a456-426614174000", "123E4567-E89B-12D3-A456-426614174000"},
+			[]string{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g"}},
+		{FormatBase64, []string{"aGVsbG8=", "aGk+Lw=="}, []string{"aGVsbG8", "aGk-Lw==", "not base64!"}},
+		{FormatBase64URL, []string{"aGk-Lw", "aGk-Lw=="}, []string{"aGk+Lw", "a"}},
+		{FormatHex, []string{"deadBEEF", "0"}, []string{"0x1f", "xyz"}},
+		{FormatEmail, []string{"dev@example.com"}, []string{"dev@", "Dev <dev@example.com>", "example.com"}},
+	}
+	for _, c := range cases {
+		p := policytest.New(t, GetPolicy, map[string]interface{}{"headers": map[string]interface{}{"X-Value": c.format}})
+		for _, value := range c.valid {
+			if _, ok := onRequest(p, map[string][]string{"x-value": {value}}).(policy.UpstreamRequestModifications); !ok {
+				t.Errorf("Expected %q to be a valid %s value", value, c.format)
+			}
+		}
+		for _, value := range append(c.invalid, "") {
+			resp, ok := onRequest(p, map[string][]string{"x-value": {value}}).(policy.ImmediateResponse)
+			if !ok || resp.StatusCode != 400 {
+				t.Errorf("Expected %q to be rejected as %s, got %+v", value, c.format, resp)
+			}
+		}
+	}
+}
+
+func TestValidateHeaderFormatPolicy_CustomPattern(t *testing.T) {
+	p := policytest.New(t, GetPolicy, map[string]interface{}{
+		"headers": map[string]interface{}{"x-signature": map[string]interface{}{"pattern": "v1=[0-9a-f]{8}"}},
+	})
+
+	if _, ok := onRequest(p, map[string][]string{"x-signature": {"v1=0badf00d"}}).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected a matching value to pass")
+	}
+	// The pattern must match the whole value
+	for _, value := range []string{"v1=0badf00d0", "xv1=0badf00d", "v2=0badf00d"} {
+		if resp, ok := onRequest(p, map[string][]string{"x-signature": {value}}).(policy.ImmediateResponse); !ok || resp.StatusCode != 400 {
+			t.Errorf("Expected %q to be rejected, got %+v", value, resp)
+		}
+	}
+}
+
+func TestValidateHeaderFormatPolicy_MissingHeader(t *testing.T) {
+	headers := map[string]interface{}{"x-request-id": "uuid"}
+	p := policytest.New(t, GetPolicy, map[string]interface{}{"headers": headers})
+	if _, ok := onRequest(p, nil).(policy.UpstreamRequestModifications); !ok {
+		t.Error("Expected missing header to pass through by default")
+	}
+
+	p = policytest.New(t, GetPolicy, map[string]interface{}{"headers": headers, "onMissing": OnMissingReject})
+	resp, ok := onRequest(p, nil).(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != 400 {
+		t.Errorf("Expected 400 for missing header, got %+v", resp)
+	}
+}
@@ -0,0 +1,332 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package webhookverify
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	// Provider presets
+	ProviderGitHub = "github"
+	ProviderStripe = "stripe"
+	ProviderSlack  = "slack"
+	ProviderCustom = "custom"
+
+	// Signature encodings for the custom provider
+	EncodingHex    = "hex"
+	EncodingBase64 = "base64"
+)
+
+// algorithms maps the algorithm names accepted by the custom provider to hash constructors
+var algorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// WebhookVerifyPolicy rejects webhook deliveries whose HMAC signature does not match the
+// request body under a shared secret
+type WebhookVerifyPolicy struct {
+	provider  string
+	secret    []byte
+	tolerance time.Duration // Maximum age of timestamped signatures (stripe, slack)
+
+	// Custom provider settings
+	header   string
+	newHash  func() hash.Hash
+	encoding string
+	prefix   string
+
+	now func() time.Time // Injectable clock (for testing)
+}
+
+func GetPolicy(
+	metadata policy.PolicyMetadata,
+	params map[string]interface{},
+) (policy.Policy, error) {
+	provider, _ := params["provider"].(string)
+	switch provider {
+	case ProviderGitHub, ProviderStripe, ProviderSlack, ProviderCustom:
+	default:
+		return nil, fmt.Errorf("'provider' parameter is required and must be one of %s, %s, %s, %s",
+			ProviderGitHub, ProviderStripe, ProviderSlack, ProviderCustom)
+	}
+
+	secret, ok := params["secret"].(string)
+	if !ok || secret == "" {
+		return nil, fmt.Errorf("'secret' parameter is required and must be a non-empty string")
+	}
+
+	p := &WebhookVerifyPolicy{
+		provider:  provider,
+		secret:    []byte(secret),
+		tolerance: 5 * time.Minute,
+		newHash:   sha256.New,
+		encoding:  EncodingHex,
+		now:       time.Now,
+	}
+
+	if raw, ok := params["toleranceSeconds"]; ok {
+		seconds, err := extractInt(raw)
+		if err != nil || seconds < 1 {
+			return nil, fmt.Errorf("'toleranceSeconds' must be a positive integer")
+		}
+		p.tolerance = time.Duration(seconds) * time.Second
+	}
+
+	if provider != ProviderCustom {
+		for _, name := range []string{"signatureHeader", "algorithm", "encoding", "prefix"} {
+			if _, ok := params[name]; ok {
+				return nil, fmt.Errorf("'%s' is only supported with provider %s", name, ProviderCustom)
+			}
+		}
+		return p, nil
+	}
+
+	header, ok := params["signatureHeader"].(string)
+	if !ok || strings.TrimSpace(header) == "" {
+		return nil, fmt.Errorf("'signatureHeader' is required for provider %s", ProviderCustom)
+	}
+	p.header = strings.ToLower(strings.TrimSpace(header))
+
+	if raw, ok := params["algorithm"]; ok {
+		algorithm, _ := raw.(string)
+		newHash, ok := algorithms[algorithm]
+		if !ok {
+			return nil, fmt.Errorf("'algorithm' must be one of sha1, sha256, sha512")
+		}
+		p.newHash = newHash
+	}
+
+	if raw, ok := params["encoding"]; ok {
+		encoding, ok := raw.(string)
+		if !ok || (encoding != EncodingHex && encoding != EncodingBase64) {
+			return nil, fmt.Errorf("'encoding' must be one of %s, %s", EncodingHex, EncodingBase64)
+		}
+		p.encoding = encoding
+	}
+
+	if raw, ok := params["prefix"]; ok {
+		prefix, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("'prefix' must be a string")
+		}
+		p.prefix = prefix
+	}
+
+	return p, nil
+}
+
+// Mode returns the processing mode for this policy
+func (p *WebhookVerifyPolicy) Mode() policy.ProcessingMode {
+	return policy.ProcessingMode{
+		RequestHeaderMode:  policy.HeaderModeProcess, // Need signature headers
+		RequestBodyMode:    policy.BodyModeBuffer,    // Need the raw request body to verify it
+		ResponseHeaderMode: policy.HeaderModeSkip,    // Don't process response headers
+		ResponseBodyMode:   policy.BodyModeSkip,      // Don't need response body
+	}
+}
+
+// OnRequest verifies the webhook signature and rejects mismatches with 401
+func (p *WebhookVerifyPolicy) OnRequest(ctx *policy.RequestContext, params map[string]interface{}) policy.RequestAction {
+	var body []byte
+	if ctx.Body != nil {
+		body = ctx.Body.Content
+	}
+
+	var err error
+	switch p.provider {
+	case ProviderGitHub:
+		err = p.verifyGitHub(ctx.Headers, body)
+	case ProviderStripe:
+		err = p.verifyStripe(ctx.Headers, body)
+	case ProviderSlack:
+		err = p.verifySlack(ctx.Headers, body)
+	default:
+		err = p.verifyCustom(ctx.Headers, body)
+	}
+	if err != nil {
+		slog.Debug("WebhookVerify: Rejecting webhook", "provider", p.provider, "error", err)
+		responseBody, _ := json.Marshal(map[string]string{
+			"error":   "Unauthorized",
+			"message": "Webhook signature verification failed",
+		})
+		return policy.ImmediateResponse{
+			StatusCode: http.StatusUnauthorized,
+			Headers: map[string]string{
+				"content-type": "application/json",
+			},
+			Body: responseBody,
+		}
+	}
+
+	return policy.UpstreamRequestModifications{}
+}
+
+// OnResponse is not used by this policy
+func (p *WebhookVerifyPolicy) OnResponse(ctx *policy.ResponseContext, params map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// verifyGitHub checks X-Hub-Signature-256: "sha256=" followed by the hex HMAC-SHA256 of the body
+func (p *WebhookVerifyPolicy) verifyGitHub(headers *policy.Headers, body []byte) error {
+	signature, ok := strings.CutPrefix(firstValue(headers, "x-hub-signature-256"), "sha256=")
+	if !ok {
+		return fmt.Errorf("missing or malformed X-Hub-Signature-256 header")
+	}
+	return p.compareHex(sha256.New, body, signature)
+}
+
+// verifyStripe checks Stripe-Signature: "t=<timestamp>,v1=<hex>[,v1=<hex>...]", where each v1
+// signature is the HMAC-SHA256 of "<timestamp>.<body>". Any matching v1 signature is accepted
+// so secrets can be rolled.
+func (p *WebhookVerifyPolicy) verifyStripe(headers *policy.Headers, body []byte) error {
+	var timestamp string
+	var signatures []string
+	for _, element := range strings.Split(firstValue(headers, "stripe-signature"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(element), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("missing or malformed Stripe-Signature header")
+	}
+	if err := p.checkTimestamp(timestamp); err != nil {
+		return err
+	}
+
+	payload := append([]byte(timestamp+"."), body...)
+	for _, signature := range signatures {
+		if p.compareHex(sha256.New, payload, signature) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no matching v1 signature")
+}
+
+// verifySlack checks X-Slack-Signature: "v0=" followed by the hex HMAC-SHA256 of
+// "v0:<X-Slack-Request-Timestamp>:<body>"
+func (p *WebhookVerifyPolicy) verifySlack(headers *policy.Headers, body []byte) error {
+	signature, ok := strings.CutPrefix(firstValue(headers, "x-slack-signature"), "v0=")
+	timestamp := firstValue(headers, "x-slack-request-timestamp")
+	if !ok || timestamp == "" {
+		return fmt.Errorf("missing or malformed Slack signature headers")
+	}
+	if err := p.checkTimestamp(timestamp); err != nil {
+		return err
+	}
+	return p.compareHex(sha256.New, append([]byte("v0:"+timestamp+":"), body...), signature)
+}
+
+// verifyCustom checks the configured header against the HMAC of the body with the configured
+// algorithm, encoding and prefix
+func (p *WebhookVerifyPolicy) verifyCustom(headers *policy.Headers, body []byte) error {
+	signature, ok := strings.CutPrefix(firstValue(headers, p.header), p.prefix)
+	if !ok || signature == "" {
+		return fmt.Errorf("missing or malformed %s header", p.header)
+	}
+	if p.encoding == EncodingHex {
+		return p.compareHex(p.newHash, body, signature)
+	}
+	provided, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("signature is not valid base64")
+	}
+	if !hmac.Equal(provided, p.sum(p.newHash, body)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// checkTimestamp rejects signatures whose Unix timestamp is further than the tolerance from now
+func (p *WebhookVerifyPolicy) checkTimestamp(timestamp string) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("timestamp is not a Unix time")
+	}
+	age := p.now().Sub(time.Unix(seconds, 0))
+	if age > p.tolerance || age < -p.tolerance {
+		return fmt.Errorf("timestamp is outside the %s tolerance", p.tolerance)
+	}
+	return nil
+}
+
+// compareHex compares a hex signature with the HMAC of the payload in constant time
+func (p *WebhookVerifyPolicy) compareHex(newHash func() hash.Hash, payload []byte, signature string) error {
+	provided, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return fmt.Errorf("signature is not valid hex")
+	}
+	if !hmac.Equal(provided, p.sum(newHash, payload)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func (p *WebhookVerifyPolicy) sum(newHash func() hash.Hash, payload []byte) []byte {
+	mac := hmac.New(newHash, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func firstValue(headers *policy.Headers, name string) string {
+	if values := headers.Get(name); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// extractInt safely extracts an integer from various types
+func extractInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer but got %v", v)
+		}
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", value)
+	}
+}
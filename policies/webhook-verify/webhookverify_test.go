@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package webhookverify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/policytest"
+)
+
+const (
+	testSecret = "whsec_test"
+	testBody   = `{"action":"opened"}`
+)
+
+var testNow = time.Unix(1_800_000_000, 0)
+
+func newPolicy(t *testing.T, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	params["secret"] = testSecret
+	p := policytest.New(t, GetPolicy, params)
+	p.(*WebhookVerifyPolicy).now = func() time.Time { return testNow }
+	return p
+}
+
+func sign(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func onRequest(p policy.Policy, headers map[string][]string) policy.RequestAction {
+	ctx := &policy.RequestContext{
+		Headers: policy.NewHeaders(headers),
+		Body:    &policy.Body{Content: []byte(testBody), Present: true, EndOfStream: true},
+	}
+	return p.OnRequest(ctx, nil)
+}
+
+func TestGetPolicy_InvalidParams(t *testing.T) {
+	invalid := []map[string]interface{}{
+		{"secret": "x"},
+		{"provider": "gitlab", "secret": "x"},
+		{"provider": "github"},
+		{"provider": "github", "secret": "x", "prefix": "sha256="},
+		{"provider": "stripe", "secret": "x", "toleranceSeconds": 0},
+		{"provider": "custom", "secret": "x"},
+		{"provider": "custom", "secret": "x", "signatureHeader": "x-sig", "algorithm": "md5"},
+		{"provider": "custom", "secret": "x", "signatureHeader": "x-sig", "encoding": "base32"},
+	}
+	policytest.ExpectInvalidParams(t, GetPolicy, invalid)
+}
+
+func TestWebhookVerifyPolicy_GitHubValid(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"provider": "github"})
+
+	signature := "sha256=" + hex.EncodeToString(sign(testBody))
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-hub-signature-256": {signature}}), 0)
+}
+
+func TestWebhookVerifyPolicy_GitHubInvalid(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"provider": "github"})
+
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-hub-signature-256": {"sha256=" + hex.EncodeToString(sign("other"))}}), 401)
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-hub-signature-256": {hex.EncodeToString(sign(testBody))}}), 401)
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{}), 401)
+}
+
+func TestWebhookVerifyPolicy_StripeTimestamped(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{"provider": "stripe"})
+
+	valid := hex.EncodeToString(sign("1800000000." + testBody))
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"stripe-signature": {"t=1800000000,v1=deadbeef,v1=" + valid}}), 0)
+
+	// The signature covers the timestamp, so altering it fails verification
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"stripe-signature": {"t=1800000001,v1=" + valid}}), 401)
+
+	// A correctly signed but stale delivery is rejected
+	stale := hex.EncodeToString(sign("1799999000." + testBody))
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"stripe-signature": {"t=1799999000,v1=" + stale}}), 401)
+}
+
+func TestWebhookVerifyPolicy_Custom(t *testing.T) {
+	p := newPolicy(t, map[string]interface{}{
+		"provider":        "custom",
+		"signatureHeader": "X-Signature",
+		"encoding":        "base64",
+		"prefix":          "hmac ",
+	})
+
+	signature := "hmac " + base64.StdEncoding.EncodeToString(sign(testBody))
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-signature": {signature}}), 0)
+	policytest.ExpectStatus(t, onRequest(p, map[string][]string{"x-signature": {"hmac AAAA"}}), 401)
+}
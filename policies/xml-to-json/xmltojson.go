@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
 
 	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+	"github.com/wso2/gateway-controllers/utils/bodyutil"
 )
 
 // XMLToJSONPolicy implements transforming a request/response with a XML payload to a request/response with a JSON payload
@@ -66,6 +68,12 @@ func (p *XMLToJSONPolicy) OnRequest(ctx *policy.RequestContext, params map[strin
 		return policy.UpstreamRequestModifications{}
 	}
 
+	// Leave streaming or oversized payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.Headers, ctx.Body, streamOptions(params)); pass {
+		slog.Debug("XMLToJSON: Skipping request body transformation", "reason", reason)
+		return policy.UpstreamRequestModifications{}
+	}
+
 	// Check content type to ensure it is XML
 	contentType := ""
 	if contentTypeHeaders := ctx.Headers.Get("content-type"); len(contentTypeHeaders) > 0 {
@@ -109,14 +117,21 @@ func (p *XMLToJSONPolicy) OnResponse(ctx *policy.ResponseContext, params map[str
 		return policy.UpstreamResponseModifications{}
 	}
 
+	// Leave streaming or oversized payloads untouched
+	if pass, reason := bodyutil.ShouldPassThrough(ctx.ResponseHeaders, ctx.ResponseBody, streamOptions(params)); pass {
+		slog.Debug("XMLToJSON: Skipping response body transformation", "reason", reason)
+		return policy.UpstreamResponseModifications{}
+	}
+
 	// Check content type to ensure it is XML
 	contentType := ""
 	if contentTypeHeaders := ctx.ResponseHeaders.Get("content-type"); len(contentTypeHeaders) > 0 {
 		contentType = strings.ToLower(contentTypeHeaders[0])
 	}
 
+	// Non-XML responses (e.g. upstream error pages or JSON) are passed through unchanged
 	if !strings.Contains(contentType, "application/xml") && !strings.Contains(contentType, "text/xml") {
-		return p.handleInternalServerErrorResponse("Content-Type must be application/xml or text/xml for XML to JSON transformation")
+		return policy.UpstreamResponseModifications{}
 	}
 
 	// Parse XML and convert to JSON
@@ -135,6 +150,20 @@ func (p *XMLToJSONPolicy) OnResponse(ctx *policy.ResponseContext, params map[str
 	}
 }
 
+// streamOptions builds the streaming guard options from the policy parameters
+func streamOptions(params map[string]interface{}) bodyutil.Options {
+	opts := bodyutil.Options{}
+	switch v := params["maxBodyBytes"].(type) {
+	case float64:
+		opts.MaxBodyBytes = int64(v)
+	case int:
+		opts.MaxBodyBytes = int64(v)
+	case int64:
+		opts.MaxBodyBytes = v
+	}
+	return opts
+}
+
 // handleInternalServerError returns a 500 internal server error response for request flow
 func (p *XMLToJSONPolicy) handleInternalServerError(message string) policy.RequestAction {
 	errorResponse := map[string]interface{}{
@@ -293,14 +293,67 @@ func TestXMLToJSONPolicy_OnResponse_WrongContentType(t *testing.T) {
 
 	result := p.OnResponse(ctx, params)
 
-	// Should return 500 error for wrong content type in response
+	// Non-XML responses should pass through untouched
 	mods, ok := result.(policy.UpstreamResponseModifications)
 	if !ok {
 		t.Errorf("Expected UpstreamResponseModifications for wrong content type in response, got %T", result)
 	}
 
-	if mods.StatusCode == nil || *mods.StatusCode != 500 {
-		t.Errorf("Expected status code 500 for wrong content type, got: %v", mods.StatusCode)
+	if mods.StatusCode != nil {
+		t.Errorf("Expected no status code change for non-XML response, got: %d", *mods.StatusCode)
+	}
+	if mods.Body != nil {
+		t.Errorf("Expected no body modification for non-XML response, got: %s", string(mods.Body))
+	}
+	if len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected no header modification for non-XML response, got: %v", mods.SetHeaders)
+	}
+}
+
+func TestXMLToJSONPolicy_OnResponse_TextXMLWithAttributesAndText(t *testing.T) {
+	p := &XMLToJSONPolicy{}
+	ctx := &policy.ResponseContext{
+		ResponseBody: &policy.Body{
+			Content: []byte(`<order id="A-1"><item sku="x1">Pen</item><item sku="x2">Book</item></order>`),
+			Present: true,
+		},
+		ResponseHeaders: createTestHeaders("content-type", "text/xml; charset=utf-8"),
+	}
+
+	result := p.OnResponse(ctx, map[string]interface{}{"onResponseFlow": true})
+
+	mods, ok := result.(policy.UpstreamResponseModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamResponseModifications, got %T", result)
+	}
+
+	var jsonResult map[string]interface{}
+	if err := json.Unmarshal(mods.Body, &jsonResult); err != nil {
+		t.Fatalf("Failed to parse transformed JSON: %v", err)
+	}
+
+	order, ok := jsonResult["order"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected order to be an object, got: %T", jsonResult["order"])
+	}
+	if order["@id"] != "A-1" {
+		t.Errorf("Expected @id to be 'A-1', got: %v", order["@id"])
+	}
+
+	items, ok := order["item"].([]interface{})
+	if !ok || len(items) != 2 {
+		t.Fatalf("Expected item to be an array of 2, got: %v", order["item"])
+	}
+	first, ok := items[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected first item to be an object, got: %T", items[0])
+	}
+	if first["@sku"] != "x1" || first["#text"] != "Pen" {
+		t.Errorf("Expected first item {@sku: x1, #text: Pen}, got: %v", first)
+	}
+
+	if mods.SetHeaders["content-type"] != "application/json" {
+		t.Errorf("Expected content-type to be application/json, got: %s", mods.SetHeaders["content-type"])
 	}
 }
 
@@ -531,3 +584,46 @@ func TestGetPolicy(t *testing.T) {
 		t.Errorf("Expected *XMLToJSONPolicy, got %T", policyInstance)
 	}
 }
+
+func TestXMLToJSONPolicy_OnResponse_StreamingPassthrough(t *testing.T) {
+	p := &XMLToJSONPolicy{}
+	ctx := &policy.ResponseContext{
+		ResponseBody: &policy.Body{
+			Content:     []byte("data: <root><name>test</name></root>\n\n"),
+			Present:     true,
+			EndOfStream: true,
+		},
+		ResponseHeaders: createTestHeaders("content-type", "text/event-stream"),
+	}
+
+	result := p.OnResponse(ctx, map[string]interface{}{"onResponseFlow": true})
+
+	mods, ok := result.(policy.UpstreamResponseModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamResponseModifications, got %T", result)
+	}
+	if mods.Body != nil || mods.StatusCode != nil || len(mods.SetHeaders) != 0 {
+		t.Errorf("Expected SSE response to pass through untouched, got %+v", mods)
+	}
+}
+
+func TestXMLToJSONPolicy_OnRequest_MaxBodyBytesPassthrough(t *testing.T) {
+	p := &XMLToJSONPolicy{}
+	ctx := &policy.RequestContext{
+		Body: &policy.Body{
+			Content: []byte(`<root><name>a value that exceeds the limit</name></root>`),
+			Present: true,
+		},
+		Headers: createTestHeaders("content-type", "application/xml"),
+	}
+
+	result := p.OnRequest(ctx, map[string]interface{}{"onRequestFlow": true, "maxBodyBytes": float64(16)})
+
+	mods, ok := result.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Fatalf("Expected UpstreamRequestModifications, got %T", result)
+	}
+	if mods.Body != nil {
+		t.Errorf("Expected oversized body to pass through untouched, got: %s", string(mods.Body))
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package bodyutil provides helpers shared by body-processing policies.
+//
+// Policies that buffer and mutate request/response bodies assume the complete payload is
+// available. Streaming payloads such as Server-Sent Events, incomplete chunked transfers, or
+// very large bodies must not be rewritten. Body-mutating policies call ShouldPassThrough at the
+// start of OnRequest/OnResponse and return an empty modification when it reports true.
+package bodyutil
+
+import (
+	"strconv"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// DefaultStreamingContentTypes are media types that are always treated as streams
+var DefaultStreamingContentTypes = []string{
+	"text/event-stream",
+	"application/x-ndjson",
+	"application/grpc",
+}
+
+// Reasons reported by ShouldPassThrough
+const (
+	ReasonNone              = ""
+	ReasonStreamingType     = "streaming content type"
+	ReasonChunkedIncomplete = "incomplete chunked body"
+	ReasonOverSizeLimit     = "body exceeds size limit"
+)
+
+// Options configures streaming detection
+type Options struct {
+	// MaxBodyBytes is the largest body a policy is willing to process. Zero disables the check.
+	MaxBodyBytes int64
+
+	// StreamingContentTypes overrides DefaultStreamingContentTypes when non-nil
+	StreamingContentTypes []string
+}
+
+// ShouldPassThrough reports whether a body-mutating policy should leave the message untouched
+// because it is (or is likely to be) a stream. The returned reason is intended for logging.
+func ShouldPassThrough(headers *policy.Headers, body *policy.Body, opts Options) (bool, string) {
+	if IsStreamingContentType(headers, opts.StreamingContentTypes) {
+		return true, ReasonStreamingType
+	}
+
+	if isChunked(headers) && (body == nil || !body.EndOfStream) {
+		return true, ReasonChunkedIncomplete
+	}
+
+	if opts.MaxBodyBytes > 0 {
+		if body != nil && int64(len(body.Content)) > opts.MaxBodyBytes {
+			return true, ReasonOverSizeLimit
+		}
+		if length, ok := ContentLength(headers); ok && length > opts.MaxBodyBytes {
+			return true, ReasonOverSizeLimit
+		}
+	}
+
+	return false, ReasonNone
+}
+
+// IsStreamingContentType reports whether the Content-Type header matches one of the streaming
+// media types. When types is nil, DefaultStreamingContentTypes is used.
+func IsStreamingContentType(headers *policy.Headers, types []string) bool {
+	if types == nil {
+		types = DefaultStreamingContentTypes
+	}
+	mediaType := MediaType(headers)
+	if mediaType == "" {
+		return false
+	}
+	for _, t := range types {
+		if mediaType == strings.ToLower(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// MediaType returns the lower-cased media type from the Content-Type header without parameters
+func MediaType(headers *policy.Headers) string {
+	values := headers.Get("content-type")
+	if len(values) == 0 {
+		return ""
+	}
+	mediaType, _, _ := strings.Cut(values[0], ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// ContentLength returns the parsed Content-Length header, if present and valid
+func ContentLength(headers *policy.Headers) (int64, bool) {
+	values := headers.Get("content-length")
+	if len(values) == 0 {
+		return 0, false
+	}
+	length, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 64)
+	if err != nil || length < 0 {
+		return 0, false
+	}
+	return length, true
+}
+
+// IsContentEncoded reports whether any Content-Encoding header declares a coding other than
+// identity. Validating policies use it to reject bodies they would otherwise inspect encoded.
+func IsContentEncoded(headers *policy.Headers) bool {
+	for _, value := range headers.Get("content-encoding") {
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" && !strings.EqualFold(token, "identity") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isChunked reports whether a Transfer-Encoding header declares chunked encoding
+func isChunked(headers *policy.Headers) bool {
+	for _, value := range headers.Get("transfer-encoding") {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "chunked") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package bodyutil
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+func headers(kv map[string]string) *policy.Headers {
+	m := make(map[string][]string)
+	for k, v := range kv {
+		m[k] = []string{v}
+	}
+	return policy.NewHeaders(m)
+}
+
+func TestShouldPassThrough_SSE(t *testing.T) {
+	h := headers(map[string]string{"Content-Type": "text/event-stream; charset=utf-8"})
+	body := &policy.Body{Content: []byte("data: hello\n\n"), Present: true, EndOfStream: true}
+
+	pass, reason := ShouldPassThrough(h, body, Options{})
+	if !pass || reason != ReasonStreamingType {
+		t.Errorf("Expected SSE to pass through with reason %q, got %v %q", ReasonStreamingType, pass, reason)
+	}
+}
+
+func TestShouldPassThrough_CustomStreamingTypes(t *testing.T) {
+	h := headers(map[string]string{"content-type": "application/x-ndjson"})
+
+	if pass, _ := ShouldPassThrough(h, nil, Options{StreamingContentTypes: []string{"text/event-stream"}}); pass {
+		t.Error("Expected ndjson not to be treated as a stream when overridden")
+	}
+}
+
+func TestShouldPassThrough_ChunkedIncomplete(t *testing.T) {
+	h := headers(map[string]string{"content-type": "application/json", "transfer-encoding": "gzip, Chunked"})
+
+	if pass, reason := ShouldPassThrough(h, &policy.Body{Present: true}, Options{}); !pass || reason != ReasonChunkedIncomplete {
+		t.Errorf("Expected incomplete chunked body to pass through, got %v %q", pass, reason)
+	}
+
+	complete := &policy.Body{Content: []byte(`{}`), Present: true, EndOfStream: true}
+	if pass, _ := ShouldPassThrough(h, complete, Options{}); pass {
+		t.Error("Expected fully buffered chunked body to be processed")
+	}
+}
+
+func TestShouldPassThrough_SizeLimit(t *testing.T) {
+	h := headers(map[string]string{"content-type": "application/json"})
+	body := &policy.Body{Content: []byte(`{"a":"0123456789"}`), Present: true, EndOfStream: true}
+
+	if pass, reason := ShouldPassThrough(h, body, Options{MaxBodyBytes: 8}); !pass || reason != ReasonOverSizeLimit {
+		t.Errorf("Expected oversized body to pass through, got %v %q", pass, reason)
+	}
+	if pass, _ := ShouldPassThrough(h, body, Options{MaxBodyBytes: 1024}); pass {
+		t.Error("Expected body within limit to be processed")
+	}
+
+	declared := headers(map[string]string{"content-length": "4096"})
+	if pass, _ := ShouldPassThrough(declared, nil, Options{MaxBodyBytes: 1024}); !pass {
+		t.Error("Expected declared Content-Length over the limit to pass through")
+	}
+}
+
+func TestShouldPassThrough_RegularBody(t *testing.T) {
+	h := headers(map[string]string{"content-type": "application/xml"})
+	body := &policy.Body{Content: []byte(`<a/>`), Present: true}
+
+	if pass, reason := ShouldPassThrough(h, body, Options{}); pass {
+		t.Errorf("Expected regular body to be processed, got reason %q", reason)
+	}
+	if pass, _ := ShouldPassThrough(nil, nil, Options{}); pass {
+		t.Error("Expected nil headers and body not to be treated as a stream")
+	}
+}
+
+func TestIsContentEncoded(t *testing.T) {
+	encoded := []map[string][]string{
+		{"content-encoding": {"gzip"}},
+		{"content-encoding": {"identity, br"}},
+		{"content-encoding": {"identity", "deflate"}},
+	}
+	for _, h := range encoded {
+		if !IsContentEncoded(policy.NewHeaders(h)) {
+			t.Errorf("Expected %v to be treated as encoded", h)
+		}
+	}
+
+	plain := []map[string][]string{
+		nil,
+		{"content-encoding": {""}},
+		{"content-encoding": {"Identity"}},
+	}
+	for _, h := range plain {
+		if IsContentEncoded(policy.NewHeaders(h)) {
+			t.Errorf("Expected %v not to be treated as encoded", h)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package clientkey identifies the client sending a request for policies that keep per-client
+// state, such as rate limiters.
+//
+// A client is identified by a request header, a shared metadata entry or its IP address. The
+// IP comes from X-Forwarded-For, where each proxy appends the address it received the request
+// from. Entries a client sends itself come first and can be forged, so the address is read
+// from the right: by default the rightmost entry, and one entry further left for each of the
+// TrustedProxyCount proxies between the client-facing proxy and the gateway.
+package clientkey
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+const (
+	TypeHeader   = "header"
+	TypeMetadata = "metadata"
+	TypeIP       = "ip"
+)
+
+// Key identifies the client sending a request. The zero value is not valid; use Parse.
+type Key struct {
+	Type              string
+	Name              string // Lower-cased header name or metadata key
+	TrustedProxyCount int    // Rightmost X-Forwarded-For entries added by trusted proxies
+}
+
+// Parse reads a clientKey parameter object. A nil value selects the client IP.
+func Parse(raw interface{}) (Key, error) {
+	key := Key{Type: TypeIP}
+	if raw == nil {
+		return key, nil
+	}
+	keyMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return Key{}, fmt.Errorf("'clientKey' must be an object")
+	}
+
+	keyType, _ := keyMap["type"].(string)
+	switch keyType {
+	case TypeIP:
+		if raw, ok := keyMap["trustedProxyCount"]; ok {
+			count, ok := toInt(raw)
+			if !ok || count < 0 {
+				return Key{}, fmt.Errorf("'clientKey.trustedProxyCount' must be a non-negative integer")
+			}
+			key.TrustedProxyCount = count
+		}
+	case TypeHeader, TypeMetadata:
+		name, ok := keyMap["key"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			return Key{}, fmt.Errorf("'clientKey.key' is required for type '%s'", keyType)
+		}
+		key.Name = strings.TrimSpace(name)
+		if keyType == TypeHeader {
+			key.Name = strings.ToLower(key.Name)
+		}
+	default:
+		return Key{}, fmt.Errorf("'clientKey.type' must be one of: header, metadata, ip")
+	}
+	key.Type = keyType
+	return key, nil
+}
+
+// Resolve returns the key of the client sending the request. Requests without the configured
+// header or metadata entry share a placeholder key per name.
+func (k Key) Resolve(ctx *policy.RequestContext) string {
+	switch k.Type {
+	case TypeHeader:
+		if values := ctx.Headers.Get(k.Name); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+		return fmt.Sprintf("_missing_header_%s_", k.Name)
+	case TypeMetadata:
+		if ctx.SharedContext != nil {
+			if val, ok := ctx.Metadata[k.Name].(string); ok && val != "" {
+				return val
+			}
+		}
+		return fmt.Sprintf("_missing_metadata_%s_", k.Name)
+	default:
+		return k.clientIP(ctx.Headers)
+	}
+}
+
+// clientIP returns the X-Forwarded-For entry added by the outermost trusted proxy, falling
+// back to X-Real-IP
+func (k Key) clientIP(headers *policy.Headers) string {
+	var hops []string
+	for _, value := range headers.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	if len(hops) > 0 {
+		// A chain shorter than the trusted count was added entirely by trusted proxies
+		return hops[max(len(hops)-1-k.TrustedProxyCount, 0)]
+	}
+	if xri := headers.Get("x-real-ip"); len(xri) > 0 && strings.TrimSpace(xri[0]) != "" {
+		return strings.TrimSpace(xri[0])
+	}
+	return "unknown"
+}
+
+// toInt converts a whole number parameter value to an int
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
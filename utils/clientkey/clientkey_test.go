@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package clientkey
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+func TestParse(t *testing.T) {
+	invalid := []interface{}{
+		"ip",
+		map[string]interface{}{"type": "cookie"},
+		map[string]interface{}{"type": "header"},
+		map[string]interface{}{"type": "metadata", "key": " "},
+		map[string]interface{}{"type": "ip", "trustedProxyCount": -1},
+		map[string]interface{}{"type": "ip", "trustedProxyCount": 1.5},
+	}
+	for _, raw := range invalid {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("Expected error for %v", raw)
+		}
+	}
+
+	key, err := Parse(nil)
+	if err != nil || key.Type != TypeIP {
+		t.Errorf("Expected the client IP by default, got %+v, %v", key, err)
+	}
+	key, err = Parse(map[string]interface{}{"type": "header", "key": " X-Client-Id "})
+	if err != nil || key.Name != "x-client-id" {
+		t.Errorf("Expected a lower-cased header name, got %+v, %v", key, err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	request := func(headers map[string][]string) *policy.RequestContext {
+		return &policy.RequestContext{
+			SharedContext: &policy.SharedContext{Metadata: map[string]interface{}{"tenant": "acme"}},
+			Headers:       policy.NewHeaders(headers),
+		}
+	}
+	spoofed := map[string][]string{"x-forwarded-for": {"6.6.6.6, 203.0.113.7", "10.0.0.5"}}
+
+	tests := []struct {
+		key     Key
+		headers map[string][]string
+		want    string
+	}{
+		// The client can't pick its own key by prepending entries
+		{Key{Type: TypeIP}, spoofed, "10.0.0.5"},
+		{Key{Type: TypeIP, TrustedProxyCount: 1}, spoofed, "203.0.113.7"},
+		{Key{Type: TypeIP, TrustedProxyCount: 5}, spoofed, "6.6.6.6"},
+		{Key{Type: TypeIP}, map[string][]string{"x-real-ip": {" 198.51.100.1 "}}, "198.51.100.1"},
+		{Key{Type: TypeIP}, nil, "unknown"},
+		{Key{Type: TypeHeader, Name: "x-client"}, map[string][]string{"x-client": {"a"}}, "a"},
+		{Key{Type: TypeHeader, Name: "x-client"}, nil, "_missing_header_x-client_"},
+		{Key{Type: TypeMetadata, Name: "tenant"}, nil, "acme"},
+		{Key{Type: TypeMetadata, Name: "user"}, nil, "_missing_metadata_user_"},
+	}
+	for _, tt := range tests {
+		if got := tt.key.Resolve(request(tt.headers)); got != tt.want {
+			t.Errorf("%+v with %v: expected %q, got %q", tt.key, tt.headers, tt.want, got)
+		}
+	}
+}
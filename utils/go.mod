@@ -0,0 +1,5 @@
+module github.com/wso2/gateway-controllers/utils
+
+go 1.25.1
+
+require github.com/wso2/api-platform/sdk v0.3.1
@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package jsonpath provides the simple JSONPath subset shared by JSON body-processing policies.
+//
+// A path such as "$.items[*].state" is parsed into segments: object keys, "[n]" for an array
+// element and the wildcards "*" (any key or element) and "[*]" (any element). Walk traverses a
+// decoded JSON document and lets the policy replace the nodes it selects.
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var arrayIndexRegex = regexp.MustCompile(`^([a-zA-Z0-9_]+)\[(\*|\d+)\]$`)
+
+// Path is a parsed JSONPath. The empty path selects the document root.
+type Path []string
+
+// Transform replaces a selected node, reporting whether the value changed
+type Transform func(value interface{}) (interface{}, bool)
+
+// Parse splits a JSONPath expression such as "$.status" or "$.items[*].state" into segments.
+// Array indices become separate "[n]" or "[*]" segments and "$" alone is the document root.
+func Parse(path string) (Path, error) {
+	path = strings.TrimSpace(path)
+	if path != "$" && !strings.HasPrefix(path, "$.") {
+		return nil, fmt.Errorf("JSONPath must start with '$.': %s", path)
+	}
+
+	segments := Path{}
+	for _, key := range strings.Split(path, ".")[1:] {
+		if key == "" {
+			return nil, fmt.Errorf("JSONPath contains an empty segment: %s", path)
+		}
+		if matches := arrayIndexRegex.FindStringSubmatch(key); len(matches) == 3 {
+			segments = append(segments, matches[1], "["+matches[2]+"]")
+			continue
+		}
+		if strings.ContainsAny(key, "[]") {
+			return nil, fmt.Errorf("invalid JSONPath segment %q in %s", key, path)
+		}
+		segments = append(segments, key)
+	}
+	return segments, nil
+}
+
+// Matches reports whether the path selects the node at nodePath exactly
+func (p Path) Matches(nodePath []string) bool {
+	return len(p) == len(nodePath) && p.Covers(nodePath)
+}
+
+// Covers reports whether the path selects the node at nodePath or one of its ancestors
+func (p Path) Covers(nodePath []string) bool {
+	if len(p) > len(nodePath) {
+		return false
+	}
+	for i, segment := range p {
+		if !segmentMatches(segment, nodePath[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Walk traverses a decoded JSON value depth-first, tracking the path of each node. For every
+// node, selector returns the Transform that replaces it, or nil to descend into its children.
+// Objects and arrays are updated in place; Walk reports whether anything changed.
+func Walk(value interface{}, selector func(path []string, value interface{}) Transform) (interface{}, bool) {
+	return walk(value, nil, selector)
+}
+
+func walk(value interface{}, path []string, selector func([]string, interface{}) Transform) (interface{}, bool) {
+	if transform := selector(path, value); transform != nil {
+		return transform(value)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		changed := false
+		for key, child := range v {
+			if result, ok := walk(child, append(path, key), selector); ok {
+				v[key] = result
+				changed = true
+			}
+		}
+		return v, changed
+	case []interface{}:
+		changed := false
+		for i, child := range v {
+			if result, ok := walk(child, append(path, "["+strconv.Itoa(i)+"]"), selector); ok {
+				v[i] = result
+				changed = true
+			}
+		}
+		return v, changed
+	default:
+		return v, false
+	}
+}
+
+// segmentMatches compares a pattern segment with a concrete path segment. "*" matches any
+// object key or array element and "[*]" matches any array element.
+func segmentMatches(pattern, segment string) bool {
+	switch pattern {
+	case "*":
+		return true
+	case "[*]":
+		return strings.HasPrefix(segment, "[")
+	default:
+		return pattern == segment
+	}
+}
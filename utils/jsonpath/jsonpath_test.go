@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]Path{
+		"$":                     {},
+		"$.status":              {"status"},
+		" $.items[*].state ":    {"items", "[*]", "state"},
+		"$._links.*.href":       {"_links", "*", "href"},
+		"$.orders[2].lines[*]":  {"orders", "[2]", "lines", "[*]"},
+		"$.meta.created_at":     {"meta", "created_at"},
+		"$.matrix[0].values[1]": {"matrix", "[0]", "values", "[1]"},
+	}
+	for expr, expected := range cases {
+		path, err := Parse(expr)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %v", expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(path, expected) {
+			t.Errorf("Expected %q to parse to %v, got %v", expr, expected, path)
+		}
+	}
+
+	for _, expr := range []string{"status", "$..status", "$.items[x]", "$.items]", ""} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected %q to be rejected", expr)
+		}
+	}
+}
+
+func TestMatchesAndCovers(t *testing.T) {
+	path, _ := Parse("$.items[*].state")
+
+	if !path.Matches([]string{"items", "[3]", "state"}) {
+		t.Error("Expected [*] to match an array element")
+	}
+	if path.Matches([]string{"items", "name", "state"}) {
+		t.Error("Expected [*] not to match an object key")
+	}
+	if path.Matches([]string{"items", "[3]", "state", "code"}) {
+		t.Error("Expected Matches to require the exact node")
+	}
+	if !path.Covers([]string{"items", "[3]", "state", "code"}) {
+		t.Error("Expected Covers to include descendants")
+	}
+	if path.Covers([]string{"items", "[3]"}) {
+		t.Error("Expected Covers not to include ancestors")
+	}
+
+	wildcard, _ := Parse("$._links.*.href")
+	if !wildcard.Matches([]string{"_links", "self", "href"}) || !wildcard.Matches([]string{"_links", "[0]", "href"}) {
+		t.Error("Expected * to match any key or element")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"items":[{"state":"a"},{"state":"b"}],"state":"c"}`), &data); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := Parse("$.items[*].state")
+
+	upper := func(value interface{}) (interface{}, bool) {
+		s, ok := value.(string)
+		if !ok {
+			return value, false
+		}
+		return strings.ToUpper(s), true
+	}
+	result, changed := Walk(data, func(nodePath []string, value interface{}) Transform {
+		if path.Matches(nodePath) {
+			return upper
+		}
+		return nil
+	})
+	if !changed {
+		t.Fatal("Expected the document to change")
+	}
+
+	encoded, _ := json.Marshal(result)
+	expected := `{"items":[{"state":"A"},{"state":"B"}],"state":"c"}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+
+	if _, changed := Walk(data, func([]string, interface{}) Transform { return nil }); changed {
+		t.Error("Expected no change without selected nodes")
+	}
+}
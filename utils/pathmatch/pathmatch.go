@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package pathmatch provides the request path patterns shared by path-routed policies.
+//
+// Patterns use path.Match syntax, where "*" matches within a single segment, and a trailing
+// "/**" matches the prefix itself and any remaining segments, so "/api/**" covers "/api" and
+// "/api/v1/users" but not "/apis". Request paths are put through Normalize before matching.
+package pathmatch
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Normalize drops the query string, decodes percent-escapes and resolves dot segments so that
+// encoded or relative forms of a path can't slip past path rules. It reports false for invalid
+// escapes and for encoded slashes, which upstreams disagree on whether to decode and so can't
+// be matched safely either way.
+func Normalize(fullPath string) (string, bool) {
+	reqPath, _, _ := strings.Cut(fullPath, "?")
+	if strings.Contains(strings.ToLower(reqPath), "%2f") {
+		return "", false
+	}
+	decoded, err := url.PathUnescape(reqPath)
+	if err != nil {
+		return "", false
+	}
+	if decoded == "" {
+		return "/", true
+	}
+	cleaned := path.Clean("/" + decoded)
+	// Keep a trailing slash, which is significant to many routes
+	if strings.HasSuffix(decoded, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned, true
+}
+
+// Validate reports whether a pattern is well formed
+func Validate(pattern string) error {
+	_, err := path.Match(strings.TrimSuffix(pattern, "/**"), "/")
+	return err
+}
+
+// Match reports whether a path matches a pattern. Malformed patterns match nothing.
+func Match(pattern, reqPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		if prefix == "" {
+			return true
+		}
+		if matched, _ := path.Match(prefix, reqPath); matched {
+			return true
+		}
+		segments := strings.Split(reqPath, "/")
+		for i := len(segments) - 1; i > 0; i-- {
+			if matched, _ := path.Match(prefix, strings.Join(segments[:i], "/")); matched {
+				return true
+			}
+		}
+		return false
+	}
+	matched, _ := path.Match(pattern, reqPath)
+	return matched
+}
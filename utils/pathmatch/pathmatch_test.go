@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package pathmatch
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/login", "/login", true},
+		{"/login", "/login/extra", false},
+		{"/users/*/orders", "/users/42/orders", true},
+		{"/users/*/orders", "/users/42/7/orders", false},
+		{"/api/**", "/api", true},
+		{"/api/**", "/api/v1/users", true},
+		{"/api/**", "/apis", false},
+		{"/users/*/**", "/users/42/orders/7", true},
+		{"/users/*/**", "/users", false},
+		{"/**", "/anything", true},
+		{"/[", "/[", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("Expected Match(%q, %q) = %v, got %v", tt.pattern, tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, pattern := range []string{"/api/**", "/users/*/orders", "/**", "/static/*.[jc]s"} {
+		if err := Validate(pattern); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"/[", "/files/[a-/**", "/a\\"} {
+		if err := Validate(pattern); err == nil {
+			t.Errorf("Expected %q to be invalid", pattern)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		fullPath, want string
+	}{
+		{"", "/"},
+		{"/users/42?expand=true", "/users/42"},
+		{"/users/42/", "/users/42/"},
+		{"/api//login", "/api/login"},
+		{"/api/./login", "/api/login"},
+		{"/public/../admin", "/admin"},
+		{"/public/%2e%2e/admin", "/admin"},
+		{"/api/%6cogin", "/api/login"},
+		{"/files/a%20b", "/files/a b"},
+		{"/files?next=%2Fhome", "/files"},
+	}
+	for _, tt := range tests {
+		if got, ok := Normalize(tt.fullPath); !ok || got != tt.want {
+			t.Errorf("Expected Normalize(%q) = %q, got %q (ok %v)", tt.fullPath, tt.want, got, ok)
+		}
+	}
+
+	// Encoded slashes and invalid escapes are refused
+	for _, fullPath := range []string{"/public%2F..%2Fadmin", "/orders/42%2fcancel", "/orders/%zz", "/orders/42%"} {
+		if got, ok := Normalize(fullPath); ok {
+			t.Errorf("Expected Normalize(%q) to fail, got %q", fullPath, got)
+		}
+	}
+}
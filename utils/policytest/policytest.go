@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package policytest provides the helpers shared by policy unit tests: building a policy from
+// its factory, checking that bad configurations are refused and checking the action a policy
+// returns.
+package policytest
+
+import (
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// New builds a policy with empty metadata and fails the test if the factory rejects params
+func New(t testing.TB, factory policy.PolicyFactory, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	return NewWithMetadata(t, factory, policy.PolicyMetadata{}, params)
+}
+
+// NewWithMetadata builds a policy and fails the test if the factory rejects params
+func NewWithMetadata(t testing.TB, factory policy.PolicyFactory, metadata policy.PolicyMetadata, params map[string]interface{}) policy.Policy {
+	t.Helper()
+	p, err := factory(metadata, params)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p == nil {
+		t.Fatal("Expected policy instance, got nil")
+	}
+	return p
+}
+
+// ExpectInvalidParams checks that the factory rejects each set of params
+func ExpectInvalidParams(t testing.TB, factory policy.PolicyFactory, invalid []map[string]interface{}) {
+	t.Helper()
+	for _, params := range invalid {
+		if _, err := factory(policy.PolicyMetadata{}, params); err == nil {
+			t.Errorf("Expected error for params %v", params)
+		}
+	}
+}
+
+// ExpectStatus checks that a request action is an immediate response with the given status
+// and returns it. A status of 0 instead expects the request to be forwarded.
+func ExpectStatus(t testing.TB, action policy.RequestAction, status int) policy.ImmediateResponse {
+	t.Helper()
+	if status == 0 {
+		ExpectForwarded(t, action)
+		return policy.ImmediateResponse{}
+	}
+	resp, ok := action.(policy.ImmediateResponse)
+	if !ok || resp.StatusCode != status {
+		t.Errorf("Expected status %d, got %+v", status, action)
+	}
+	return resp
+}
+
+// ExpectForwarded checks that a request action forwards the request and returns its
+// modifications
+func ExpectForwarded(t testing.TB, action policy.RequestAction) policy.UpstreamRequestModifications {
+	t.Helper()
+	mods, ok := action.(policy.UpstreamRequestModifications)
+	if !ok {
+		t.Errorf("Expected request to pass, got %+v", action)
+	}
+	return mods
+}
+
+// ExpectResponseStatus checks that a response action replaces the upstream status with the
+// given one and returns its modifications. A status of 0 instead expects the status to be left
+// unchanged.
+func ExpectResponseStatus(t testing.TB, action policy.ResponseAction, status int) policy.UpstreamResponseModifications {
+	t.Helper()
+	mods, ok := action.(policy.UpstreamResponseModifications)
+	if !ok {
+		t.Errorf("Expected response modifications, got %+v", action)
+		return mods
+	}
+	if status == 0 {
+		if mods.StatusCode != nil {
+			t.Errorf("Expected status to be unchanged, got %d", *mods.StatusCode)
+		}
+		return mods
+	}
+	if mods.StatusCode == nil || *mods.StatusCode != status {
+		t.Errorf("Expected status %d, got %v", status, mods.StatusCode)
+	}
+	return mods
+}
@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package policytest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	policy "github.com/wso2/api-platform/sdk/gateway/policy/v1alpha"
+)
+
+// recorder captures failures instead of failing the enclosing test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+func (r *recorder) Fatal(args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+type stubPolicy struct{}
+
+func (stubPolicy) Mode() policy.ProcessingMode { return policy.ProcessingMode{} }
+
+func (stubPolicy) OnRequest(*policy.RequestContext, map[string]interface{}) policy.RequestAction {
+	return nil
+}
+
+func (stubPolicy) OnResponse(*policy.ResponseContext, map[string]interface{}) policy.ResponseAction {
+	return nil
+}
+
+// factory accepts params unless they set "invalid"
+func factory(metadata policy.PolicyMetadata, params map[string]interface{}) (policy.Policy, error) {
+	if _, ok := params["invalid"]; ok {
+		return nil, fmt.Errorf("'invalid' is not supported")
+	}
+	return stubPolicy{}, nil
+}
+
+func TestNew(t *testing.T) {
+	r := &recorder{TB: t}
+	if p := New(r, factory, nil); p == nil || len(r.failures) != 0 {
+		t.Errorf("Expected policy without failures, got %v %v", p, r.failures)
+	}
+	New(r, factory, map[string]interface{}{"invalid": true})
+	if len(r.failures) == 0 {
+		t.Error("Expected a failure for rejected params")
+	}
+}
+
+func TestExpectInvalidParams(t *testing.T) {
+	r := &recorder{TB: t}
+	ExpectInvalidParams(r, factory, []map[string]interface{}{{"invalid": 1}, {}, {"other": 1}})
+	if len(r.failures) != 2 {
+		t.Errorf("Expected failures for the two accepted params, got %v", r.failures)
+	}
+}
+
+func TestExpectStatus(t *testing.T) {
+	r := &recorder{TB: t}
+	resp := ExpectStatus(r, policy.ImmediateResponse{StatusCode: http.StatusForbidden}, http.StatusForbidden)
+	ExpectStatus(r, policy.UpstreamRequestModifications{}, 0)
+	if len(r.failures) != 0 || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected matching actions to pass, got %v", r.failures)
+	}
+
+	ExpectStatus(r, policy.ImmediateResponse{StatusCode: http.StatusBadRequest}, http.StatusForbidden)
+	ExpectStatus(r, policy.UpstreamRequestModifications{}, http.StatusForbidden)
+	ExpectStatus(r, policy.ImmediateResponse{StatusCode: http.StatusForbidden}, 0)
+	if len(r.failures) != 3 {
+		t.Errorf("Expected three failures, got %v", r.failures)
+	}
+}
+
+func TestExpectResponseStatus(t *testing.T) {
+	status := http.StatusBadGateway
+	r := &recorder{TB: t}
+	ExpectResponseStatus(r, policy.UpstreamResponseModifications{StatusCode: &status}, http.StatusBadGateway)
+	ExpectResponseStatus(r, policy.UpstreamResponseModifications{}, 0)
+	if len(r.failures) != 0 {
+		t.Errorf("Expected matching actions to pass, got %v", r.failures)
+	}
+
+	ExpectResponseStatus(r, policy.UpstreamResponseModifications{StatusCode: &status}, 0)
+	ExpectResponseStatus(r, policy.UpstreamResponseModifications{}, http.StatusBadGateway)
+	ExpectResponseStatus(r, nil, 0)
+	if len(r.failures) != 3 {
+		t.Errorf("Expected three failures, got %v", r.failures)
+	}
+}